@@ -0,0 +1,31 @@
+package analytics_config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const defaultConfigPath = "../deploy/config/analytics/config.toml"
+
+type AnalyticsConfig struct {
+	FlushInterval time.Duration `toml:"flush_interval"` // how often views counted in redis are saved to database
+}
+
+func NewAnalyticsConfig(configPath string) (*AnalyticsConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg AnalyticsConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse analytics config from file %v: %w", configPath, err)
+	}
+
+	if cfg.FlushInterval <= 0 {
+		return nil, fmt.Errorf("flush_interval in analytics config must be positive, got %v", cfg.FlushInterval)
+	}
+	return &cfg, nil
+}
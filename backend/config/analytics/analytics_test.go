@@ -0,0 +1,39 @@
+package analytics_config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	file, err := os.CreateTemp("", "analytics_config_*.toml")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(file.Name()) })
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return file.Name()
+}
+
+func TestNewAnalyticsConfig_Success(t *testing.T) {
+	cfg, err := NewAnalyticsConfig(writeConfig(t, `flush_interval = "1m"`))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, cfg.FlushInterval)
+}
+
+func TestNewAnalyticsConfig_InvalidInterval(t *testing.T) {
+	for _, content := range []string{``, `flush_interval = "0s"`, `flush_interval = "-30s"`} {
+		_, err := NewAnalyticsConfig(writeConfig(t, content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestNewAnalyticsConfig_FileNotFound(t *testing.T) {
+	_, err := NewAnalyticsConfig("non_existent_file.toml")
+	assert.Error(t, err)
+}
@@ -1,11 +1,17 @@
 package config
 
 import (
+	analytics_config "quickflow/config/analytics"
 	cors_config "quickflow/config/cors"
+	gc_config "quickflow/config/gc"
+	image_config "quickflow/config/image"
 	minio_config "quickflow/config/minio"
 	"quickflow/config/postgres"
+	recommendation_config "quickflow/config/recommendation"
 	redis_config "quickflow/config/redis"
+	scheduler_config "quickflow/config/scheduler"
 	server_config "quickflow/config/server"
+	storage_config "quickflow/config/storage"
 	validation_config "quickflow/config/validation"
 )
 
@@ -16,4 +22,11 @@ type Config struct {
 	RedisConfig      *redis_config.RedisConfig
 	ServerConfig     *server_config.ServerConfig
 	ValidationConfig *validation_config.ValidationConfig
+	ImageConfig      *image_config.ImageConfig
+	StorageConfig    *storage_config.StorageConfig
+
+	RecommendationConfig *recommendation_config.RecommendationConfig
+	FileGCConfig         *gc_config.FileGCConfig
+	SchedulerConfig      *scheduler_config.SchedulerConfig
+	AnalyticsConfig      *analytics_config.AnalyticsConfig
 }
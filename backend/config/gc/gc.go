@@ -0,0 +1,36 @@
+package gc_config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const defaultConfigPath = "../deploy/config/gc/config.toml"
+
+type FileGCConfig struct {
+	Enabled     bool          `toml:"enabled"`      // whether background collector is started with the server
+	Interval    time.Duration `toml:"interval"`     // how often background collector runs
+	GracePeriod time.Duration `toml:"grace_period"` // objects younger than this may still be in-flight uploads
+	BatchSize   int           `toml:"batch_size"`   // number of object names checked against database at once
+	DryRun      bool          `toml:"dry_run"`      // only report orphaned objects without deleting them
+}
+
+func NewFileGCConfig(configPath string) (*FileGCConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg FileGCConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse file gc config from file %v: %w", configPath, err)
+	}
+
+	// the interval is checked even if the collector is disabled, so enabling it can not crash the server
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("interval in file gc config must be positive, got %v", cfg.Interval)
+	}
+	return &cfg, nil
+}
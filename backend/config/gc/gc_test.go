@@ -0,0 +1,59 @@
+package gc_config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	file, err := os.CreateTemp("", "gc_config_*.toml")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(file.Name()) })
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return file.Name()
+}
+
+func TestNewFileGCConfig_Success(t *testing.T) {
+	cfg, err := NewFileGCConfig(writeConfig(t, `
+enabled = true
+interval = "6h"
+grace_period = "24h"
+batch_size = 500
+`))
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, 6*time.Hour, cfg.Interval)
+	assert.Equal(t, 24*time.Hour, cfg.GracePeriod)
+	assert.Equal(t, 500, cfg.BatchSize)
+}
+
+func TestNewFileGCConfig_InvalidInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing", content: `enabled = true`},
+		{name: "zero", content: `interval = "0s"`},
+		{name: "negative", content: `interval = "-6h"`},
+		{name: "disabled collector", content: `enabled = false`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFileGCConfig(writeConfig(t, tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewFileGCConfig_FileNotFound(t *testing.T) {
+	_, err := NewFileGCConfig("non_existent_file.toml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package image_config
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+const defaultConfigPath = "../deploy/config/image/config.toml"
+
+type ImageConfig struct {
+	ThumbnailSize int `toml:"thumbnail_size"` // max side of thumbnail variant in pixels
+	FeedSize      int `toml:"feed_size"`      // max side of variant shown in feed
+	FullSize      int `toml:"full_size"`      // max side of full variant
+	JPEGQuality   int `toml:"jpeg_quality"`
+	MaxPixels     int `toml:"max_pixels"` // images with more pixels are rejected before decoding
+}
+
+func NewImageConfig(configPath string) (*ImageConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg ImageConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse image config from file %v: %w", configPath, err)
+	}
+	return &cfg, nil
+}
@@ -31,11 +31,24 @@ type MinioConfig struct {
 	MinioUseSSL            bool
 	PresignedURLExpiration time.Duration
 	Scheme                 string
+
+	// max size of a single stored file in bytes, zero means no limit
+	PostMaxFileSize       int64
+	AttachmentMaxFileSize int64
+	AvatarMaxFileSize     int64
+	CoverMaxFileSize      int64
+	ChatAvatarMaxFileSize int64
 }
 
 type loadableConfig struct {
 	PresignedURLExpiration time.Duration `toml:"presigned_url_expiration"`
 	MinioUseSSL            bool          `toml:"minio_use_ssl"`
+
+	PostMaxFileSize       int64 `toml:"post_max_file_size"`
+	AttachmentMaxFileSize int64 `toml:"attachment_max_file_size"`
+	AvatarMaxFileSize     int64 `toml:"avatar_max_file_size"`
+	CoverMaxFileSize      int64 `toml:"cover_max_file_size"`
+	ChatAvatarMaxFileSize int64 `toml:"chat_avatar_max_file_size"`
 }
 
 // loadMinioConfig loads config from file.
@@ -65,6 +78,11 @@ func newMinioConfig(config loadableConfig) *MinioConfig {
 		Scheme:                 getenv.GetEnv("MINIO_SCHEME", defaultScheme),
 		MinioUseSSL:            config.MinioUseSSL,
 		PresignedURLExpiration: config.PresignedURLExpiration,
+		PostMaxFileSize:        config.PostMaxFileSize,
+		AttachmentMaxFileSize:  config.AttachmentMaxFileSize,
+		AvatarMaxFileSize:      config.AvatarMaxFileSize,
+		CoverMaxFileSize:       config.CoverMaxFileSize,
+		ChatAvatarMaxFileSize:  config.ChatAvatarMaxFileSize,
 	}
 }
 
@@ -13,6 +13,7 @@ func TestLoadMinioConfig_Success(t *testing.T) {
 	cfg := &loadableConfig{
 		MinioUseSSL:            false,
 		PresignedURLExpiration: 24 * time.Hour,
+		AvatarMaxFileSize:      5 << 20,
 	}
 
 	// Создаём временный конфигурационный файл для теста
@@ -36,6 +37,7 @@ func TestLoadMinioConfig_Success(t *testing.T) {
 
 	assert.Equal(t, cfg.MinioUseSSL, loadedCfg.MinioUseSSL)
 	assert.Equal(t, cfg.PresignedURLExpiration, loadedCfg.PresignedURLExpiration)
+	assert.Equal(t, cfg.AvatarMaxFileSize, loadedCfg.AvatarMaxFileSize)
 }
 
 func TestLoadMinioConfig_FileNotFound(t *testing.T) {
@@ -0,0 +1,48 @@
+package recommendation_config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const defaultConfigPath = "../deploy/config/recommendation/config.toml"
+
+type RecommendationConfig struct {
+	RefreshInterval time.Duration `toml:"refresh_interval"` // how often background worker recomputes scores
+	ActiveWindow    time.Duration `toml:"active_window"`    // users that requested recommendations within this window are refreshed
+	CandidateWindow time.Duration `toml:"candidate_window"` // only posts newer than this are considered
+	MaxCandidates   int           `toml:"max_candidates"`
+	CacheSize       int           `toml:"cache_size"` // number of scored posts kept per user
+	CacheTTL        time.Duration `toml:"cache_ttl"`
+	SeenTTL         time.Duration `toml:"seen_ttl"`
+
+	LikeWeight    float64 `toml:"like_weight"`
+	CommentWeight float64 `toml:"comment_weight"`
+	RepostWeight  float64 `toml:"repost_weight"`
+
+	FriendAffinity         float64 `toml:"friend_affinity"`
+	FollowedAffinity       float64 `toml:"followed_affinity"`
+	FriendOfFriendAffinity float64 `toml:"friend_of_friend_affinity"`
+
+	HalfLife         time.Duration `toml:"half_life"`         // post score halves every HalfLife
+	DiversityPenalty float64       `toml:"diversity_penalty"` // multiplier applied for every previous post of the same author
+}
+
+func NewRecommendationConfig(configPath string) (*RecommendationConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg RecommendationConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse recommendation config from file %v: %w", configPath, err)
+	}
+
+	if cfg.RefreshInterval <= 0 {
+		return nil, fmt.Errorf("refresh_interval in recommendation config must be positive, got %v", cfg.RefreshInterval)
+	}
+	return &cfg, nil
+}
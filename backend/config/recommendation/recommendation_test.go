@@ -0,0 +1,58 @@
+package recommendation_config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	file, err := os.CreateTemp("", "recommendation_config_*.toml")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(file.Name()) })
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return file.Name()
+}
+
+func TestNewRecommendationConfig_Success(t *testing.T) {
+	cfg, err := NewRecommendationConfig(writeConfig(t, `
+refresh_interval = "5m"
+cache_size = 300
+half_life = "24h"
+diversity_penalty = 0.7
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.RefreshInterval)
+	assert.Equal(t, 300, cfg.CacheSize)
+	assert.Equal(t, 24*time.Hour, cfg.HalfLife)
+	assert.Equal(t, 0.7, cfg.DiversityPenalty)
+}
+
+func TestNewRecommendationConfig_InvalidInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing", content: `cache_size = 300`},
+		{name: "zero", content: `refresh_interval = "0s"`},
+		{name: "negative", content: `refresh_interval = "-5m"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecommendationConfig(writeConfig(t, tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewRecommendationConfig_FileNotFound(t *testing.T) {
+	_, err := NewRecommendationConfig("non_existent_file.toml")
+	assert.Error(t, err)
+}
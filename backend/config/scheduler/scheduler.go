@@ -0,0 +1,32 @@
+package scheduler_config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const defaultConfigPath = "../deploy/config/scheduler/config.toml"
+
+type SchedulerConfig struct {
+	PublishInterval time.Duration `toml:"publish_interval"` // how often scheduled posts are checked for publication
+}
+
+func NewSchedulerConfig(configPath string) (*SchedulerConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg SchedulerConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse scheduler config from file %v: %w", configPath, err)
+	}
+
+	// ticker of the publish worker panics on intervals that are not positive
+	if cfg.PublishInterval <= 0 {
+		return nil, fmt.Errorf("publish_interval in scheduler config must be positive, got %v", cfg.PublishInterval)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,50 @@
+package scheduler_config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	file, err := os.CreateTemp("", "scheduler_config_*.toml")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(file.Name()) })
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return file.Name()
+}
+
+func TestNewSchedulerConfig_Success(t *testing.T) {
+	cfg, err := NewSchedulerConfig(writeConfig(t, `publish_interval = "30s"`))
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.PublishInterval)
+}
+
+func TestNewSchedulerConfig_InvalidInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing", content: ``},
+		{name: "zero", content: `publish_interval = "0s"`},
+		{name: "negative", content: `publish_interval = "-1m"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSchedulerConfig(writeConfig(t, tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewSchedulerConfig_FileNotFound(t *testing.T) {
+	_, err := NewSchedulerConfig("non_existent_file.toml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package storage_config
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	getenv "quickflow/utils/get-env"
+)
+
+const defaultConfigPath = "../deploy/config/storage/config.toml"
+
+const (
+	BackendMinio = "minio" // files are kept in MinIO buckets
+	BackendLocal = "local" // files are kept in local directory, meant for development and tests
+)
+
+type StorageConfig struct {
+	Backend        string `toml:"backend"`          // minio or local, FILE_STORAGE_BACKEND overrides it
+	LocalDir       string `toml:"local_dir"`        // directory local backend keeps files in
+	LocalPublicURL string `toml:"local_public_url"` // URL local files are served under by the server itself
+}
+
+func NewStorageConfig(configPath string) (*StorageConfig, error) {
+	if len(configPath) == 0 {
+		configPath = defaultConfigPath
+	}
+
+	var cfg StorageConfig
+	_, err := toml.DecodeFile(configPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse storage config from file %v: %w", configPath, err)
+	}
+
+	cfg.Backend = getenv.GetEnv("FILE_STORAGE_BACKEND", cfg.Backend)
+	if cfg.Backend != BackendMinio && cfg.Backend != BackendLocal {
+		return nil, fmt.Errorf("unknown file storage backend %q", cfg.Backend)
+	}
+	return &cfg, nil
+}
@@ -2,6 +2,7 @@ package validation_config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -16,6 +17,31 @@ type ValidationConfig struct {
 	MaxMessagePicturesSize  string   `toml:"max_message_pictures_size"`
 	MaxPostTextLength       int      `toml:"max_post_text_length"`
 	MaxMessageTextLength    int      `toml:"max_message_text_length"`
+
+	Image    MediaLimits `toml:"image"`
+	Video    MediaLimits `toml:"video"`
+	Audio    MediaLimits `toml:"audio"`
+	Document MediaLimits `toml:"document"`
+}
+
+// MediaLimits restricts uploaded files of a single media type, zero means no limit.
+type MediaLimits struct {
+	MaxSize     int64         `toml:"max_size"` // in bytes
+	MaxDuration time.Duration `toml:"max_duration"`
+}
+
+// LimitsFor returns limits of given media type.
+func (c *ValidationConfig) LimitsFor(mediaType string) MediaLimits {
+	switch mediaType {
+	case "image":
+		return c.Image
+	case "video":
+		return c.Video
+	case "audio":
+		return c.Audio
+	default:
+		return c.Document
+	}
 }
 
 func NewValidationConfig(configPath string) (*ValidationConfig, error) {
@@ -17,6 +17,15 @@ type HttpHandlerCollection struct {
 	MessageHandler *http2.MessageHandler
 	FriendHandler  *http2.FriendHandler
 	CSRFHandler    *http2.CSRFHandler
+	UploadHandler  *http2.UploadHandler
+
+	NotificationHandler *http2.NotificationHandler
+	PollHandler         *http2.PollHandler
+	BookmarkHandler     *http2.BookmarkHandler
+	AnalyticsHandler    *http2.AnalyticsHandler
+	FeedFilterHandler   *http2.FeedFilterHandler
+	ReportHandler       *http2.ReportHandler
+	AdminHandler        *http2.AdminHandler
 }
 
 type HttpWSHandlerFactory struct {
@@ -41,11 +50,20 @@ func (f *HttpWSHandlerFactory) InitHttpHandlers() *HttpHandlerCollection {
 		ChatHandler:    http2.NewChatHandler(f.serviceFactory.ChatService(), f.serviceFactory.ProfileService(), f.connManager),
 		FeedHandler:    http2.NewFeedHandler(f.serviceFactory.AuthService(), f.serviceFactory.PostService(), f.serviceFactory.ProfileService(), f.serviceFactory.FriendService()),
 		PostHandler:    http2.NewPostHandler(f.serviceFactory.PostService(), f.serviceFactory.ProfileService(), f.sanitizer),
-		ProfileHandler: http2.NewProfileHandler(f.serviceFactory.ProfileService(), f.serviceFactory.FriendService(), f.serviceFactory.AuthService(), f.serviceFactory.ChatService(), f.connManager, f.sanitizer),
+		ProfileHandler: http2.NewProfileHandler(f.serviceFactory.ProfileService(), f.serviceFactory.ChatService(), f.connManager, f.sanitizer),
 		SearchHandler:  http2.NewSearchHandler(f.serviceFactory.SearchService()),
 		MessageHandler: http2.NewMessageHandler(f.serviceFactory.MessageService(), f.serviceFactory.AuthService(), f.serviceFactory.ProfileService(), f.sanitizer),
 		FriendHandler:  http2.NewFriendHandler(f.serviceFactory.FriendService(), f.connManager),
 		CSRFHandler:    http2.NewCSRFHandler(),
+		UploadHandler:  http2.NewUploadHandler(f.serviceFactory.UploadService()),
+
+		NotificationHandler: http2.NewNotificationHandler(f.serviceFactory.NotificationService(), f.serviceFactory.ProfileService()),
+		PollHandler:         http2.NewPollHandler(f.serviceFactory.PollService(), f.serviceFactory.ProfileService()),
+		BookmarkHandler:     http2.NewBookmarkHandler(f.serviceFactory.BookmarkService(), f.sanitizer),
+		AnalyticsHandler:    http2.NewAnalyticsHandler(f.serviceFactory.AnalyticsService()),
+		FeedFilterHandler:   http2.NewFeedFilterHandler(f.serviceFactory.FeedFilterService()),
+		ReportHandler:       http2.NewReportHandler(f.serviceFactory.ReportService(), f.sanitizer),
+		AdminHandler:        http2.NewAdminHandler(f.serviceFactory.AdminService()),
 	}
 }
 
@@ -13,6 +13,21 @@ type RepositoryFactory interface {
 	MessageRepository() usecase.MessageRepository
 	FileRepository() usecase.FileRepository
 	FriendRepository() usecase.FriendsRepository
+	RecommendationRepository() usecase.RecommendationRepository
+	RecommendationCache() usecase.RecommendationCache
+	FileStorage() usecase.FileStorage
+	FileReferenceRepository() usecase.FileReferenceRepository
+	FileMover() usecase.FileMover
+	FileURLRepository() usecase.FileURLRepository
+	UploadStorage() usecase.UploadStorage
+	UploadSessionRepository() usecase.UploadSessionRepository
+	NotificationRepository() usecase.NotificationRepository
+	PollRepository() usecase.PollRepository
+	BookmarkRepository() usecase.BookmarkRepository
+	FeedFilterRepository() usecase.FeedFilterRepository
+	AnalyticsRepository() usecase.AnalyticsRepository
+	ViewCounter() usecase.ViewCounter
+	ReportRepository() usecase.ReportRepository
 	Close() error
 }
 
@@ -24,6 +39,17 @@ type ServiceFactory interface {
 	MessageService() *usecase.MessageService
 	FriendService() *usecase.FriendsService
 	SearchService() *usecase.SearchService
+	RecommendationService() *usecase.RecommendationService
+	FileGCService() *usecase.FileGCService
+	FileMigrationService() *usecase.FileMigrationService
+	UploadService() *usecase.UploadService
+	NotificationService() *usecase.NotificationService
+	PollService() *usecase.PollService
+	BookmarkService() *usecase.BookmarkService
+	FeedFilterService() *usecase.FeedFilterService
+	AnalyticsService() *usecase.AnalyticsService
+	ReportService() *usecase.ReportService
+	AdminService() *usecase.AdminService
 }
 
 type HandlerFactory interface {
@@ -2,18 +2,41 @@ package factory
 
 import (
 	"database/sql"
+	"net/http"
+	"strings"
 
 	"quickflow/config"
+	image_config "quickflow/config/image"
+	storage_config "quickflow/config/storage"
+	validation_config "quickflow/config/validation"
+	"quickflow/internal/repository/dedup"
+	"quickflow/internal/repository/images"
+	"quickflow/internal/repository/local"
+	"quickflow/internal/repository/media"
 	"quickflow/internal/repository/minio"
 	"quickflow/internal/repository/postgres"
 	"quickflow/internal/repository/redis"
 	"quickflow/internal/usecase"
 )
 
+// fileBackend is implemented by every storage files can be kept in.
+type fileBackend interface {
+	usecase.FileRepository
+	usecase.FileStorage
+	usecase.FileMover
+	usecase.UploadStorage
+}
+
 type PGMFactory struct {
 	db        *sql.DB
-	minioRepo *minio.MinioRepository
+	files     fileBackend
+	localRepo *local.LocalFileRepository // set only when files are kept locally
 	redisRepo *redis.RedisSessionRepository
+	recCache  *redis.RedisRecommendationRepository
+	uploads   *redis.RedisUploadSessionRepository
+	views     *redis.RedisViewCounterRepository
+	imageCfg  *image_config.ImageConfig
+	mediaCfg  *validation_config.ValidationConfig
 }
 
 func NewPGMFactory(cfg *config.Config) (*PGMFactory, error) {
@@ -21,16 +44,41 @@ func NewPGMFactory(cfg *config.Config) (*PGMFactory, error) {
 	if err != nil {
 		return nil, err
 	}
-	fileRepo, err := minio.NewMinioRepository(cfg.MinioConfig)
-	if err != nil {
-		return nil, err
+
+	var (
+		files     fileBackend
+		localRepo *local.LocalFileRepository
+	)
+	switch cfg.StorageConfig.Backend {
+	case storage_config.BackendLocal:
+		localRepo, err = local.NewLocalFileRepository(cfg.StorageConfig, cfg.MinioConfig)
+		if err != nil {
+			return nil, err
+		}
+		files = localRepo
+	default:
+		minioRepo, err := minio.NewMinioRepository(cfg.MinioConfig)
+		if err != nil {
+			return nil, err
+		}
+		files = minioRepo
 	}
+
 	redisRepo := redis.NewRedisSessionRepository()
+	recCache := redis.NewRedisRecommendationRepository()
+	uploads := redis.NewRedisUploadSessionRepository()
+	views := redis.NewRedisViewCounterRepository()
 
 	return &PGMFactory{
 		db:        db,
-		minioRepo: fileRepo,
+		files:     files,
+		localRepo: localRepo,
 		redisRepo: redisRepo,
+		recCache:  recCache,
+		uploads:   uploads,
+		views:     views,
+		imageCfg:  cfg.ImageConfig,
+		mediaCfg:  cfg.ValidationConfig,
 	}, nil
 }
 
@@ -58,14 +106,93 @@ func (f *PGMFactory) MessageRepository() usecase.MessageRepository {
 	return postgres.NewPostgresMessageRepository(f.db)
 }
 
+// FileRepository returns file storage that checks uploaded files against limits of their media type,
+// converts uploaded images to sized variants and keeps files with the same content once.
 func (f *PGMFactory) FileRepository() usecase.FileRepository {
-	return f.minioRepo
+	return media.NewMediaValidatingRepository(
+		dedup.NewDeduplicatingFileRepository(
+			images.NewImageProcessingRepository(f.files, f.imageCfg),
+			f.files,
+			postgres.NewPostgresStoredFileRepository(f.db),
+		),
+		f.mediaCfg,
+	)
 }
 
 func (f *PGMFactory) FriendRepository() usecase.FriendsRepository {
 	return postgres.NewPostgresFriendsRepository(f.db)
 }
 
+func (f *PGMFactory) NotificationRepository() usecase.NotificationRepository {
+	return postgres.NewPostgresNotificationRepository(f.db)
+}
+
+func (f *PGMFactory) PollRepository() usecase.PollRepository {
+	return postgres.NewPostgresPollRepository(f.db)
+}
+
+func (f *PGMFactory) BookmarkRepository() usecase.BookmarkRepository {
+	return postgres.NewPostgresBookmarkRepository(f.db)
+}
+
+func (f *PGMFactory) FeedFilterRepository() usecase.FeedFilterRepository {
+	return postgres.NewPostgresFeedFilterRepository(f.db)
+}
+
+func (f *PGMFactory) AnalyticsRepository() usecase.AnalyticsRepository {
+	return postgres.NewPostgresAnalyticsRepository(f.db)
+}
+
+func (f *PGMFactory) ViewCounter() usecase.ViewCounter {
+	return f.views
+}
+
+func (f *PGMFactory) ReportRepository() usecase.ReportRepository {
+	return postgres.NewPostgresReportRepository(f.db)
+}
+
+func (f *PGMFactory) RecommendationRepository() usecase.RecommendationRepository {
+	return postgres.NewPostgresRecommendationRepository(f.db)
+}
+
+func (f *PGMFactory) RecommendationCache() usecase.RecommendationCache {
+	return f.recCache
+}
+
+func (f *PGMFactory) FileStorage() usecase.FileStorage {
+	return f.files
+}
+
+func (f *PGMFactory) FileReferenceRepository() usecase.FileReferenceRepository {
+	return postgres.NewPostgresFileReferenceRepository(f.db)
+}
+
+func (f *PGMFactory) FileMover() usecase.FileMover {
+	return f.files
+}
+
+func (f *PGMFactory) FileURLRepository() usecase.FileURLRepository {
+	return postgres.NewPostgresFileReferenceRepository(f.db)
+}
+
+func (f *PGMFactory) UploadStorage() usecase.UploadStorage {
+	return f.files
+}
+
+func (f *PGMFactory) UploadSessionRepository() usecase.UploadSessionRepository {
+	return f.uploads
+}
+
+// LocalFiles returns handler serving locally kept files and URL path to mount it at.
+// ok is false when files are kept in MinIO, which serves them itself.
+func (f *PGMFactory) LocalFiles() (handler http.Handler, pathPrefix string, ok bool) {
+	if f.localRepo == nil {
+		return nil, "", false
+	}
+	pathPrefix = f.localRepo.PublicPath()
+	return http.StripPrefix(strings.TrimSuffix(pathPrefix, "/"), f.localRepo), pathPrefix, true
+}
+
 func (f *PGMFactory) Close() error {
 	if err := f.db.Close(); err != nil {
 		return err
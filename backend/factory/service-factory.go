@@ -1,16 +1,19 @@
 package factory
 
 import (
+	"quickflow/config"
 	"quickflow/internal/usecase"
 )
 
 type DefaultServiceFactory struct {
 	repoFactory RepositoryFactory
+	cfg         *config.Config
 }
 
-func NewDefaultServiceFactory(repoFactory RepositoryFactory) *DefaultServiceFactory {
+func NewDefaultServiceFactory(repoFactory RepositoryFactory, cfg *config.Config) *DefaultServiceFactory {
 	return &DefaultServiceFactory{
 		repoFactory: repoFactory,
+		cfg:         cfg,
 	}
 }
 
@@ -27,6 +30,8 @@ func (f *DefaultServiceFactory) ProfileService() *usecase.ProfileService {
 		f.repoFactory.ProfileRepository(),
 		f.repoFactory.UserRepository(),
 		f.repoFactory.FileRepository(),
+		f.repoFactory.FriendRepository(),
+		f.UploadService(),
 	)
 }
 
@@ -34,6 +39,15 @@ func (f *DefaultServiceFactory) PostService() *usecase.PostService {
 	return usecase.NewPostService(
 		f.repoFactory.PostRepository(),
 		f.repoFactory.FileRepository(),
+		f.repoFactory.ProfileRepository(),
+		f.repoFactory.FriendRepository(),
+		f.RecommendationService(),
+		f.UploadService(),
+		f.NotificationService(),
+		f.repoFactory.PollRepository(),
+		f.repoFactory.BookmarkRepository(),
+		f.repoFactory.ViewCounter(),
+		f.repoFactory.FeedFilterRepository(),
 	)
 }
 
@@ -51,6 +65,8 @@ func (f *DefaultServiceFactory) MessageService() *usecase.MessageService {
 		f.repoFactory.MessageRepository(),
 		f.repoFactory.FileRepository(),
 		f.repoFactory.ChatRepository(),
+		f.UploadService(),
+		f.NotificationService(),
 	)
 }
 
@@ -65,3 +81,95 @@ func (f *DefaultServiceFactory) SearchService() *usecase.SearchService {
 		f.repoFactory.UserRepository(),
 	)
 }
+
+func (f *DefaultServiceFactory) RecommendationService() *usecase.RecommendationService {
+	return usecase.NewRecommendationService(
+		f.repoFactory.RecommendationRepository(),
+		f.repoFactory.RecommendationCache(),
+		f.cfg.RecommendationConfig,
+	)
+}
+
+func (f *DefaultServiceFactory) FileGCService() *usecase.FileGCService {
+	return usecase.NewFileGCService(
+		f.repoFactory.FileStorage(),
+		f.repoFactory.FileReferenceRepository(),
+		f.cfg.FileGCConfig,
+	)
+}
+
+func (f *DefaultServiceFactory) FileMigrationService() *usecase.FileMigrationService {
+	return usecase.NewFileMigrationService(
+		f.repoFactory.FileMover(),
+		f.repoFactory.FileURLRepository(),
+	)
+}
+
+func (f *DefaultServiceFactory) UploadService() *usecase.UploadService {
+	return usecase.NewUploadService(
+		f.repoFactory.UploadStorage(),
+		f.repoFactory.UploadSessionRepository(),
+		f.repoFactory.FileRepository(),
+		f.cfg.MinioConfig.PresignedURLExpiration,
+	)
+}
+
+func (f *DefaultServiceFactory) NotificationService() *usecase.NotificationService {
+	return usecase.NewNotificationService(
+		f.repoFactory.NotificationRepository(),
+		f.repoFactory.UserRepository(),
+		f.repoFactory.FriendRepository(),
+		f.repoFactory.ChatRepository(),
+	)
+}
+
+func (f *DefaultServiceFactory) PollService() *usecase.PollService {
+	return usecase.NewPollService(
+		f.repoFactory.PollRepository(),
+		f.repoFactory.PostRepository(),
+		f.repoFactory.FriendRepository(),
+		f.repoFactory.ProfileRepository(),
+	)
+}
+
+func (f *DefaultServiceFactory) BookmarkService() *usecase.BookmarkService {
+	return usecase.NewBookmarkService(
+		f.repoFactory.BookmarkRepository(),
+		f.repoFactory.PostRepository(),
+		f.repoFactory.FriendRepository(),
+		f.repoFactory.ProfileRepository(),
+	)
+}
+
+func (f *DefaultServiceFactory) FeedFilterService() *usecase.FeedFilterService {
+	return usecase.NewFeedFilterService(
+		f.repoFactory.FeedFilterRepository(),
+		f.repoFactory.PostRepository(),
+		f.repoFactory.FriendRepository(),
+		f.repoFactory.UserRepository(),
+		f.repoFactory.ProfileRepository(),
+	)
+}
+
+func (f *DefaultServiceFactory) AnalyticsService() *usecase.AnalyticsService {
+	return usecase.NewAnalyticsService(
+		f.repoFactory.AnalyticsRepository(),
+		f.repoFactory.ViewCounter(),
+	)
+}
+
+func (f *DefaultServiceFactory) AdminService() *usecase.AdminService {
+	return usecase.NewAdminService(
+		f.repoFactory.UserRepository(),
+		f.repoFactory.SessionRepository(),
+		f.PostService(),
+	)
+}
+
+func (f *DefaultServiceFactory) ReportService() *usecase.ReportService {
+	return usecase.NewReportService(
+		f.repoFactory.ReportRepository(),
+		f.repoFactory.UserRepository(),
+		f.PostService(),
+	)
+}
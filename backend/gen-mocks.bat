@@ -17,6 +17,7 @@ REM Delivery mocks
 %MOCKGEN% -source=%DELIEVERY_PATH%/http/message-handlerWS.go -destination=%DELIEVERY_PATH%/http/mocks/messageWS-mock.go -package=mocks
 %MOCKGEN% -source=%DELIEVERY_PATH%/http/profile-handler.go -destination=%DELIEVERY_PATH%/http/mocks/profile-mock.go -package=mocks
 %MOCKGEN% -source=%DELIEVERY_PATH%/http/search-handler.go -destination=%DELIEVERY_PATH%/http/mocks/search-mock.go -package=mocks
+%MOCKGEN% -source=%DELIEVERY_PATH%/http/upload-handler.go -destination=%DELIEVERY_PATH%/http/mocks/upload-mock.go -package=mocks
 %MOCKGEN% -source=%DELIEVERY_PATH%/ws/ws-manager.go -destination=%DELIEVERY_PATH%/ws/mocks/manager-mock.go -package=mocks
 
 REM Usecase mocks
@@ -27,6 +28,10 @@ REM Usecase mocks
 %MOCKGEN% -source=%USECASE_PATH%/message-usecase.go -destination=%USECASE_PATH%/mocks/message-mock.go -package=mocks
 %MOCKGEN% -source=%USECASE_PATH%/profile-usecase.go -destination=%USECASE_PATH%/mocks/profile-mock.go -package=mocks
 %MOCKGEN% -source=%USECASE_PATH%/search-usecase.go -destination=%USECASE_PATH%/mocks/search-mock.go -package=mocks
+%MOCKGEN% -source=%USECASE_PATH%/recommendation-usecase.go -destination=%USECASE_PATH%/mocks/recommendation-mock.go -package=mocks
+%MOCKGEN% -source=%USECASE_PATH%/file-gc-usecase.go -destination=%USECASE_PATH%/mocks/file-gc-mock.go -package=mocks
+%MOCKGEN% -source=%USECASE_PATH%/file-migration-usecase.go -destination=%USECASE_PATH%/mocks/file-migration-mock.go -package=mocks
+%MOCKGEN% -source=%USECASE_PATH%/upload-usecase.go -destination=%USECASE_PATH%/mocks/upload-mock.go -package=mocks
 
 REM Repository mocks
 %MOCKGEN% -source=%REPOSITORY_PATH%/postgres/user.go -destination=%REPOSITORY_PATH%/postgres/mocks/user-mock.go -package=mocks
@@ -0,0 +1,157 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+const defaultAccountsCount = 20
+
+type UserRoleForm struct {
+	Role string `json:"role"`
+}
+
+type UserRoleOut struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func UserRoleToOut(user models.User) UserRoleOut {
+	return UserRoleOut{
+		Id:       user.Id.String(),
+		Username: user.Username,
+		Role:     string(user.Role),
+	}
+}
+
+// SearchAccountsForm is a search of accounts by username or full name, all accounts are listed without query.
+type SearchAccountsForm struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// GetParams gets parameters from the map, missing count is set to default.
+func (f *SearchAccountsForm) GetParams(values url.Values) error {
+	f.Query = values.Get("query")
+
+	f.Count = defaultAccountsCount
+	if values.Has("count") {
+		count, err := strconv.Atoi(values.Get("count"))
+		if err != nil {
+			return errors.New("failed to parse count")
+		}
+		f.Count = count
+	}
+	return nil
+}
+
+// BanForm suspends the user until the time, the ban is permanent without it.
+type BanForm struct {
+	Reason string `json:"reason"`
+	Until  string `json:"until,omitempty"`
+}
+
+// GetUntil returns the end of the ban, zero for a permanent ban.
+func (f *BanForm) GetUntil() (time.Time, error) {
+	if len(f.Until) == 0 {
+		return time.Time{}, nil
+	}
+	until, err := time.Parse(time2.TimeStampLayout, f.Until)
+	if err != nil {
+		return time.Time{}, errors.New("failed to parse until")
+	}
+	return until, nil
+}
+
+type BanOut struct {
+	Id        string `json:"id"`
+	Reason    string `json:"reason"`
+	BannedBy  string `json:"banned_by,omitempty"`
+	Until     string `json:"until,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+func BanToOut(ban models.Ban) BanOut {
+	out := BanOut{
+		Id:        ban.Id.String(),
+		Reason:    ban.Reason,
+		CreatedAt: ban.CreatedAt.Format(time2.TimeStampLayout),
+	}
+	if ban.BannedBy != uuid.Nil {
+		out.BannedBy = ban.BannedBy.String()
+	}
+	if !ban.Until.IsZero() {
+		out.Until = ban.Until.Format(time2.TimeStampLayout)
+	}
+	return out
+}
+
+type AccountOut struct {
+	Id        string  `json:"id"`
+	Username  string  `json:"username"`
+	Role      string  `json:"role"`
+	Firstname string  `json:"firstname,omitempty"`
+	Lastname  string  `json:"lastname,omitempty"`
+	Ban       *BanOut `json:"ban,omitempty"`
+}
+
+func AccountToOut(account models.Account) AccountOut {
+	out := AccountOut{
+		Id:        account.Id.String(),
+		Username:  account.Username,
+		Role:      string(account.Role),
+		Firstname: account.Firstname,
+		Lastname:  account.Lastname,
+	}
+	if account.Ban.Id != uuid.Nil {
+		ban := BanToOut(account.Ban)
+		out.Ban = &ban
+	}
+	return out
+}
+
+func AccountsToOut(accounts []models.Account) []AccountOut {
+	accountsOut := make([]AccountOut, 0, len(accounts))
+	for _, account := range accounts {
+		accountsOut = append(accountsOut, AccountToOut(account))
+	}
+	return accountsOut
+}
+
+type AccountDetailsOut struct {
+	AccountOut
+	ActiveSessions int `json:"active_sessions"`
+}
+
+func AccountDetailsToOut(details models.AccountDetails) AccountDetailsOut {
+	return AccountDetailsOut{
+		AccountOut:     AccountToOut(details.Account),
+		ActiveSessions: details.ActiveSessions,
+	}
+}
+
+type LogoutOut struct {
+	DeletedSessions int `json:"deleted_sessions"`
+}
+
+type DeletedContentOut struct {
+	Posts    int `json:"posts"`
+	Comments int `json:"comments"`
+	Messages int `json:"messages"`
+}
+
+func DeletedContentToOut(deleted models.DeletedContent) DeletedContentOut {
+	return DeletedContentOut{
+		Posts:    deleted.Posts,
+		Comments: deleted.Comments,
+		Messages: deleted.Messages,
+	}
+}
@@ -0,0 +1,94 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+const defaultAnalyticsDays = 30
+
+// AnalyticsForm is a range of days, both ends are included.
+type AnalyticsForm struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// GetParams gets parameters from the map. Missing end of the range is today,
+// missing start is set so that the range covers the last 30 days.
+func (f *AnalyticsForm) GetParams(values url.Values, now time.Time) error {
+	f.To = now.UTC()
+	if values.Has("to") {
+		to, err := time.Parse(time2.DateLayout, values.Get("to"))
+		if err != nil {
+			return errors.New("failed to parse to")
+		}
+		f.To = to
+	}
+
+	f.From = f.To.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if values.Has("from") {
+		from, err := time.Parse(time2.DateLayout, values.Get("from"))
+		if err != nil {
+			return errors.New("failed to parse from")
+		}
+		f.From = from
+	}
+	return nil
+}
+
+type DailyStatsOut struct {
+	Day          string `json:"day"`
+	Views        int    `json:"views"`
+	Likes        int    `json:"likes"`
+	Comments     int    `json:"comments"`
+	NewFollowers int    `json:"new_followers"`
+}
+
+type PostStatsOut struct {
+	PostId    string `json:"post_id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+	Views     int    `json:"views"`
+	Likes     int    `json:"likes"`
+	Comments  int    `json:"comments"`
+}
+
+type AnalyticsOut struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Days     []DailyStatsOut `json:"days"`
+	TopPosts []PostStatsOut  `json:"top_posts"`
+}
+
+func AnalyticsToOut(analytics models.Analytics) AnalyticsOut {
+	out := AnalyticsOut{
+		From:     analytics.From.Format(time2.DateLayout),
+		To:       analytics.To.Format(time2.DateLayout),
+		Days:     make([]DailyStatsOut, 0, len(analytics.Days)),
+		TopPosts: make([]PostStatsOut, 0, len(analytics.TopPosts)),
+	}
+	for _, day := range analytics.Days {
+		out.Days = append(out.Days, DailyStatsOut{
+			Day:          day.Day.Format(time2.DateLayout),
+			Views:        day.Views,
+			Likes:        day.Likes,
+			Comments:     day.Comments,
+			NewFollowers: day.NewFollowers,
+		})
+	}
+	for _, post := range analytics.TopPosts {
+		out.TopPosts = append(out.TopPosts, PostStatsOut{
+			PostId:    post.PostId.String(),
+			Text:      post.Text,
+			CreatedAt: post.CreatedAt.Format(time2.TimeStampLayout),
+			Views:     post.Views,
+			Likes:     post.Likes,
+			Comments:  post.Comments,
+		})
+	}
+	return out
+}
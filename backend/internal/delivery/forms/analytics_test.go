@@ -0,0 +1,55 @@
+package forms_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+)
+
+func TestAnalyticsForm_GetParams(t *testing.T) {
+	now := time.Date(2025, 5, 31, 18, 0, 0, 0, time.UTC)
+
+	var form forms.AnalyticsForm
+	require.NoError(t, form.GetParams(url.Values{}, now))
+	assert.Equal(t, now, form.To)
+	assert.Equal(t, time.Date(2025, 5, 2, 18, 0, 0, 0, time.UTC), form.From)
+
+	require.NoError(t, form.GetParams(url.Values{"to": {"2025-04-30"}}, now))
+	assert.Equal(t, time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), form.To)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), form.From)
+
+	require.NoError(t, form.GetParams(url.Values{"from": {"2025-01-01"}}, now))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), form.From)
+	assert.Equal(t, now, form.To)
+
+	assert.Error(t, form.GetParams(url.Values{"from": {"yesterday"}}, now))
+	assert.Error(t, form.GetParams(url.Values{"to": {"31.05.2025"}}, now))
+}
+
+func TestAnalyticsToOut(t *testing.T) {
+	postId := uuid.New()
+	day := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	out := forms.AnalyticsToOut(models.Analytics{
+		From:     day,
+		To:       day,
+		Days:     []models.DailyStats{{Day: day, Views: 10, Likes: 2, Comments: 1, NewFollowers: 3}},
+		TopPosts: []models.PostStats{{PostId: postId, Text: "hello", CreatedAt: day, Views: 10, Likes: 2, Comments: 1}},
+	})
+	assert.Equal(t, "2025-05-01", out.From)
+	assert.Equal(t, []forms.DailyStatsOut{{Day: "2025-05-01", Views: 10, Likes: 2, Comments: 1, NewFollowers: 3}}, out.Days)
+	require.Len(t, out.TopPosts, 1)
+	assert.Equal(t, postId.String(), out.TopPosts[0].PostId)
+	assert.Equal(t, 10, out.TopPosts[0].Views)
+
+	empty := forms.AnalyticsToOut(models.Analytics{From: day, To: day})
+	assert.NotNil(t, empty.Days)
+	assert.NotNil(t, empty.TopPosts)
+}
@@ -0,0 +1,79 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+// BookmarkForm puts bookmarked post to the collection, empty collection takes it out of collections.
+type BookmarkForm struct {
+	CollectionId string `json:"collection_id,omitempty"`
+}
+
+// Collection returns id of the collection, uuid.Nil if it is empty.
+func (f *BookmarkForm) Collection() (uuid.UUID, error) {
+	if len(f.CollectionId) == 0 {
+		return uuid.Nil, nil
+	}
+	collectionId, err := uuid.Parse(f.CollectionId)
+	if err != nil {
+		return uuid.Nil, errors.New("failed to parse collection_id")
+	}
+	return collectionId, nil
+}
+
+// BookmarksForm is a page of bookmarked posts, posts of all collections are requested without collection_id.
+type BookmarksForm struct {
+	FeedForm
+	CollectionId uuid.UUID `json:"collection_id"`
+}
+
+// GetParams gets parameters from the map
+func (f *BookmarksForm) GetParams(values url.Values) error {
+	if err := f.FeedForm.GetParams(values); err != nil {
+		return err
+	}
+
+	f.CollectionId = uuid.Nil
+	if values.Has("collection_id") {
+		collectionId, err := uuid.Parse(values.Get("collection_id"))
+		if err != nil {
+			return errors.New("failed to parse collection_id")
+		}
+		f.CollectionId = collectionId
+	}
+	return nil
+}
+
+type BookmarkCollectionForm struct {
+	Name string `json:"name"`
+}
+
+type BookmarkCollectionOut struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	PostCount int    `json:"post_count"`
+}
+
+func BookmarkCollectionToOut(collection models.BookmarkCollection) BookmarkCollectionOut {
+	return BookmarkCollectionOut{
+		Id:        collection.Id.String(),
+		Name:      collection.Name,
+		CreatedAt: collection.CreatedAt.Format(time2.TimeStampLayout),
+		PostCount: collection.PostCount,
+	}
+}
+
+func BookmarkCollectionsToOut(collections []models.BookmarkCollection) []BookmarkCollectionOut {
+	collectionsOut := make([]BookmarkCollectionOut, 0, len(collections))
+	for _, collection := range collections {
+		collectionsOut = append(collectionsOut, BookmarkCollectionToOut(collection))
+	}
+	return collectionsOut
+}
@@ -0,0 +1,45 @@
+package forms_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+)
+
+func TestBookmarksForm_GetParams(t *testing.T) {
+	var form forms.BookmarksForm
+	require.NoError(t, form.GetParams(url.Values{"posts_count": {"10"}}))
+	assert.Equal(t, 10, form.Posts)
+	assert.Equal(t, uuid.Nil, form.CollectionId)
+
+	collectionId := uuid.New()
+	require.NoError(t, form.GetParams(url.Values{"posts_count": {"10"}, "collection_id": {collectionId.String()}, "cursor": {""}}))
+	assert.Equal(t, collectionId, form.CollectionId)
+	assert.True(t, form.UseCursor)
+
+	assert.Error(t, form.GetParams(url.Values{"posts_count": {"10"}, "collection_id": {"recipes"}}))
+	assert.Error(t, form.GetParams(url.Values{}))
+}
+
+func TestBookmarkForm_Collection(t *testing.T) {
+	form := forms.BookmarkForm{}
+	collectionId, err := form.Collection()
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, collectionId)
+
+	form.CollectionId = "recipes"
+	_, err = form.Collection()
+	assert.Error(t, err)
+}
+
+func TestPostOut_IsBookmarked(t *testing.T) {
+	var out forms.PostOut
+	out.FromPost(models.Post{Id: uuid.New(), IsBookmarked: true})
+	assert.True(t, out.IsBookmarked)
+}
@@ -13,8 +13,10 @@ import (
 )
 
 type GetChatsForm struct {
-	ChatsCount int       `json:"chats_count"`
-	Ts         time.Time `json:"ts,omitempty"`
+	ChatsCount int           `json:"chats_count"`
+	Ts         time.Time     `json:"ts,omitempty"`
+	Cursor     models.Cursor `json:"-"`
+	UseCursor  bool          `json:"-"`
 }
 
 type ChatOut struct {
@@ -54,11 +56,11 @@ func (g *GetChatsForm) GetParams(values url.Values) error {
 
 	g.ChatsCount = int(numChats)
 
-	ts, err := time.Parse(time2.TimeStampLayout, values.Get("ts"))
+	g.Cursor, g.UseCursor, err = parseCursor(values)
 	if err != nil {
-		ts = time.Now()
+		return errors.New("failed to parse cursor")
 	}
-	g.Ts = ts
+	g.Ts = g.Cursor.Ts
 	return nil
 }
 
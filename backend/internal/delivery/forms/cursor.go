@@ -0,0 +1,33 @@
+package forms
+
+import (
+	"net/url"
+	"time"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+// CursorPage is returned by paginated endpoints when client requested cursor pagination.
+type CursorPage[T any] struct {
+	Payload    T      `json:"payload"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// parseCursor gets page position from query parameters.
+// Opaque cursor has priority over legacy ts, empty cursor means the first page.
+func parseCursor(values url.Values) (cursor models.Cursor, useCursor bool, err error) {
+	if values.Has("cursor") {
+		if values.Get("cursor") == "" {
+			return models.CursorFromTs(time.Now()), true, nil
+		}
+		cursor, err = models.ParseCursor(values.Get("cursor"))
+		return cursor, true, err
+	}
+
+	ts, err := time.Parse(time2.TimeStampLayout, values.Get("ts"))
+	if err != nil {
+		ts = time.Now()
+	}
+	return models.CursorFromTs(ts), false, nil
+}
@@ -0,0 +1,22 @@
+package forms
+
+import (
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+type MutedUserOut struct {
+	User    PublicUserInfoOut `json:"user"`
+	MutedAt string            `json:"muted_at"`
+}
+
+func MutedUsersToOut(muted []models.MutedUser) []MutedUserOut {
+	mutedOut := make([]MutedUserOut, 0, len(muted))
+	for _, user := range muted {
+		mutedOut = append(mutedOut, MutedUserOut{
+			User:    PublicUserInfoToOut(user.User, ""),
+			MutedAt: user.CreatedAt.Format(time2.TimeStampLayout),
+		})
+	}
+	return mutedOut
+}
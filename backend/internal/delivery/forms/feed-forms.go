@@ -10,6 +10,7 @@ import (
 
 	time2 "quickflow/config/time"
 	"quickflow/internal/models"
+	"quickflow/pkg/hashtag"
 )
 
 type File struct {
@@ -18,9 +19,26 @@ type File struct {
 }
 
 type PostForm struct {
-	Text     string         `json:"text"`
-	Images   []*models.File `json:"pics"`
-	IsRepost bool           `json:"is_repost"`
+	Text       string         `json:"text"`
+	Images     []*models.File `json:"pics"`
+	UploadKeys []string       `json:"upload_keys"`
+	IsRepost   bool           `json:"is_repost"`
+	Visibility string         `json:"visibility"`
+	Draft      bool           `json:"draft"`
+	PublishAt  time.Time      `json:"publish_at"`
+	Poll       *models.Poll   `json:"poll"`
+}
+
+// ParsePublishAt parses time the post is scheduled at, empty value means the post is not scheduled.
+func ParsePublishAt(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	publishAt, err := time.Parse(time2.TimeStampLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("failed to parse publish_at")
+	}
+	return publishAt, nil
 }
 
 func (p *PostForm) ToPostModel(userId uuid.UUID) models.Post {
@@ -30,14 +48,30 @@ func (p *PostForm) ToPostModel(userId uuid.UUID) models.Post {
 	postModel.CreatedAt = time.Now()
 	postModel.UpdatedAt = time.Now()
 	postModel.Images = p.Images
+	postModel.UploadKeys = p.UploadKeys
 	postModel.IsRepost = p.IsRepost
+	postModel.Visibility = models.PostVisibility(p.Visibility)
+	postModel.Poll = p.Poll
+
+	switch {
+	case !p.PublishAt.IsZero():
+		postModel.Status = models.PostStatusScheduled
+		postModel.PublishAt = p.PublishAt
+	case p.Draft:
+		postModel.Status = models.PostStatusDraft
+	default:
+		postModel.Status = models.PostStatusPublished
+	}
 
 	return postModel
 }
 
 type FeedForm struct {
-	Posts int    `json:"posts_count"`
-	Ts    string `json:"ts"`
+	Posts     int           `json:"posts_count"`
+	Ts        string        `json:"ts"`
+	Cursor    models.Cursor `json:"-"`
+	UseCursor bool          `json:"-"`
+	FirstPage bool          `json:"-"` // page is requested without position, so it starts from the newest posts
 }
 
 // GetParams gets parameters from the map
@@ -58,6 +92,12 @@ func (f *FeedForm) GetParams(values url.Values) error {
 
 	f.Posts = int(numPosts)
 	f.Ts = values.Get("ts")
+	f.Cursor, f.UseCursor, err = parseCursor(values)
+	if err != nil {
+		return errors.New("failed to parse cursor")
+	}
+	// pages requested by ts may start from the newest posts as well, it is checked against the posts
+	f.FirstPage = values.Get("cursor") == "" && values.Get("ts") == ""
 	return nil
 }
 
@@ -83,47 +123,121 @@ func PublicUserInfoToOut(info models.PublicUserInfo, relation models.UserRelatio
 }
 
 type PostOut struct {
-	Id           string            `json:"id"`
-	Creator      PublicUserInfoOut `json:"author"`
-	Desc         string            `json:"text"`
-	Pics         []string          `json:"pics"`
-	CreatedAt    string            `json:"created_at"`
-	UpdatedAt    string            `json:"updated_at"`
-	LikeCount    int               `json:"like_count"`
-	RepostCount  int               `json:"repost_count"`
-	CommentCount int               `json:"comment_count"`
-	IsRepost     bool              `json:"is_repost"`
+	Id           string             `json:"id"`
+	Creator      PublicUserInfoOut  `json:"author"`
+	Desc         string             `json:"text"`
+	Pics         []string           `json:"pics"`
+	PicsVariants []ImageVariantsOut `json:"pics_variants"`
+	Media        []MediaOut         `json:"media"`
+	CreatedAt    string             `json:"created_at"`
+	UpdatedAt    string             `json:"updated_at"`
+	LikeCount    int                `json:"like_count"`
+	RepostCount  int                `json:"repost_count"`
+	CommentCount int                `json:"comment_count"`
+	ViewCount    int                `json:"view_count"`
+	IsRepost     bool               `json:"is_repost"`
+	Visibility   string             `json:"visibility"`
+	Status       string             `json:"status"`
+	PublishAt    string             `json:"publish_at,omitempty"`
+	Edited       bool               `json:"edited"`
+	EditedAt     string             `json:"edited_at,omitempty"`
+	Tags         []string           `json:"tags"`
+	Mentions     []MentionOut       `json:"mentions"`
+	Poll         *PollOut           `json:"poll,omitempty"`
+	IsBookmarked bool               `json:"is_bookmarked"`
+	Pinned       bool               `json:"pinned"`
 }
 
 func (p *PostOut) FromPost(post models.Post) {
 	var urls []string
+	var variants []ImageVariantsOut
 	for _, url := range post.ImagesURL {
 		urls = append(urls, url)
+		variants = append(variants, ImageVariantsToOut(url))
 	}
 
 	p.Id = post.Id.String()
 	p.Desc = post.Desc
 	p.Pics = urls
+	p.PicsVariants = variants
+	p.Media = MediaToOut(post.ImagesURL, post.Media)
 	p.CreatedAt = post.CreatedAt.Format(time2.TimeStampLayout)
 	p.UpdatedAt = post.UpdatedAt.Format(time2.TimeStampLayout)
 	p.Creator.ID = post.CreatorId.String()
 	p.LikeCount = post.LikeCount
 	p.RepostCount = post.RepostCount
 	p.CommentCount = post.CommentCount
+	p.ViewCount = post.ViewCount
 	p.IsRepost = post.IsRepost
+	p.Visibility = string(post.Visibility)
+	p.Status = string(post.Status)
+	if p.Status == "" {
+		p.Status = string(models.PostStatusPublished)
+	}
+	if !post.PublishAt.IsZero() {
+		p.PublishAt = post.PublishAt.Format(time2.TimeStampLayout)
+	}
+	p.Tags = hashtag.Parse(post.Desc)
+	p.Mentions = MentionsToOut(post.Mentions)
+	if post.Poll != nil {
+		poll := PollToOut(*post.Poll)
+		p.Poll = &poll
+	}
+	p.IsBookmarked = post.IsBookmarked
+	p.Pinned = post.IsPinned
+	p.Edited = !post.EditedAt.IsZero()
+	if p.Edited {
+		p.EditedAt = post.EditedAt.Format(time2.TimeStampLayout)
+	}
+}
+
+// PostRevisionOut is a version of the post in its edit history.
+type PostRevisionOut struct {
+	Id           string             `json:"id"`
+	EditorId     string             `json:"editor_id,omitempty"`
+	Desc         string             `json:"text"`
+	Pics         []string           `json:"pics"`
+	PicsVariants []ImageVariantsOut `json:"pics_variants"`
+	CreatedAt    string             `json:"created_at"`
+}
+
+func (r *PostRevisionOut) FromPostRevision(revision models.PostRevision) {
+	r.Id = revision.Id.String()
+	if revision.EditorId != uuid.Nil {
+		r.EditorId = revision.EditorId.String()
+	}
+	r.Desc = revision.Desc
+	r.Pics = revision.ImagesURL
+	for _, url := range revision.ImagesURL {
+		r.PicsVariants = append(r.PicsVariants, ImageVariantsToOut(url))
+	}
+	r.CreatedAt = revision.CreatedAt.Format(time2.TimeStampLayout)
+}
+
+// SchedulePostForm sets time draft or scheduled post is published at.
+type SchedulePostForm struct {
+	PublishAt string `json:"publish_at"`
 }
 
 type UpdatePostForm struct {
-	Id     string         `json:"-"`
-	Text   string         `json:"text"`
-	Images []*models.File `json:"pics"`
+	Id         string         `json:"-"`
+	Text       string         `json:"text"`
+	Images     []*models.File `json:"pics"`
+	UploadKeys []string       `json:"upload_keys"`
+	Visibility string         `json:"visibility"`
 }
 
 func (p *UpdatePostForm) ToPostUpdateModel(postId uuid.UUID) (models.PostUpdate, error) {
+	visibility := models.PostVisibility(p.Visibility)
+	if len(visibility) != 0 && !visibility.IsValid() {
+		return models.PostUpdate{}, errors.New("invalid visibility")
+	}
 
 	return models.PostUpdate{
-		Id:    postId,
-		Desc:  p.Text,
-		Files: p.Images,
+		Id:         postId,
+		Desc:       p.Text,
+		Files:      p.Images,
+		UploadKeys: p.UploadKeys,
+		Visibility: visibility,
 	}, nil
 }
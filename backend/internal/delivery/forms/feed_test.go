@@ -36,6 +36,8 @@ func TestPostForm_ToPostModel(t *testing.T) {
 }
 
 func TestFeedForm_GetParams(t *testing.T) {
+	testCursor := models.Cursor{Ts: time.Date(2025, 4, 15, 12, 0, 0, 123, time.UTC), Id: uuid.New()}
+
 	tests := []struct {
 		name          string
 		values        url.Values
@@ -49,11 +51,36 @@ func TestFeedForm_GetParams(t *testing.T) {
 				"ts":          []string{"2025-04-16T00:00:00Z"},
 			},
 			expectedForm: forms.FeedForm{
-				Posts: 5,
-				Ts:    "2025-04-16T00:00:00Z",
+				Posts:  5,
+				Ts:     "2025-04-16T00:00:00Z",
+				Cursor: models.CursorFromTs(time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC)),
 			},
 			expectedError: nil,
 		},
+		{
+			name: "cursor has priority over ts",
+			values: url.Values{
+				"posts_count": []string{"5"},
+				"ts":          []string{"2025-04-16T00:00:00Z"},
+				"cursor":      []string{testCursor.String()},
+			},
+			expectedForm: forms.FeedForm{
+				Posts:     5,
+				Ts:        "2025-04-16T00:00:00Z",
+				Cursor:    testCursor,
+				UseCursor: true,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "invalid cursor",
+			values: url.Values{
+				"posts_count": []string{"5"},
+				"cursor":      []string{"not-a-cursor"},
+			},
+			expectedForm:  forms.FeedForm{},
+			expectedError: errors.New("failed to parse cursor"),
+		},
 		{
 			name: "missing posts_count parameter",
 			values: url.Values{
@@ -87,6 +114,29 @@ func TestFeedForm_GetParams(t *testing.T) {
 	}
 }
 
+func TestFeedForm_GetParams_FirstPage(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+		want   bool
+	}{
+		{name: "no position", values: url.Values{"posts_count": []string{"5"}}, want: true},
+		{name: "empty cursor", values: url.Values{"posts_count": []string{"5"}, "cursor": []string{""}}, want: true},
+		// whether the current second is the first page is decided by posts of the user
+		{name: "current second", values: url.Values{"posts_count": []string{"5"}, "ts": []string{time.Now().UTC().Truncate(time.Second).Format(time.RFC3339)}}},
+		{name: "older posts", values: url.Values{"posts_count": []string{"5"}, "ts": []string{"2025-04-16T00:00:00Z"}}},
+		{name: "cursor", values: url.Values{"posts_count": []string{"5"}, "cursor": []string{models.CursorFromTs(time.Now().Add(time.Minute)).String()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form forms.FeedForm
+			assert.NoError(t, form.GetParams(tt.values))
+			assert.Equal(t, tt.want, form.FirstPage)
+		})
+	}
+}
+
 func TestPublicUserInfoToOut(t *testing.T) {
 	userID := uuid.New()
 	// Create a mock PublicUserInfo
@@ -136,6 +186,65 @@ func TestPostOut_FromPost(t *testing.T) {
 	assert.Equal(t, postOut.Pics[0], "http://example.com/image.jpg")
 	assert.Equal(t, postOut.LikeCount, 10)
 	assert.Equal(t, postOut.IsRepost, false)
+	assert.Equal(t, postOut.Status, "published")
+	assert.Empty(t, postOut.PublishAt)
+	assert.False(t, postOut.Edited)
+
+	post.EditedAt = time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC)
+	postOut.FromPost(post)
+	assert.True(t, postOut.Edited)
+	assert.Equal(t, "2025-04-15T12:00:00Z", postOut.EditedAt)
+}
+
+func TestPostRevisionOut_FromPostRevision(t *testing.T) {
+	revision := models.PostRevision{
+		Id:        uuid.New(),
+		Desc:      "original",
+		ImagesURL: []string{"http://example.com/image.jpg"},
+		CreatedAt: time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	var revisionOut forms.PostRevisionOut
+	revisionOut.FromPostRevision(revision)
+
+	assert.Equal(t, revision.Id.String(), revisionOut.Id)
+	assert.Empty(t, revisionOut.EditorId)
+	assert.Equal(t, "original", revisionOut.Desc)
+	assert.Equal(t, revision.ImagesURL, revisionOut.Pics)
+	assert.Len(t, revisionOut.PicsVariants, 1)
+	assert.Equal(t, "2025-04-15T12:00:00Z", revisionOut.CreatedAt)
+}
+
+func TestPostForm_ToPostModel_Status(t *testing.T) {
+	publishAt := time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC)
+
+	draftForm := forms.PostForm{Text: "draft", Draft: true}
+	draft := draftForm.ToPostModel(uuid.New())
+	assert.Equal(t, models.PostStatusDraft, draft.Status)
+	assert.True(t, draft.PublishAt.IsZero())
+
+	scheduledForm := forms.PostForm{Text: "scheduled", PublishAt: publishAt}
+	scheduled := scheduledForm.ToPostModel(uuid.New())
+	assert.Equal(t, models.PostStatusScheduled, scheduled.Status)
+	assert.Equal(t, publishAt, scheduled.PublishAt)
+
+	var postOut forms.PostOut
+	postOut.FromPost(scheduled)
+	assert.Equal(t, "scheduled", postOut.Status)
+	assert.Equal(t, "2025-04-15T12:00:00Z", postOut.PublishAt)
+}
+
+func TestParsePublishAt(t *testing.T) {
+	publishAt, err := forms.ParsePublishAt("")
+	assert.NoError(t, err)
+	assert.True(t, publishAt.IsZero())
+
+	publishAt, err = forms.ParsePublishAt("2025-04-15T12:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC), publishAt)
+
+	_, err = forms.ParsePublishAt("tomorrow")
+	assert.Error(t, err)
 }
 
 func TestUpdatePostForm_ToPostUpdateModel(t *testing.T) {
@@ -155,3 +264,16 @@ func TestUpdatePostForm_ToPostUpdateModel(t *testing.T) {
 	assert.Len(t, postUpdate.Files, 1)
 	assert.Equal(t, postUpdate.Files[0].Name, "image1.jpg")
 }
+
+func TestTrendingTagsForm_GetParams(t *testing.T) {
+	var form forms.TrendingTagsForm
+	assert.NoError(t, form.GetParams(url.Values{}))
+	assert.Equal(t, "day", form.Window)
+	assert.Equal(t, 10, form.Count)
+
+	assert.NoError(t, form.GetParams(url.Values{"window": {"week"}, "tags_count": {"5"}}))
+	assert.Equal(t, "week", form.Window)
+	assert.Equal(t, 5, form.Count)
+
+	assert.Error(t, form.GetParams(url.Values{"tags_count": {"five"}}))
+}
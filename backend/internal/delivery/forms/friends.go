@@ -36,7 +36,7 @@ func (f *FriendsInfoOut) toFriendsInfoOutForm(info models.FriendInfo, isOnline b
 	}
 }
 
-func (f *FriendsInfoOut) ToJson(friendsInfo []models.FriendInfo, friendsOnline []bool, hasMore bool, friendsCount int) map[string]map[string]interface{} {
+func (f *FriendsInfoOut) ToJson(friendsInfo []models.FriendInfo, friendsOnline []bool, hasMore bool, friendsCount int, nextCursor string) map[string]map[string]interface{} {
 	res := make(map[string]map[string]interface{})
 	res["body"] = make(map[string]interface{})
 
@@ -48,6 +48,9 @@ func (f *FriendsInfoOut) ToJson(friendsInfo []models.FriendInfo, friendsOnline [
 	res["body"]["friends"] = friendsInfoOut
 	res["body"]["has_more"] = hasMore
 	res["body"]["total_count"] = friendsCount
+	if nextCursor != "" {
+		res["body"]["next_cursor"] = nextCursor
+	}
 
 	return res
 
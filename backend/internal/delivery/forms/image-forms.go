@@ -0,0 +1,27 @@
+package forms
+
+import "quickflow/internal/models"
+
+type ImageVariantsOut struct {
+	Thumbnail string `json:"thumbnail"`
+	Feed      string `json:"feed"`
+	Full      string `json:"full"`
+}
+
+func ImageVariantsToOut(url string) ImageVariantsOut {
+	variants := models.ImageVariantsFromURL(url)
+	return ImageVariantsOut{
+		Thumbnail: variants.Thumbnail,
+		Feed:      variants.Feed,
+		Full:      variants.Full,
+	}
+}
+
+// imageVariantsToOutPtr is used for optional images, nil means there is no image.
+func imageVariantsToOutPtr(url string) *ImageVariantsOut {
+	if len(url) == 0 {
+		return nil
+	}
+	out := ImageVariantsToOut(url)
+	return &out
+}
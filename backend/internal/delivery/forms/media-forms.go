@@ -0,0 +1,32 @@
+package forms
+
+import "quickflow/internal/models"
+
+type MediaOut struct {
+	URL        string `json:"url"`
+	Type       string `json:"type"`
+	MimeType   string `json:"mime_type,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+}
+
+// MediaToOut describes files in order of urls, files without media info are omitted.
+func MediaToOut(urls []string, media map[string]models.MediaInfo) []MediaOut {
+	result := make([]MediaOut, 0, len(media))
+	for _, url := range urls {
+		info, ok := media[url]
+		if !ok {
+			continue
+		}
+		result = append(result, MediaOut{
+			URL:        url,
+			Type:       string(info.Type),
+			MimeType:   info.MimeType,
+			DurationMs: info.Duration.Milliseconds(),
+			Width:      info.Width,
+			Height:     info.Height,
+		})
+	}
+	return result
+}
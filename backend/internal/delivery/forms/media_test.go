@@ -0,0 +1,27 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+)
+
+func TestMediaToOut(t *testing.T) {
+	urls := []string{"http://example.com/old.jpg", "http://example.com/clip.mp4", "http://example.com/voice.ogg"}
+	media := map[string]models.MediaInfo{
+		"http://example.com/voice.ogg": {Type: models.MediaTypeAudio, MimeType: "audio/ogg", Duration: 3 * time.Second},
+		"http://example.com/clip.mp4": {
+			Type: models.MediaTypeVideo, MimeType: "video/mp4", Duration: 1500 * time.Millisecond, Width: 640, Height: 360,
+		},
+	}
+
+	assert.Equal(t, []forms.MediaOut{
+		{URL: "http://example.com/clip.mp4", Type: "video", MimeType: "video/mp4", DurationMs: 1500, Width: 640, Height: 360},
+		{URL: "http://example.com/voice.ogg", Type: "audio", MimeType: "audio/ogg", DurationMs: 3000},
+	}, forms.MediaToOut(urls, media))
+	assert.Empty(t, forms.MediaToOut(urls, nil))
+}
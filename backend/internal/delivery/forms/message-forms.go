@@ -13,8 +13,10 @@ import (
 )
 
 type GetMessagesForm struct {
-	MessagesCount int       `json:"messages_count"`
-	Ts            time.Time `json:"ts,omitempty"`
+	MessagesCount int           `json:"messages_count"`
+	Ts            time.Time     `json:"ts,omitempty"`
+	Cursor        models.Cursor `json:"-"`
+	UseCursor     bool          `json:"-"`
 }
 
 func (m *GetMessagesForm) GetParams(values url.Values) error {
@@ -34,20 +36,22 @@ func (m *GetMessagesForm) GetParams(values url.Values) error {
 
 	m.MessagesCount = int(numMessages)
 
-	ts, err := time.Parse(time2.TimeStampLayout, values.Get("ts"))
+	m.Cursor, m.UseCursor, err = parseCursor(values)
 	if err != nil {
-		ts = time.Now()
+		return errors.New("failed to parse cursor")
 	}
-	m.Ts = ts
+	m.Ts = m.Cursor.Ts
 	return nil
 }
 
 type MessageOut struct {
-	ID             uuid.UUID `json:"id,omitempty"`
-	Text           string    `json:"text"`
-	CreatedAt      string    `json:"created_at"`
-	UpdatedAt      string    `json:"updated_at"`
-	AttachmentURLs []string  `json:"attachment_urls"`
+	ID             uuid.UUID    `json:"id,omitempty"`
+	Text           string       `json:"text"`
+	CreatedAt      string       `json:"created_at"`
+	UpdatedAt      string       `json:"updated_at"`
+	AttachmentURLs []string     `json:"attachment_urls"`
+	Attachments    []MediaOut   `json:"attachments"`
+	Mentions       []MentionOut `json:"mentions"`
 
 	Sender PublicUserInfoOut `json:"sender"`
 	ChatId uuid.UUID         `json:"chat_id"`
@@ -60,6 +64,8 @@ func ToMessageOut(message models.Message, info models.PublicUserInfo) MessageOut
 		CreatedAt:      message.CreatedAt.Format(time2.TimeStampLayout),
 		UpdatedAt:      message.UpdatedAt.Format(time2.TimeStampLayout),
 		AttachmentURLs: message.AttachmentURLs,
+		Attachments:    MediaToOut(message.AttachmentURLs, message.Media),
+		Mentions:       MentionsToOut(message.Mentions),
 
 		Sender: PublicUserInfoToOut(info, ""),
 		ChatId: message.ChatID,
@@ -75,6 +81,8 @@ func ToMessagesOut(messages []models.Message, usersInfo map[uuid.UUID]models.Pub
 			CreatedAt:      message.CreatedAt.Format(time2.TimeStampLayout),
 			UpdatedAt:      message.UpdatedAt.Format(time2.TimeStampLayout),
 			AttachmentURLs: message.AttachmentURLs,
+			Attachments:    MediaToOut(message.AttachmentURLs, message.Media),
+			Mentions:       MentionsToOut(message.Mentions),
 
 			Sender: PublicUserInfoToOut(usersInfo[message.SenderID], ""),
 			ChatId: message.ChatID,
@@ -86,12 +94,14 @@ func ToMessagesOut(messages []models.Message, usersInfo map[uuid.UUID]models.Pub
 type MessagesOut struct {
 	Messages   []MessageOut `json:"messages"`
 	LastReadTs string       `json:"last_read_ts,omitempty"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 type MessageForm struct {
 	Text            string    `form:"text" json:"text"`
 	ChatId          uuid.UUID `form:"chat_id" json:"chat_id,omitempty"`
 	AttachmentsUrls []string  `form:"attachment_urls" json:"attachment_urls,omitempty"`
+	UploadKeys      []string  `form:"upload_keys" json:"upload_keys,omitempty"`
 	ReceiverId      uuid.UUID `json:"receiver_id,omitempty"`
 	SenderId        uuid.UUID `json:"-"`
 }
@@ -103,6 +113,7 @@ func (f *MessageForm) ToMessageModel() models.Message {
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 		AttachmentURLs: f.AttachmentsUrls,
+		UploadKeys:     f.UploadKeys,
 		ReceiverID:     f.ReceiverId,
 		SenderID:       f.SenderId,
 		ChatID:         f.ChatId,
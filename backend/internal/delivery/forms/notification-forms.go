@@ -0,0 +1,110 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+const defaultNotificationsCount = 20
+
+// MentionOut is a mention in post or message text clients render as a link to the user profile.
+// Offset and length are measured in UTF-16 code units and include the leading '@'.
+type MentionOut struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+func MentionsToOut(mentions []models.Mention) []MentionOut {
+	mentionsOut := make([]MentionOut, 0, len(mentions))
+	for _, m := range mentions {
+		mentionsOut = append(mentionsOut, MentionOut{
+			UserId:   m.UserId.String(),
+			Username: m.Username,
+			Offset:   m.Offset,
+			Length:   m.Length,
+		})
+	}
+	return mentionsOut
+}
+
+type NotificationsForm struct {
+	Count  int           `json:"notifications_count"`
+	Cursor models.Cursor `json:"-"`
+}
+
+// GetParams gets parameters from the map, missing count is set to default.
+func (f *NotificationsForm) GetParams(values url.Values) error {
+	f.Count = defaultNotificationsCount
+	if values.Has("notifications_count") {
+		count, err := strconv.Atoi(values.Get("notifications_count"))
+		if err != nil {
+			return errors.New("failed to parse notifications_count")
+		}
+		f.Count = count
+	}
+
+	cursor, _, err := parseCursor(values)
+	if err != nil {
+		return errors.New("failed to parse cursor")
+	}
+	f.Cursor = cursor
+	return nil
+}
+
+type NotificationOut struct {
+	Id        string            `json:"id"`
+	Type      string            `json:"type"`
+	Actor     PublicUserInfoOut `json:"actor"`
+	PostId    string            `json:"post_id,omitempty"`
+	MessageId string            `json:"message_id,omitempty"`
+	ChatId    string            `json:"chat_id,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	Read      bool              `json:"read"`
+}
+
+func NotificationToOut(notification models.Notification, actor models.PublicUserInfo) NotificationOut {
+	out := NotificationOut{
+		Id:        notification.Id.String(),
+		Type:      string(notification.Type),
+		Actor:     PublicUserInfoToOut(actor, ""),
+		CreatedAt: notification.CreatedAt.Format(time2.TimeStampLayout),
+		Read:      !notification.ReadAt.IsZero(),
+	}
+	if notification.PostId != uuid.Nil {
+		out.PostId = notification.PostId.String()
+	}
+	if notification.MessageId != uuid.Nil {
+		out.MessageId = notification.MessageId.String()
+	}
+	if notification.ChatId != uuid.Nil {
+		out.ChatId = notification.ChatId.String()
+	}
+	return out
+}
+
+type NotificationsOut struct {
+	Notifications []NotificationOut `json:"notifications"`
+	UnreadCount   int               `json:"unread_count"`
+	NextCursor    string            `json:"next_cursor,omitempty"`
+}
+
+type MarkNotificationsReadForm struct {
+	Ts string `json:"ts,omitempty"`
+}
+
+// UpTo returns time notifications up to which are read, zero time means all of them.
+func (f *MarkNotificationsReadForm) UpTo() (time.Time, error) {
+	if len(f.Ts) == 0 {
+		return time.Time{}, nil
+	}
+	return time.Parse(time2.TimeStampLayout, f.Ts)
+}
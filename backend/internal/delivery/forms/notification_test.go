@@ -0,0 +1,56 @@
+package forms_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+)
+
+func TestNotificationsForm_GetParams(t *testing.T) {
+	form := forms.NotificationsForm{}
+	require.NoError(t, form.GetParams(url.Values{}))
+	assert.Equal(t, 20, form.Count)
+
+	cursor := models.Cursor{Ts: time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC), Id: uuid.New()}
+	require.NoError(t, form.GetParams(url.Values{"notifications_count": {"5"}, "cursor": {cursor.String()}}))
+	assert.Equal(t, 5, form.Count)
+	assert.Equal(t, cursor.Id, form.Cursor.Id)
+	assert.True(t, cursor.Ts.Equal(form.Cursor.Ts))
+
+	assert.Error(t, form.GetParams(url.Values{"notifications_count": {"many"}}))
+}
+
+func TestNotificationToOut(t *testing.T) {
+	notification := models.Notification{
+		Id:        uuid.New(),
+		Type:      models.NotificationMessageMention,
+		ActorId:   uuid.New(),
+		MessageId: uuid.New(),
+		ChatId:    uuid.New(),
+		CreatedAt: time.Now(),
+	}
+
+	out := forms.NotificationToOut(notification, models.PublicUserInfo{Id: notification.ActorId, Username: "anna"})
+	assert.Equal(t, "message_mention", out.Type)
+	assert.Equal(t, "anna", out.Actor.Username)
+	assert.Empty(t, out.PostId)
+	assert.Equal(t, notification.MessageId.String(), out.MessageId)
+	assert.Equal(t, notification.ChatId.String(), out.ChatId)
+	assert.False(t, out.Read)
+}
+
+func TestMentionsToOut(t *testing.T) {
+	userId := uuid.New()
+	out := forms.MentionsToOut([]models.Mention{{UserId: userId, Username: "anna", Offset: 6, Length: 5}})
+	assert.Equal(t, []forms.MentionOut{{UserId: userId.String(), Username: "anna", Offset: 6, Length: 5}}, out)
+
+	// posts without mentions are rendered with an empty list, not null
+	assert.NotNil(t, forms.MentionsToOut(nil))
+}
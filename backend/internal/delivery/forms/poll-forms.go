@@ -0,0 +1,136 @@
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+const defaultVotersCount = 20
+
+// PollForm is a poll attached to a new post.
+// Votes are anonymous unless anonymous is set to false.
+type PollForm struct {
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multiple_choice"`
+	Anonymous      *bool    `json:"anonymous,omitempty"`
+	Deadline       string   `json:"deadline,omitempty"`
+}
+
+// ParsePoll parses poll passed as JSON, empty value means the post has no poll.
+func ParsePoll(value string) (*models.Poll, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	var form PollForm
+	if err := json.Unmarshal([]byte(value), &form); err != nil {
+		return nil, errors.New("failed to parse poll")
+	}
+
+	poll := &models.Poll{
+		Question:       form.Question,
+		MultipleChoice: form.MultipleChoice,
+		Anonymous:      form.Anonymous == nil || *form.Anonymous,
+	}
+	for _, option := range form.Options {
+		poll.Options = append(poll.Options, models.PollOption{Text: option})
+	}
+	if len(form.Deadline) != 0 {
+		deadline, err := time.Parse(time2.TimeStampLayout, form.Deadline)
+		if err != nil {
+			return nil, errors.New("failed to parse poll deadline")
+		}
+		poll.Deadline = deadline
+	}
+	return poll, nil
+}
+
+type PollOptionOut struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	Votes    int    `json:"votes"`
+	Chosen   bool   `json:"chosen"`
+}
+
+type PollOut struct {
+	Question       string          `json:"question"`
+	Options        []PollOptionOut `json:"options"`
+	MultipleChoice bool            `json:"multiple_choice"`
+	Anonymous      bool            `json:"anonymous"`
+	Deadline       string          `json:"deadline,omitempty"`
+	Closed         bool            `json:"closed"`
+	VoterCount     int             `json:"voter_count"`
+	Voted          bool            `json:"voted"`
+}
+
+// PollToOut converts poll with options chosen by the viewer to output form.
+func PollToOut(poll models.Poll) PollOut {
+	chosen := make(map[int]struct{}, len(poll.ViewerVote))
+	for _, position := range poll.ViewerVote {
+		chosen[position] = struct{}{}
+	}
+
+	out := PollOut{
+		Question:       poll.Question,
+		Options:        make([]PollOptionOut, 0, len(poll.Options)),
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		Closed:         poll.IsClosed(time.Now()),
+		VoterCount:     poll.VoterCount,
+		Voted:          len(poll.ViewerVote) > 0,
+	}
+	if !poll.Deadline.IsZero() {
+		out.Deadline = poll.Deadline.Format(time2.TimeStampLayout)
+	}
+	for i, option := range poll.Options {
+		_, isChosen := chosen[i]
+		out.Options = append(out.Options, PollOptionOut{
+			Position: i,
+			Text:     option.Text,
+			Votes:    option.VoteCount,
+			Chosen:   isChosen,
+		})
+	}
+	return out
+}
+
+// PollVoteForm lists positions of chosen options.
+type PollVoteForm struct {
+	Options []int `json:"options"`
+}
+
+type PollVotersForm struct {
+	Count  int           `json:"voters_count"`
+	Cursor models.Cursor `json:"-"`
+}
+
+// GetParams gets parameters from the map, missing count is set to default.
+func (f *PollVotersForm) GetParams(values url.Values) error {
+	f.Count = defaultVotersCount
+	if values.Has("voters_count") {
+		count, err := strconv.Atoi(values.Get("voters_count"))
+		if err != nil {
+			return errors.New("failed to parse voters_count")
+		}
+		f.Count = count
+	}
+
+	cursor, _, err := parseCursor(values)
+	if err != nil {
+		return errors.New("failed to parse cursor")
+	}
+	f.Cursor = cursor
+	return nil
+}
+
+type PollVotersOut struct {
+	Voters     []PublicUserInfoOut `json:"voters"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
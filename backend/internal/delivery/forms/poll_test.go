@@ -0,0 +1,55 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+)
+
+func TestParsePoll(t *testing.T) {
+	poll, err := forms.ParsePoll("")
+	require.NoError(t, err)
+	assert.Nil(t, poll)
+
+	poll, err = forms.ParsePoll(`{"question": "Tea or coffee?", "options": ["Tea", "Coffee"], "multiple_choice": true}`)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Poll{
+		Question:       "Tea or coffee?",
+		Options:        []models.PollOption{{Text: "Tea"}, {Text: "Coffee"}},
+		MultipleChoice: true,
+		Anonymous:      true,
+	}, poll)
+
+	poll, err = forms.ParsePoll(`{"question": "Tea?", "options": ["Yes", "No"], "anonymous": false, "deadline": "2025-04-15T12:00:00Z"}`)
+	require.NoError(t, err)
+	assert.False(t, poll.Anonymous)
+	assert.False(t, poll.Deadline.IsZero())
+
+	_, err = forms.ParsePoll(`{"question": "Tea?", "deadline": "tomorrow"}`)
+	assert.Error(t, err)
+	_, err = forms.ParsePoll(`not a poll`)
+	assert.Error(t, err)
+}
+
+func TestPollToOut(t *testing.T) {
+	out := forms.PollToOut(models.Poll{
+		Question:   "Tea or coffee?",
+		Options:    []models.PollOption{{Text: "Tea", VoteCount: 1}, {Text: "Coffee", VoteCount: 2}},
+		Deadline:   time.Now().Add(-time.Hour),
+		VoterCount: 3,
+		ViewerVote: []int{1},
+	})
+
+	assert.True(t, out.Closed)
+	assert.True(t, out.Voted)
+	assert.NotEmpty(t, out.Deadline)
+	assert.Equal(t, []forms.PollOptionOut{
+		{Position: 0, Text: "Tea", Votes: 1},
+		{Position: 1, Text: "Coffee", Votes: 2, Chosen: true},
+	}, out.Options)
+}
@@ -15,16 +15,22 @@ type ProfileInfo struct {
 	Name          string     `json:"firstname"`
 	Surname       string     `json:"lastname"`
 	Sex           models.Sex `json:"sex"`
-	DateOfBirth   string     `json:"birth_date"`
+	DateOfBirth   string     `json:"birth_date,omitempty"`
 	Bio           string     `json:"bio"`
 	AvatarUrl     string     `json:"avatar_url,omitempty"`
 	BackgroundUrl string     `json:"cover_url,omitempty"`
+
+	AvatarVariants *ImageVariantsOut `json:"avatar_variants,omitempty"`
+	CoverVariants  *ImageVariantsOut `json:"cover_variants,omitempty"`
 }
 
 type ProfileForm struct {
 	Id         string       `json:"id,omitempty"`
 	Avatar     *models.File `json:"-"`
 	Background *models.File `json:"-"`
+	// keys of images uploaded directly to storage
+	AvatarUploadKey     string `json:"-"`
+	BackgroundUploadKey string `json:"-"`
 
 	ProfileInfo         *ProfileInfo             `json:"profile"`
 	ContactInfo         *ContactInfo             `json:"contact_info,omitempty"`
@@ -68,6 +74,9 @@ func (f *ProfileForm) FormToModel() (models.Profile, error) {
 		Avatar:     f.Avatar,
 		Background: f.Background,
 
+		AvatarUploadKey:     f.AvatarUploadKey,
+		BackgroundUploadKey: f.BackgroundUploadKey,
+
 		SchoolEducation:     SchoolFormToModel(f.SchoolEducation),
 		UniversityEducation: UniversityFormToModel(f.UniversityEducation),
 		ContactInfo:         contactInfo,
@@ -175,16 +184,23 @@ func UniversityFormToModel(uniForm *UniversityEducationForm) *models.UniversityE
 }
 
 func BasicInfoToForm(info models.BasicInfo, username string) *ProfileInfo {
-	return &ProfileInfo{
+	profileInfo := &ProfileInfo{
 		Username:      username,
 		Name:          info.Name,
 		Surname:       info.Surname,
 		Sex:           info.Sex,
-		DateOfBirth:   info.DateOfBirth.Format(time2.DateLayout),
 		Bio:           info.Bio,
 		AvatarUrl:     info.AvatarUrl,
 		BackgroundUrl: info.BackgroundUrl,
+
+		AvatarVariants: imageVariantsToOutPtr(info.AvatarUrl),
+		CoverVariants:  imageVariantsToOutPtr(info.BackgroundUrl),
 	}
+	// birth date is zero when it is hidden by privacy settings
+	if !info.DateOfBirth.IsZero() {
+		profileInfo.DateOfBirth = info.DateOfBirth.Format(time2.DateLayout)
+	}
+	return profileInfo
 }
 
 func ProfileInfoToModel(info ProfileInfo) (*models.BasicInfo, error) {
@@ -202,3 +218,28 @@ func ProfileInfoToModel(info ProfileInfo) (*models.BasicInfo, error) {
 		BackgroundUrl: info.BackgroundUrl,
 	}, nil
 }
+
+type PrivacySettingsForm struct {
+	ContactInfo models.PrivacyLevel `json:"contact_info,omitempty"`
+	BirthDate   models.PrivacyLevel `json:"birth_date,omitempty"`
+	Education   models.PrivacyLevel `json:"education,omitempty"`
+	Posts       models.PrivacyLevel `json:"posts,omitempty"`
+}
+
+func (f *PrivacySettingsForm) ToModel() models.PrivacySettings {
+	return models.PrivacySettings{
+		ContactInfo: f.ContactInfo,
+		BirthDate:   f.BirthDate,
+		Education:   f.Education,
+		Posts:       f.Posts,
+	}
+}
+
+func PrivacySettingsToForm(settings models.PrivacySettings) PrivacySettingsForm {
+	return PrivacySettingsForm{
+		ContactInfo: settings.ContactInfo,
+		BirthDate:   settings.BirthDate,
+		Education:   settings.Education,
+		Posts:       settings.Posts,
+	}
+}
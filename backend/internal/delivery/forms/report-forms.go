@@ -0,0 +1,175 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	time2 "quickflow/config/time"
+	"quickflow/internal/models"
+)
+
+const defaultReportsCount = 20
+
+// ReportForm is a report of content, target_id of a profile is the id of the user.
+type ReportForm struct {
+	TargetType string `json:"target_type"`
+	TargetId   string `json:"target_id"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details,omitempty"`
+}
+
+func (f *ReportForm) ToReport() (models.Report, error) {
+	targetId, err := uuid.Parse(f.TargetId)
+	if err != nil {
+		return models.Report{}, errors.New("failed to parse target_id")
+	}
+	return models.Report{
+		TargetType: models.ReportTarget(f.TargetType),
+		TargetId:   targetId,
+		Reason:     models.ReportReason(f.Reason),
+		Details:    f.Details,
+	}, nil
+}
+
+// ReportsForm is a page of the moderation queue, open reports are requested without status.
+type ReportsForm struct {
+	Status models.ReportStatus `json:"status"`
+	Count  int                 `json:"reports_count"`
+	Cursor models.Cursor       `json:"-"`
+}
+
+// GetParams gets parameters from the map, missing count is set to default.
+func (f *ReportsForm) GetParams(values url.Values) error {
+	f.Status = models.ReportStatusOpen
+	if values.Has("status") {
+		f.Status = models.ReportStatus(values.Get("status"))
+		if !f.Status.IsValid() {
+			return errors.New("unknown status")
+		}
+	}
+
+	f.Count = defaultReportsCount
+	if values.Has("reports_count") {
+		count, err := strconv.Atoi(values.Get("reports_count"))
+		if err != nil {
+			return errors.New("failed to parse reports_count")
+		}
+		f.Count = count
+	}
+
+	cursor, _, err := parseCursor(values)
+	if err != nil {
+		return errors.New("failed to parse cursor")
+	}
+	f.Cursor = cursor
+	return nil
+}
+
+// ResolveReportForm is the decision of a moderator, ban_until is only used by ban_user
+// and the ban is permanent without it.
+type ResolveReportForm struct {
+	Action   string `json:"action"`
+	Note     string `json:"note,omitempty"`
+	BanUntil string `json:"ban_until,omitempty"`
+}
+
+func (f *ResolveReportForm) ToResolution() (models.ReportResolution, error) {
+	resolution := models.ReportResolution{
+		Action: models.ModerationAction(f.Action),
+		Note:   f.Note,
+	}
+	if len(f.BanUntil) != 0 {
+		banUntil, err := time.Parse(time2.TimeStampLayout, f.BanUntil)
+		if err != nil {
+			return models.ReportResolution{}, errors.New("failed to parse ban_until")
+		}
+		resolution.BanUntil = banUntil
+	}
+	return resolution, nil
+}
+
+type ReportOut struct {
+	Id            string `json:"id"`
+	ReporterId    string `json:"reporter_id"`
+	TargetType    string `json:"target_type"`
+	TargetId      string `json:"target_id"`
+	TargetOwnerId string `json:"target_owner_id,omitempty"`
+	Reason        string `json:"reason"`
+	Details       string `json:"details,omitempty"`
+	Status        string `json:"status"`
+	ModeratorId   string `json:"moderator_id,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+func ReportToOut(report models.Report) ReportOut {
+	out := ReportOut{
+		Id:         report.Id.String(),
+		ReporterId: report.ReporterId.String(),
+		TargetType: string(report.TargetType),
+		TargetId:   report.TargetId.String(),
+		Reason:     string(report.Reason),
+		Details:    report.Details,
+		Status:     string(report.Status),
+		CreatedAt:  report.CreatedAt.Format(time2.TimeStampLayout),
+		UpdatedAt:  report.UpdatedAt.Format(time2.TimeStampLayout),
+	}
+	if report.TargetOwnerId != uuid.Nil {
+		out.TargetOwnerId = report.TargetOwnerId.String()
+	}
+	if report.ModeratorId != uuid.Nil {
+		out.ModeratorId = report.ModeratorId.String()
+	}
+	return out
+}
+
+func ReportsToOut(reports []models.Report) []ReportOut {
+	reportsOut := make([]ReportOut, 0, len(reports))
+	for _, report := range reports {
+		reportsOut = append(reportsOut, ReportToOut(report))
+	}
+	return reportsOut
+}
+
+type ReportsOut struct {
+	Reports    []ReportOut `json:"reports"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+type ModerationDecisionOut struct {
+	ModeratorId string `json:"moderator_id,omitempty"`
+	Status      string `json:"status"`
+	Action      string `json:"action,omitempty"`
+	Note        string `json:"note,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ReportDetailsOut is the report with its audit trail, oldest decisions first.
+type ReportDetailsOut struct {
+	Report    ReportOut               `json:"report"`
+	Decisions []ModerationDecisionOut `json:"decisions"`
+}
+
+func ReportDetailsToOut(report models.Report, decisions []models.ModerationDecision) ReportDetailsOut {
+	out := ReportDetailsOut{
+		Report:    ReportToOut(report),
+		Decisions: make([]ModerationDecisionOut, 0, len(decisions)),
+	}
+	for _, decision := range decisions {
+		decisionOut := ModerationDecisionOut{
+			Status:    string(decision.Status),
+			Action:    string(decision.Action),
+			Note:      decision.Note,
+			CreatedAt: decision.CreatedAt.Format(time2.TimeStampLayout),
+		}
+		if decision.ModeratorId != uuid.Nil {
+			decisionOut.ModeratorId = decision.ModeratorId.String()
+		}
+		out.Decisions = append(out.Decisions, decisionOut)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"quickflow/internal/models"
+)
+
+const (
+	defaultTrendingWindow = "day"
+	defaultTrendingCount  = 10
+)
+
+type TrendingTagsForm struct {
+	Window string `json:"window"`
+	Count  int    `json:"tags_count"`
+}
+
+// GetParams gets parameters from the map, missing ones are set to defaults.
+func (f *TrendingTagsForm) GetParams(values url.Values) error {
+	f.Window = values.Get("window")
+	if len(f.Window) == 0 {
+		f.Window = defaultTrendingWindow
+	}
+
+	f.Count = defaultTrendingCount
+	if values.Has("tags_count") {
+		count, err := strconv.Atoi(values.Get("tags_count"))
+		if err != nil {
+			return errors.New("failed to parse tags_count")
+		}
+		f.Count = count
+	}
+	return nil
+}
+
+type TrendingTagOut struct {
+	Tag               string `json:"tag"`
+	PostCount         int    `json:"post_count"`
+	AuthorCount       int    `json:"author_count"`
+	PreviousPostCount int    `json:"previous_post_count"`
+}
+
+func TrendingTagsToOut(tags []models.TrendingTag) []TrendingTagOut {
+	tagsOut := make([]TrendingTagOut, 0, len(tags))
+	for _, tag := range tags {
+		tagsOut = append(tagsOut, TrendingTagOut{
+			Tag:               tag.Tag,
+			PostCount:         tag.PostCount,
+			AuthorCount:       tag.AuthorCount,
+			PreviousPostCount: tag.PreviousPostCount,
+		})
+	}
+	return tagsOut
+}
@@ -0,0 +1,52 @@
+package forms
+
+import (
+	"time"
+
+	"quickflow/internal/models"
+)
+
+type UploadFileForm struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+}
+
+type UploadsForm struct {
+	Purpose string           `json:"purpose"`
+	Files   []UploadFileForm `json:"files"`
+}
+
+func (f *UploadsForm) ToModel() (models.FilePurpose, []models.UploadRequest) {
+	requests := make([]models.UploadRequest, 0, len(f.Files))
+	for _, file := range f.Files {
+		requests = append(requests, models.UploadRequest{
+			Name:     file.Name,
+			Size:     file.Size,
+			MimeType: file.MimeType,
+		})
+	}
+	return models.FilePurpose(f.Purpose), requests
+}
+
+type PresignedUploadOut struct {
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+func PresignedUploadsToOut(uploads []models.PresignedUpload) []PresignedUploadOut {
+	out := make([]PresignedUploadOut, 0, len(uploads))
+	for _, upload := range uploads {
+		out = append(out, PresignedUploadOut{
+			Key:       upload.Key,
+			URL:       upload.URL,
+			Method:    "PUT",
+			Headers:   upload.Headers,
+			ExpiresAt: upload.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	return out
+}
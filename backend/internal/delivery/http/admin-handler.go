@@ -0,0 +1,387 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type AdminUseCase interface {
+	SetUserRole(ctx context.Context, admin models.User, username string, role models.Role) (models.User, error)
+	SearchAccounts(ctx context.Context, admin models.User, query string, count int) ([]models.Account, error)
+	GetAccount(ctx context.Context, admin models.User, username string) (models.AccountDetails, error)
+	BanUser(ctx context.Context, admin models.User, username string, reason string, until time.Time) (models.Ban, error)
+	LiftBan(ctx context.Context, admin models.User, username string) error
+	LogoutUser(ctx context.Context, admin models.User, username string) (int, error)
+	DeleteUserContent(ctx context.Context, admin models.User, username string) (models.DeletedContent, error)
+}
+
+type AdminHandler struct {
+	adminUseCase AdminUseCase
+}
+
+// NewAdminHandler creates new handler of administration of users.
+// Its routes must be protected by PermissionMiddleware.
+func NewAdminHandler(adminUseCase AdminUseCase) *AdminHandler {
+	return &AdminHandler{
+		adminUseCase: adminUseCase,
+	}
+}
+
+// SetUserRole changes the role of the user
+// @Summary Set user role
+// @Description Grants the role (user, moderator or admin) to the user instead of their current one. Only for admins
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param role body forms.UserRoleForm true "Role"
+// @Success 200 {object} forms.PayloadWrapper[forms.UserRoleOut] "User with the new role"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username}/role [put]
+func (a *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while setting user role")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var roleForm forms.UserRoleForm
+	if err := json.NewDecoder(r.Body).Decode(&roleForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode role form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s grants role %q to %s", admin.Username, roleForm.Role, username))
+
+	user, err := a.adminUseCase.SetUserRole(ctx, admin, username, models.Role(roleForm.Role))
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage roles", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidRole) || errors.Is(err, usecase.ErrCannotChangeOwnRole) {
+		logger.Info(ctx, fmt.Sprintf("Invalid role change: %s", err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to set user role: %v", err))
+		http2.WriteJSONError(w, "Failed to set role", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.UserRoleOut]{Payload: forms.UserRoleToOut(user)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode user role: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode user", http.StatusInternalServerError)
+	}
+}
+
+// SearchAccounts searches accounts of users
+// @Summary Search accounts
+// @Description Returns accounts whose username or full name contains the query with their active bans, ordered by username. Only for admins
+// @Tags Admin
+// @Produce json
+// @Param query query string false "Part of username or full name"
+// @Param count query int false "Number of accounts" default(20)
+// @Success 200 {object} forms.PayloadWrapper[[]forms.AccountOut] "Accounts"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users [get]
+func (a *AdminHandler) SearchAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while searching accounts")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var searchForm forms.SearchAccountsForm
+	if err := searchForm.GetParams(r.URL.Query()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	accounts, err := a.adminUseCase.SearchAccounts(ctx, admin, searchForm.Query, searchForm.Count)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidNumAccounts) {
+		logger.Info(ctx, fmt.Sprintf("Invalid number of accounts %d", searchForm.Count))
+		http2.WriteJSONError(w, "Invalid count", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to search accounts: %v", err))
+		http2.WriteJSONError(w, "Failed to search accounts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.AccountOut]{Payload: forms.AccountsToOut(accounts)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode accounts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode accounts", http.StatusInternalServerError)
+	}
+}
+
+// GetAccount returns the account of the user
+// @Summary Get account
+// @Description Returns the account of the user with their active ban and the number of active sessions. Only for admins
+// @Tags Admin
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} forms.PayloadWrapper[forms.AccountDetailsOut] "Account"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username} [get]
+func (a *AdminHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching account")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+	username := mux.Vars(r)["username"]
+
+	details, err := a.adminUseCase.GetAccount(ctx, admin, username)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch account %s: %v", username, err))
+		http2.WriteJSONError(w, "Failed to load account", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.AccountDetailsOut]{Payload: forms.AccountDetailsToOut(details)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode account: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode account", http.StatusInternalServerError)
+	}
+}
+
+// BanUser bans the user
+// @Summary Ban user
+// @Description Forbids the user to use the service until the time or permanently without it and logs them out of every session. Only for admins
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param ban body forms.BanForm true "Ban"
+// @Success 200 {object} forms.PayloadWrapper[forms.BanOut] "Ban"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username}/ban [post]
+func (a *AdminHandler) BanUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while banning user")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var banForm forms.BanForm
+	if err := json.NewDecoder(r.Body).Decode(&banForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode ban form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	until, err := banForm.GetUntil()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse ban form: %s", err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s bans %s until %q", admin.Username, username, banForm.Until))
+
+	ban, err := a.adminUseCase.BanUser(ctx, admin, username, banForm.Reason, until)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidBan) || errors.Is(err, usecase.ErrCannotBanSelf) {
+		logger.Info(ctx, fmt.Sprintf("Invalid ban: %s", err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to ban user %s: %v", username, err))
+		http2.WriteJSONError(w, "Failed to ban user", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.BanOut]{Payload: forms.BanToOut(ban)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode ban: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode ban", http.StatusInternalServerError)
+	}
+}
+
+// LiftBan lifts the ban of the user
+// @Summary Lift ban
+// @Description Ends active bans of the user. Only for admins
+// @Tags Admin
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 "Ban is lifted"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found or not banned"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username}/ban [delete]
+func (a *AdminHandler) LiftBan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while lifting ban")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s lifts ban of %s", admin.Username, username))
+
+	err := a.adminUseCase.LiftBan(ctx, admin, username)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found or not banned", username))
+		http2.WriteJSONError(w, "User not found or not banned", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to lift ban of user %s: %v", username, err))
+		http2.WriteJSONError(w, "Failed to lift ban", http.StatusInternalServerError)
+		return
+	}
+}
+
+// LogoutUser logs the user out of every session
+// @Summary Force logout
+// @Description Ends every session of the user. Only for admins
+// @Tags Admin
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} forms.PayloadWrapper[forms.LogoutOut] "Number of ended sessions"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username}/logout [post]
+func (a *AdminHandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while logging out user")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s logs out %s", admin.Username, username))
+
+	deleted, err := a.adminUseCase.LogoutUser(ctx, admin, username)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to log out user %s: %v", username, err))
+		http2.WriteJSONError(w, "Failed to log out user", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.LogoutOut]{Payload: forms.LogoutOut{DeletedSessions: deleted}})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode logout: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode logout", http.StatusInternalServerError)
+	}
+}
+
+// DeleteUserContent deletes all content of the user
+// @Summary Delete user content
+// @Description Deletes every post, comment and message of the user. Only for admins
+// @Tags Admin
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} forms.PayloadWrapper[forms.DeletedContentOut] "Number of deleted posts, comments and messages"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/admin/users/{username}/content [delete]
+func (a *AdminHandler) DeleteUserContent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while deleting user content")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s deletes content of %s", admin.Username, username))
+
+	deleted, err := a.adminUseCase.DeleteUserContent(ctx, admin, username)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to manage users", admin.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to delete content of user %s: %v", username, err))
+		http2.WriteJSONError(w, "Failed to delete content", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.DeletedContentOut]{Payload: forms.DeletedContentToOut(deleted)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode deleted content: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode deleted content", http.StatusInternalServerError)
+	}
+}
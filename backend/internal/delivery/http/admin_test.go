@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestAdminHandler_SetUserRole(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+
+	tests := []struct {
+		name          string
+		body          string
+		expectUseCase bool
+		useCaseErr    error
+		expectedCode  int
+	}{
+		{name: "moderator", body: `{"role":"moderator"}`, expectUseCase: true, expectedCode: http.StatusOK},
+		{name: "invalid json", body: `{"role":`, expectedCode: http.StatusBadRequest},
+		{name: "unknown role", body: `{"role":"owner"}`, expectUseCase: true, useCaseErr: fmt.Errorf("%w: owner", usecase.ErrInvalidRole), expectedCode: http.StatusBadRequest},
+		{name: "unknown user", body: `{"role":"admin"}`, expectUseCase: true, useCaseErr: fmt.Errorf("wrapped: %w", usecase.ErrNotFound), expectedCode: http.StatusNotFound},
+		{name: "not an admin", body: `{"role":"admin"}`, expectUseCase: true, useCaseErr: usecase.ErrForbidden, expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminUseCase := mocks.NewMockAdminUseCase(ctrl)
+			if tt.expectUseCase {
+				mockAdminUseCase.EXPECT().SetUserRole(gomock.Any(), admin, "johndoe", gomock.Any()).
+					Return(models.User{Id: uuid.New(), Username: "johndoe", Role: models.RoleModerator}, tt.useCaseErr)
+			}
+			handler := http2.NewAdminHandler(mockAdminUseCase)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/johndoe/role", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"username": "johndoe"})
+			req = req.WithContext(context.WithValue(req.Context(), "user", admin))
+			rr := httptest.NewRecorder()
+			handler.SetUserRole(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAdminHandler_BanUser(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	until := time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		body          string
+		expectUseCase bool
+		expectedUntil time.Time
+		useCaseErr    error
+		expectedCode  int
+	}{
+		{name: "permanent", body: `{"reason":"spam"}`, expectUseCase: true, expectedCode: http.StatusOK},
+		{name: "suspension", body: `{"reason":"spam","until":"2100-01-02T03:04:05Z"}`, expectUseCase: true, expectedUntil: until, expectedCode: http.StatusOK},
+		{name: "invalid until", body: `{"reason":"spam","until":"tomorrow"}`, expectedCode: http.StatusBadRequest},
+		{name: "invalid ban", body: `{"reason":""}`, expectUseCase: true, useCaseErr: fmt.Errorf("%w: reason is required", usecase.ErrInvalidBan), expectedCode: http.StatusBadRequest},
+		{name: "own account", body: `{"reason":"spam"}`, expectUseCase: true, useCaseErr: usecase.ErrCannotBanSelf, expectedCode: http.StatusBadRequest},
+		{name: "unknown user", body: `{"reason":"spam"}`, expectUseCase: true, useCaseErr: fmt.Errorf("wrapped: %w", usecase.ErrNotFound), expectedCode: http.StatusNotFound},
+		{name: "not an admin", body: `{"reason":"spam"}`, expectUseCase: true, useCaseErr: usecase.ErrForbidden, expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminUseCase := mocks.NewMockAdminUseCase(ctrl)
+			if tt.expectUseCase {
+				mockAdminUseCase.EXPECT().BanUser(gomock.Any(), admin, "johndoe", gomock.Any(), tt.expectedUntil).
+					Return(models.Ban{Id: uuid.New(), Reason: "spam", Until: tt.expectedUntil}, tt.useCaseErr)
+			}
+			handler := http2.NewAdminHandler(mockAdminUseCase)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/johndoe/ban", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"username": "johndoe"})
+			req = req.WithContext(context.WithValue(req.Context(), "user", admin))
+			rr := httptest.NewRecorder()
+			handler.BanUser(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAdminHandler_SearchAccounts(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int
+		useCaseErr    error
+		expectUseCase bool
+		expectedCode  int
+	}{
+		{name: "default count", query: "?query=john", expectedCount: 20, expectUseCase: true, expectedCode: http.StatusOK},
+		{name: "invalid count", query: "?count=many", expectedCode: http.StatusBadRequest},
+		{name: "too many", query: "?count=1000", expectedCount: 1000, expectUseCase: true, useCaseErr: usecase.ErrInvalidNumAccounts, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminUseCase := mocks.NewMockAdminUseCase(ctrl)
+			if tt.expectUseCase {
+				mockAdminUseCase.EXPECT().SearchAccounts(gomock.Any(), admin, gomock.Any(), tt.expectedCount).
+					Return([]models.Account{{Id: uuid.New(), Username: "johndoe"}}, tt.useCaseErr)
+			}
+			handler := http2.NewAdminHandler(mockAdminUseCase)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user", admin))
+			rr := httptest.NewRecorder()
+			handler.SearchAccounts(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAdminHandler_DeleteUserContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	mockAdminUseCase := mocks.NewMockAdminUseCase(ctrl)
+	mockAdminUseCase.EXPECT().DeleteUserContent(gomock.Any(), admin, "johndoe").
+		Return(models.DeletedContent{Posts: 1, Comments: 2, Messages: 3}, nil)
+	handler := http2.NewAdminHandler(mockAdminUseCase)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/johndoe/content", nil)
+	req = mux.SetURLVars(req, map[string]string{"username": "johndoe"})
+	req = req.WithContext(context.WithValue(req.Context(), "user", admin))
+	rr := httptest.NewRecorder()
+	handler.DeleteUserContent(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"payload":{"posts":1,"comments":2,"messages":3}}`, rr.Body.String())
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type AnalyticsUseCase interface {
+	FetchAnalytics(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time) (models.Analytics, error)
+}
+
+type AnalyticsHandler struct {
+	analyticsUseCase AnalyticsUseCase
+}
+
+// NewAnalyticsHandler creates new handler of analytics of post authors.
+func NewAnalyticsHandler(analyticsUseCase AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+	}
+}
+
+// GetAnalytics returns activity around posts and profile of the user
+// @Summary Get author analytics
+// @Description Returns daily views, likes, comments and new followers of the user and the posts with the most views. Days are UTC, both ends of the range are included
+// @Tags Analytics
+// @Produce json
+// @Param from query string false "First day of the range, 30 days before the last one by default" format(date)
+// @Param to query string false "Last day of the range, today by default" format(date)
+// @Success 200 {object} forms.PayloadWrapper[forms.AnalyticsOut] "Analytics"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/me/analytics [get]
+func (a *AnalyticsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching analytics")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var analyticsForm forms.AnalyticsForm
+	if err := analyticsForm.GetParams(r.URL.Query(), time.Now()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested analytics from %s to %s", user.Username, analyticsForm.From, analyticsForm.To))
+
+	analytics, err := a.analyticsUseCase.FetchAnalytics(ctx, user.Id, analyticsForm.From, analyticsForm.To)
+	if errors.Is(err, usecase.ErrInvalidDateRange) {
+		logger.Info(ctx, fmt.Sprintf("Invalid date range: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid date range", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch analytics: %v", err))
+		http2.WriteJSONError(w, "Failed to load analytics", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.AnalyticsOut]{Payload: forms.AnalyticsToOut(analytics)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode analytics: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode analytics", http.StatusInternalServerError)
+	}
+}
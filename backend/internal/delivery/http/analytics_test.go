@@ -0,0 +1,59 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestAnalyticsHandler_GetAnalytics(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+	from, to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		query        string
+		useCaseErr   error
+		callUseCase  bool
+		expectedCode int
+	}{
+		{name: "range of days", query: "?from=2025-05-01&to=2025-05-31", callUseCase: true, expectedCode: http.StatusOK},
+		{name: "invalid date", query: "?from=may&to=2025-05-31", expectedCode: http.StatusBadRequest},
+		{name: "invalid range", query: "?from=2025-05-01&to=2025-05-31", callUseCase: true, useCaseErr: fmt.Errorf("%w: too long", usecase.ErrInvalidDateRange), expectedCode: http.StatusBadRequest},
+		{name: "server error", query: "?from=2025-05-01&to=2025-05-31", callUseCase: true, useCaseErr: errors.New("db error"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAnalyticsUseCase := mocks.NewMockAnalyticsUseCase(ctrl)
+			if tt.callUseCase {
+				mockAnalyticsUseCase.EXPECT().FetchAnalytics(gomock.Any(), user.Id, from, to).
+					Return(models.Analytics{From: from, To: to}, tt.useCaseErr)
+			}
+			handler := http2.NewAnalyticsHandler(mockAnalyticsUseCase)
+
+			req := httptest.NewRequest(http.MethodGet, "/me/analytics"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.GetAnalytics(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
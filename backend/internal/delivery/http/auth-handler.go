@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,6 +14,7 @@ import (
 	time2 "quickflow/config/time"
 	"quickflow/internal/delivery/forms"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 	"quickflow/pkg/logger"
 	"quickflow/pkg/sanitizer"
 	http2 "quickflow/utils/http"
@@ -154,6 +156,7 @@ func (a *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} forms.AuthResponse "Успешная авторизация"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
 // @Failure 401 {object} forms.ErrorForm "Пользователь не авторизован"
+// @Failure 403 {object} forms.ErrorForm "Пользователь заблокирован"
 // @Router /api/login [post]
 func (a *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -178,7 +181,11 @@ func (a *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	// process data
 	session, err := a.authUseCase.AuthUser(r.Context(), loginData)
-	if err != nil {
+	if errors.Is(err, usecase.ErrUserBanned) {
+		logger.Info(ctx, fmt.Sprintf("Banned user %s tried to log in: %s", loginData.Login, err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusForbidden)
+		return
+	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Get User error: %s", err.Error()))
 		http2.WriteJSONError(w, "Unauthorized", http.StatusUnauthorized)
 		return
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	http2 "quickflow/internal/delivery/http"
 	"quickflow/internal/delivery/http/mocks"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 )
 
 func TestAuthHandler_SignUp(t *testing.T) {
@@ -164,6 +166,20 @@ func TestAuthHandler_Login(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusUnauthorized,
 		},
+		{
+			name: "Banned",
+			inputBody: toJSON(forms.AuthForm{
+				Login:    "Timex",
+				Password: "228Amogus!",
+			}),
+			mockBehavior: func(mockUC *mocks.MockAuthUseCase) {
+				mockUC.EXPECT().
+					AuthUser(gomock.Any(), gomock.Any()).
+					Return(models.Session{}, fmt.Errorf("%w until 2100-01-02T03:04:05Z: spam", usecase.ErrUserBanned))
+			},
+			expectedStatusCode: http.StatusForbidden,
+			responseContains:   "user is banned until 2100-01-02T03:04:05Z: spam",
+		},
 	}
 
 	for _, tc := range testTable {
@@ -0,0 +1,262 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/microcosm-cc/bluemonday"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	"quickflow/pkg/sanitizer"
+	http2 "quickflow/utils/http"
+)
+
+type BookmarkUseCase interface {
+	AddBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID, collectionId uuid.UUID) error
+	RemoveBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	CreateCollection(ctx context.Context, userId uuid.UUID, name string) (models.BookmarkCollection, error)
+	FetchCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error)
+	DeleteCollection(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID) error
+}
+
+type BookmarkHandler struct {
+	bookmarkUseCase BookmarkUseCase
+	policy          *bluemonday.Policy
+}
+
+// NewBookmarkHandler creates new handler of bookmarks and their collections.
+// Bookmarked posts themselves are listed by FeedHandler.
+func NewBookmarkHandler(bookmarkUseCase BookmarkUseCase, policy *bluemonday.Policy) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkUseCase: bookmarkUseCase,
+		policy:          policy,
+	}
+}
+
+// AddBookmark bookmarks the post
+// @Summary Bookmark post
+// @Description Saves the post to bookmarks of the user. Bookmarked post is moved to the collection, empty collection takes it out of collections
+// @Tags Bookmarks
+// @Accept json
+// @Param post_id path string true "Post ID"
+// @Param bookmark body forms.BookmarkForm false "Collection of the bookmark"
+// @Success 200 "Post is bookmarked"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 404 {object} forms.ErrorForm "Post or collection not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/bookmark [post]
+func (b *BookmarkHandler) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while adding bookmark")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+
+	var bookmarkForm forms.BookmarkForm
+	if r.ContentLength != 0 {
+		if err = json.NewDecoder(r.Body).Decode(&bookmarkForm); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to decode bookmark form: %s", err.Error()))
+			http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+	}
+	collectionId, err := bookmarkForm.Collection()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse collection id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse collection id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s bookmarks post %s to collection %s", user.Username, postId, collectionId))
+
+	err = b.bookmarkUseCase.AddBookmark(ctx, user.Id, postId, collectionId)
+	if errors.Is(err, usecase.ErrPostNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Post %s not found", postId))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, usecase.ErrCollectionNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Collection %s of user %s not found", collectionId, user.Username))
+		http2.WriteJSONError(w, "Collection not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to add bookmark: %v", err))
+		http2.WriteJSONError(w, "Failed to add bookmark", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// RemoveBookmark removes the post from bookmarks
+// @Summary Remove bookmark
+// @Description Removes the post from bookmarks of the user
+// @Tags Bookmarks
+// @Param post_id path string true "Post ID"
+// @Success 200 "Post is not bookmarked"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/bookmark [delete]
+func (b *BookmarkHandler) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while removing bookmark")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s removes bookmark of post %s", user.Username, postId))
+
+	if err = b.bookmarkUseCase.RemoveBookmark(ctx, user.Id, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to remove bookmark: %v", err))
+		http2.WriteJSONError(w, "Failed to remove bookmark", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetCollections returns collections of bookmarks
+// @Summary Get bookmark collections
+// @Description Returns collections of bookmarks of the user in order of creation
+// @Tags Bookmarks
+// @Produce json
+// @Success 200 {object} forms.PayloadWrapper[[]forms.BookmarkCollectionOut] "Collections"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/bookmarks/collections [get]
+func (b *BookmarkHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching bookmark collections")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	collections, err := b.bookmarkUseCase.FetchCollections(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch bookmark collections: %v", err))
+		http2.WriteJSONError(w, "Failed to load collections", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.BookmarkCollectionOut]{Payload: forms.BookmarkCollectionsToOut(collections)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode bookmark collections: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode collections", http.StatusInternalServerError)
+	}
+}
+
+// CreateCollection creates collection of bookmarks
+// @Summary Create bookmark collection
+// @Description Creates named collection of bookmarks, names of collections of the user are unique
+// @Tags Bookmarks
+// @Accept json
+// @Produce json
+// @Param collection body forms.BookmarkCollectionForm true "Name of the collection"
+// @Success 200 {object} forms.PayloadWrapper[forms.BookmarkCollectionOut] "Collection"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 409 {object} forms.ErrorForm "Collection already exists"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/bookmarks/collections [post]
+func (b *BookmarkHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while creating bookmark collection")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var collectionForm forms.BookmarkCollectionForm
+	if err := json.NewDecoder(r.Body).Decode(&collectionForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode collection form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	sanitizer.SanitizeBookmarkCollection(&collectionForm, b.policy)
+	logger.Info(ctx, fmt.Sprintf("User %s creates bookmark collection %q", user.Username, collectionForm.Name))
+
+	collection, err := b.bookmarkUseCase.CreateCollection(ctx, user.Id, collectionForm.Name)
+	if errors.Is(err, usecase.ErrInvalidCollectionName) || errors.Is(err, usecase.ErrTooManyCollections) {
+		logger.Info(ctx, fmt.Sprintf("Invalid collection: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid collection", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrCollectionExists) {
+		logger.Info(ctx, fmt.Sprintf("Collection %q of user %s already exists", collectionForm.Name, user.Username))
+		http2.WriteJSONError(w, "Collection already exists", http.StatusConflict)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to create bookmark collection: %v", err))
+		http2.WriteJSONError(w, "Failed to create collection", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.BookmarkCollectionOut]{Payload: forms.BookmarkCollectionToOut(collection)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode bookmark collection: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode collection", http.StatusInternalServerError)
+	}
+}
+
+// DeleteCollection deletes collection of bookmarks
+// @Summary Delete bookmark collection
+// @Description Deletes the collection, its posts stay bookmarked outside of collections
+// @Tags Bookmarks
+// @Param collection_id path string true "Collection ID"
+// @Success 200 "Collection is deleted"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 404 {object} forms.ErrorForm "Collection not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/bookmarks/collections/{collection_id} [delete]
+func (b *BookmarkHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while deleting bookmark collection")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	collectionId, err := uuid.Parse(mux.Vars(r)["collection_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse collection id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse collection id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s deletes bookmark collection %s", user.Username, collectionId))
+
+	err = b.bookmarkUseCase.DeleteCollection(ctx, user.Id, collectionId)
+	if errors.Is(err, usecase.ErrCollectionNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Collection %s of user %s not found", collectionId, user.Username))
+		http2.WriteJSONError(w, "Collection not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to delete bookmark collection: %v", err))
+		http2.WriteJSONError(w, "Failed to delete collection", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
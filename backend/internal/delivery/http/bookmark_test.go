@@ -0,0 +1,94 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/stretchr/testify/assert"
+
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestBookmarkHandler_AddBookmark(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+	postId, collectionId := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		body         string
+		collectionId uuid.UUID
+		useCaseErr   error
+		callUseCase  bool
+		expectedCode int
+	}{
+		{name: "without collection", callUseCase: true, expectedCode: http.StatusOK},
+		{name: "to collection", body: `{"collection_id": "` + collectionId.String() + `"}`, collectionId: collectionId, callUseCase: true, expectedCode: http.StatusOK},
+		{name: "invalid collection id", body: `{"collection_id": "recipes"}`, expectedCode: http.StatusBadRequest},
+		{name: "hidden post", callUseCase: true, useCaseErr: usecase.ErrPostNotFound, expectedCode: http.StatusNotFound},
+		{name: "unknown collection", body: `{"collection_id": "` + collectionId.String() + `"}`, collectionId: collectionId, callUseCase: true, useCaseErr: usecase.ErrCollectionNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookmarkUseCase := mocks.NewMockBookmarkUseCase(ctrl)
+			if tt.callUseCase {
+				mockBookmarkUseCase.EXPECT().AddBookmark(gomock.Any(), user.Id, postId, tt.collectionId).Return(tt.useCaseErr)
+			}
+			handler := http2.NewBookmarkHandler(mockBookmarkUseCase, bluemonday.UGCPolicy())
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+postId.String()+"/bookmark", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"post_id": postId.String()})
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.AddBookmark(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestBookmarkHandler_CreateCollection(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+	}{
+		{name: "success", expectedCode: http.StatusOK},
+		{name: "invalid name", useCaseErr: usecase.ErrInvalidCollectionName, expectedCode: http.StatusBadRequest},
+		{name: "existing name", useCaseErr: usecase.ErrCollectionExists, expectedCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookmarkUseCase := mocks.NewMockBookmarkUseCase(ctrl)
+			mockBookmarkUseCase.EXPECT().CreateCollection(gomock.Any(), user.Id, "Recipes").
+				Return(models.BookmarkCollection{Id: uuid.New(), UserId: user.Id, Name: "Recipes"}, tt.useCaseErr)
+			handler := http2.NewBookmarkHandler(mockBookmarkUseCase, bluemonday.UGCPolicy())
+
+			req := httptest.NewRequest(http.MethodPost, "/bookmarks/collections", strings.NewReader(`{"name": "Recipes"}`))
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.CreateCollection(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
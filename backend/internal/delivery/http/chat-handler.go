@@ -21,7 +21,7 @@ import (
 type ChatUseCase interface {
 	CreateChat(ctx context.Context, chatInfo models.ChatCreationInfo) (models.Chat, error)
 	GetChatParticipants(ctx context.Context, chatId uuid.UUID) ([]models.User, error)
-	GetUserChats(ctx context.Context, userId uuid.UUID) ([]models.Chat, error)
+	GetUserChats(ctx context.Context, userId uuid.UUID, numChats int, cursor models.Cursor) ([]models.Chat, error)
 	GetPrivateChat(ctx context.Context, userId1, userId2 uuid.UUID) (models.Chat, error)
 	DeleteChat(ctx context.Context, chatId uuid.UUID) error
 	GetChat(ctx context.Context, chatId uuid.UUID) (models.Chat, error)
@@ -50,6 +50,7 @@ func NewChatHandler(chatUseCase ChatUseCase, profileUseCase ProfileUseCase, conn
 // @Accept json
 // @Produce json
 // @Param ts query string false "Timestamp"
+// @Param cursor query string false "Cursor of the next page, response is wrapped into forms.CursorPage when present"
 // @Param chats_count query int true "Number of chats"
 // @Success 200 {array} forms.ChatOut "List of chats"
 // @Failure 400 {object} forms.ErrorForm "Invalid data"
@@ -75,11 +76,15 @@ func (c *ChatHandler) GetUserChats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info(ctx, fmt.Sprintf("Fetching feed for user %s with %d posts with timestamp %v (autogenerated: %t)",
-		user.Username, chatForm.ChatsCount, chatForm.Ts, !r.URL.Query().Has("ts")))
+	logger.Info(ctx, fmt.Sprintf("Fetching chats for user %s with %d chats with cursor %v",
+		user.Username, chatForm.ChatsCount, chatForm.Cursor))
 
-	chats, err := c.chatUseCase.GetUserChats(ctx, user.Id)
-	if errors.Is(err, usecase.ErrNotFound) {
+	chats, err := c.chatUseCase.GetUserChats(ctx, user.Id, chatForm.ChatsCount, chatForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidNumChats) {
+		logger.Info(ctx, fmt.Sprintf("Invalid number of chats requested: %d", chatForm.ChatsCount))
+		http2.WriteJSONError(w, "chats_count must be greater than 0", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrNotFound) {
 		logger.Info(ctx, fmt.Sprintf("User %s has no chats", user.Username))
 		http2.WriteJSONError(w, "user has no chats", http.StatusNotFound)
 		return
@@ -153,8 +158,18 @@ func (c *ChatHandler) GetUserChats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	chatsOut := forms.ToChatsOut(chats, lastMessageSenderInfo, privateChatsOnlineStatus)
+	var out interface{} = chatsOut
+	if chatForm.UseCursor {
+		var next models.Cursor
+		if len(chats) == chatForm.ChatsCount {
+			last := chats[len(chats)-1]
+			next = models.Cursor{Ts: last.UpdatedAt, Id: last.ID}
+		}
+		out = forms.CursorPage[[]forms.ChatOut]{Payload: chatsOut, NextCursor: next.String()}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(chatsOut)
+	err = json.NewEncoder(w).Encode(out)
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to encode chats: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to encode chats", http.StatusInternalServerError)
@@ -74,7 +74,7 @@ func TestChatHandler_GetUserChats(t *testing.T) {
 			queryParams: "chats_count=10",
 			mockBehavior: func() {
 				mockChatUC.EXPECT().
-					GetUserChats(gomock.Any(), myUserID).
+					GetUserChats(gomock.Any(), myUserID, 10, gomock.Any()).
 					Return(nil, usecase.ErrNotFound)
 			},
 			expectedStatusCode: http.StatusNotFound,
@@ -88,7 +88,7 @@ func TestChatHandler_GetUserChats(t *testing.T) {
 			queryParams: "chats_count=10",
 			mockBehavior: func() {
 				mockChatUC.EXPECT().
-					GetUserChats(gomock.Any(), myUserID).
+					GetUserChats(gomock.Any(), myUserID, 10, gomock.Any()).
 					Return(nil, errors.New("db failure"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -110,7 +110,7 @@ func TestChatHandler_GetUserChats(t *testing.T) {
 					},
 				}
 				mockChatUC.EXPECT().
-					GetUserChats(gomock.Any(), myUserID).
+					GetUserChats(gomock.Any(), myUserID, 10, gomock.Any()).
 					Return([]models.Chat{chat1}, nil)
 
 				mockProfileUC.EXPECT().
@@ -141,7 +141,7 @@ func TestChatHandler_GetUserChats(t *testing.T) {
 					LastMessage: models.Message{}, // все поля — нули
 				}
 				mockChatUC.EXPECT().
-					GetUserChats(gomock.Any(), myUserID).
+					GetUserChats(gomock.Any(), myUserID, 10, gomock.Any()).
 					Return([]models.Chat{chat1, chat2}, nil)
 
 				publicInfo1 := models.PublicUserInfo{
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type FeedFilterUseCase interface {
+	HidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	UnhidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	MuteUser(ctx context.Context, userId uuid.UUID, username string) error
+	UnmuteUser(ctx context.Context, userId uuid.UUID, username string) error
+	FetchMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error)
+}
+
+type FeedFilterHandler struct {
+	feedFilterUseCase FeedFilterUseCase
+}
+
+// NewFeedFilterHandler creates new handler of hidden posts and muted authors.
+// Hidden posts themselves are listed by FeedHandler.
+func NewFeedFilterHandler(feedFilterUseCase FeedFilterUseCase) *FeedFilterHandler {
+	return &FeedFilterHandler{
+		feedFilterUseCase: feedFilterUseCase,
+	}
+}
+
+// HidePost hides the post from the feed
+// @Summary Hide post
+// @Description Hides the post from the feed and recommendations of the user
+// @Tags Feed filter
+// @Param post_id path string true "Post ID"
+// @Success 200 "Post is hidden"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/hide [post]
+func (f *FeedFilterHandler) HidePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while hiding post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s hides post %s", user.Username, postId))
+
+	err = f.feedFilterUseCase.HidePost(ctx, user.Id, postId)
+	if errors.Is(err, usecase.ErrPostNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Post %s not found", postId))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to hide post: %v", err))
+		http2.WriteJSONError(w, "Failed to hide post", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnhidePost returns the post to the feed
+// @Summary Unhide post
+// @Description Returns the hidden post to the feed and recommendations of the user
+// @Tags Feed filter
+// @Param post_id path string true "Post ID"
+// @Success 200 "Post is not hidden"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/hide [delete]
+func (f *FeedFilterHandler) UnhidePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while unhiding post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s unhides post %s", user.Username, postId))
+
+	if err = f.feedFilterUseCase.UnhidePost(ctx, user.Id, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to unhide post: %v", err))
+		http2.WriteJSONError(w, "Failed to unhide post", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// MuteUser hides posts of the author from the feed
+// @Summary Mute author
+// @Description Hides posts of the author from the feed and recommendations of the user, the user keeps following the author
+// @Tags Feed filter
+// @Param username path string true "Username of the author"
+// @Success 200 "Author is muted"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/users/{username}/mute [post]
+func (f *FeedFilterHandler) MuteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while muting user")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s mutes user %s", user.Username, username))
+
+	err := f.feedFilterUseCase.MuteUser(ctx, user.Id, username)
+	if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, usecase.ErrCannotMuteSelf) {
+		logger.Info(ctx, fmt.Sprintf("User %s tried to mute themselves", user.Username))
+		http2.WriteJSONError(w, "Can not mute yourself", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to mute user: %v", err))
+		http2.WriteJSONError(w, "Failed to mute user", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnmuteUser returns posts of the author to the feed
+// @Summary Unmute author
+// @Description Returns posts of the muted author to the feed and recommendations of the user
+// @Tags Feed filter
+// @Param username path string true "Username of the author"
+// @Success 200 "Author is not muted"
+// @Failure 404 {object} forms.ErrorForm "User not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/users/{username}/mute [delete]
+func (f *FeedFilterHandler) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while unmuting user")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	username := mux.Vars(r)["username"]
+	logger.Info(ctx, fmt.Sprintf("User %s unmutes user %s", user.Username, username))
+
+	err := f.feedFilterUseCase.UnmuteUser(ctx, user.Id, username)
+	if errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("User %s not found", username))
+		http2.WriteJSONError(w, "User not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to unmute user: %v", err))
+		http2.WriteJSONError(w, "Failed to unmute user", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetMutedUsers returns muted authors
+// @Summary Get muted authors
+// @Description Returns authors muted by the user, latest muted first
+// @Tags Feed filter
+// @Produce json
+// @Success 200 {object} forms.PayloadWrapper[[]forms.MutedUserOut] "Muted authors"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/users/muted [get]
+func (f *FeedFilterHandler) GetMutedUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching muted users")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	muted, err := f.feedFilterUseCase.FetchMutedUsers(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch muted users: %v", err))
+		http2.WriteJSONError(w, "Failed to load muted users", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.MutedUserOut]{Payload: forms.MutedUsersToOut(muted)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode muted users: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode muted users", http.StatusInternalServerError)
+	}
+}
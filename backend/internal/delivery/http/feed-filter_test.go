@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestFeedFilterHandler_HidePost(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+	postId := uuid.New()
+
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+	}{
+		{name: "visible post", expectedCode: http.StatusOK},
+		{name: "post of stranger for friends", useCaseErr: usecase.ErrPostNotFound, expectedCode: http.StatusNotFound},
+		{name: "server error", useCaseErr: fmt.Errorf("db error"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFeedFilterUseCase := mocks.NewMockFeedFilterUseCase(ctrl)
+			mockFeedFilterUseCase.EXPECT().HidePost(gomock.Any(), user.Id, postId).Return(tt.useCaseErr)
+			handler := http2.NewFeedFilterHandler(mockFeedFilterUseCase)
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+postId.String()+"/hide", nil)
+			req = mux.SetURLVars(req, map[string]string{"post_id": postId.String()})
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.HidePost(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestFeedFilterHandler_MuteUser(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+
+	tests := []struct {
+		name         string
+		username     string
+		useCaseErr   error
+		expectedCode int
+	}{
+		{name: "followed author", username: "author", expectedCode: http.StatusOK},
+		{name: "unknown author", username: "nobody", useCaseErr: fmt.Errorf("wrapped: %w", usecase.ErrNotFound), expectedCode: http.StatusNotFound},
+		{name: "themselves", username: user.Username, useCaseErr: usecase.ErrCannotMuteSelf, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFeedFilterUseCase := mocks.NewMockFeedFilterUseCase(ctrl)
+			mockFeedFilterUseCase.EXPECT().MuteUser(gomock.Any(), user.Id, tt.username).Return(tt.useCaseErr)
+			handler := http2.NewFeedFilterHandler(mockFeedFilterUseCase)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.username+"/mute", nil)
+			req = mux.SetURLVars(req, map[string]string{"username": tt.username})
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.MuteUser(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	time2 "quickflow/config/time"
 	"quickflow/internal/usecase"
 	"quickflow/pkg/logger"
 
@@ -21,12 +20,23 @@ import (
 )
 
 type PostUseCase interface {
-	FetchFeed(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error)
-	FetchRecommendations(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error)
-	FetchUserPosts(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error)
+	FetchFeed(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, error)
+	FetchRecommendations(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error)
+	FetchUserPosts(ctx context.Context, user models.User, viewerId uuid.UUID, numPosts int, cursor models.Cursor, withPinned bool) ([]models.Post, error)
 	AddPost(ctx context.Context, post models.Post) (models.Post, error)
 	DeletePost(ctx context.Context, user models.User, postId uuid.UUID) error
 	UpdatePost(ctx context.Context, update models.PostUpdate, userId uuid.UUID) (models.Post, error)
+	FetchPost(ctx context.Context, postId uuid.UUID, viewerId uuid.UUID) (models.Post, error)
+	FetchUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error)
+	SchedulePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID, publishAt time.Time) (models.Post, error)
+	CancelScheduledPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Post, error)
+	FetchPostHistory(ctx context.Context, user models.User, postId uuid.UUID) ([]models.PostRevision, error)
+	FetchTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error)
+	FetchTrendingTags(ctx context.Context, window string, numTags int) ([]models.TrendingTag, error)
+	PinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	UnpinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	FetchBookmarks(ctx context.Context, user models.User, collectionId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error)
+	FetchHiddenPosts(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error)
 }
 
 type FeedHandler struct {
@@ -53,6 +63,7 @@ func NewFeedHandler(authUseCase AuthUseCase, postUseCase PostUseCase, profileUse
 // @Produce json
 // @Param posts_count query int true "Количество постов"
 // @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
 // @Success 200 {array} forms.PostOut "Список постов"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
 // @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
@@ -77,13 +88,8 @@ func (f *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := time.Parse(time2.TimeStampLayout, feedForm.Ts)
-	if err != nil {
-		ts = time.Now()
-	}
-
-	logger.Info(ctx, fmt.Sprintf("Fetching feed for user %s with %d posts with timestamp %v (autogenerated: %t)", user.Username, feedForm.Posts, ts, err != nil))
-	posts, err := f.postUseCase.FetchFeed(ctx, user, feedForm.Posts, ts)
+	logger.Info(ctx, fmt.Sprintf("Fetching feed for user %s with %d posts with cursor %v", user.Username, feedForm.Posts, feedForm.Cursor))
+	posts, err := f.postUseCase.FetchFeed(ctx, user, feedForm.Posts, feedForm.Cursor)
 	if errors.Is(err, usecase.ErrInvalidNumPosts) {
 		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for user %v: %v", user, err))
 		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
@@ -99,30 +105,15 @@ func (f *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info(ctx, fmt.Sprintf("Fetched %d posts for user %s", len(posts), user.Username))
-	var postsOut []forms.PostOut
-	var authors []uuid.UUID
-	for _, post := range posts {
-		var postOut forms.PostOut
-		postOut.FromPost(post)
-		postsOut = append(postsOut, postOut)
-		authors = append(authors, post.CreatorId)
-	}
-
-	// Fetching authors
-	publicAuthorsInfo, err := f.profileUseCase.GetPublicUsersInfo(ctx, authors)
-	for i := range postsOut {
-		rel, err := f.friendUseCase.GetUserRelation(ctx, user.Id, authors[i])
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Failed to get user relation: %s", err.Error()))
-			http2.WriteJSONError(w, "Failed to get user relation", http.StatusInternalServerError)
-			return
-		}
-
-		postsOut[i].Creator = forms.PublicUserInfoToOut(publicAuthorsInfo[authors[i]], rel)
+	postsOut, err := f.postsWithCreators(ctx, user.Id, posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get posts creators: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get posts creators", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(postsOut)
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, feedForm, nextPostsCursor(posts, feedForm.Posts)))
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to encode feed: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to encode feed", http.StatusInternalServerError)
@@ -137,6 +128,7 @@ func (f *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param posts_count query int true "Количество постов"
 // @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
 // @Success 200 {array} forms.PostOut "Список постов"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
 // @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
@@ -162,13 +154,8 @@ func (f *FeedHandler) GetRecommendations(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ts, err := time.Parse(time2.TimeStampLayout, feedForm.Ts)
-	if err != nil {
-		ts = time.Now()
-	}
-
-	logger.Info(ctx, fmt.Sprintf("Fetching recommendations for user %s with %d posts with timestamp %v (autogenerated: %t)", user.Username, feedForm.Posts, ts, err != nil))
-	posts, err := f.postUseCase.FetchRecommendations(ctx, user, feedForm.Posts, ts)
+	logger.Info(ctx, fmt.Sprintf("Fetching recommendations for user %s with %d posts with cursor %v", user.Username, feedForm.Posts, feedForm.Cursor))
+	posts, nextCursor, err := f.postUseCase.FetchRecommendations(ctx, user, feedForm.Posts, feedForm.Cursor)
 	if errors.Is(err, usecase.ErrInvalidNumPosts) {
 		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for user %v: %v", user, err))
 		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
@@ -184,29 +171,19 @@ func (f *FeedHandler) GetRecommendations(w http.ResponseWriter, r *http.Request)
 	}
 
 	logger.Info(ctx, fmt.Sprintf("Fetched %d posts for user %s", len(posts), user.Username))
-	var postsOut []forms.PostOut
-	var authors []uuid.UUID
-	for _, post := range posts {
-		var postOut forms.PostOut
-		postOut.FromPost(post)
-		postsOut = append(postsOut, postOut)
-		authors = append(authors, post.CreatorId)
-	}
-
-	publicAuthorsInfo, err := f.profileUseCase.GetPublicUsersInfo(ctx, authors)
-	for i := range postsOut {
-		rel, err := f.friendUseCase.GetUserRelation(ctx, user.Id, authors[i])
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Failed to get user relation: %s", err.Error()))
-			http2.WriteJSONError(w, "Failed to get user relation", http.StatusInternalServerError)
-			return
-		}
-
-		postsOut[i].Creator = forms.PublicUserInfoToOut(publicAuthorsInfo[authors[i]], rel)
+	postsOut, err := f.postsWithCreators(ctx, user.Id, posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get posts creators: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get posts creators", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(postsOut)
+	if len(posts) < feedForm.Posts {
+		// both ranked and chronological posts are over
+		nextCursor = models.Cursor{}
+	}
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, feedForm, nextCursor))
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to encode recommendations: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to encode recommendations", http.StatusInternalServerError)
@@ -215,13 +192,15 @@ func (f *FeedHandler) GetRecommendations(w http.ResponseWriter, r *http.Request)
 
 // FetchUserPosts возвращает посты пользователя
 // @Summary Получить посты пользователя
-// @Description Возвращает список постов пользователя, опубликованных до указанного времени
+// @Description Возвращает список постов пользователя, опубликованных до указанного времени. Закреплённый пост возвращается первым на первой странице
 // @Tags Feed
 // @Produce json
 // @Param posts_count query int true "Количество постов"
 // @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
 // @Success 200 {array} forms.PostOut "Список постов"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 403 {object} forms.ErrorForm "Посты скрыты настройками приватности"
 // @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
 // @Router /api/profiles/{username}/posts [get]
 // @Security Session
@@ -253,7 +232,6 @@ func (f *FeedHandler) FetchUserPosts(w http.ResponseWriter, r *http.Request) {
 	} else {
 		logger.Info(ctx, fmt.Sprintf("User %s requested user posts by user %v", requester.Username, user))
 	}
-
 	// parsing JSON
 	var feedForm forms.FeedForm
 	err = feedForm.GetParams(r.URL.Query())
@@ -263,14 +241,13 @@ func (f *FeedHandler) FetchUserPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := time.Parse(time2.TimeStampLayout, feedForm.Ts)
-	if err != nil {
-		ts = time.Now()
-	}
-
-	logger.Info(ctx, fmt.Sprintf("Fetching user posts for user %s with %d posts with timestamp %v (autogenerated: %t)", user.Username, feedForm.Posts, ts, err != nil))
-	posts, err := f.postUseCase.FetchUserPosts(ctx, user, feedForm.Posts, ts)
-	if errors.Is(err, usecase.ErrInvalidNumPosts) {
+	logger.Info(ctx, fmt.Sprintf("Fetching user posts for user %s with %d posts with cursor %v", user.Username, feedForm.Posts, feedForm.Cursor))
+	posts, err := f.postUseCase.FetchUserPosts(ctx, user, requester.Id, feedForm.Posts, feedForm.Cursor, feedForm.FirstPage)
+	if errors.Is(err, usecase.ErrAccessDenied) {
+		logger.Info(ctx, fmt.Sprintf("Posts of user %s are hidden from %s", user.Username, requester.Username))
+		http2.WriteJSONError(w, "Posts are hidden by privacy settings", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidNumPosts) {
 		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for user %v: %v", user, err))
 		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
 		return
@@ -300,13 +277,376 @@ func (f *FeedHandler) FetchUserPosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for i := range postsOut {
-		postsOut[i].Creator = forms.PublicUserInfoToOut(publicUserInfo, models.RelationSelf)
+		postsOut[i].Creator = forms.PublicUserInfoToOut(publicUserInfo, posts[i].CreatorRelation)
+	}
+
+	// the pinned post is out of chronological order and does not take place on the page
+	pagePosts := posts
+	if len(pagePosts) > 0 && pagePosts[0].IsPinned {
+		pagePosts = pagePosts[1:]
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(postsOut)
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, feedForm, nextPostsCursor(pagePosts, feedForm.Posts)))
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to encode recommendations: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to encode recommendations", http.StatusInternalServerError)
 	}
 }
+
+// GetPost возвращает пост по идентификатору
+// @Summary Получить пост
+// @Description Возвращает пост, если он доступен запрашивающему пользователю
+// @Tags Feed
+// @Produce json
+// @Param post_id path string true "Идентификатор поста"
+// @Success 200 {object} forms.PayloadWrapper[forms.PostOut] "Пост"
+// @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 404 {object} forms.ErrorForm "Пост не найден"
+// @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
+// @Router /api/posts/{post_id} [get]
+func (f *FeedHandler) GetPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+
+	// extracting requester from context, guests have uuid.Nil id
+	requester, ok := ctx.Value("user").(models.User)
+	logger.Info(ctx, fmt.Sprintf("User %s requested post %s", requester.Username, postId))
+
+	post, err := f.postUseCase.FetchPost(ctx, postId, requester.Id)
+	if errors.Is(err, usecase.ErrPostNotFound) || errors.Is(err, usecase.ErrNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Post %s not found", postId))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch post: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to fetch post", http.StatusInternalServerError)
+		return
+	}
+
+	relation := models.RelationNone
+	if ok {
+		relation, err = f.friendUseCase.GetUserRelation(ctx, requester.Id, post.CreatorId)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to get user relation: %s", err.Error()))
+			http2.WriteJSONError(w, "Failed to get user relation", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	publicUserInfo, err := f.profileUseCase.GetPublicUserInfo(ctx, post.CreatorId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch user info: %v", err))
+		http2.WriteJSONError(w, "Failed to load user info", http.StatusInternalServerError)
+		return
+	}
+
+	var postOut forms.PostOut
+	postOut.FromPost(post)
+	postOut.Creator = forms.PublicUserInfoToOut(publicUserInfo, relation)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PostOut]{Payload: postOut})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode post: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode post", http.StatusInternalServerError)
+	}
+}
+
+// GetTagPosts возвращает посты с хэштегом
+// @Summary Получить посты с хэштегом
+// @Description Возвращает список доступных пользователю постов с хэштегом, опубликованных до указанного времени
+// @Tags Feed
+// @Produce json
+// @Param tag path string true "Хэштег без символа #"
+// @Param posts_count query int true "Количество постов"
+// @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
+// @Success 200 {array} forms.PostOut "Список постов"
+// @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
+// @Router /api/tags/{tag}/posts [get]
+func (f *FeedHandler) GetTagPosts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tag := mux.Vars(r)["tag"]
+
+	// extracting requester from context, guests have uuid.Nil id
+	requester, _ := ctx.Value("user").(models.User)
+	logger.Info(ctx, fmt.Sprintf("User %s requested posts with tag %s", requester.Username, tag))
+
+	var feedForm forms.FeedForm
+	err := feedForm.GetParams(r.URL.Query())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	posts, err := f.postUseCase.FetchTagPosts(ctx, tag, requester.Id, feedForm.Posts, feedForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidTag) {
+		logger.Info(ctx, fmt.Sprintf("Invalid tag %s", tag))
+		http2.WriteJSONError(w, "Invalid tag", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidNumPosts) {
+		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for tag %s: %v", tag, err))
+		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidTimestamp) {
+		logger.Info(ctx, fmt.Sprintf("Invalid timestamp for tag %s: %v", tag, err))
+		http2.WriteJSONError(w, "Invalid timestamp", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch posts with tag %s: %v", tag, err))
+		http2.WriteJSONError(w, "Failed to load posts", http.StatusInternalServerError)
+		return
+	}
+
+	postsOut, err := f.postsWithCreators(ctx, requester.Id, posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to load authors of posts: %v", err))
+		http2.WriteJSONError(w, "Failed to load posts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, feedForm, nextPostsCursor(posts, feedForm.Posts)))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode posts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode posts", http.StatusInternalServerError)
+	}
+}
+
+// GetBookmarks возвращает закладки пользователя
+// @Summary Получить закладки
+// @Description Возвращает посты, сохранённые пользователем в закладки, начиная с последних. Посты, ставшие недоступными, пропускаются
+// @Tags Feed
+// @Produce json
+// @Param posts_count query int true "Количество постов"
+// @Param collection_id query string false "Идентификатор коллекции, без него возвращаются закладки всех коллекций"
+// @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
+// @Success 200 {array} forms.PostOut "Список постов"
+// @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
+// @Router /api/bookmarks [get]
+// @Security Session
+func (f *FeedHandler) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching bookmarks")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var bookmarksForm forms.BookmarksForm
+	err := bookmarksForm.GetParams(r.URL.Query())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Fetching bookmarks of user %s in collection %s with %d posts with cursor %v",
+		user.Username, bookmarksForm.CollectionId, bookmarksForm.Posts, bookmarksForm.Cursor))
+	posts, nextCursor, err := f.postUseCase.FetchBookmarks(ctx, user, bookmarksForm.CollectionId, bookmarksForm.Posts, bookmarksForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidNumPosts) {
+		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for user %v: %v", user, err))
+		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidTimestamp) {
+		logger.Info(ctx, fmt.Sprintf("Invalid timestamp for user %v: %v", user, err))
+		http2.WriteJSONError(w, "Invalid timestamp", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch bookmarks: %v", err))
+		http2.WriteJSONError(w, "Failed to load bookmarks", http.StatusInternalServerError)
+		return
+	}
+
+	postsOut, err := f.postsWithCreators(ctx, user.Id, posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get posts creators: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get posts creators", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, bookmarksForm.FeedForm, nextCursor))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode bookmarks: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode bookmarks", http.StatusInternalServerError)
+	}
+}
+
+// GetHiddenPosts возвращает скрытые посты пользователя
+// @Summary Получить скрытые посты
+// @Description Возвращает посты, скрытые пользователем из ленты, начиная с последних. Посты, ставшие недоступными, пропускаются
+// @Tags Feed
+// @Produce json
+// @Param posts_count query int true "Количество постов"
+// @Param ts query string false "Временная метка"
+// @Param cursor query string false "Курсор следующей страницы, при его наличии ответ оборачивается в forms.CursorPage"
+// @Success 200 {array} forms.PostOut "Список постов"
+// @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
+// @Router /api/posts/hidden [get]
+// @Security Session
+func (f *FeedHandler) GetHiddenPosts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching hidden posts")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var feedForm forms.FeedForm
+	err := feedForm.GetParams(r.URL.Query())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Fetching posts hidden by user %s with %d posts with cursor %v",
+		user.Username, feedForm.Posts, feedForm.Cursor))
+	posts, nextCursor, err := f.postUseCase.FetchHiddenPosts(ctx, user, feedForm.Posts, feedForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidNumPosts) {
+		logger.Info(ctx, fmt.Sprintf("Invalid numPosts for user %v: %v", user, err))
+		http2.WriteJSONError(w, "Invalid numPosts", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidTimestamp) {
+		logger.Info(ctx, fmt.Sprintf("Invalid timestamp for user %v: %v", user, err))
+		http2.WriteJSONError(w, "Invalid timestamp", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch hidden posts: %v", err))
+		http2.WriteJSONError(w, "Failed to load hidden posts", http.StatusInternalServerError)
+		return
+	}
+
+	postsOut, err := f.postsWithCreators(ctx, user.Id, posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get posts creators: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get posts creators", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(postsPage(postsOut, feedForm, nextCursor))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode hidden posts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode hidden posts", http.StatusInternalServerError)
+	}
+}
+
+// GetTrendingTags возвращает популярные хэштеги
+// @Summary Получить популярные хэштеги
+// @Description Возвращает хэштеги публичных постов, которые использовало больше всего авторов за последний период
+// @Tags Feed
+// @Produce json
+// @Param window query string false "Период: hour, day или week" default(day)
+// @Param tags_count query int false "Количество хэштегов" default(10)
+// @Success 200 {object} forms.PayloadWrapper[[]forms.TrendingTagOut] "Список хэштегов"
+// @Failure 400 {object} forms.ErrorForm "Некорректные данные"
+// @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
+// @Router /api/tags/trending [get]
+func (f *FeedHandler) GetTrendingTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var trendingForm forms.TrendingTagsForm
+	if err := trendingForm.GetParams(r.URL.Query()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("Requested %d trending tags for window %s", trendingForm.Count, trendingForm.Window))
+
+	tags, err := f.postUseCase.FetchTrendingTags(ctx, trendingForm.Window, trendingForm.Count)
+	if errors.Is(err, usecase.ErrInvalidTrendingWindow) {
+		logger.Info(ctx, fmt.Sprintf("Invalid trending window %s", trendingForm.Window))
+		http2.WriteJSONError(w, "Invalid window", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidNumTags) {
+		logger.Info(ctx, fmt.Sprintf("Invalid number of trending tags %d", trendingForm.Count))
+		http2.WriteJSONError(w, "Invalid tags_count", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch trending tags: %v", err))
+		http2.WriteJSONError(w, "Failed to load trending tags", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.TrendingTagOut]{Payload: forms.TrendingTagsToOut(tags)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode trending tags: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode trending tags", http.StatusInternalServerError)
+	}
+}
+
+// postsWithCreators converts posts to output forms with their creators.
+// Creators and their relation to the viewer are loaded in batches, independently of the number of posts.
+func (f *FeedHandler) postsWithCreators(ctx context.Context, viewerId uuid.UUID, posts []models.Post) ([]forms.PostOut, error) {
+	var (
+		postsOut []forms.PostOut
+		authors  []uuid.UUID
+		included = make(map[uuid.UUID]struct{})
+	)
+	for _, post := range posts {
+		var postOut forms.PostOut
+		postOut.FromPost(post)
+		postsOut = append(postsOut, postOut)
+
+		if _, ok := included[post.CreatorId]; !ok {
+			included[post.CreatorId] = struct{}{}
+			authors = append(authors, post.CreatorId)
+		}
+	}
+	if len(authors) == 0 {
+		return postsOut, nil
+	}
+
+	publicAuthorsInfo, err := f.profileUseCase.GetPublicUsersInfo(ctx, authors)
+	if err != nil {
+		return nil, fmt.Errorf("f.profileUseCase.GetPublicUsersInfo: %w", err)
+	}
+	// guests have no relations to authors
+	var relations map[uuid.UUID]models.UserRelation
+	if viewerId != uuid.Nil {
+		relations, err = f.friendUseCase.GetUserRelations(ctx, viewerId, authors)
+		if err != nil {
+			return nil, fmt.Errorf("f.friendUseCase.GetUserRelations: %w", err)
+		}
+	}
+
+	for i, post := range posts {
+		postsOut[i].Creator = forms.PublicUserInfoToOut(publicAuthorsInfo[post.CreatorId], relations[post.CreatorId])
+	}
+	return postsOut, nil
+}
+
+// nextPostsCursor returns cursor of the page following posts.
+// Zero cursor is returned when the page is not full, so there is nothing to fetch.
+func nextPostsCursor(posts []models.Post, numPosts int) models.Cursor {
+	if len(posts) == 0 || len(posts) < numPosts {
+		return models.Cursor{}
+	}
+	last := posts[len(posts)-1]
+	return models.Cursor{Ts: last.CreatedAt, Id: last.Id}
+}
+
+// postsPage keeps legacy plain array response for clients paginating by ts.
+func postsPage(postsOut []forms.PostOut, feedForm forms.FeedForm, next models.Cursor) interface{} {
+	if !feedForm.UseCursor {
+		return postsOut
+	}
+	return forms.CursorPage[[]forms.PostOut]{Payload: postsOut, NextCursor: next.String()}
+}
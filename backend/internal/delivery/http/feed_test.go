@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	time2 "quickflow/config/time"
@@ -19,6 +20,7 @@ import (
 	http2 "quickflow/internal/delivery/http"
 	"quickflow/internal/delivery/http/mocks"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 )
 
 func TestFeedHandler_GetFeed(t *testing.T) {
@@ -71,11 +73,13 @@ func TestFeedHandler_GetFeed(t *testing.T) {
 						}, nil
 					})
 
-				// Expect two relation checks with any UUID
+				// relations of all authors are requested at once
 				mockFriendsUseCase.EXPECT().
-					GetUserRelation(gomock.Any(), user.Id, gomock.Any()).
-					Return(models.RelationNone, nil).
-					Times(2)
+					GetUserRelations(gomock.Any(), user.Id, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, viewer uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error) {
+						assert.Len(t, others, 2)
+						return map[uuid.UUID]models.UserRelation{}, nil
+					})
 			},
 			expectedStatus: http.StatusOK,
 			expectedLen:    2,
@@ -97,6 +101,17 @@ func TestFeedHandler_GetFeed(t *testing.T) {
 			expectedLen:    0,
 			passUser:       true,
 		},
+		{
+			name: "Invalid cursor",
+			queryParams: url.Values{
+				"posts_count": []string{"3"},
+				"cursor":      []string{"not-a-cursor"},
+			},
+			mockSetup:      func(t *testing.T) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedLen:    0,
+			passUser:       true,
+		},
 		{
 			name: "Invalid request format (missing posts_count)",
 			queryParams: url.Values{
@@ -148,3 +163,131 @@ func TestFeedHandler_GetFeed(t *testing.T) {
 		})
 	}
 }
+
+func TestFeedHandler_GetTagPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostUseCase := mocks.NewMockPostUseCase(ctrl)
+	mockFriendsUseCase := mocks.NewMockFriendsUseCase(ctrl)
+	mockProfileUC := mocks.NewMockProfileUseCase(ctrl)
+	handler := http2.NewFeedHandler(mocks.NewMockAuthUseCase(ctrl), mockPostUseCase, mockProfileUC, mockFriendsUseCase)
+
+	t.Run("guest gets posts without relations", func(t *testing.T) {
+		post := models.Post{Id: uuid.New(), CreatorId: uuid.New(), Desc: "#Go"}
+		mockPostUseCase.EXPECT().FetchTagPosts(gomock.Any(), "Go", uuid.Nil, 2, gomock.Any()).Return([]models.Post{post}, nil)
+		mockProfileUC.EXPECT().GetPublicUsersInfo(gomock.Any(), []uuid.UUID{post.CreatorId}).
+			Return(map[uuid.UUID]models.PublicUserInfo{post.CreatorId: {Id: post.CreatorId}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tags/Go/posts?posts_count=2", nil)
+		req = mux.SetURLVars(req, map[string]string{"tag": "Go"})
+		w := httptest.NewRecorder()
+		handler.GetTagPosts(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var posts []forms.PostOut
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&posts))
+		assert.Len(t, posts, 1)
+		assert.Equal(t, []string{"go"}, posts[0].Tags)
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		mockPostUseCase.EXPECT().FetchTagPosts(gomock.Any(), "123", uuid.Nil, 2, gomock.Any()).Return(nil, usecase.ErrInvalidTag)
+
+		req := httptest.NewRequest(http.MethodGet, "/tags/123/posts?posts_count=2", nil)
+		req = mux.SetURLVars(req, map[string]string{"tag": "123"})
+		w := httptest.NewRecorder()
+		handler.GetTagPosts(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFeedHandler_GetTrendingTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostUseCase := mocks.NewMockPostUseCase(ctrl)
+	handler := http2.NewFeedHandler(mocks.NewMockAuthUseCase(ctrl), mockPostUseCase, mocks.NewMockProfileUseCase(ctrl), mocks.NewMockFriendsUseCase(ctrl))
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "defaults",
+			mockSetup: func() {
+				mockPostUseCase.EXPECT().FetchTrendingTags(gomock.Any(), "day", 10).
+					Return([]models.TrendingTag{{Tag: "go", PostCount: 3, AuthorCount: 2, PreviousPostCount: 1}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "invalid window",
+			query: "?window=year",
+			mockSetup: func() {
+				mockPostUseCase.EXPECT().FetchTrendingTags(gomock.Any(), "year", 10).Return(nil, usecase.ErrInvalidTrendingWindow)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid tags_count",
+			query:          "?tags_count=many",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			w := httptest.NewRecorder()
+			handler.GetTrendingTags(w, httptest.NewRequest(http.MethodGet, "/tags/trending"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var tags forms.PayloadWrapper[[]forms.TrendingTagOut]
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&tags))
+				assert.Equal(t, []forms.TrendingTagOut{{Tag: "go", PostCount: 3, AuthorCount: 2, PreviousPostCount: 1}}, tags.Payload)
+			}
+		})
+	}
+}
+
+func TestPostHandler_PinPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostUseCase := mocks.NewMockPostUseCase(ctrl)
+	handler := http2.NewPostHandler(mockPostUseCase, mocks.NewMockProfileUseCase(ctrl), nil)
+	user := models.User{Id: uuid.New(), Username: "author"}
+	postId := uuid.New()
+
+	tests := []struct {
+		name           string
+		pinErr         error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "post of another user", pinErr: usecase.ErrPostDoesNotBelongToUser, expectedStatus: http.StatusForbidden},
+		{name: "post not found", pinErr: usecase.ErrPostNotFound, expectedStatus: http.StatusNotFound},
+		{name: "draft", pinErr: usecase.ErrPostNotPublished, expectedStatus: http.StatusConflict},
+		{name: "server error", pinErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPostUseCase.EXPECT().PinPost(gomock.Any(), user.Id, postId).Return(tt.pinErr)
+
+			req := httptest.NewRequest(http.MethodPut, "/posts/"+postId.String()+"/pin", nil)
+			req = mux.SetURLVars(req, map[string]string{"post_id": postId.String()})
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			w := httptest.NewRecorder()
+			handler.PinPost(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -16,13 +16,14 @@ import (
 )
 
 type FriendsUseCase interface {
-	GetFriendsInfo(ctx context.Context, userID string, limit string, offset string) ([]models.FriendInfo, bool, int, error)
+	GetFriendsInfo(ctx context.Context, userID string, limit string, offset string, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error)
 	SendFriendRequest(ctx context.Context, senderID string, receiverID string) error
 	AcceptFriendRequest(ctx context.Context, senderID string, receiverID string) error
 	Unfollow(ctx context.Context, userID string, friendID string) error
 	DeleteFriend(ctx context.Context, user string, friend string) error
 	IsExistsFriendRequest(ctx context.Context, senderID string, receiverID string) (bool, error)
 	GetUserRelation(ctx context.Context, user1 uuid.UUID, user2 uuid.UUID) (models.UserRelation, error)
+	GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error)
 }
 
 type FriendHandler struct {
@@ -42,6 +43,9 @@ func NewFriendHandler(friendsUseCase FriendsUseCase, connService IWebSocketConne
 // @Description Возвращает список друзей пользователя
 // @Tags Friends
 // @Produce json
+// @Param count query int true "Количество друзей"
+// @Param offset query int false "Смещение (устаревший способ пагинации)"
+// @Param cursor query string false "Курсор следующей страницы, имеет приоритет над смещением"
 // @Success 200 {array} forms.FriendsInfoOut "Список друзей"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
 // @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
@@ -60,6 +64,17 @@ func (f *FriendHandler) GetFriends(w http.ResponseWriter, r *http.Request) {
 	offset := r.URL.Query().Get("offset")
 	userID := r.URL.Query().Get("user_id")
 
+	var cursor models.NameCursor
+	if r.URL.Query().Get("cursor") != "" {
+		var err error
+		cursor, err = models.ParseNameCursor(r.URL.Query().Get("cursor"))
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to parse cursor: %s", err.Error()))
+			http2.WriteJSONError(w, "Failed to parse cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
 	targetUserID := userID
 	if targetUserID == "" {
 		targetUserID = user.Id.String()
@@ -67,7 +82,7 @@ func (f *FriendHandler) GetFriends(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info(ctx, fmt.Sprintf("User %s requested friends", targetUserID))
 
-	friendsInfo, hasMore, friendsCount, err := f.FriendsUseCase.GetFriendsInfo(ctx, targetUserID, limit, offset)
+	friendsInfo, hasMore, friendsCount, err := f.FriendsUseCase.GetFriendsInfo(ctx, targetUserID, limit, offset, cursor)
 
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to get list of friends for user %s: %s", user.Username, err.Error()))
@@ -82,10 +97,16 @@ func (f *FriendHandler) GetFriends(w http.ResponseWriter, r *http.Request) {
 		friendsOnline = append(friendsOnline, isOnline)
 	}
 
+	var nextCursor models.NameCursor
+	if hasMore && len(friendsInfo) > 0 {
+		last := friendsInfo[len(friendsInfo)-1]
+		nextCursor = models.NameCursor{Lastname: last.Lastname, Firstname: last.Firstname, Id: last.Id}
+	}
+
 	var friendsInfoOut forms.FriendsInfoOut
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(friendsInfoOut.ToJson(friendsInfo, friendsOnline, hasMore, friendsCount))
+	err = json.NewEncoder(w).Encode(friendsInfoOut.ToJson(friendsInfo, friendsOnline, hasMore, friendsCount, nextCursor.String()))
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to encode friends info to json: %s", err))
 		http2.WriteJSONError(w, "Unable to encode friends info to json", http.StatusInternalServerError)
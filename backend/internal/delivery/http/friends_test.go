@@ -42,7 +42,7 @@ func TestFriendsHandler_GetFriends(t *testing.T) {
 			queryParams: map[string]string{},
 			mockBehavior: func() {
 				mockFriendsUseCase.EXPECT().
-					GetFriendsInfo(gomock.Any(), userID.String(), "", "").
+					GetFriendsInfo(gomock.Any(), userID.String(), "", "", models.NameCursor{}).
 					Return([]models.FriendInfo{}, false, 0, nil)
 				mockWS.EXPECT().IsConnected(gomock.Any()).Return(nil, false).AnyTimes()
 			},
@@ -54,7 +54,7 @@ func TestFriendsHandler_GetFriends(t *testing.T) {
 			queryParams: map[string]string{"user_id": targetUserID.String()},
 			mockBehavior: func() {
 				mockFriendsUseCase.EXPECT().
-					GetFriendsInfo(gomock.Any(), targetUserID.String(), "", "").
+					GetFriendsInfo(gomock.Any(), targetUserID.String(), "", "", models.NameCursor{}).
 					Return([]models.FriendInfo{}, false, 0, nil)
 				mockWS.EXPECT().IsConnected(gomock.Any()).Return(nil, false).AnyTimes()
 			},
@@ -66,7 +66,7 @@ func TestFriendsHandler_GetFriends(t *testing.T) {
 			queryParams: map[string]string{},
 			mockBehavior: func() {
 				mockFriendsUseCase.EXPECT().
-					GetFriendsInfo(gomock.Any(), userID.String(), "", "").
+					GetFriendsInfo(gomock.Any(), userID.String(), "", "", models.NameCursor{}).
 					Return(nil, false, 0, errors.New("internal error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -45,6 +45,7 @@ func NewMessageHandler(messageUseCase MessageUseCase, authUseCase AuthUseCase, p
 // @Param chat_id path string true "Chat ID"
 // @Param posts_count query int true "Number of messages"
 // @Param ts query string false "Timestamp"
+// @Param cursor query string false "Cursor of the next page"
 // @Success 200 {array} forms.MessageOut "List of messages"
 // @Failure 400 {object} forms.ErrorForm "Invalid data"
 // @Failure 403 {object} forms.ErrorForm "User is not a participant in the chat"
@@ -82,9 +83,9 @@ func (m *MessageHandler) GetMessagesForChat(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	logger.Info(ctx, fmt.Sprintf("Fetching feed for user %s with %d posts with timestamp %v (autogenerated: %t)", user.Username, messageForm.MessagesCount, messageForm.Ts, !r.URL.Query().Has("ts")))
+	logger.Info(ctx, fmt.Sprintf("Fetching messages for user %s with %d messages with cursor %v", user.Username, messageForm.MessagesCount, messageForm.Cursor))
 
-	messages, err := m.messageUseCase.GetMessagesForChat(ctx, chatId, user.Id, messageForm.MessagesCount, messageForm.Ts)
+	messages, err := m.messageUseCase.GetMessagesForChat(ctx, chatId, user.Id, messageForm.MessagesCount, messageForm.Cursor)
 	switch {
 	case errors.Is(err, usecase.ErrNotParticipant):
 		logger.Info(ctx, fmt.Sprintf("User %s is not a participant in chat %s", user.Username, chatId))
@@ -126,6 +127,10 @@ func (m *MessageHandler) GetMessagesForChat(w http.ResponseWriter, r *http.Reque
 	if getLastReadTs != nil {
 		out.LastReadTs = getLastReadTs.Format(time2.TimeStampLayout)
 	}
+	// messages are sorted from old to new, so the next page starts before the first one
+	if len(messages) > 0 && len(messages) == messageForm.MessagesCount {
+		out.NextCursor = models.Cursor{Ts: messages[0].CreatedAt, Id: messages[0].ID}.String()
+	}
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(out)
 	if err != nil {
@@ -198,7 +203,19 @@ func (m *MessageHandler) SendMessageToUsername(w http.ResponseWriter, r *http.Re
 
 	message := messageForm.ToMessageModel()
 	_, err = m.messageUseCase.SaveMessage(ctx, message)
-	if err != nil {
+	if errors.Is(err, usecase.ErrInvalidUpload) || errors.Is(err, usecase.ErrTooManyUploads) {
+		logger.Error(ctx, fmt.Sprintf("Invalid upload: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid upload", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidMedia) {
+		logger.Error(ctx, fmt.Sprintf("Invalid media file: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid media file", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrFileTooLarge) || errors.Is(err, usecase.ErrMediaTooLong) {
+		logger.Error(ctx, fmt.Sprintf("Attachment exceeds limits: %s", err.Error()))
+		http2.WriteJSONError(w, "Attachment exceeds limits", http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to save message: %v", message))
 		http2.WriteJSONError(w, "Failed to save message", http.StatusInternalServerError)
 		return
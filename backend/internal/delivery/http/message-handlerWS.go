@@ -22,8 +22,8 @@ import (
 
 type MessageUseCase interface {
 	GetMessageById(ctx context.Context, messageId uuid.UUID) (models.Message, error)
-	GetMessagesForChat(ctx context.Context, chatId uuid.UUID, userId uuid.UUID, numMessages int, timestamp time.Time) ([]models.Message, error)
-	SaveMessage(ctx context.Context, message models.Message) (uuid.UUID, error)
+	GetMessagesForChat(ctx context.Context, chatId uuid.UUID, userId uuid.UUID, numMessages int, cursor models.Cursor) ([]models.Message, error)
+	SaveMessage(ctx context.Context, message models.Message) (models.Message, error)
 	DeleteMessage(ctx context.Context, messageId uuid.UUID) error
 	GetLastReadTs(ctx context.Context, chatId uuid.UUID, userId uuid.UUID) (*time.Time, error)
 	UpdateLastReadTs(ctx context.Context, timestamp time.Time, chatId uuid.UUID, userId uuid.UUID) error
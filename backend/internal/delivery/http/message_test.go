@@ -177,7 +177,7 @@ func TestMessageHandler_SendMessageToUsername(t *testing.T) {
 					Return(models.User{Id: recipientID}, nil)
 				mockMessageUC.EXPECT().
 					SaveMessage(gomock.Any(), gomock.Any()).
-					Return(uuid.New(), nil)
+					Return(models.Message{ChatID: uuid.New()}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
@@ -227,7 +227,7 @@ func TestMessageHandler_SendMessageToUsername(t *testing.T) {
 					Return(models.User{Id: recipientID}, nil)
 				mockMessageUC.EXPECT().
 					SaveMessage(gomock.Any(), gomock.Any()).
-					Return(uuid.Nil, errors.New("save error"))
+					Return(models.Message{}, errors.New("save error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
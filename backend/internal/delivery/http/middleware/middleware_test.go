@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +16,7 @@ import (
 	"quickflow/config/cors"
 	"quickflow/internal/delivery/http/mocks"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 )
 
 func TestContentTypeMiddleware(t *testing.T) {
@@ -129,6 +132,21 @@ func TestSessionMiddleware(t *testing.T) {
 		handler.ServeHTTP(w, r)
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
+
+	t.Run("Banned User", func(t *testing.T) {
+		sessionID := uuid.New()
+
+		mockAuthService.EXPECT().LookupUserSession(gomock.Any(), models.Session{SessionId: sessionID}).
+			Return(models.User{}, fmt.Errorf("%w permanently: spam", usecase.ErrUserBanned)).Times(1)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "session", Value: sessionID.String()})
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "user is banned permanently: spam")
+	})
 }
 
 func TestCSRFMiddleware(t *testing.T) {
@@ -211,3 +229,33 @@ func TestCSRFMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *models.User
+		expectedStatus int
+	}{
+		{name: "admin", user: &models.User{Id: uuid.New(), Role: models.RoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "moderator", user: &models.User{Id: uuid.New(), Role: models.RoleModerator}, expectedStatus: http.StatusForbidden},
+		{name: "user without role", user: &models.User{Id: uuid.New()}, expectedStatus: http.StatusForbidden},
+		{name: "no session", expectedStatus: http.StatusUnauthorized},
+	}
+
+	handler := PermissionMiddleware(models.PermissionManageRoles)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/admin/users/johndoe/role", nil)
+			if tt.user != nil {
+				r = r.WithContext(context.WithValue(r.Context(), "user", *tt.user))
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	httpUtils "quickflow/utils/http"
+)
+
+// PermissionMiddleware passes requests of users whose role grants the permission.
+// It must run after SessionMiddleware, which puts the user into context.
+func PermissionMiddleware(permission models.Permission) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := r.Context().Value("user").(models.User)
+			if !ok {
+				httpUtils.WriteJSONError(w, "Authorization needed", http.StatusUnauthorized)
+				return
+			}
+
+			if err := usecase.Authorize(user, permission); err != nil {
+				httpUtils.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
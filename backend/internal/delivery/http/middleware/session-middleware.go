@@ -10,6 +10,7 @@ import (
 
 	http2 "quickflow/internal/delivery/http"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 	httpUtils "quickflow/utils/http"
 )
 
@@ -32,7 +33,10 @@ func SessionMiddleware(authUseCase http2.AuthUseCase) mux.MiddlewareFunc {
 
 			// lookup user by session
 			user, err := authUseCase.LookupUserSession(r.Context(), models.Session{SessionId: sessionUuid})
-			if err != nil {
+			if errors.Is(err, usecase.ErrUserBanned) {
+				httpUtils.WriteJSONError(w, err.Error(), http.StatusForbidden)
+				return
+			} else if err != nil {
 				httpUtils.WriteJSONError(w, "Failed to authorize user", http.StatusUnauthorized)
 				return
 			}
@@ -46,3 +50,32 @@ func SessionMiddleware(authUseCase http2.AuthUseCase) mux.MiddlewareFunc {
 		})
 	}
 }
+
+// OptionalSessionMiddleware adds user to context if request has a valid session
+// and passes guests through unchanged. Banned users are treated as guests.
+func OptionalSessionMiddleware(authUseCase http2.AuthUseCase) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, err := r.Cookie("session")
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionUuid, err := uuid.Parse(session.Value)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := authUseCase.LookupUserSession(r.Context(), models.Session{SessionId: sessionUuid})
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "user", user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/admin-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminUseCase is a mock of AdminUseCase interface.
+type MockAdminUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUseCaseMockRecorder
+}
+
+// MockAdminUseCaseMockRecorder is the mock recorder for MockAdminUseCase.
+type MockAdminUseCaseMockRecorder struct {
+	mock *MockAdminUseCase
+}
+
+// NewMockAdminUseCase creates a new mock instance.
+func NewMockAdminUseCase(ctrl *gomock.Controller) *MockAdminUseCase {
+	mock := &MockAdminUseCase{ctrl: ctrl}
+	mock.recorder = &MockAdminUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUseCase) EXPECT() *MockAdminUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockAdminUseCase) BanUser(ctx context.Context, admin models.User, username, reason string, until time.Time) (models.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, admin, username, reason, until)
+	ret0, _ := ret[0].(models.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockAdminUseCaseMockRecorder) BanUser(ctx, admin, username, reason, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockAdminUseCase)(nil).BanUser), ctx, admin, username, reason, until)
+}
+
+// DeleteUserContent mocks base method.
+func (m *MockAdminUseCase) DeleteUserContent(ctx context.Context, admin models.User, username string) (models.DeletedContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserContent", ctx, admin, username)
+	ret0, _ := ret[0].(models.DeletedContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserContent indicates an expected call of DeleteUserContent.
+func (mr *MockAdminUseCaseMockRecorder) DeleteUserContent(ctx, admin, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserContent", reflect.TypeOf((*MockAdminUseCase)(nil).DeleteUserContent), ctx, admin, username)
+}
+
+// GetAccount mocks base method.
+func (m *MockAdminUseCase) GetAccount(ctx context.Context, admin models.User, username string) (models.AccountDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, admin, username)
+	ret0, _ := ret[0].(models.AccountDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAdminUseCaseMockRecorder) GetAccount(ctx, admin, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAdminUseCase)(nil).GetAccount), ctx, admin, username)
+}
+
+// LiftBan mocks base method.
+func (m *MockAdminUseCase) LiftBan(ctx context.Context, admin models.User, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftBan", ctx, admin, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LiftBan indicates an expected call of LiftBan.
+func (mr *MockAdminUseCaseMockRecorder) LiftBan(ctx, admin, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftBan", reflect.TypeOf((*MockAdminUseCase)(nil).LiftBan), ctx, admin, username)
+}
+
+// LogoutUser mocks base method.
+func (m *MockAdminUseCase) LogoutUser(ctx context.Context, admin models.User, username string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutUser", ctx, admin, username)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogoutUser indicates an expected call of LogoutUser.
+func (mr *MockAdminUseCaseMockRecorder) LogoutUser(ctx, admin, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockAdminUseCase)(nil).LogoutUser), ctx, admin, username)
+}
+
+// SearchAccounts mocks base method.
+func (m *MockAdminUseCase) SearchAccounts(ctx context.Context, admin models.User, query string, count int) ([]models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAccounts", ctx, admin, query, count)
+	ret0, _ := ret[0].([]models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockAdminUseCaseMockRecorder) SearchAccounts(ctx, admin, query, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockAdminUseCase)(nil).SearchAccounts), ctx, admin, query, count)
+}
+
+// SetUserRole mocks base method.
+func (m *MockAdminUseCase) SetUserRole(ctx context.Context, admin models.User, username string, role models.Role) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, admin, username, role)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockAdminUseCaseMockRecorder) SetUserRole(ctx, admin, username, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockAdminUseCase)(nil).SetUserRole), ctx, admin, username, role)
+}
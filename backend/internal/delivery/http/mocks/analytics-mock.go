@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/analytics-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAnalyticsUseCase is a mock of AnalyticsUseCase interface.
+type MockAnalyticsUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsUseCaseMockRecorder
+}
+
+// MockAnalyticsUseCaseMockRecorder is the mock recorder for MockAnalyticsUseCase.
+type MockAnalyticsUseCaseMockRecorder struct {
+	mock *MockAnalyticsUseCase
+}
+
+// NewMockAnalyticsUseCase creates a new mock instance.
+func NewMockAnalyticsUseCase(ctrl *gomock.Controller) *MockAnalyticsUseCase {
+	mock := &MockAnalyticsUseCase{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsUseCase) EXPECT() *MockAnalyticsUseCaseMockRecorder {
+	return m.recorder
+}
+
+// FetchAnalytics mocks base method.
+func (m *MockAnalyticsUseCase) FetchAnalytics(ctx context.Context, userId uuid.UUID, from, to time.Time) (models.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAnalytics", ctx, userId, from, to)
+	ret0, _ := ret[0].(models.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAnalytics indicates an expected call of FetchAnalytics.
+func (mr *MockAnalyticsUseCaseMockRecorder) FetchAnalytics(ctx, userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAnalytics", reflect.TypeOf((*MockAnalyticsUseCase)(nil).FetchAnalytics), ctx, userId, from, to)
+}
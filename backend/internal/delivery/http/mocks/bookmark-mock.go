@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/bookmark-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockBookmarkUseCase is a mock of BookmarkUseCase interface.
+type MockBookmarkUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookmarkUseCaseMockRecorder
+}
+
+// MockBookmarkUseCaseMockRecorder is the mock recorder for MockBookmarkUseCase.
+type MockBookmarkUseCaseMockRecorder struct {
+	mock *MockBookmarkUseCase
+}
+
+// NewMockBookmarkUseCase creates a new mock instance.
+func NewMockBookmarkUseCase(ctrl *gomock.Controller) *MockBookmarkUseCase {
+	mock := &MockBookmarkUseCase{ctrl: ctrl}
+	mock.recorder = &MockBookmarkUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookmarkUseCase) EXPECT() *MockBookmarkUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AddBookmark mocks base method.
+func (m *MockBookmarkUseCase) AddBookmark(ctx context.Context, userId, postId, collectionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", ctx, userId, postId, collectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockBookmarkUseCaseMockRecorder) AddBookmark(ctx, userId, postId, collectionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockBookmarkUseCase)(nil).AddBookmark), ctx, userId, postId, collectionId)
+}
+
+// CreateCollection mocks base method.
+func (m *MockBookmarkUseCase) CreateCollection(ctx context.Context, userId uuid.UUID, name string) (models.BookmarkCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, userId, name)
+	ret0, _ := ret[0].(models.BookmarkCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockBookmarkUseCaseMockRecorder) CreateCollection(ctx, userId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockBookmarkUseCase)(nil).CreateCollection), ctx, userId, name)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockBookmarkUseCase) DeleteCollection(ctx context.Context, userId, collectionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, userId, collectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockBookmarkUseCaseMockRecorder) DeleteCollection(ctx, userId, collectionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockBookmarkUseCase)(nil).DeleteCollection), ctx, userId, collectionId)
+}
+
+// FetchCollections mocks base method.
+func (m *MockBookmarkUseCase) FetchCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCollections", ctx, userId)
+	ret0, _ := ret[0].([]models.BookmarkCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCollections indicates an expected call of FetchCollections.
+func (mr *MockBookmarkUseCaseMockRecorder) FetchCollections(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCollections", reflect.TypeOf((*MockBookmarkUseCase)(nil).FetchCollections), ctx, userId)
+}
+
+// RemoveBookmark mocks base method.
+func (m *MockBookmarkUseCase) RemoveBookmark(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookmark", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookmark indicates an expected call of RemoveBookmark.
+func (mr *MockBookmarkUseCaseMockRecorder) RemoveBookmark(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookmark", reflect.TypeOf((*MockBookmarkUseCase)(nil).RemoveBookmark), ctx, userId, postId)
+}
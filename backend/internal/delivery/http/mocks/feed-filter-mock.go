@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/feed-filter-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFeedFilterUseCase is a mock of FeedFilterUseCase interface.
+type MockFeedFilterUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedFilterUseCaseMockRecorder
+}
+
+// MockFeedFilterUseCaseMockRecorder is the mock recorder for MockFeedFilterUseCase.
+type MockFeedFilterUseCaseMockRecorder struct {
+	mock *MockFeedFilterUseCase
+}
+
+// NewMockFeedFilterUseCase creates a new mock instance.
+func NewMockFeedFilterUseCase(ctrl *gomock.Controller) *MockFeedFilterUseCase {
+	mock := &MockFeedFilterUseCase{ctrl: ctrl}
+	mock.recorder = &MockFeedFilterUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedFilterUseCase) EXPECT() *MockFeedFilterUseCaseMockRecorder {
+	return m.recorder
+}
+
+// FetchMutedUsers mocks base method.
+func (m *MockFeedFilterUseCase) FetchMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMutedUsers", ctx, userId)
+	ret0, _ := ret[0].([]models.MutedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMutedUsers indicates an expected call of FetchMutedUsers.
+func (mr *MockFeedFilterUseCaseMockRecorder) FetchMutedUsers(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMutedUsers", reflect.TypeOf((*MockFeedFilterUseCase)(nil).FetchMutedUsers), ctx, userId)
+}
+
+// HidePost mocks base method.
+func (m *MockFeedFilterUseCase) HidePost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HidePost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HidePost indicates an expected call of HidePost.
+func (mr *MockFeedFilterUseCaseMockRecorder) HidePost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HidePost", reflect.TypeOf((*MockFeedFilterUseCase)(nil).HidePost), ctx, userId, postId)
+}
+
+// MuteUser mocks base method.
+func (m *MockFeedFilterUseCase) MuteUser(ctx context.Context, userId uuid.UUID, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", ctx, userId, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockFeedFilterUseCaseMockRecorder) MuteUser(ctx, userId, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockFeedFilterUseCase)(nil).MuteUser), ctx, userId, username)
+}
+
+// UnhidePost mocks base method.
+func (m *MockFeedFilterUseCase) UnhidePost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhidePost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnhidePost indicates an expected call of UnhidePost.
+func (mr *MockFeedFilterUseCaseMockRecorder) UnhidePost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhidePost", reflect.TypeOf((*MockFeedFilterUseCase)(nil).UnhidePost), ctx, userId, postId)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockFeedFilterUseCase) UnmuteUser(ctx context.Context, userId uuid.UUID, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", ctx, userId, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockFeedFilterUseCaseMockRecorder) UnmuteUser(ctx, userId, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockFeedFilterUseCase)(nil).UnmuteUser), ctx, userId, username)
+}
@@ -52,6 +52,21 @@ func (mr *MockPostUseCaseMockRecorder) AddPost(ctx, post interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPost", reflect.TypeOf((*MockPostUseCase)(nil).AddPost), ctx, post)
 }
 
+// CancelScheduledPost mocks base method.
+func (m *MockPostUseCase) CancelScheduledPost(ctx context.Context, userId, postId uuid.UUID) (models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledPost", ctx, userId, postId)
+	ret0, _ := ret[0].(models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledPost indicates an expected call of CancelScheduledPost.
+func (mr *MockPostUseCaseMockRecorder) CancelScheduledPost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledPost", reflect.TypeOf((*MockPostUseCase)(nil).CancelScheduledPost), ctx, userId, postId)
+}
+
 // DeletePost mocks base method.
 func (m *MockPostUseCase) DeletePost(ctx context.Context, user models.User, postId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -66,49 +81,200 @@ func (mr *MockPostUseCaseMockRecorder) DeletePost(ctx, user, postId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockPostUseCase)(nil).DeletePost), ctx, user, postId)
 }
 
+// FetchBookmarks mocks base method.
+func (m *MockPostUseCase) FetchBookmarks(ctx context.Context, user models.User, collectionId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookmarks", ctx, user, collectionId, numPosts, cursor)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchBookmarks indicates an expected call of FetchBookmarks.
+func (mr *MockPostUseCaseMockRecorder) FetchBookmarks(ctx, user, collectionId, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookmarks", reflect.TypeOf((*MockPostUseCase)(nil).FetchBookmarks), ctx, user, collectionId, numPosts, cursor)
+}
+
 // FetchFeed mocks base method.
-func (m *MockPostUseCase) FetchFeed(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (m *MockPostUseCase) FetchFeed(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchFeed", ctx, user, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "FetchFeed", ctx, user, numPosts, cursor)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchFeed indicates an expected call of FetchFeed.
-func (mr *MockPostUseCaseMockRecorder) FetchFeed(ctx, user, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostUseCaseMockRecorder) FetchFeed(ctx, user, numPosts, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFeed", reflect.TypeOf((*MockPostUseCase)(nil).FetchFeed), ctx, user, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFeed", reflect.TypeOf((*MockPostUseCase)(nil).FetchFeed), ctx, user, numPosts, cursor)
 }
 
-// FetchRecommendations mocks base method.
-func (m *MockPostUseCase) FetchRecommendations(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+// FetchHiddenPosts mocks base method.
+func (m *MockPostUseCase) FetchHiddenPosts(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchRecommendations", ctx, user, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "FetchHiddenPosts", ctx, user, numPosts, cursor)
 	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchHiddenPosts indicates an expected call of FetchHiddenPosts.
+func (mr *MockPostUseCaseMockRecorder) FetchHiddenPosts(ctx, user, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchHiddenPosts", reflect.TypeOf((*MockPostUseCase)(nil).FetchHiddenPosts), ctx, user, numPosts, cursor)
+}
+
+// FetchPost mocks base method.
+func (m *MockPostUseCase) FetchPost(ctx context.Context, postId, viewerId uuid.UUID) (models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPost", ctx, postId, viewerId)
+	ret0, _ := ret[0].(models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPost indicates an expected call of FetchPost.
+func (mr *MockPostUseCaseMockRecorder) FetchPost(ctx, postId, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPost", reflect.TypeOf((*MockPostUseCase)(nil).FetchPost), ctx, postId, viewerId)
+}
+
+// FetchPostHistory mocks base method.
+func (m *MockPostUseCase) FetchPostHistory(ctx context.Context, user models.User, postId uuid.UUID) ([]models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPostHistory", ctx, user, postId)
+	ret0, _ := ret[0].([]models.PostRevision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// FetchPostHistory indicates an expected call of FetchPostHistory.
+func (mr *MockPostUseCaseMockRecorder) FetchPostHistory(ctx, user, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPostHistory", reflect.TypeOf((*MockPostUseCase)(nil).FetchPostHistory), ctx, user, postId)
+}
+
+// FetchRecommendations mocks base method.
+func (m *MockPostUseCase) FetchRecommendations(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRecommendations", ctx, user, numPosts, cursor)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // FetchRecommendations indicates an expected call of FetchRecommendations.
-func (mr *MockPostUseCaseMockRecorder) FetchRecommendations(ctx, user, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostUseCaseMockRecorder) FetchRecommendations(ctx, user, numPosts, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRecommendations", reflect.TypeOf((*MockPostUseCase)(nil).FetchRecommendations), ctx, user, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRecommendations", reflect.TypeOf((*MockPostUseCase)(nil).FetchRecommendations), ctx, user, numPosts, cursor)
+}
+
+// FetchTagPosts mocks base method.
+func (m *MockPostUseCase) FetchTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTagPosts", ctx, tag, viewerId, numPosts, cursor)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTagPosts indicates an expected call of FetchTagPosts.
+func (mr *MockPostUseCaseMockRecorder) FetchTagPosts(ctx, tag, viewerId, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTagPosts", reflect.TypeOf((*MockPostUseCase)(nil).FetchTagPosts), ctx, tag, viewerId, numPosts, cursor)
+}
+
+// FetchTrendingTags mocks base method.
+func (m *MockPostUseCase) FetchTrendingTags(ctx context.Context, window string, numTags int) ([]models.TrendingTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTrendingTags", ctx, window, numTags)
+	ret0, _ := ret[0].([]models.TrendingTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTrendingTags indicates an expected call of FetchTrendingTags.
+func (mr *MockPostUseCaseMockRecorder) FetchTrendingTags(ctx, window, numTags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTrendingTags", reflect.TypeOf((*MockPostUseCase)(nil).FetchTrendingTags), ctx, window, numTags)
+}
+
+// FetchUnpublishedPosts mocks base method.
+func (m *MockPostUseCase) FetchUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUnpublishedPosts", ctx, userId)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUnpublishedPosts indicates an expected call of FetchUnpublishedPosts.
+func (mr *MockPostUseCaseMockRecorder) FetchUnpublishedPosts(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUnpublishedPosts", reflect.TypeOf((*MockPostUseCase)(nil).FetchUnpublishedPosts), ctx, userId)
 }
 
 // FetchUserPosts mocks base method.
-func (m *MockPostUseCase) FetchUserPosts(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (m *MockPostUseCase) FetchUserPosts(ctx context.Context, user models.User, viewerId uuid.UUID, numPosts int, cursor models.Cursor, withPinned bool) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchUserPosts", ctx, user, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "FetchUserPosts", ctx, user, viewerId, numPosts, cursor, withPinned)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchUserPosts indicates an expected call of FetchUserPosts.
-func (mr *MockPostUseCaseMockRecorder) FetchUserPosts(ctx, user, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostUseCaseMockRecorder) FetchUserPosts(ctx, user, viewerId, numPosts, cursor, withPinned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserPosts", reflect.TypeOf((*MockPostUseCase)(nil).FetchUserPosts), ctx, user, viewerId, numPosts, cursor, withPinned)
+}
+
+// PinPost mocks base method.
+func (m *MockPostUseCase) PinPost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinPost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinPost indicates an expected call of PinPost.
+func (mr *MockPostUseCaseMockRecorder) PinPost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinPost", reflect.TypeOf((*MockPostUseCase)(nil).PinPost), ctx, userId, postId)
+}
+
+// SchedulePost mocks base method.
+func (m *MockPostUseCase) SchedulePost(ctx context.Context, userId, postId uuid.UUID, publishAt time.Time) (models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePost", ctx, userId, postId, publishAt)
+	ret0, _ := ret[0].(models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePost indicates an expected call of SchedulePost.
+func (mr *MockPostUseCaseMockRecorder) SchedulePost(ctx, userId, postId, publishAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePost", reflect.TypeOf((*MockPostUseCase)(nil).SchedulePost), ctx, userId, postId, publishAt)
+}
+
+// UnpinPost mocks base method.
+func (m *MockPostUseCase) UnpinPost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinPost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinPost indicates an expected call of UnpinPost.
+func (mr *MockPostUseCaseMockRecorder) UnpinPost(ctx, userId, postId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserPosts", reflect.TypeOf((*MockPostUseCase)(nil).FetchUserPosts), ctx, user, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinPost", reflect.TypeOf((*MockPostUseCase)(nil).UnpinPost), ctx, userId, postId)
 }
 
 // UpdatePost mocks base method.
@@ -65,9 +65,9 @@ func (mr *MockFriendsUseCaseMockRecorder) DeleteFriend(ctx, user, friend interfa
 }
 
 // GetFriendsInfo mocks base method.
-func (m *MockFriendsUseCase) GetFriendsInfo(ctx context.Context, userID, limit, offset string) ([]models.FriendInfo, bool, int, error) {
+func (m *MockFriendsUseCase) GetFriendsInfo(ctx context.Context, userID, limit, offset string, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFriendsInfo", ctx, userID, limit, offset)
+	ret := m.ctrl.Call(m, "GetFriendsInfo", ctx, userID, limit, offset, cursor)
 	ret0, _ := ret[0].([]models.FriendInfo)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(int)
@@ -76,9 +76,9 @@ func (m *MockFriendsUseCase) GetFriendsInfo(ctx context.Context, userID, limit,
 }
 
 // GetFriendsInfo indicates an expected call of GetFriendsInfo.
-func (mr *MockFriendsUseCaseMockRecorder) GetFriendsInfo(ctx, userID, limit, offset interface{}) *gomock.Call {
+func (mr *MockFriendsUseCaseMockRecorder) GetFriendsInfo(ctx, userID, limit, offset, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendsInfo", reflect.TypeOf((*MockFriendsUseCase)(nil).GetFriendsInfo), ctx, userID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendsInfo", reflect.TypeOf((*MockFriendsUseCase)(nil).GetFriendsInfo), ctx, userID, limit, offset, cursor)
 }
 
 // GetUserRelation mocks base method.
@@ -96,6 +96,21 @@ func (mr *MockFriendsUseCaseMockRecorder) GetUserRelation(ctx, user1, user2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelation", reflect.TypeOf((*MockFriendsUseCase)(nil).GetUserRelation), ctx, user1, user2)
 }
 
+// GetUserRelations mocks base method.
+func (m *MockFriendsUseCase) GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRelations", ctx, user, others)
+	ret0, _ := ret[0].(map[uuid.UUID]models.UserRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRelations indicates an expected call of GetUserRelations.
+func (mr *MockFriendsUseCaseMockRecorder) GetUserRelations(ctx, user, others interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelations", reflect.TypeOf((*MockFriendsUseCase)(nil).GetUserRelations), ctx, user, others)
+}
+
 // IsExistsFriendRequest mocks base method.
 func (m *MockFriendsUseCase) IsExistsFriendRequest(ctx context.Context, senderID, receiverID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -8,7 +8,6 @@ import (
 	context "context"
 	models "quickflow/internal/models"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -52,18 +51,18 @@ func (mr *MockMessageUseCaseMockRecorder) DeleteMessage(ctx, messageId interface
 }
 
 // GetMessagesForChat mocks base method.
-func (m *MockMessageUseCase) GetMessagesForChat(ctx context.Context, chatId, userId uuid.UUID, numMessages int, timestamp time.Time) ([]models.Message, error) {
+func (m *MockMessageUseCase) GetMessagesForChat(ctx context.Context, chatId, userId uuid.UUID, numMessages int, cursor models.Cursor) ([]models.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessagesForChat", ctx, chatId, userId, numMessages, timestamp)
+	ret := m.ctrl.Call(m, "GetMessagesForChat", ctx, chatId, userId, numMessages, cursor)
 	ret0, _ := ret[0].([]models.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMessagesForChat indicates an expected call of GetMessagesForChat.
-func (mr *MockMessageUseCaseMockRecorder) GetMessagesForChat(ctx, chatId, userId, numMessages, timestamp interface{}) *gomock.Call {
+func (mr *MockMessageUseCaseMockRecorder) GetMessagesForChat(ctx, chatId, userId, numMessages, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesForChat", reflect.TypeOf((*MockMessageUseCase)(nil).GetMessagesForChat), ctx, chatId, userId, numMessages, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesForChat", reflect.TypeOf((*MockMessageUseCase)(nil).GetMessagesForChat), ctx, chatId, userId, numMessages, cursor)
 }
 
 // MarkRead mocks base method.
@@ -81,10 +80,10 @@ func (mr *MockMessageUseCaseMockRecorder) MarkRead(ctx, messageId interface{}) *
 }
 
 // SaveMessage mocks base method.
-func (m *MockMessageUseCase) SaveMessage(ctx context.Context, message models.Message) (uuid.UUID, error) {
+func (m *MockMessageUseCase) SaveMessage(ctx context.Context, message models.Message) (models.Message, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveMessage", ctx, message)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret0, _ := ret[0].(models.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
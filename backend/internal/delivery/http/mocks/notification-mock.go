@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/notification-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationUseCase is a mock of NotificationUseCase interface.
+type MockNotificationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationUseCaseMockRecorder
+}
+
+// MockNotificationUseCaseMockRecorder is the mock recorder for MockNotificationUseCase.
+type MockNotificationUseCaseMockRecorder struct {
+	mock *MockNotificationUseCase
+}
+
+// NewMockNotificationUseCase creates a new mock instance.
+func NewMockNotificationUseCase(ctrl *gomock.Controller) *MockNotificationUseCase {
+	mock := &MockNotificationUseCase{ctrl: ctrl}
+	mock.recorder = &MockNotificationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationUseCase) EXPECT() *MockNotificationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationUseCase) CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationUseCaseMockRecorder) CountUnreadNotifications(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationUseCase)(nil).CountUnreadNotifications), ctx, userId)
+}
+
+// FetchNotifications mocks base method.
+func (m *MockNotificationUseCase) FetchNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchNotifications", ctx, userId, numNotifications, cursor)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchNotifications indicates an expected call of FetchNotifications.
+func (mr *MockNotificationUseCaseMockRecorder) FetchNotifications(ctx, userId, numNotifications, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchNotifications", reflect.TypeOf((*MockNotificationUseCase)(nil).FetchNotifications), ctx, userId, numNotifications, cursor)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *MockNotificationUseCase) MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, userId, upTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockNotificationUseCaseMockRecorder) MarkNotificationsRead(ctx, userId, upTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockNotificationUseCase)(nil).MarkNotificationsRead), ctx, userId, upTo)
+}
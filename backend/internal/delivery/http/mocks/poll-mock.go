@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/poll-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPollUseCase is a mock of PollUseCase interface.
+type MockPollUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollUseCaseMockRecorder
+}
+
+// MockPollUseCaseMockRecorder is the mock recorder for MockPollUseCase.
+type MockPollUseCaseMockRecorder struct {
+	mock *MockPollUseCase
+}
+
+// NewMockPollUseCase creates a new mock instance.
+func NewMockPollUseCase(ctrl *gomock.Controller) *MockPollUseCase {
+	mock := &MockPollUseCase{ctrl: ctrl}
+	mock.recorder = &MockPollUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollUseCase) EXPECT() *MockPollUseCaseMockRecorder {
+	return m.recorder
+}
+
+// FetchPollVoters mocks base method.
+func (m *MockPollUseCase) FetchPollVoters(ctx context.Context, postId uuid.UUID, option int, viewerId uuid.UUID, numVoters int, cursor models.Cursor) ([]models.PollVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPollVoters", ctx, postId, option, viewerId, numVoters, cursor)
+	ret0, _ := ret[0].([]models.PollVoter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPollVoters indicates an expected call of FetchPollVoters.
+func (mr *MockPollUseCaseMockRecorder) FetchPollVoters(ctx, postId, option, viewerId, numVoters, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPollVoters", reflect.TypeOf((*MockPollUseCase)(nil).FetchPollVoters), ctx, postId, option, viewerId, numVoters, cursor)
+}
+
+// RetractVote mocks base method.
+func (m *MockPollUseCase) RetractVote(ctx context.Context, userId, postId uuid.UUID) (models.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractVote", ctx, userId, postId)
+	ret0, _ := ret[0].(models.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetractVote indicates an expected call of RetractVote.
+func (mr *MockPollUseCaseMockRecorder) RetractVote(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractVote", reflect.TypeOf((*MockPollUseCase)(nil).RetractVote), ctx, userId, postId)
+}
+
+// Vote mocks base method.
+func (m *MockPollUseCase) Vote(ctx context.Context, userId, postId uuid.UUID, options []int) (models.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, userId, postId, options)
+	ret0, _ := ret[0].(models.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockPollUseCaseMockRecorder) Vote(ctx, userId, postId, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockPollUseCase)(nil).Vote), ctx, userId, postId, options)
+}
@@ -36,6 +36,21 @@ func (m *MockProfileUseCase) EXPECT() *MockProfileUseCaseMockRecorder {
 	return m.recorder
 }
 
+// GetPrivacySettings mocks base method.
+func (m *MockProfileUseCase) GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettings", ctx, userId)
+	ret0, _ := ret[0].(models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettings indicates an expected call of GetPrivacySettings.
+func (mr *MockProfileUseCaseMockRecorder) GetPrivacySettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettings", reflect.TypeOf((*MockProfileUseCase)(nil).GetPrivacySettings), ctx, userId)
+}
+
 // GetPublicUserInfo mocks base method.
 func (m *MockProfileUseCase) GetPublicUserInfo(ctx context.Context, userId uuid.UUID) (models.PublicUserInfo, error) {
 	m.ctrl.T.Helper()
@@ -67,18 +82,18 @@ func (mr *MockProfileUseCaseMockRecorder) GetPublicUsersInfo(ctx, userIds interf
 }
 
 // GetUserInfoByUserName mocks base method.
-func (m *MockProfileUseCase) GetUserInfoByUserName(ctx context.Context, username string) (models.Profile, error) {
+func (m *MockProfileUseCase) GetUserInfoByUserName(ctx context.Context, username string, viewerId uuid.UUID) (models.Profile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserInfoByUserName", ctx, username)
+	ret := m.ctrl.Call(m, "GetUserInfoByUserName", ctx, username, viewerId)
 	ret0, _ := ret[0].(models.Profile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserInfoByUserName indicates an expected call of GetUserInfoByUserName.
-func (mr *MockProfileUseCaseMockRecorder) GetUserInfoByUserName(ctx, username interface{}) *gomock.Call {
+func (mr *MockProfileUseCaseMockRecorder) GetUserInfoByUserName(ctx, username, viewerId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoByUserName", reflect.TypeOf((*MockProfileUseCase)(nil).GetUserInfoByUserName), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoByUserName", reflect.TypeOf((*MockProfileUseCase)(nil).GetUserInfoByUserName), ctx, username, viewerId)
 }
 
 // UpdateLastSeen mocks base method.
@@ -95,6 +110,21 @@ func (mr *MockProfileUseCaseMockRecorder) UpdateLastSeen(ctx, userId interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockProfileUseCase)(nil).UpdateLastSeen), ctx, userId)
 }
 
+// UpdatePrivacySettings mocks base method.
+func (m *MockProfileUseCase) UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, settings models.PrivacySettings) (models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacySettings", ctx, userId, settings)
+	ret0, _ := ret[0].(models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivacySettings indicates an expected call of UpdatePrivacySettings.
+func (mr *MockProfileUseCaseMockRecorder) UpdatePrivacySettings(ctx, userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacySettings", reflect.TypeOf((*MockProfileUseCase)(nil).UpdatePrivacySettings), ctx, userId, settings)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockProfileUseCase) UpdateProfile(ctx context.Context, newProfile models.Profile) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/report-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockReportUseCase is a mock of ReportUseCase interface.
+type MockReportUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportUseCaseMockRecorder
+}
+
+// MockReportUseCaseMockRecorder is the mock recorder for MockReportUseCase.
+type MockReportUseCaseMockRecorder struct {
+	mock *MockReportUseCase
+}
+
+// NewMockReportUseCase creates a new mock instance.
+func NewMockReportUseCase(ctrl *gomock.Controller) *MockReportUseCase {
+	mock := &MockReportUseCase{ctrl: ctrl}
+	mock.recorder = &MockReportUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportUseCase) EXPECT() *MockReportUseCaseMockRecorder {
+	return m.recorder
+}
+
+// FetchQueue mocks base method.
+func (m *MockReportUseCase) FetchQueue(ctx context.Context, moderator models.User, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchQueue", ctx, moderator, status, numReports, cursor)
+	ret0, _ := ret[0].([]models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchQueue indicates an expected call of FetchQueue.
+func (mr *MockReportUseCaseMockRecorder) FetchQueue(ctx, moderator, status, numReports, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchQueue", reflect.TypeOf((*MockReportUseCase)(nil).FetchQueue), ctx, moderator, status, numReports, cursor)
+}
+
+// FetchReport mocks base method.
+func (m *MockReportUseCase) FetchReport(ctx context.Context, moderator models.User, reportId uuid.UUID) (models.Report, []models.ModerationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReport", ctx, moderator, reportId)
+	ret0, _ := ret[0].(models.Report)
+	ret1, _ := ret[1].([]models.ModerationDecision)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchReport indicates an expected call of FetchReport.
+func (mr *MockReportUseCaseMockRecorder) FetchReport(ctx, moderator, reportId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReport", reflect.TypeOf((*MockReportUseCase)(nil).FetchReport), ctx, moderator, reportId)
+}
+
+// Report mocks base method.
+func (m *MockReportUseCase) Report(ctx context.Context, reporterId uuid.UUID, report models.Report) (models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, reporterId, report)
+	ret0, _ := ret[0].(models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockReportUseCaseMockRecorder) Report(ctx, reporterId, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockReportUseCase)(nil).Report), ctx, reporterId, report)
+}
+
+// ResolveReport mocks base method.
+func (m *MockReportUseCase) ResolveReport(ctx context.Context, moderator models.User, reportId uuid.UUID, resolution models.ReportResolution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReport", ctx, moderator, reportId, resolution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReport indicates an expected call of ResolveReport.
+func (mr *MockReportUseCaseMockRecorder) ResolveReport(ctx, moderator, reportId, resolution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReport", reflect.TypeOf((*MockReportUseCase)(nil).ResolveReport), ctx, moderator, reportId, resolution)
+}
+
+// ReviewReport mocks base method.
+func (m *MockReportUseCase) ReviewReport(ctx context.Context, moderator models.User, reportId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewReport", ctx, moderator, reportId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewReport indicates an expected call of ReviewReport.
+func (mr *MockReportUseCaseMockRecorder) ReviewReport(ctx, moderator, reportId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewReport", reflect.TypeOf((*MockReportUseCase)(nil).ReviewReport), ctx, moderator, reportId)
+}
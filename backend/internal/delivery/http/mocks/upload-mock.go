@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery/http/upload-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUploadUseCase is a mock of UploadUseCase interface.
+type MockUploadUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadUseCaseMockRecorder
+}
+
+// MockUploadUseCaseMockRecorder is the mock recorder for MockUploadUseCase.
+type MockUploadUseCaseMockRecorder struct {
+	mock *MockUploadUseCase
+}
+
+// NewMockUploadUseCase creates a new mock instance.
+func NewMockUploadUseCase(ctrl *gomock.Controller) *MockUploadUseCase {
+	mock := &MockUploadUseCase{ctrl: ctrl}
+	mock.recorder = &MockUploadUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadUseCase) EXPECT() *MockUploadUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateUploads mocks base method.
+func (m *MockUploadUseCase) CreateUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, requests []models.UploadRequest) ([]models.PresignedUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUploads", ctx, userId, purpose, requests)
+	ret0, _ := ret[0].([]models.PresignedUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUploads indicates an expected call of CreateUploads.
+func (mr *MockUploadUseCaseMockRecorder) CreateUploads(ctx, userId, purpose, requests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploads", reflect.TypeOf((*MockUploadUseCase)(nil).CreateUploads), ctx, userId, purpose, requests)
+}
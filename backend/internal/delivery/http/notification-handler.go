@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type NotificationUseCase interface {
+	FetchNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error)
+	MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo time.Time) error
+}
+
+type NotificationHandler struct {
+	notificationUseCase NotificationUseCase
+	profileUseCase      ProfileUseCase
+}
+
+// NewNotificationHandler creates new handler of user notifications.
+func NewNotificationHandler(notificationUseCase NotificationUseCase, profileUseCase ProfileUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+		profileUseCase:      profileUseCase,
+	}
+}
+
+// GetNotifications returns notifications of the user
+// @Summary Get notifications
+// @Description Returns notifications of the user, newest first, together with the number of unread ones
+// @Tags Notifications
+// @Produce json
+// @Param notifications_count query int false "Number of notifications" default(20)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} forms.PayloadWrapper[forms.NotificationsOut] "Notifications"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/notifications [get]
+func (n *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching notifications")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var notificationsForm forms.NotificationsForm
+	if err := notificationsForm.GetParams(r.URL.Query()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	notifications, err := n.notificationUseCase.FetchNotifications(ctx, user.Id, notificationsForm.Count, notificationsForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidNumNotifications) {
+		logger.Info(ctx, fmt.Sprintf("Invalid number of notifications %d", notificationsForm.Count))
+		http2.WriteJSONError(w, "Invalid notifications_count", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch notifications: %v", err))
+		http2.WriteJSONError(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	unread, err := n.notificationUseCase.CountUnreadNotifications(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to count unread notifications: %v", err))
+		http2.WriteJSONError(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	notificationsOut, err := n.notificationsWithActors(ctx, notifications)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to load actors of notifications: %v", err))
+		http2.WriteJSONError(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	out := forms.NotificationsOut{Notifications: notificationsOut, UnreadCount: unread}
+	if len(notifications) == notificationsForm.Count {
+		last := notifications[len(notifications)-1]
+		out.NextCursor = models.Cursor{Ts: last.CreatedAt, Id: last.Id}.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.NotificationsOut]{Payload: out})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode notifications: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode notifications", http.StatusInternalServerError)
+	}
+}
+
+// MarkNotificationsRead marks notifications of the user as read
+// @Summary Mark notifications read
+// @Description Marks notifications created up to ts as read, all of them when ts is omitted
+// @Tags Notifications
+// @Accept json
+// @Param read body forms.MarkNotificationsReadForm false "Time of the newest notification seen"
+// @Success 200 "Notifications are marked read"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/notifications/read [post]
+func (n *NotificationHandler) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while marking notifications read")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var readForm forms.MarkNotificationsReadForm
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&readForm); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to decode read form: %s", err.Error()))
+			http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+	}
+	upTo, err := readForm.UpTo()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse ts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse ts", http.StatusBadRequest)
+		return
+	}
+
+	if err = n.notificationUseCase.MarkNotificationsRead(ctx, user.Id, upTo); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to mark notifications read: %v", err))
+		http2.WriteJSONError(w, "Failed to mark notifications read", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// notificationsWithActors converts notifications to output forms with public info of their actors loaded in one batch.
+func (n *NotificationHandler) notificationsWithActors(ctx context.Context, notifications []models.Notification) ([]forms.NotificationOut, error) {
+	notificationsOut := make([]forms.NotificationOut, 0, len(notifications))
+	if len(notifications) == 0 {
+		return notificationsOut, nil
+	}
+
+	var (
+		actors   []uuid.UUID
+		included = make(map[uuid.UUID]struct{})
+	)
+	for _, notification := range notifications {
+		if _, ok := included[notification.ActorId]; !ok && notification.ActorId != uuid.Nil {
+			included[notification.ActorId] = struct{}{}
+			actors = append(actors, notification.ActorId)
+		}
+	}
+
+	actorsInfo, err := n.profileUseCase.GetPublicUsersInfo(ctx, actors)
+	if err != nil {
+		return nil, fmt.Errorf("n.profileUseCase.GetPublicUsersInfo: %w", err)
+	}
+	for _, notification := range notifications {
+		notificationsOut = append(notificationsOut, forms.NotificationToOut(notification, actorsInfo[notification.ActorId]))
+	}
+	return notificationsOut, nil
+}
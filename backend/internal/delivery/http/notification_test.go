@@ -0,0 +1,130 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestNotificationHandler_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	mockProfileUseCase := mocks.NewMockProfileUseCase(ctrl)
+	handler := http2.NewNotificationHandler(mockNotificationUseCase, mockProfileUseCase)
+
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+	actorId := uuid.New()
+	notifications := []models.Notification{
+		{Id: uuid.New(), UserId: user.Id, Type: models.NotificationPostMention, ActorId: actorId, PostId: uuid.New(), CreatedAt: time.Now()},
+		{Id: uuid.New(), UserId: user.Id, Type: models.NotificationPostMention, ActorId: actorId, PostId: uuid.New(), CreatedAt: time.Now().Add(-time.Hour)},
+	}
+
+	t.Run("full page has next cursor", func(t *testing.T) {
+		mockNotificationUseCase.EXPECT().FetchNotifications(gomock.Any(), user.Id, 2, gomock.Any()).Return(notifications, nil)
+		mockNotificationUseCase.EXPECT().CountUnreadNotifications(gomock.Any(), user.Id).Return(1, nil)
+		// actors are loaded once for the whole page
+		mockProfileUseCase.EXPECT().GetPublicUsersInfo(gomock.Any(), []uuid.UUID{actorId}).
+			Return(map[uuid.UUID]models.PublicUserInfo{actorId: {Id: actorId, Username: "anna"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/notifications?notifications_count=2", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user", user))
+		rr := httptest.NewRecorder()
+		handler.GetNotifications(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var body forms.PayloadWrapper[forms.NotificationsOut]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Len(t, body.Payload.Notifications, 2)
+		assert.Equal(t, "anna", body.Payload.Notifications[0].Actor.Username)
+		assert.Equal(t, 1, body.Payload.UnreadCount)
+		assert.Equal(t, models.Cursor{Ts: notifications[1].CreatedAt, Id: notifications[1].Id}.String(), body.Payload.NextCursor)
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		mockNotificationUseCase.EXPECT().FetchNotifications(gomock.Any(), user.Id, 1000, gomock.Any()).
+			Return(nil, usecase.ErrInvalidNumNotifications)
+
+		req := httptest.NewRequest(http.MethodGet, "/notifications?notifications_count=1000", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user", user))
+		rr := httptest.NewRecorder()
+		handler.GetNotifications(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestNotificationHandler_MarkNotificationsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	handler := http2.NewNotificationHandler(mockNotificationUseCase, mocks.NewMockProfileUseCase(ctrl))
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func()
+		expectedStatusCode int
+	}{
+		{
+			name: "all notifications without body",
+			mockBehavior: func() {
+				mockNotificationUseCase.EXPECT().MarkNotificationsRead(gomock.Any(), user.Id, time.Time{}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "notifications up to ts",
+			body: `{"ts": "2025-04-15T12:00:00Z"}`,
+			mockBehavior: func() {
+				mockNotificationUseCase.EXPECT().MarkNotificationsRead(gomock.Any(), user.Id, gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid ts",
+			body:               `{"ts": "yesterday"}`,
+			mockBehavior:       func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error in usecase",
+			mockBehavior: func() {
+				mockNotificationUseCase.EXPECT().MarkNotificationsRead(gomock.Any(), user.Id, time.Time{}).Return(errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			req := httptest.NewRequest(http.MethodPost, "/notifications/read", strings.NewReader(tc.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.MarkNotificationsRead(rr, req)
+
+			assert.Equal(t, tc.expectedStatusCode, rr.Code)
+		})
+	}
+}
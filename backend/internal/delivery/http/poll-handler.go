@@ -0,0 +1,238 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type PollUseCase interface {
+	Vote(ctx context.Context, userId uuid.UUID, postId uuid.UUID, options []int) (models.Poll, error)
+	RetractVote(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Poll, error)
+	FetchPollVoters(ctx context.Context, postId uuid.UUID, option int, viewerId uuid.UUID, numVoters int, cursor models.Cursor) ([]models.PollVoter, error)
+}
+
+type PollHandler struct {
+	pollUseCase    PollUseCase
+	profileUseCase ProfileUseCase
+}
+
+// NewPollHandler creates new handler of votes in polls attached to posts.
+func NewPollHandler(pollUseCase PollUseCase, profileUseCase ProfileUseCase) *PollHandler {
+	return &PollHandler{
+		pollUseCase:    pollUseCase,
+		profileUseCase: profileUseCase,
+	}
+}
+
+// Vote votes in the poll of the post
+// @Summary Vote in poll
+// @Description Saves options of the poll chosen by the user. The vote has to be retracted before voting again
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Param vote body forms.PollVoteForm true "Positions of chosen options"
+// @Success 200 {object} forms.PayloadWrapper[forms.PollOut] "Poll results"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Poll is closed"
+// @Failure 404 {object} forms.ErrorForm "Poll not found"
+// @Failure 409 {object} forms.ErrorForm "User has already voted"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/poll/vote [post]
+func (p *PollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while voting in poll")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+
+	var voteForm forms.PollVoteForm
+	if err = json.NewDecoder(r.Body).Decode(&voteForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode vote form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s votes for options %v in poll %s", user.Username, voteForm.Options, postId))
+
+	poll, err := p.pollUseCase.Vote(ctx, user.Id, postId, voteForm.Options)
+	if errors.Is(err, usecase.ErrInvalidPollVote) {
+		logger.Info(ctx, fmt.Sprintf("Invalid vote: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid vote", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrAlreadyVoted) {
+		logger.Info(ctx, fmt.Sprintf("User %s has already voted in poll %s", user.Username, postId))
+		http2.WriteJSONError(w, "User has already voted", http.StatusConflict)
+		return
+	} else if err != nil {
+		writePollError(ctx, w, postId, err, "Failed to vote")
+		return
+	}
+
+	writePoll(ctx, w, poll)
+}
+
+// RetractVote retracts the vote in the poll of the post
+// @Summary Retract vote
+// @Description Removes the vote of the user while the poll is open
+// @Tags Polls
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Success 200 {object} forms.PayloadWrapper[forms.PollOut] "Poll results"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Poll is closed"
+// @Failure 404 {object} forms.ErrorForm "Poll not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/poll/vote [delete]
+func (p *PollHandler) RetractVote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while retracting vote")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s retracts vote in poll %s", user.Username, postId))
+
+	poll, err := p.pollUseCase.RetractVote(ctx, user.Id, postId)
+	if err != nil {
+		writePollError(ctx, w, postId, err, "Failed to retract vote")
+		return
+	}
+
+	writePoll(ctx, w, poll)
+}
+
+// GetPollVoters returns users who chose the option of the public poll
+// @Summary Get poll voters
+// @Description Returns users who chose the option, latest first. Voters of anonymous polls are hidden
+// @Tags Polls
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Param option path int true "Position of the option"
+// @Param voters_count query int false "Number of voters" default(20)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} forms.PayloadWrapper[forms.PollVotersOut] "Voters"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Poll is anonymous"
+// @Failure 404 {object} forms.ErrorForm "Poll not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/poll/options/{option}/voters [get]
+func (p *PollHandler) GetPollVoters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	option, err := strconv.Atoi(mux.Vars(r)["option"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse option: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse option", http.StatusBadRequest)
+		return
+	}
+
+	var votersForm forms.PollVotersForm
+	if err = votersForm.GetParams(r.URL.Query()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	// extracting requester from context, guests have uuid.Nil id
+	requester, _ := ctx.Value("user").(models.User)
+
+	voters, err := p.pollUseCase.FetchPollVoters(ctx, postId, option, requester.Id, votersForm.Count, votersForm.Cursor)
+	if errors.Is(err, usecase.ErrInvalidNumVoters) || errors.Is(err, usecase.ErrInvalidPollVote) {
+		logger.Info(ctx, fmt.Sprintf("Invalid voters request: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid voters request", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrPollAnonymous) {
+		logger.Info(ctx, fmt.Sprintf("Poll %s is anonymous", postId))
+		http2.WriteJSONError(w, "Poll is anonymous", http.StatusForbidden)
+		return
+	} else if err != nil {
+		writePollError(ctx, w, postId, err, "Failed to load voters")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(voters))
+	for _, voter := range voters {
+		ids = append(ids, voter.UserId)
+	}
+	usersInfo, err := p.profileUseCase.GetPublicUsersInfo(ctx, ids)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to load voters info: %v", err))
+		http2.WriteJSONError(w, "Failed to load voters", http.StatusInternalServerError)
+		return
+	}
+
+	out := forms.PollVotersOut{Voters: make([]forms.PublicUserInfoOut, 0, len(voters))}
+	for _, voter := range voters {
+		out.Voters = append(out.Voters, forms.PublicUserInfoToOut(usersInfo[voter.UserId], ""))
+	}
+	if len(voters) == votersForm.Count {
+		last := voters[len(voters)-1]
+		out.NextCursor = models.Cursor{Ts: last.VotedAt, Id: last.UserId}.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PollVotersOut]{Payload: out})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode voters: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode voters", http.StatusInternalServerError)
+	}
+}
+
+// writePollError writes errors of finding the poll that are common to all poll requests.
+func writePollError(ctx context.Context, w http.ResponseWriter, postId uuid.UUID, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrPostNotFound), errors.Is(err, usecase.ErrPollNotFound):
+		logger.Info(ctx, fmt.Sprintf("Poll %s not found", postId))
+		http2.WriteJSONError(w, "Poll not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrPollClosed):
+		logger.Info(ctx, fmt.Sprintf("Poll %s is closed", postId))
+		http2.WriteJSONError(w, "Poll is closed", http.StatusForbidden)
+	default:
+		logger.Error(ctx, fmt.Sprintf("%s: %s", message, err.Error()))
+		http2.WriteJSONError(w, message, http.StatusInternalServerError)
+	}
+}
+
+func writePoll(ctx context.Context, w http.ResponseWriter, poll models.Poll) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PollOut]{Payload: forms.PollToOut(poll)}); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode poll: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode poll", http.StatusInternalServerError)
+	}
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -43,6 +44,11 @@ func NewPostHandler(postUseCase PostUseCase, profileUseCase ProfileUseCase, poli
 // @Produce json
 // @Param text formData string true "Текст поста"
 // @Param pics formData file false "Изображения"
+// @Param upload_keys formData []string false "Ключи файлов, загруженных напрямую в хранилище"
+// @Param visibility formData string false "Аудитория поста: public, friends или private"
+// @Param draft formData bool false "Сохранить пост как черновик, который видит только автор"
+// @Param publish_at formData string false "Время отложенной публикации поста"
+// @Param poll formData string false "Опрос в формате JSON: question, options, multiple_choice, anonymous, deadline"
 // @Success 200 {string} string "OK"
 // @Failure 400 {object} forms.ErrorForm "Некорректные данные"
 // @Failure 500 {object} forms.ErrorForm "Ошибка сервера"
@@ -68,7 +74,14 @@ func (p *PostHandler) AddPost(w http.ResponseWriter, r *http.Request) {
 	// parsing JSON
 	var postForm forms.PostForm
 	postForm.Text = r.FormValue("text")
+	postForm.Visibility = r.FormValue("visibility")
 	isRepostString := r.FormValue("is_repost")
+	postForm.Poll, err = forms.ParsePoll(r.FormValue("poll"))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse poll: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse poll", http.StatusBadRequest)
+		return
+	}
 
 	if utf8.RuneCountInString(postForm.Text) > 4000 {
 		logger.Error(ctx, fmt.Sprintf("Text length validation failed: length=%d", utf8.RuneCountInString(postForm.Text)))
@@ -87,6 +100,22 @@ func (p *PostHandler) AddPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if draftString := r.FormValue("draft"); len(draftString) != 0 {
+		postForm.Draft, err = strconv.ParseBool(draftString)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to parse draft: %s", err.Error()))
+			http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+	}
+	postForm.PublishAt, err = forms.ParsePublishAt(r.FormValue("publish_at"))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse publish_at: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse publish time", http.StatusBadRequest)
+		return
+	}
+
+	postForm.UploadKeys = r.MultipartForm.Value["upload_keys"]
 	postForm.Images, err = http2.GetFiles(r, "pics")
 	if errors.Is(err, http2.TooManyFilesErr) {
 		logger.Error(ctx, fmt.Sprintf("Too many pics requested: %s", err.Error()))
@@ -102,7 +131,39 @@ func (p *PostHandler) AddPost(w http.ResponseWriter, r *http.Request) {
 	post := postForm.ToPostModel(user.Id)
 
 	post, err = p.postUseCase.AddPost(ctx, post)
-	if err != nil {
+	if errors.Is(err, usecase.ErrInvalidVisibility) {
+		logger.Error(ctx, fmt.Sprintf("Invalid post visibility: %s", postForm.Visibility))
+		http2.WriteJSONError(w, "Invalid post visibility", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidPublishTime) {
+		logger.Error(ctx, fmt.Sprintf("Invalid publish time: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid publish time", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidPoll) {
+		logger.Error(ctx, fmt.Sprintf("Invalid poll: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid poll", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidImage) {
+		logger.Error(ctx, fmt.Sprintf("Invalid image: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid image", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidMedia) {
+		logger.Error(ctx, fmt.Sprintf("Invalid media file: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid media file", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrMediaTooLong) {
+		logger.Error(ctx, fmt.Sprintf("Media is too long: %s", err.Error()))
+		http2.WriteJSONError(w, "Media is too long", http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, usecase.ErrFileTooLarge) {
+		logger.Error(ctx, fmt.Sprintf("File is too large: %s", err.Error()))
+		http2.WriteJSONError(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidUpload) || errors.Is(err, usecase.ErrTooManyUploads) {
+		logger.Error(ctx, fmt.Sprintf("Invalid upload: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid upload", http.StatusBadRequest)
+		return
+	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to add post: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to add post", http.StatusInternalServerError)
 		return
@@ -222,6 +283,7 @@ func (p *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 
 	var updatePostForm forms.UpdatePostForm
 	updatePostForm.Text = r.FormValue("text")
+	updatePostForm.Visibility = r.FormValue("visibility")
 
 	if utf8.RuneCountInString(updatePostForm.Text) > 4000 {
 		logger.Error(ctx, fmt.Sprintf("Text length validation failed: length=%d", utf8.RuneCountInString(updatePostForm.Text)))
@@ -232,6 +294,7 @@ func (p *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 
 	sanitizer.SanitizeUpdatePost(&updatePostForm, p.policy)
 
+	updatePostForm.UploadKeys = r.MultipartForm.Value["upload_keys"]
 	updatePostForm.Images, err = http2.GetFiles(r, "pics")
 	if errors.Is(err, http2.TooManyFilesErr) {
 		logger.Error(ctx, fmt.Sprintf("Too many pics requested: %s", err.Error()))
@@ -258,6 +321,30 @@ func (p *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		logger.Error(ctx, fmt.Sprintf("Post %s not found", postIdString))
 		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
 		return
+	} else if errors.Is(err, usecase.ErrInvalidVisibility) {
+		logger.Error(ctx, fmt.Sprintf("Invalid post visibility: %s", updatePostForm.Visibility))
+		http2.WriteJSONError(w, "Invalid post visibility", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidImage) {
+		logger.Error(ctx, fmt.Sprintf("Invalid image: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid image", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidMedia) {
+		logger.Error(ctx, fmt.Sprintf("Invalid media file: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid media file", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrMediaTooLong) {
+		logger.Error(ctx, fmt.Sprintf("Media is too long: %s", err.Error()))
+		http2.WriteJSONError(w, "Media is too long", http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, usecase.ErrFileTooLarge) {
+		logger.Error(ctx, fmt.Sprintf("File is too large: %s", err.Error()))
+		http2.WriteJSONError(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidUpload) || errors.Is(err, usecase.ErrTooManyUploads) {
+		logger.Error(ctx, fmt.Sprintf("Invalid upload: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid upload", http.StatusBadRequest)
+		return
 	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to update post: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to update post", http.StatusInternalServerError)
@@ -282,3 +369,329 @@ func (p *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// GetScheduledPosts returns drafts and scheduled posts of the user
+// @Summary Get scheduled posts
+// @Description Returns drafts and scheduled posts of the user, the ones published sooner go first
+// @Tags Post
+// @Produce json
+// @Success 200 {array} forms.PostOut "Unpublished posts"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/scheduled [get]
+func (p *PostHandler) GetScheduledPosts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching scheduled posts")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	posts, err := p.postUseCase.FetchUnpublishedPosts(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch scheduled posts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to load scheduled posts", http.StatusInternalServerError)
+		return
+	}
+
+	publicUserInfo, err := p.profileUseCase.GetPublicUserInfo(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get public user info: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get public user info", http.StatusInternalServerError)
+		return
+	}
+
+	postsOut := make([]forms.PostOut, 0, len(posts))
+	for _, post := range posts {
+		var postOut forms.PostOut
+		postOut.FromPost(post)
+		postOut.Creator = forms.PublicUserInfoToOut(publicUserInfo, models.RelationSelf)
+		postsOut = append(postsOut, postOut)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.PostOut]{Payload: postsOut})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode scheduled posts: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode scheduled posts", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SchedulePost sets time a draft or scheduled post is published at
+// @Summary Schedule post
+// @Description Schedules a draft or reschedules a scheduled post, the post is published right away if the time has come
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Param schedule body forms.SchedulePostForm true "Publish time"
+// @Success 200 {object} forms.PostOut "Scheduled post"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Post does not belong to user"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 409 {object} forms.ErrorForm "Post is already published"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/schedule [put]
+func (p *PostHandler) SchedulePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while scheduling post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+
+	var scheduleForm forms.SchedulePostForm
+	if err = json.NewDecoder(r.Body).Decode(&scheduleForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode schedule: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	publishAt, err := forms.ParsePublishAt(scheduleForm.PublishAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse publish_at: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse publish time", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested to schedule post %s at %v", user.Username, postId, publishAt))
+
+	post, err := p.postUseCase.SchedulePost(ctx, user.Id, postId, publishAt)
+	p.writeScheduledPost(w, r, user, post, err)
+}
+
+// CancelScheduledPost turns a scheduled post back into a draft
+// @Summary Cancel scheduled post
+// @Description Cancels publication of a scheduled post, the post is kept as a draft
+// @Tags Post
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Success 200 {object} forms.PostOut "Draft"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Post does not belong to user"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 409 {object} forms.ErrorForm "Post is already published"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/schedule [delete]
+func (p *PostHandler) CancelScheduledPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while cancelling scheduled post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested to cancel scheduled post %s", user.Username, postId))
+
+	post, err := p.postUseCase.CancelScheduledPost(ctx, user.Id, postId)
+	p.writeScheduledPost(w, r, user, post, err)
+}
+
+// writeScheduledPost writes result of changing post schedule.
+func (p *PostHandler) writeScheduledPost(w http.ResponseWriter, r *http.Request, user models.User, post models.Post, err error) {
+	ctx := r.Context()
+	if errors.Is(err, usecase.ErrPostDoesNotBelongToUser) {
+		logger.Error(ctx, fmt.Sprintf("Post does not belong to user %s", user.Username))
+		http2.WriteJSONError(w, "Post does not belong to user", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrPostNotFound) {
+		logger.Error(ctx, fmt.Sprintf("Post not found: %s", err.Error()))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, usecase.ErrPostAlreadyPublished) {
+		logger.Error(ctx, fmt.Sprintf("Post is already published: %s", err.Error()))
+		http2.WriteJSONError(w, "Post is already published", http.StatusConflict)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidPublishTime) {
+		logger.Error(ctx, fmt.Sprintf("Invalid publish time: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid publish time", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to schedule post: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to schedule post", http.StatusInternalServerError)
+		return
+	}
+
+	var postOut forms.PostOut
+	postOut.FromPost(post)
+	publicUserInfo, err := p.profileUseCase.GetPublicUserInfo(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get public user info: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to get public user info", http.StatusInternalServerError)
+		return
+	}
+	postOut.Creator = forms.PublicUserInfoToOut(publicUserInfo, models.RelationSelf)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PostOut]{Payload: postOut})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode post: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode post", http.StatusInternalServerError)
+		return
+	}
+}
+
+// PinPost pins the post to the top of the user profile
+// @Summary Pin post
+// @Description Pins the published post to the top of the author's profile. A previously pinned post is unpinned
+// @Tags Post
+// @Param post_id path string true "Post ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Post does not belong to user"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 409 {object} forms.ErrorForm "Post is not published"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/pin [put]
+func (p *PostHandler) PinPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while pinning post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested to pin post %s", user.Username, postId))
+
+	err = p.postUseCase.PinPost(ctx, user.Id, postId)
+	if errors.Is(err, usecase.ErrPostNotPublished) {
+		logger.Info(ctx, fmt.Sprintf("Post %s is not published", postId))
+		http2.WriteJSONError(w, "Post is not published", http.StatusConflict)
+		return
+	} else if err != nil {
+		writePinError(ctx, w, user, postId, err, "Failed to pin post")
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("Successfully pinned post %s", postId))
+}
+
+// UnpinPost unpins the post from the top of the user profile
+// @Summary Unpin post
+// @Description Returns the pinned post to its place in the author's profile
+// @Tags Post
+// @Param post_id path string true "Post ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Post does not belong to user"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/pin [delete]
+func (p *PostHandler) UnpinPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while unpinning post")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested to unpin post %s", user.Username, postId))
+
+	if err = p.postUseCase.UnpinPost(ctx, user.Id, postId); err != nil {
+		writePinError(ctx, w, user, postId, err, "Failed to unpin post")
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("Successfully unpinned post %s", postId))
+}
+
+// writePinError writes errors of finding the post that are common to pin requests.
+func writePinError(ctx context.Context, w http.ResponseWriter, user models.User, postId uuid.UUID, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrPostDoesNotBelongToUser):
+		logger.Error(ctx, fmt.Sprintf("Post %s does not belong to user %s", postId, user.Username))
+		http2.WriteJSONError(w, "Post does not belong to user", http.StatusForbidden)
+	case errors.Is(err, usecase.ErrPostNotFound):
+		logger.Error(ctx, fmt.Sprintf("Post %s not found", postId))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+	default:
+		logger.Error(ctx, fmt.Sprintf("%s: %s", message, err.Error()))
+		http2.WriteJSONError(w, message, http.StatusInternalServerError)
+	}
+}
+
+// GetPostHistory returns edit history of the post
+// @Summary Get post history
+// @Description Returns versions of the post from the original one to the latest, available to the author and moderators
+// @Tags Post
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Success 200 {array} forms.PostRevisionOut "Post revisions"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Post does not belong to user"
+// @Failure 404 {object} forms.ErrorForm "Post not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/posts/{post_id}/history [get]
+func (p *PostHandler) GetPostHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching post history")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	postId, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse post id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse post id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s requested history of post %s", user.Username, postId))
+
+	revisions, err := p.postUseCase.FetchPostHistory(ctx, user, postId)
+	if errors.Is(err, usecase.ErrPostDoesNotBelongToUser) {
+		logger.Error(ctx, fmt.Sprintf("Post does not belong to user %s", user.Username))
+		http2.WriteJSONError(w, "Post does not belong to user", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrPostNotFound) {
+		logger.Error(ctx, fmt.Sprintf("Post not found: %s", err.Error()))
+		http2.WriteJSONError(w, "Post not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch post history: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to load post history", http.StatusInternalServerError)
+		return
+	}
+
+	revisionsOut := make([]forms.PostRevisionOut, 0, len(revisions))
+	for _, revision := range revisions {
+		var revisionOut forms.PostRevisionOut
+		revisionOut.FromPostRevision(revision)
+		revisionsOut = append(revisionsOut, revisionOut)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.PostRevisionOut]{Payload: revisionsOut})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode post history: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode post history", http.StatusInternalServerError)
+		return
+	}
+}
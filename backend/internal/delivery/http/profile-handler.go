@@ -21,31 +21,29 @@ import (
 )
 
 type ProfileUseCase interface {
-	GetUserInfoByUserName(ctx context.Context, username string) (models.Profile, error)
+	GetUserInfoByUserName(ctx context.Context, username string, viewerId uuid.UUID) (models.Profile, error)
 	UpdateProfile(ctx context.Context, newProfile models.Profile) error
 	GetPublicUserInfo(ctx context.Context, userId uuid.UUID) (models.PublicUserInfo, error)
 	GetPublicUsersInfo(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]models.PublicUserInfo, error)
 	UpdateLastSeen(ctx context.Context, userId uuid.UUID) error
+	GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, settings models.PrivacySettings) (models.PrivacySettings, error)
 }
 
 type ProfileHandler struct {
-	profileUC      ProfileUseCase
-	friendsUseCase FriendsUseCase
-	authUseCase    AuthUseCase
-	chatUseCase    ChatUseCase
-	connService    IWebSocketConnectionManager
-	policy         *bluemonday.Policy
+	profileUC   ProfileUseCase
+	chatUseCase ChatUseCase
+	connService IWebSocketConnectionManager
+	policy      *bluemonday.Policy
 }
 
-func NewProfileHandler(profileUC ProfileUseCase, friendUseCase FriendsUseCase, authUseCase AuthUseCase,
-	chatUseCase ChatUseCase, connService IWebSocketConnectionManager, policy *bluemonday.Policy) *ProfileHandler {
+func NewProfileHandler(profileUC ProfileUseCase, chatUseCase ChatUseCase,
+	connService IWebSocketConnectionManager, policy *bluemonday.Policy) *ProfileHandler {
 	return &ProfileHandler{
-		profileUC:      profileUC,
-		connService:    connService,
-		friendsUseCase: friendUseCase,
-		authUseCase:    authUseCase,
-		chatUseCase:    chatUseCase,
-		policy:         policy,
+		profileUC:   profileUC,
+		connService: connService,
+		chatUseCase: chatUseCase,
+		policy:      policy,
 	}
 }
 
@@ -67,7 +65,10 @@ func (p *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	userRequested := mux.Vars(r)["username"]
 	logger.Info(ctx, fmt.Sprintf("Request profile of %s", userRequested))
 
-	profileInfo, err := p.profileUC.GetUserInfoByUserName(ctx, userRequested)
+	// extracting viewer from context, guests have uuid.Nil id
+	viewer, isSignedIn := ctx.Value("user").(models.User)
+
+	profileInfo, err := p.profileUC.GetUserInfoByUserName(ctx, userRequested, viewer.Id)
 	if errors.Is(err, usecase.ErrNotFound) {
 		logger.Info(ctx, fmt.Sprintf("Profile of %s not found", userRequested))
 		http2.WriteJSONError(w, "profile not found", http.StatusNotFound)
@@ -81,35 +82,10 @@ func (p *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 	_, isOnline := p.connService.IsConnected(profileInfo.UserId)
 
-	var relation = models.RelationNone
 	var chatId *uuid.UUID
-	if session, err := r.Cookie("session"); err == nil {
-		// parse session
-		sessionUuid, err := uuid.Parse(session.Value)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Failed to parse session: %s", err.Error()))
-			http2.WriteJSONError(w, "Failed to parse session", http.StatusBadRequest)
-			return
-		}
-
-		// lookup user by session
-		user, err := p.authUseCase.LookupUserSession(r.Context(), models.Session{SessionId: sessionUuid})
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Failed to lookup user by session: %s", err.Error()))
-			http2.WriteJSONError(w, "Failed to authorize user", http.StatusUnauthorized)
-			return
-		}
-
-		rel, err := p.friendsUseCase.GetUserRelation(ctx, user.Id, profileInfo.UserId)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Failed to get user relation: %s", err.Error()))
-			http2.WriteJSONError(w, "Failed to get user relation", http.StatusInternalServerError)
-			return
-		}
-		relation = rel
-
+	if isSignedIn {
 		// get chat id
-		chat, err := p.chatUseCase.GetPrivateChat(ctx, user.Id, profileInfo.UserId)
+		chat, err := p.chatUseCase.GetPrivateChat(ctx, viewer.Id, profileInfo.UserId)
 		if err != nil && !errors.Is(err, usecase.ErrNotFound) {
 			logger.Error(ctx, fmt.Sprintf("Failed to get chat id: %s", err.Error()))
 			http2.WriteJSONError(w, "Failed to get chat id", http.StatusInternalServerError)
@@ -122,7 +98,7 @@ func (p *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(forms.ModelToForm(profileInfo, userRequested, isOnline, relation, chatId))
+	err = json.NewEncoder(w).Encode(forms.ModelToForm(profileInfo, userRequested, isOnline, profileInfo.Relation, chatId))
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to encode profile: %s", err.Error()))
 		http2.WriteJSONError(w, "Failed to encode feed", http.StatusInternalServerError)
@@ -142,6 +118,8 @@ func (p *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 // @Param sex formData int true "Sex"
 // @Param bio formData string true "Bio"
 // @Param avatar formData file false "Avatar"
+// @Param avatar_upload_key formData string false "Key of avatar uploaded directly to storage"
+// @Param cover_upload_key formData string false "Key of cover uploaded directly to storage"
 // @Success 200 {string} string "Profile updated"
 // @Failure 400 {object} forms.ErrorForm "Failed to parse form"
 // @Failure 500 {object} forms.ErrorForm "Failed to update profile"
@@ -186,7 +164,11 @@ func (p *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		logger.Info(ctx, fmt.Sprintf("Loaded cover: %v size: %v", profileForm.Background.Name, profileForm.Background.Size))
 	}
 
-	var recievedValidInfo = profileForm.Avatar != nil || profileForm.Background != nil
+	profileForm.AvatarUploadKey = r.FormValue("avatar_upload_key")
+	profileForm.BackgroundUploadKey = r.FormValue("cover_upload_key")
+
+	var recievedValidInfo = profileForm.Avatar != nil || profileForm.Background != nil ||
+		len(profileForm.AvatarUploadKey) != 0 || len(profileForm.BackgroundUploadKey) != 0
 	// parsing main profile info
 	var profileInfo forms.ProfileInfo
 	err = json.NewDecoder(strings.NewReader(r.FormValue("profile"))).Decode(&profileInfo)
@@ -255,6 +237,22 @@ func (p *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		logger.Error(ctx, fmt.Sprintf("Profile of %s already exists", user.Username))
 		http2.WriteJSONError(w, "profile already exists", http.StatusBadRequest)
 		return
+	} else if errors.Is(err, usecase.ErrInvalidImage) {
+		logger.Error(ctx, fmt.Sprintf("Invalid image: %s", err.Error()))
+		http2.WriteJSONError(w, "invalid image", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidMedia) {
+		logger.Error(ctx, fmt.Sprintf("Invalid media file: %s", err.Error()))
+		http2.WriteJSONError(w, "invalid media file", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrFileTooLarge) {
+		logger.Error(ctx, fmt.Sprintf("File is too large: %s", err.Error()))
+		http2.WriteJSONError(w, "file is too large", http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidUpload) {
+		logger.Error(ctx, fmt.Sprintf("Invalid upload: %s", err.Error()))
+		http2.WriteJSONError(w, "invalid upload", http.StatusBadRequest)
+		return
 	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Failed to update profile: %s", err.Error()))
 		http2.WriteJSONError(w, err.Error(), http.StatusInternalServerError)
@@ -262,3 +260,84 @@ func (p *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	}
 	logger.Info(ctx, fmt.Sprintf("Profile of %s was successfully updated", user.Username))
 }
+
+// GetPrivacySettings returns privacy settings of current user
+// @Summary Get privacy settings
+// @Description Get privacy settings of current user
+// @Tags Profile
+// @Produce json
+// @Success 200 {object} forms.PrivacySettingsForm "Privacy settings"
+// @Failure 500 {object} forms.ErrorForm "Failed to get privacy settings"
+// @Router /api/profile/privacy [get]
+func (p *ProfileHandler) GetPrivacySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while getting privacy settings")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	settings, err := p.profileUC.GetPrivacySettings(ctx, user.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get privacy settings of %s: %s", user.Username, err.Error()))
+		http2.WriteJSONError(w, "Failed to get privacy settings", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PrivacySettingsForm]{Payload: forms.PrivacySettingsToForm(settings)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode privacy settings: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode privacy settings", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdatePrivacySettings updates privacy settings of current user
+// @Summary Update privacy settings
+// @Description Sets who can see contact info, birth date, education and posts. Omitted fields are not changed
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param settings body forms.PrivacySettingsForm true "Privacy settings"
+// @Success 200 {object} forms.PrivacySettingsForm "Updated privacy settings"
+// @Failure 400 {object} forms.ErrorForm "Invalid privacy settings"
+// @Failure 500 {object} forms.ErrorForm "Failed to update privacy settings"
+// @Router /api/profile/privacy [post]
+func (p *ProfileHandler) UpdatePrivacySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while updating privacy settings")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var settingsForm forms.PrivacySettingsForm
+	if err := json.NewDecoder(r.Body).Decode(&settingsForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode privacy settings: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse privacy settings", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := p.profileUC.UpdatePrivacySettings(ctx, user.Id, settingsForm.ToModel())
+	if errors.Is(err, usecase.ErrInvalidPrivacySettings) {
+		logger.Info(ctx, fmt.Sprintf("Invalid privacy settings from %s: %+v", user.Username, settingsForm))
+		http2.WriteJSONError(w, "invalid privacy settings", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to update privacy settings of %s: %s", user.Username, err.Error()))
+		http2.WriteJSONError(w, "Failed to update privacy settings", http.StatusInternalServerError)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("Privacy settings of %s were successfully updated", user.Username))
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.PrivacySettingsForm]{Payload: forms.PrivacySettingsToForm(settings)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode privacy settings: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode privacy settings", http.StatusInternalServerError)
+		return
+	}
+}
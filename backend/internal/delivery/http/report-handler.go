@@ -0,0 +1,326 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/microcosm-cc/bluemonday"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	"quickflow/pkg/sanitizer"
+	http2 "quickflow/utils/http"
+)
+
+type ReportUseCase interface {
+	Report(ctx context.Context, reporterId uuid.UUID, report models.Report) (models.Report, error)
+	FetchQueue(ctx context.Context, moderator models.User, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error)
+	FetchReport(ctx context.Context, moderator models.User, reportId uuid.UUID) (models.Report, []models.ModerationDecision, error)
+	ReviewReport(ctx context.Context, moderator models.User, reportId uuid.UUID) error
+	ResolveReport(ctx context.Context, moderator models.User, reportId uuid.UUID, resolution models.ReportResolution) error
+}
+
+type ReportHandler struct {
+	reportUseCase ReportUseCase
+	policy        *bluemonday.Policy
+}
+
+// NewReportHandler creates new handler of reports of content and the moderation queue.
+func NewReportHandler(reportUseCase ReportUseCase, policy *bluemonday.Policy) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: reportUseCase,
+		policy:        policy,
+	}
+}
+
+// Report reports content to moderators
+// @Summary Report content
+// @Description Reports a post, comment, message or profile the user can see to moderators, target_id of a profile is the id of the user
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param report body forms.ReportForm true "Report"
+// @Success 200 {object} forms.PayloadWrapper[forms.ReportOut] "Report"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 404 {object} forms.ErrorForm "Content not found"
+// @Failure 409 {object} forms.ErrorForm "Content is already reported"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/reports [post]
+func (h *ReportHandler) Report(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while reporting content")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var reportForm forms.ReportForm
+	if err := json.NewDecoder(r.Body).Decode(&reportForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode report form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	sanitizer.SanitizeReport(&reportForm, h.policy)
+	report, err := reportForm.ToReport()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse report: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse target_id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s reports %s %s for %s", user.Username, report.TargetType, report.TargetId, report.Reason))
+
+	report, err = h.reportUseCase.Report(ctx, user.Id, report)
+	if errors.Is(err, usecase.ErrInvalidReport) {
+		logger.Info(ctx, fmt.Sprintf("Invalid report: %s", err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrReportTargetNotFound) {
+		logger.Info(ctx, fmt.Sprintf("Reported %s %s not found", reportForm.TargetType, reportForm.TargetId))
+		http2.WriteJSONError(w, "Content not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, usecase.ErrAlreadyReported) {
+		logger.Info(ctx, fmt.Sprintf("User %s has already reported %s %s", user.Username, reportForm.TargetType, reportForm.TargetId))
+		http2.WriteJSONError(w, "Content is already reported", http.StatusConflict)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to report content: %v", err))
+		http2.WriteJSONError(w, "Failed to report content", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.ReportOut]{Payload: forms.ReportToOut(report)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode report: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode report", http.StatusInternalServerError)
+	}
+}
+
+// GetReports returns the moderation queue
+// @Summary Get moderation queue
+// @Description Returns reports with the status, newest first. Only for moderators
+// @Tags Moderation
+// @Produce json
+// @Param status query string false "Status of reports: open, in_review or resolved" default(open)
+// @Param reports_count query int false "Number of reports" default(20)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} forms.PayloadWrapper[forms.ReportsOut] "Reports"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/moderation/reports [get]
+func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching reports")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var reportsForm forms.ReportsForm
+	if err := reportsForm.GetParams(r.URL.Query()); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse query params: %v", err))
+		http2.WriteJSONError(w, "Failed to parse query params", http.StatusBadRequest)
+		return
+	}
+
+	reports, err := h.reportUseCase.FetchQueue(ctx, user, reportsForm.Status, reportsForm.Count, reportsForm.Cursor)
+	if errors.Is(err, usecase.ErrForbidden) {
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to moderate reports", user.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+		return
+	} else if errors.Is(err, usecase.ErrInvalidNumReports) {
+		logger.Info(ctx, fmt.Sprintf("Invalid number of reports %d", reportsForm.Count))
+		http2.WriteJSONError(w, "Invalid reports_count", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch reports: %v", err))
+		http2.WriteJSONError(w, "Failed to load reports", http.StatusInternalServerError)
+		return
+	}
+
+	out := forms.ReportsOut{Reports: forms.ReportsToOut(reports)}
+	if len(reports) == reportsForm.Count {
+		last := reports[len(reports)-1]
+		out.NextCursor = models.Cursor{Ts: last.CreatedAt, Id: last.Id}.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.ReportsOut]{Payload: out})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode reports: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode reports", http.StatusInternalServerError)
+	}
+}
+
+// GetReport returns the report with decisions of moderators
+// @Summary Get report
+// @Description Returns the report with its audit trail, oldest decisions first. Only for moderators
+// @Tags Moderation
+// @Produce json
+// @Param report_id path string true "Report ID"
+// @Success 200 {object} forms.PayloadWrapper[forms.ReportDetailsOut] "Report"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "Report not found"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/moderation/reports/{report_id} [get]
+func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while fetching report")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	reportId, err := uuid.Parse(mux.Vars(r)["report_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse report id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse report id", http.StatusBadRequest)
+		return
+	}
+
+	report, decisions, err := h.reportUseCase.FetchReport(ctx, user, reportId)
+	if h.writeModerationError(ctx, w, user, reportId, err) {
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to fetch report: %v", err))
+		http2.WriteJSONError(w, "Failed to load report", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[forms.ReportDetailsOut]{Payload: forms.ReportDetailsToOut(report, decisions)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode report: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode report", http.StatusInternalServerError)
+	}
+}
+
+// ReviewReport takes the report into review
+// @Summary Review report
+// @Description Takes the unresolved report into review by the moderator. Only for moderators
+// @Tags Moderation
+// @Param report_id path string true "Report ID"
+// @Success 200 "Report is in review"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "Report not found"
+// @Failure 409 {object} forms.ErrorForm "Report is already resolved"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/moderation/reports/{report_id}/review [post]
+func (h *ReportHandler) ReviewReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while reviewing report")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	reportId, err := uuid.Parse(mux.Vars(r)["report_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse report id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse report id", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s reviews report %s", user.Username, reportId))
+
+	err = h.reportUseCase.ReviewReport(ctx, user, reportId)
+	if h.writeModerationError(ctx, w, user, reportId, err) {
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to review report: %v", err))
+		http2.WriteJSONError(w, "Failed to review report", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ResolveReport resolves reports of the content
+// @Summary Resolve report
+// @Description Applies the action to the reported content and resolves every unresolved report of it. Only for moderators
+// @Tags Moderation
+// @Accept json
+// @Param report_id path string true "Report ID"
+// @Param resolution body forms.ResolveReportForm true "Decision of the moderator: dismiss, remove_content, warn_user or ban_user"
+// @Success 200 "Reports are resolved"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 403 {object} forms.ErrorForm "Permission denied"
+// @Failure 404 {object} forms.ErrorForm "Report not found"
+// @Failure 409 {object} forms.ErrorForm "Report is already resolved"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/moderation/reports/{report_id}/resolve [post]
+func (h *ReportHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while resolving report")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	reportId, err := uuid.Parse(mux.Vars(r)["report_id"])
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse report id: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse report id", http.StatusBadRequest)
+		return
+	}
+
+	var resolutionForm forms.ResolveReportForm
+	if err = json.NewDecoder(r.Body).Decode(&resolutionForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode resolution form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	sanitizer.SanitizeReportResolution(&resolutionForm, h.policy)
+	resolution, err := resolutionForm.ToResolution()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to parse resolution: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse ban_until", http.StatusBadRequest)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s resolves report %s with %s", user.Username, reportId, resolution.Action))
+
+	err = h.reportUseCase.ResolveReport(ctx, user, reportId, resolution)
+	if h.writeModerationError(ctx, w, user, reportId, err) {
+		return
+	} else if errors.Is(err, usecase.ErrInvalidModerationAction) {
+		logger.Info(ctx, fmt.Sprintf("Invalid resolution: %s", err.Error()))
+		http2.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to resolve report: %v", err))
+		http2.WriteJSONError(w, "Failed to resolve report", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeModerationError writes errors common to actions of moderators on a report and reports if it did.
+func (h *ReportHandler) writeModerationError(ctx context.Context, w http.ResponseWriter, user models.User, reportId uuid.UUID, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		logger.Info(ctx, fmt.Sprintf("User %s is not allowed to moderate reports", user.Username))
+		http2.WriteJSONError(w, "Permission denied", http.StatusForbidden)
+	case errors.Is(err, usecase.ErrReportNotFound):
+		logger.Info(ctx, fmt.Sprintf("Report %s not found", reportId))
+		http2.WriteJSONError(w, "Report not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrReportResolved):
+		logger.Info(ctx, fmt.Sprintf("Report %s is already resolved", reportId))
+		http2.WriteJSONError(w, "Report is already resolved", http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/delivery/forms"
+	http2 "quickflow/internal/delivery/http"
+	"quickflow/internal/delivery/http/mocks"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestReportHandler_Report(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "testuser"}
+	postId := uuid.New()
+
+	tests := []struct {
+		name          string
+		body          string
+		expectUseCase bool
+		useCaseErr    error
+		expectedCode  int
+	}{
+		{
+			name:          "post",
+			body:          fmt.Sprintf(`{"target_type":"post","target_id":%q,"reason":"spam","details":"<b>ads</b>"}`, postId),
+			expectUseCase: true,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:         "invalid target id",
+			body:         `{"target_type":"post","target_id":"42","reason":"spam"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:          "unknown reason",
+			body:          fmt.Sprintf(`{"target_type":"post","target_id":%q,"reason":"boring"}`, postId),
+			expectUseCase: true,
+			useCaseErr:    fmt.Errorf("%w: unknown reason", usecase.ErrInvalidReport),
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "invisible post",
+			body:          fmt.Sprintf(`{"target_type":"post","target_id":%q,"reason":"spam"}`, postId),
+			expectUseCase: true,
+			useCaseErr:    usecase.ErrReportTargetNotFound,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "reported twice",
+			body:          fmt.Sprintf(`{"target_type":"post","target_id":%q,"reason":"spam"}`, postId),
+			expectUseCase: true,
+			useCaseErr:    usecase.ErrAlreadyReported,
+			expectedCode:  http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportUseCase := mocks.NewMockReportUseCase(ctrl)
+			if tt.expectUseCase {
+				mockReportUseCase.EXPECT().Report(gomock.Any(), user.Id, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, report models.Report) (models.Report, error) {
+						assert.Equal(t, postId, report.TargetId)
+						assert.NotContains(t, report.Details, "<b>")
+						report.Id = uuid.New()
+						report.Status = models.ReportStatusOpen
+						return report, tt.useCaseErr
+					})
+			}
+			handler := http2.NewReportHandler(mockReportUseCase, bluemonday.StrictPolicy())
+
+			req := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.Report(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				var out forms.PayloadWrapper[forms.ReportOut]
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&out))
+				assert.Equal(t, "open", out.Payload.Status)
+			}
+		})
+	}
+}
+
+func TestReportHandler_ResolveReport(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "moderator", Role: models.RoleModerator}
+	reportId := uuid.New()
+
+	tests := []struct {
+		name          string
+		body          string
+		expectUseCase bool
+		useCaseErr    error
+		expectedCode  int
+	}{
+		{name: "ban", body: `{"action":"ban_user","ban_until":"2030-01-01T00:00:00Z"}`, expectUseCase: true, expectedCode: http.StatusOK},
+		{name: "invalid ban_until", body: `{"action":"ban_user","ban_until":"tomorrow"}`, expectedCode: http.StatusBadRequest},
+		{name: "not a moderator", body: `{"action":"dismiss"}`, expectUseCase: true, useCaseErr: usecase.ErrForbidden, expectedCode: http.StatusForbidden},
+		{name: "unknown report", body: `{"action":"dismiss"}`, expectUseCase: true, useCaseErr: fmt.Errorf("wrapped: %w", usecase.ErrReportNotFound), expectedCode: http.StatusNotFound},
+		{name: "resolved report", body: `{"action":"dismiss"}`, expectUseCase: true, useCaseErr: usecase.ErrReportResolved, expectedCode: http.StatusConflict},
+		{name: "remove profile", body: `{"action":"remove_content"}`, expectUseCase: true, useCaseErr: usecase.ErrInvalidModerationAction, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportUseCase := mocks.NewMockReportUseCase(ctrl)
+			if tt.expectUseCase {
+				mockReportUseCase.EXPECT().ResolveReport(gomock.Any(), user, reportId, gomock.Any()).Return(tt.useCaseErr)
+			}
+			handler := http2.NewReportHandler(mockReportUseCase, bluemonday.StrictPolicy())
+
+			req := httptest.NewRequest(http.MethodPost, "/moderation/reports/"+reportId.String()+"/resolve", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"report_id": reportId.String()})
+			req = req.WithContext(context.WithValue(req.Context(), "user", user))
+			rr := httptest.NewRecorder()
+			handler.ResolveReport(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
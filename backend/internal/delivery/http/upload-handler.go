@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/delivery/forms"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+	http2 "quickflow/utils/http"
+)
+
+type UploadUseCase interface {
+	CreateUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, requests []models.UploadRequest) ([]models.PresignedUpload, error)
+}
+
+type UploadHandler struct {
+	uploadUseCase UploadUseCase
+}
+
+// NewUploadHandler creates new handler of direct uploads.
+func NewUploadHandler(uploadUseCase UploadUseCase) *UploadHandler {
+	return &UploadHandler{
+		uploadUseCase: uploadUseCase,
+	}
+}
+
+// CreateUploads issues presigned URLs for direct uploads
+// @Summary Create direct uploads
+// @Description Returns presigned URLs to upload files straight to storage. Returned keys are then passed
+// @Description as upload_keys of post or message, or as avatar_upload_key/cover_upload_key of profile.
+// @Description Profile and chat pictures must be JPEG, PNG or WebP images; HTML, SVG and other active content is rejected for every purpose.
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Param uploads body forms.UploadsForm true "Purpose and files to upload"
+// @Success 200 {object} forms.PayloadWrapper[[]forms.PresignedUploadOut] "Presigned uploads"
+// @Failure 400 {object} forms.ErrorForm "Invalid data"
+// @Failure 413 {object} forms.ErrorForm "File is too large"
+// @Failure 500 {object} forms.ErrorForm "Server error"
+// @Router /api/uploads [post]
+func (u *UploadHandler) CreateUploads(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value("user").(models.User)
+	if !ok {
+		logger.Error(ctx, "Failed to get user from context while creating uploads")
+		http2.WriteJSONError(w, "Failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var uploadsForm forms.UploadsForm
+	if err := json.NewDecoder(r.Body).Decode(&uploadsForm); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to decode uploads form: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	purpose, requests := uploadsForm.ToModel()
+	uploads, err := u.uploadUseCase.CreateUploads(ctx, user.Id, purpose, requests)
+	if errors.Is(err, usecase.ErrInvalidUpload) || errors.Is(err, usecase.ErrTooManyUploads) {
+		logger.Error(ctx, fmt.Sprintf("Invalid upload: %s", err.Error()))
+		http2.WriteJSONError(w, "Invalid upload", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, usecase.ErrFileTooLarge) {
+		logger.Error(ctx, fmt.Sprintf("File is too large: %s", err.Error()))
+		http2.WriteJSONError(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to create uploads: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to create uploads", http.StatusInternalServerError)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("User %s created %d %s uploads", user.Username, len(uploads), purpose))
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(forms.PayloadWrapper[[]forms.PresignedUploadOut]{Payload: forms.PresignedUploadsToOut(uploads)})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to encode uploads: %s", err.Error()))
+		http2.WriteJSONError(w, "Failed to encode uploads", http.StatusInternalServerError)
+		return
+	}
+}
@@ -91,8 +91,8 @@ func (m *InternalWSMessageHandler) Handle(ctx context.Context, user models.User,
 		return fmt.Errorf("invalid message: %w", err)
 	}
 
-	var err error
-	message.ChatID, err = m.MessageUseCase.SaveMessage(ctx, message)
+	// saved message has chat and attachments committed from upload keys
+	message, err := m.MessageUseCase.SaveMessage(ctx, message)
 	if err != nil {
 		log.Println("Failed to save message:", err)
 		return fmt.Errorf("failed to save message: %w", err)
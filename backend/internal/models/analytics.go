@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostViews is the number of unique views of the post during the UTC day.
+type PostViews struct {
+	PostId uuid.UUID
+	Day    time.Time
+	Count  int
+}
+
+// DailyStats is the activity around posts and profile of the author during the UTC day.
+type DailyStats struct {
+	Day          time.Time
+	Views        int
+	Likes        int
+	Comments     int
+	NewFollowers int
+}
+
+// PostStats is the activity around the post during a period.
+type PostStats struct {
+	PostId    uuid.UUID
+	Text      string
+	CreatedAt time.Time
+	Views     int
+	Likes     int
+	Comments  int
+}
+
+// Analytics is the activity around posts of the author during a period of days.
+type Analytics struct {
+	From     time.Time
+	To       time.Time // the last day of the period
+	Days     []DailyStats
+	TopPosts []PostStats
+}
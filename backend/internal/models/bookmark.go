@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark is a post saved by the user for later.
+type Bookmark struct {
+	PostId       uuid.UUID
+	CollectionId uuid.UUID // uuid.Nil if the bookmark is not in a collection
+	CreatedAt    time.Time
+}
+
+// BookmarkCollection is a named group of bookmarks of the user.
+type BookmarkCollection struct {
+	Id        uuid.UUID
+	UserId    uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	PostCount int
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points right after the last item of a page. Items are ordered by
+// (Ts, Id) descending, so the next page contains items strictly less than the cursor.
+type Cursor struct {
+	Ts time.Time
+	Id uuid.UUID
+}
+
+// CursorFromTs creates cursor that selects items older than ts.
+// It keeps the behaviour of legacy timestamp-only pagination.
+func CursorFromTs(ts time.Time) Cursor {
+	return Cursor{Ts: ts, Id: uuid.Nil}
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Ts.IsZero() && c.Id == uuid.Nil
+}
+
+// String encodes cursor into opaque url-safe string. Zero cursor is encoded as empty string.
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := c.Ts.UTC().Format(time.RFC3339Nano) + "_" + c.Id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes cursor previously encoded with Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	tsPart, idPart, found := strings.Cut(string(raw), "_")
+	if !found {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, tsPart)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{Ts: ts, Id: id}, nil
+}
+
+// NameCursor points right after the last item of a page of people ordered alphabetically
+// by (Lastname, Firstname, Id), so the next page contains items strictly greater than the cursor.
+type NameCursor struct {
+	Lastname  string
+	Firstname string
+	Id        uuid.UUID
+}
+
+func (c NameCursor) IsZero() bool {
+	return c.Id == uuid.Nil
+}
+
+// String encodes cursor into opaque url-safe string. Zero cursor is encoded as empty string.
+// Names can't contain NUL, so it separates the fields.
+func (c NameCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := strings.Join([]string{c.Lastname, c.Firstname, c.Id.String()}, "\x00")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseNameCursor decodes cursor previously encoded with NameCursor.String.
+func ParseNameCursor(s string) (NameCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return NameCursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), "\x00")
+	if len(parts) != 3 {
+		return NameCursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return NameCursor{}, ErrInvalidCursor
+	}
+
+	return NameCursor{Lastname: parts[0], Firstname: parts[1], Id: id}, nil
+}
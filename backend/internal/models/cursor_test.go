@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{
+		Ts: time.Date(2025, 4, 1, 12, 30, 15, 123456000, time.UTC),
+		Id: uuid.New(),
+	}
+
+	parsed, err := ParseCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.True(t, cursor.Ts.Equal(parsed.Ts))
+	assert.Equal(t, cursor.Id, parsed.Id)
+}
+
+func TestParseCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "%%%"},
+		{name: "no separator", cursor: "YWJj"},
+		{name: "bad timestamp", cursor: Cursor{Ts: time.Now(), Id: uuid.New()}.String()[4:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursor(tt.cursor)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestCursor_Zero(t *testing.T) {
+	assert.True(t, Cursor{}.IsZero())
+	assert.Equal(t, "", Cursor{}.String())
+	assert.False(t, CursorFromTs(time.Now()).IsZero())
+}
+
+func TestNameCursor_RoundTrip(t *testing.T) {
+	cursor := NameCursor{Lastname: "Doe_Smith", Firstname: "John", Id: uuid.New()}
+
+	parsed, err := ParseNameCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+
+	assert.Equal(t, "", NameCursor{}.String())
+	_, err = ParseNameCursor(Cursor{Ts: time.Now(), Id: uuid.New()}.String())
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
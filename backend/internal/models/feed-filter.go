@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HiddenPost is a post the user does not want to see in the feed.
+type HiddenPost struct {
+	PostId    uuid.UUID
+	CreatedAt time.Time
+}
+
+// MutedUser is an author whose posts the user does not want to see in the feed.
+// Muting does not change whether the user follows the author.
+type MutedUser struct {
+	User      PublicUserInfo
+	CreatedAt time.Time
+}
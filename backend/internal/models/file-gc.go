@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// StoredFile describes an object kept in file storage.
+type StoredFile struct {
+	Bucket       string
+	Name         string
+	Size         int64
+	MimeType     string
+	LastModified time.Time
+}
+
+// FileGCReport is the result of a single orphaned files collection.
+type FileGCReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	DryRun     bool
+
+	Scanned    int // objects listed in storage
+	Referenced int // objects still referenced from database
+	TooYoung   int // objects skipped because of grace period
+	Orphaned   int // objects that are not referenced anywhere
+	Deleted    int
+	Failed     int
+
+	OrphanedBytes int64
+	FreedBytes    int64
+	OrphanedFiles []string
+}
+
+// FileMigrationReport is the result of moving files to buckets of their purpose.
+type FileMigrationReport struct {
+	Checked int // referenced files that were checked
+	Moved   int
+	Skipped int // files that are already in place or are not stored in the legacy bucket
+	Failed  int
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"path"
+	"strings"
+)
+
+type ImageVariant string
+
+const (
+	ImageThumbnail ImageVariant = "thumbnail"
+	ImageFeed      ImageVariant = "feed"
+	ImageFull      ImageVariant = "full"
+)
+
+// ImageVariantsList holds every variant produced for uploaded image, from smallest to largest.
+var ImageVariantsList = []ImageVariant{ImageThumbnail, ImageFeed, ImageFull}
+
+// processableMimeTypes are image formats that are decoded and converted to variants.
+// Other files are stored as is.
+var processableMimeTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/webp": {},
+}
+
+// IsProcessableImage reports whether file of given mime type is converted to variants on upload.
+func IsProcessableImage(mimeType string) bool {
+	_, ok := processableMimeTypes[mimeType]
+	return ok
+}
+
+// ImageVariants contains URLs of sized copies of the same image.
+type ImageVariants struct {
+	Thumbnail string
+	Feed      string
+	Full      string
+}
+
+// ImageVariantName returns object name of the variant of image with given base name.
+// All variants of one image share base name so they can be derived from each other.
+func ImageVariantName(base string, variant ImageVariant, ext string) string {
+	return base + "_" + string(variant) + ext
+}
+
+// ImageVariantsFromURL derives variant URLs from URL of the full variant.
+// Files uploaded without processing (not images, or uploaded before images
+// were processed) have no variants, so their own URL is used for every size.
+func ImageVariantsFromURL(url string) ImageVariants {
+	dir, name := path.Split(url)
+	ext := path.Ext(name)
+	base, ok := strings.CutSuffix(strings.TrimSuffix(name, ext), "_"+string(ImageFull))
+	if !ok || len(ext) == 0 {
+		return ImageVariants{Thumbnail: url, Feed: url, Full: url}
+	}
+
+	return ImageVariants{
+		Thumbnail: dir + ImageVariantName(base, ImageThumbnail, ext),
+		Feed:      dir + ImageVariantName(base, ImageFeed, ext),
+		Full:      url,
+	}
+}
+
+// ImageVariantOwner returns name of the full variant for any variant name.
+// Only full variant is referenced from database, so smaller ones belong to it.
+func ImageVariantOwner(name string) string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for _, variant := range ImageVariantsList {
+		if base, ok := strings.CutSuffix(stem, "_"+string(variant)); ok {
+			return ImageVariantName(base, ImageFull, ext)
+		}
+	}
+	return name
+}
+
+// ImageVariantNames returns names of all variants stored for the file
+// if it is a full variant of processed image, otherwise only the name itself.
+func ImageVariantNames(name string) []string {
+	ext := path.Ext(name)
+	base, ok := strings.CutSuffix(strings.TrimSuffix(name, ext), "_"+string(ImageFull))
+	if !ok {
+		return []string{name}
+	}
+
+	names := make([]string, 0, len(ImageVariantsList))
+	for _, variant := range ImageVariantsList {
+		names = append(names, ImageVariantName(base, variant, ext))
+	}
+	return names
+}
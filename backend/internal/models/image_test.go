@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageVariantsFromURL(t *testing.T) {
+	processed := ImageVariantsFromURL("https://host/posts/abc_full.jpg")
+	assert.Equal(t, ImageVariants{
+		Thumbnail: "https://host/posts/abc_thumbnail.jpg",
+		Feed:      "https://host/posts/abc_feed.jpg",
+		Full:      "https://host/posts/abc_full.jpg",
+	}, processed)
+
+	// files uploaded without processing have no variants
+	legacy := "https://host/posts/abc.jpg"
+	assert.Equal(t, ImageVariants{Thumbnail: legacy, Feed: legacy, Full: legacy}, ImageVariantsFromURL(legacy))
+}
+
+func TestImageVariantOwner(t *testing.T) {
+	assert.Equal(t, "abc_full.png", ImageVariantOwner("abc_thumbnail.png"))
+	assert.Equal(t, "abc_full.png", ImageVariantOwner("abc_feed.png"))
+	assert.Equal(t, "abc_full.png", ImageVariantOwner("abc_full.png"))
+	assert.Equal(t, "abc.txt", ImageVariantOwner("abc.txt"))
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MediaType tells how a file is presented to users.
+type MediaType string
+
+const (
+	MediaTypeImage    MediaType = "image"
+	MediaTypeVideo    MediaType = "video"
+	MediaTypeAudio    MediaType = "audio"
+	MediaTypeDocument MediaType = "document"
+)
+
+// playableMimeTypes are containers which playback properties can be extracted.
+// Other audio and video formats are kept as documents.
+var playableMimeTypes = map[string]MediaType{
+	"video/mp4":       MediaTypeVideo,
+	"video/quicktime": MediaTypeVideo,
+	"video/webm":      MediaTypeVideo,
+	"audio/mp4":       MediaTypeAudio,
+	"audio/webm":      MediaTypeAudio,
+	"audio/ogg":       MediaTypeAudio,
+}
+
+// MediaTypeOf returns media type of file with given mime type.
+func MediaTypeOf(mimeType string) MediaType {
+	if mediaType, ok := playableMimeTypes[mimeType]; ok {
+		return mediaType
+	}
+	if strings.HasPrefix(mimeType, "image/") {
+		return MediaTypeImage
+	}
+	return MediaTypeDocument
+}
+
+// IsPlayable reports whether file of the media type has duration.
+func (t MediaType) IsPlayable() bool {
+	return t == MediaTypeVideo || t == MediaTypeAudio
+}
+
+// MediaInfo describes stored file, it is empty for files uploaded before media types were tracked.
+type MediaInfo struct {
+	Type     MediaType
+	MimeType string
+	Duration time.Duration
+	Width    int
+	Height   int
+}
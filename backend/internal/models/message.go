@@ -13,6 +13,9 @@ type Message struct {
 	UpdatedAt      time.Time
 	Attachments    []*File
 	AttachmentURLs []string
+	Media          map[string]MediaInfo // media info of attachments by URL, attachments without it are omitted
+	UploadKeys     []string             // keys of attachments uploaded directly to storage
+	Mentions       []Mention            // mentions of chat participants
 
 	SenderID   uuid.UUID
 	ChatID     uuid.UUID
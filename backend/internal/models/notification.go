@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mention is @username in a text resolved to the user it refers to.
+// Offset and Length are measured in UTF-16 code units and include the leading '@'.
+type Mention struct {
+	UserId   uuid.UUID
+	Username string
+	Offset   int
+	Length   int
+}
+
+type NotificationType string
+
+const (
+	NotificationPostMention    NotificationType = "post_mention"
+	NotificationMessageMention NotificationType = "message_mention"
+	// NotificationModerationWarning is sent without actor, so moderators stay anonymous.
+	NotificationModerationWarning NotificationType = "moderation_warning"
+)
+
+// Notification tells the user about something another user (the actor) did to them.
+type Notification struct {
+	Id        uuid.UUID
+	UserId    uuid.UUID
+	Type      NotificationType
+	ActorId   uuid.UUID
+	PostId    uuid.UUID // uuid.Nil unless the notification is about a post
+	MessageId uuid.UUID // uuid.Nil unless the notification is about a message
+	ChatId    uuid.UUID // chat of the message, if any
+	CreatedAt time.Time
+	ReadAt    time.Time // zero while the notification is unread
+}
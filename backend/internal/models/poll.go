@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll is attached to a post and shares its id.
+// Options are identified by their position in Options.
+type Poll struct {
+	Question       string
+	Options        []PollOption
+	MultipleChoice bool
+	Anonymous      bool      // voters of anonymous polls are never shown
+	Deadline       time.Time // zero if the poll never closes
+	VoterCount     int
+	ViewerVote     []int // positions of options chosen by the viewer, empty if the viewer has not voted
+}
+
+type PollOption struct {
+	Text      string
+	VoteCount int
+}
+
+// IsClosed reports whether votes of the poll can no longer be changed at the given time.
+func (p *Poll) IsClosed(now time.Time) bool {
+	return !p.Deadline.IsZero() && !now.Before(p.Deadline)
+}
+
+// PollVoter is a user who chose an option of a public poll.
+type PollVoter struct {
+	UserId  uuid.UUID
+	VotedAt time.Time
+}
@@ -7,18 +7,109 @@ import (
 	"github.com/google/uuid"
 )
 
+type PostVisibility string
+
+const (
+	VisibilityPublic  PostVisibility = "public"
+	VisibilityFriends PostVisibility = "friends"
+	VisibilityPrivate PostVisibility = "private"
+)
+
+// IsValid checks if visibility is one of the known audiences.
+func (v PostVisibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityFriends, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// AllowsRelation reports whether a post with this visibility may be shown
+// to a viewer that has the given relation to the post author.
+func (v PostVisibility) AllowsRelation(relation UserRelation) bool {
+	if relation == RelationSelf {
+		return true
+	}
+
+	switch v {
+	case VisibilityPublic:
+		return true
+	case VisibilityFriends:
+		return relation == RelationFriend
+	default:
+		return false
+	}
+}
+
+// PostStatus tells whether post is already shown to other users.
+type PostStatus string
+
+const (
+	PostStatusPublished PostStatus = "published"
+	PostStatusDraft     PostStatus = "draft"     // seen only by the author until scheduled
+	PostStatusScheduled PostStatus = "scheduled" // published by the server at PublishAt
+)
+
+// IsPublished reports whether post with this status may be shown to anyone but its author.
+// Posts created before statuses were introduced have empty status and are published.
+func (s PostStatus) IsPublished() bool {
+	return s == PostStatusPublished || s == ""
+}
+
 type Post struct {
 	Id           uuid.UUID
 	CreatorId    uuid.UUID
 	Desc         string
 	Images       []*File
 	ImagesURL    []string
+	Media        map[string]MediaInfo // media info of files by URL, files without it are omitted
+	UploadKeys   []string             // keys of files uploaded directly to storage
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	LikeCount    int
 	RepostCount  int
 	CommentCount int
+	ViewCount    int // unique views, updated periodically
 	IsRepost     bool
+	Visibility   PostVisibility
+	Status       PostStatus
+	PublishAt    time.Time // zero unless the post is scheduled
+	EditedAt     time.Time // zero unless the post was edited after it had been published
+	Tags         []string  // normalized hashtags of the text, filled when the post is saved
+	Mentions     []Mention // mentions of users allowed to see the post
+	Poll         *Poll     // nil if the post has no poll
+	IsBookmarked bool      // whether the viewer has bookmarked the post
+	IsPinned     bool      // whether the post is pinned to the top of the author's profile, filled on profile pages
+	// relation of the viewer to the author, filled on profile pages for signed in viewers
+	CreatorRelation UserRelation
+}
+
+// PostRevision is a version of the post text and files, every edit of a published post adds one.
+// The first revision keeps the post as it was published.
+type PostRevision struct {
+	Id        uuid.UUID
+	PostId    uuid.UUID
+	EditorId  uuid.UUID // uuid.Nil if the editor was deleted
+	Desc      string
+	ImagesURL []string
+	CreatedAt time.Time
+}
+
+// FilePurpose tells what uploaded file is used for.
+// Files of different purposes are kept in different buckets with their own limits.
+type FilePurpose string
+
+const (
+	FilePurposePost       FilePurpose = "post"
+	FilePurposeAttachment FilePurpose = "attachment"
+	FilePurposeAvatar     FilePurpose = "avatar"
+	FilePurposeCover      FilePurpose = "cover"
+	FilePurposeChatAvatar FilePurpose = "chat_avatar"
+)
+
+// FilePurposes lists every known file purpose.
+var FilePurposes = []FilePurpose{
+	FilePurposePost, FilePurposeAttachment, FilePurposeAvatar, FilePurposeCover, FilePurposeChatAvatar,
 }
 
 type File struct {
@@ -27,6 +118,13 @@ type File struct {
 	Size     int64
 	Ext      string
 	MimeType string
+	// StorageName is the object name to store file under, random name is generated when empty.
+	StorageName string
+	// ContentHash is hex SHA-256 of the content, it replaces random part of the name when set.
+	ContentHash string
+	// Media is filled when content of the file is validated on upload.
+	Media   MediaInfo
+	Purpose FilePurpose
 }
 
 func (f File) String() string {
@@ -34,7 +132,21 @@ func (f File) String() string {
 }
 
 type PostUpdate struct {
-	Id    uuid.UUID
-	Desc  string
-	Files []*File
+	Id         uuid.UUID
+	Desc       string
+	Files      []*File
+	UploadKeys []string       // keys of files uploaded directly to storage
+	Visibility PostVisibility // empty value keeps current visibility
+	EditorId   uuid.UUID      // user who makes the edit
+	Tags       []string       // normalized hashtags of the new text
+	Mentions   []Mention      // mentions of the new text
+}
+
+// TrendingTag is a tag with the number of public posts using it within the window
+// and within the window of the same length preceding it.
+type TrendingTag struct {
+	Tag               string
+	PostCount         int
+	AuthorCount       int
+	PreviousPostCount int
 }
@@ -0,0 +1,52 @@
+package models
+
+type PrivacyLevel string
+
+const (
+	PrivacyEveryone PrivacyLevel = "everyone"
+	PrivacyFriends  PrivacyLevel = "friends"
+	PrivacyOnlyMe   PrivacyLevel = "only_me"
+)
+
+// IsValid checks if privacy level is one of the known levels.
+func (l PrivacyLevel) IsValid() bool {
+	switch l {
+	case PrivacyEveryone, PrivacyFriends, PrivacyOnlyMe:
+		return true
+	}
+	return false
+}
+
+// AllowsRelation reports whether data protected by this level may be shown
+// to a viewer that has the given relation to the data owner.
+func (l PrivacyLevel) AllowsRelation(relation UserRelation) bool {
+	if relation == RelationSelf {
+		return true
+	}
+
+	switch l {
+	case PrivacyEveryone:
+		return true
+	case PrivacyFriends:
+		return relation == RelationFriend
+	default:
+		return false
+	}
+}
+
+type PrivacySettings struct {
+	ContactInfo PrivacyLevel
+	BirthDate   PrivacyLevel
+	Education   PrivacyLevel
+	Posts       PrivacyLevel
+}
+
+// DefaultPrivacySettings returns settings used for users that have not configured privacy yet.
+func DefaultPrivacySettings() PrivacySettings {
+	return PrivacySettings{
+		ContactInfo: PrivacyFriends,
+		BirthDate:   PrivacyEveryone,
+		Education:   PrivacyEveryone,
+		Posts:       PrivacyEveryone,
+	}
+}
@@ -46,7 +46,12 @@ type Profile struct {
 
 	Avatar     *File
 	Background *File
-	LastSeen   time.Time
+	// keys of images uploaded directly to storage, used when files are not attached
+	AvatarUploadKey     string
+	BackgroundUploadKey string
+	LastSeen            time.Time
+	// relation of the viewer to the owner, filled for signed in viewers
+	Relation UserRelation
 }
 
 func (p Profile) String() string {
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthorAffinity describes how close the post author is to the user
+// recommendations are built for. Greater value means closer author.
+type AuthorAffinity int
+
+const (
+	AffinityNone AuthorAffinity = iota
+	AffinityFriendOfFriend
+	AffinityFollowed
+	AffinityFriend
+)
+
+// RecommendationCandidate is a post that may be recommended to the user
+// together with the signals used for scoring it.
+type RecommendationCandidate struct {
+	PostId       uuid.UUID
+	CreatorId    uuid.UUID
+	CreatedAt    time.Time
+	LikeCount    int
+	CommentCount int
+	RepostCount  int
+	Affinity     AuthorAffinity
+}
+
+type ScoredPost struct {
+	PostId uuid.UUID
+	Score  float64
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportTarget is the kind of content a report is about.
+type ReportTarget string
+
+const (
+	ReportTargetPost    ReportTarget = "post"
+	ReportTargetComment ReportTarget = "comment"
+	ReportTargetMessage ReportTarget = "message"
+	ReportTargetProfile ReportTarget = "profile" // target id is the id of the user
+)
+
+// IsValid checks if target is one of the known kinds of content.
+func (t ReportTarget) IsValid() bool {
+	switch t {
+	case ReportTargetPost, ReportTargetComment, ReportTargetMessage, ReportTargetProfile:
+		return true
+	}
+	return false
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam       ReportReason = "spam"
+	ReportReasonHarassment ReportReason = "harassment"
+	ReportReasonHate       ReportReason = "hate"
+	ReportReasonViolence   ReportReason = "violence"
+	ReportReasonNudity     ReportReason = "nudity"
+	ReportReasonOther      ReportReason = "other"
+)
+
+// IsValid checks if reason is one of the known reasons.
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence, ReportReasonNudity, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// ReportStatus is the position of a report in the moderation queue.
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "open"
+	ReportStatusInReview ReportStatus = "in_review" // taken by a moderator
+	ReportStatusResolved ReportStatus = "resolved"
+)
+
+// IsValid checks if status is one of the known statuses.
+func (s ReportStatus) IsValid() bool {
+	switch s {
+	case ReportStatusOpen, ReportStatusInReview, ReportStatusResolved:
+		return true
+	}
+	return false
+}
+
+// ModerationAction is what a moderator did about reported content.
+type ModerationAction string
+
+const (
+	ModerationDismiss       ModerationAction = "dismiss" // nothing is wrong with the content
+	ModerationRemoveContent ModerationAction = "remove_content"
+	ModerationWarnUser      ModerationAction = "warn_user"
+	ModerationBanUser       ModerationAction = "ban_user"
+)
+
+// IsValid checks if action is one of the known actions.
+func (a ModerationAction) IsValid() bool {
+	switch a {
+	case ModerationDismiss, ModerationRemoveContent, ModerationWarnUser, ModerationBanUser:
+		return true
+	}
+	return false
+}
+
+// Report is a complaint of a user about content of another user.
+type Report struct {
+	Id            uuid.UUID
+	ReporterId    uuid.UUID
+	TargetType    ReportTarget
+	TargetId      uuid.UUID
+	TargetOwnerId uuid.UUID // author of the content, uuid.Nil if they are deleted
+	Reason        ReportReason
+	Details       string
+	Status        ReportStatus
+	ModeratorId   uuid.UUID // moderator who took the report, uuid.Nil while it is open
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ReportResolution is the final decision of a moderator on reported content.
+// It applies to every unresolved report of the same content.
+type ReportResolution struct {
+	Action   ModerationAction
+	Note     string
+	BanUntil time.Time // end of the ban of ModerationBanUser, zero for a permanent ban
+}
+
+// ModerationDecision is an entry of the audit trail of a report.
+type ModerationDecision struct {
+	Id          uuid.UUID
+	ReportId    uuid.UUID
+	ModeratorId uuid.UUID // uuid.Nil if the moderator is deleted
+	Status      ReportStatus
+	Action      ModerationAction // empty unless the report is resolved
+	Note        string
+	CreatedAt   time.Time
+}
+
+// Ban forbids the user to use the service until Until.
+type Ban struct {
+	Id        uuid.UUID
+	UserId    uuid.UUID
+	Reason    string
+	BannedBy  uuid.UUID
+	Until     time.Time // zero for a permanent ban
+	CreatedAt time.Time
+}
@@ -0,0 +1,59 @@
+package models
+
+// Role is a set of permissions granted to a user.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// Permission is an action beyond managing own content.
+type Permission string
+
+const (
+	// PermissionModerateContent allows to read history of and delete posts of other users.
+	PermissionModerateContent Permission = "content:moderate"
+	// PermissionModerateReports allows to work with the moderation queue.
+	PermissionModerateReports Permission = "reports:moderate"
+	// PermissionManageUsers allows to look up accounts and ban users.
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionManageRoles allows to grant and revoke roles.
+	PermissionManageRoles Permission = "roles:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleUser:      {},
+	RoleModerator: {PermissionModerateContent, PermissionModerateReports},
+	RoleAdmin:     {PermissionModerateContent, PermissionModerateReports, PermissionManageUsers, PermissionManageRoles},
+}
+
+// roleRanks orders roles from the least to the most privileged.
+var roleRanks = map[Role]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// IsValid checks if role is one of the known roles.
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role grants the permission. Unknown roles grant nothing.
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Outranks reports whether the role is more privileged than the other one.
+// Users can only be banned or managed by users of a higher role.
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
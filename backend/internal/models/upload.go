@@ -0,0 +1,74 @@
+package models
+
+import (
+	"mime"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadRequest describes a file client is going to upload directly to file storage.
+type UploadRequest struct {
+	Name     string
+	Size     int64
+	MimeType string
+}
+
+// UploadSession is a permission to upload one object that is checked when the object is committed.
+type UploadSession struct {
+	Key       string
+	UserId    uuid.UUID
+	Purpose   FilePurpose
+	Size      int64
+	MimeType  string
+	ExpiresAt time.Time
+}
+
+// PresignedUpload tells client where and how to upload a file.
+// Headers must be sent with the upload request as is.
+type PresignedUpload struct {
+	Key       string
+	URL       string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// documentMimeTypes are files other than images and playable media that can be posted or attached.
+// Types browsers render as pages or run scripts from, like HTML and SVG, are never accepted.
+var documentMimeTypes = map[string]struct{}{
+	"application/pdf":          {},
+	"application/zip":          {},
+	"application/x-gzip":       {},
+	"application/octet-stream": {},
+	"application/msword":       {},
+	"application/vnd.ms-excel": {},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   {},
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         {},
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": {},
+	"text/plain": {},
+	"text/csv":   {},
+	"image/gif":  {},
+	"audio/mpeg": {},
+	"audio/wave": {},
+}
+
+// IsUploadAllowed reports whether file of given mime type can be uploaded for the purpose.
+// Profile and chat pictures must be images, posts and messages also accept media and documents.
+func IsUploadAllowed(purpose FilePurpose, mimeType string) bool {
+	mimeType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+
+	switch purpose {
+	case FilePurposeAvatar, FilePurposeCover, FilePurposeChatAvatar:
+		return IsProcessableImage(mimeType)
+	case FilePurposePost, FilePurposeAttachment:
+		if IsProcessableImage(mimeType) || MediaTypeOf(mimeType).IsPlayable() {
+			return true
+		}
+		_, ok := documentMimeTypes[mimeType]
+		return ok
+	}
+	return false
+}
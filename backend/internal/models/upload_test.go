@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUploadAllowed(t *testing.T) {
+	assert.True(t, IsUploadAllowed(FilePurposeAvatar, "image/jpeg"))
+	assert.False(t, IsUploadAllowed(FilePurposeAvatar, "application/pdf"))
+	assert.False(t, IsUploadAllowed(FilePurposeCover, "video/mp4"))
+
+	assert.True(t, IsUploadAllowed(FilePurposeAttachment, "video/mp4"))
+	assert.True(t, IsUploadAllowed(FilePurposePost, "application/pdf"))
+	// sniffed types come with parameters
+	assert.True(t, IsUploadAllowed(FilePurposeAttachment, "text/plain; charset=utf-8"))
+
+	// pages and scripts are never served from public buckets
+	assert.False(t, IsUploadAllowed(FilePurposeAttachment, "text/html"))
+	assert.False(t, IsUploadAllowed(FilePurposeAttachment, "text/html; charset=utf-8"))
+	assert.False(t, IsUploadAllowed(FilePurposePost, "image/svg+xml"))
+	assert.False(t, IsUploadAllowed(FilePurposePost, ""))
+	assert.False(t, IsUploadAllowed("secret", "image/jpeg"))
+}
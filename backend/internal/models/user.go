@@ -20,6 +20,7 @@ type User struct {
 	Username string
 	Password string
 	Salt     string
+	Role     Role
 	LastSeen time.Time
 }
 
@@ -34,7 +35,31 @@ func CreateUser(user User) (User, error) {
 		Username: user.Username,
 		Password: hashedPassword,
 		Salt:     salt,
+		Role:     RoleUser,
 	}
 
 	return newUser, nil
 }
+
+// Account is the user as admins see them.
+type Account struct {
+	Id        uuid.UUID
+	Username  string
+	Role      Role
+	Firstname string
+	Lastname  string
+	Ban       Ban // active ban with the latest end, zero if the user is not banned
+}
+
+// AccountDetails is the account of a single user with the state of their sessions.
+type AccountDetails struct {
+	Account
+	ActiveSessions int
+}
+
+// DeletedContent counts content of the user removed in bulk.
+type DeletedContent struct {
+	Posts    int
+	Comments int
+	Messages int
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,8 @@ import (
 	"quickflow/config"
 	"quickflow/factory"
 	"quickflow/internal/delivery/http/middleware"
+	"quickflow/internal/models"
+	"quickflow/internal/worker"
 )
 
 func Run(config *config.Config) error {
@@ -24,9 +27,19 @@ func Run(config *config.Config) error {
 	defer repoFactory.Close()
 
 	// pattern abstract factory
-	serviceFactory := factory.NewDefaultServiceFactory(repoFactory)
+	serviceFactory := factory.NewDefaultServiceFactory(repoFactory, config)
 	handlerFactory := factory.NewHttpWSHandlerFactory(serviceFactory)
 
+	// background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go worker.NewRecommendationWorker(serviceFactory.RecommendationService(), config.RecommendationConfig.RefreshInterval).Run(workersCtx)
+	go worker.NewPostPublishWorker(serviceFactory.PostService(), config.SchedulerConfig.PublishInterval).Run(workersCtx)
+	go worker.NewViewFlushWorker(serviceFactory.AnalyticsService(), config.AnalyticsConfig.FlushInterval).Run(workersCtx)
+	if config.FileGCConfig.Enabled {
+		go worker.NewFileGCWorker(serviceFactory.FileGCService(), config.FileGCConfig.Interval).Run(workersCtx)
+	}
+
 	handlers := handlerFactory.InitHttpHandlers()
 	wsHandlers := handlerFactory.InitWSHandlers()
 
@@ -34,6 +47,9 @@ func Run(config *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("could not setup routers: %v", err)
 	}
+	if filesHandler, pathPrefix, ok := repoFactory.LocalFiles(); ok {
+		r.PathPrefix(pathPrefix).Handler(filesHandler).Methods(http.MethodGet, http.MethodHead)
+	}
 
 	server := http.Server{
 		Addr:         config.ServerConfig.Addr,
@@ -51,6 +67,55 @@ func Run(config *config.Config) error {
 	return nil
 }
 
+// RunFileGC performs a single orphaned files collection and prints its report.
+func RunFileGC(config *config.Config, dryRun bool) error {
+	if config == nil {
+		return fmt.Errorf("config is nil")
+	}
+
+	repoFactory, err := factory.NewPGMFactory(config)
+	if err != nil {
+		return fmt.Errorf("could not create repositories: %v", err)
+	}
+	defer repoFactory.Close()
+
+	serviceFactory := factory.NewDefaultServiceFactory(repoFactory, config)
+	report, err := serviceFactory.FileGCService().Collect(context.Background(), dryRun)
+	if err != nil {
+		return fmt.Errorf("internal.RunFileGC: %w", err)
+	}
+
+	fmt.Printf("scanned: %d\nreferenced: %d\ntoo young: %d\norphaned: %d (%d bytes)\ndeleted: %d (%d bytes)\nfailed: %d\ndry run: %v\n",
+		report.Scanned, report.Referenced, report.TooYoung, report.Orphaned, report.OrphanedBytes,
+		report.Deleted, report.FreedBytes, report.Failed, report.DryRun)
+	for _, name := range report.OrphanedFiles {
+		fmt.Printf("orphaned: %s\n", name)
+	}
+	return nil
+}
+
+// RunGrantAdmin makes the user an admin, it bootstraps the first admin of the service.
+func RunGrantAdmin(config *config.Config, username string) error {
+	if config == nil {
+		return fmt.Errorf("config is nil")
+	}
+
+	repoFactory, err := factory.NewPGMFactory(config)
+	if err != nil {
+		return fmt.Errorf("could not create repositories: %v", err)
+	}
+	defer repoFactory.Close()
+
+	serviceFactory := factory.NewDefaultServiceFactory(repoFactory, config)
+	user, err := serviceFactory.AdminService().GrantAdmin(context.Background(), username)
+	if err != nil {
+		return fmt.Errorf("internal.RunGrantAdmin: %w", err)
+	}
+
+	fmt.Printf("user %s (%s) is admin now\n", user.Username, user.Id)
+	return nil
+}
+
 func setupRouters(cfg *config.Config, httpHandlers *factory.HttpHandlerCollection, wsHandlers *factory.WSHandlerCollection, serviceFactory factory.ServiceFactory) (*mux.Router, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("config is nil")
@@ -72,13 +137,20 @@ func setupRouters(cfg *config.Config, httpHandlers *factory.HttpHandlerCollectio
 	}).Methods(http.MethodOptions)
 
 	r.HandleFunc("/hello", httpHandlers.AuthHandler.Greet).Methods(http.MethodGet)
-	r.HandleFunc("/profiles/{username}", httpHandlers.ProfileHandler.GetProfile).Methods(http.MethodGet)
 
 	apiPostRouter := r.PathPrefix("/").Subrouter()
 	apiPostRouter.Use(middleware.ContentTypeMiddleware("application/json", "multipart/form-data"))
 
 	apiGetRouter := r.PathPrefix("/").Subrouter()
-	apiGetRouter.HandleFunc("/profiles/{username}/posts", httpHandlers.FeedHandler.FetchUserPosts).Methods(http.MethodGet)
+
+	optionalSessionGet := apiGetRouter.PathPrefix("/").Subrouter()
+	optionalSessionGet.Use(middleware.OptionalSessionMiddleware(serviceFactory.AuthService()))
+	optionalSessionGet.HandleFunc("/profiles/{username}", httpHandlers.ProfileHandler.GetProfile).Methods(http.MethodGet)
+	optionalSessionGet.HandleFunc("/profiles/{username}/posts", httpHandlers.FeedHandler.FetchUserPosts).Methods(http.MethodGet)
+	optionalSessionGet.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}", httpHandlers.FeedHandler.GetPost).Methods(http.MethodGet)
+	optionalSessionGet.HandleFunc("/tags/trending", httpHandlers.FeedHandler.GetTrendingTags).Methods(http.MethodGet)
+	optionalSessionGet.HandleFunc("/tags/{tag}/posts", httpHandlers.FeedHandler.GetTagPosts).Methods(http.MethodGet)
+	optionalSessionGet.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/poll/options/{option:[0-9]+}/voters", httpHandlers.PollHandler.GetPollVoters).Methods(http.MethodGet)
 
 	apiPostRouter.HandleFunc("/signup", httpHandlers.AuthHandler.SignUp).Methods(http.MethodPost)
 	apiPostRouter.HandleFunc("/login", httpHandlers.AuthHandler.Login).Methods(http.MethodPost)
@@ -90,20 +162,62 @@ func setupRouters(cfg *config.Config, httpHandlers *factory.HttpHandlerCollectio
 	protectedPost.Use(middleware.CSRFMiddleware)
 	protectedPost.HandleFunc("/post", httpHandlers.PostHandler.AddPost).Methods(http.MethodPost)
 	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}", httpHandlers.PostHandler.UpdatePost).Methods(http.MethodPut)
+	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/schedule", httpHandlers.PostHandler.SchedulePost).Methods(http.MethodPut)
+	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/pin", httpHandlers.PostHandler.PinPost).Methods(http.MethodPut)
 	protectedPost.HandleFunc("/profile", httpHandlers.ProfileHandler.UpdateProfile).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/profile/privacy", httpHandlers.ProfileHandler.UpdatePrivacySettings).Methods(http.MethodPost)
 	protectedPost.HandleFunc("/follow", httpHandlers.FriendHandler.SendFriendRequest).Methods(http.MethodPost)
 	protectedPost.HandleFunc("/followers/accept", httpHandlers.FriendHandler.AcceptFriendRequest).Methods(http.MethodPost)
 	protectedPost.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/message", httpHandlers.MessageHandler.SendMessageToUsername).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/uploads", httpHandlers.UploadHandler.CreateUploads).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/notifications/read", httpHandlers.NotificationHandler.MarkNotificationsRead).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/poll/vote", httpHandlers.PollHandler.Vote).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/bookmark", httpHandlers.BookmarkHandler.AddBookmark).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/bookmarks/collections", httpHandlers.BookmarkHandler.CreateCollection).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/hide", httpHandlers.FeedFilterHandler.HidePost).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/mute", httpHandlers.FeedFilterHandler.MuteUser).Methods(http.MethodPost)
+	protectedPost.HandleFunc("/reports", httpHandlers.ReportHandler.Report).Methods(http.MethodPost)
+
+	moderationPost := protectedPost.PathPrefix("/moderation").Subrouter()
+	moderationPost.Use(middleware.PermissionMiddleware(models.PermissionModerateReports))
+	moderationPost.HandleFunc("/reports/{report_id:[0-9a-fA-F-]{36}}/review", httpHandlers.ReportHandler.ReviewReport).Methods(http.MethodPost)
+	moderationPost.HandleFunc("/reports/{report_id:[0-9a-fA-F-]{36}}/resolve", httpHandlers.ReportHandler.ResolveReport).Methods(http.MethodPost)
+
+	adminPost := protectedPost.PathPrefix("/admin").Subrouter()
+	adminPost.Use(middleware.PermissionMiddleware(models.PermissionManageUsers))
+	adminPost.Handle("/users/{username:[0-9a-zA-Z-]+}/role", middleware.PermissionMiddleware(models.PermissionManageRoles)(
+		http.HandlerFunc(httpHandlers.AdminHandler.SetUserRole))).Methods(http.MethodPut)
+	adminPost.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/ban", httpHandlers.AdminHandler.BanUser).Methods(http.MethodPost)
+	adminPost.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/logout", httpHandlers.AdminHandler.LogoutUser).Methods(http.MethodPost)
 
 	protectedGet := apiGetRouter.PathPrefix("/").Subrouter()
 	protectedGet.Use(middleware.SessionMiddleware(serviceFactory.AuthService()))
 	protectedGet.HandleFunc("/feed", httpHandlers.FeedHandler.GetFeed).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/recommendations", httpHandlers.FeedHandler.GetRecommendations).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/posts/scheduled", httpHandlers.PostHandler.GetScheduledPosts).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/history", httpHandlers.PostHandler.GetPostHistory).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/chats/{chat_id:[0-9a-fA-F-]{36}}/messages", httpHandlers.MessageHandler.GetMessagesForChat).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/chats", httpHandlers.ChatHandler.GetUserChats).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/friends", httpHandlers.FriendHandler.GetFriends).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/csrf", httpHandlers.CSRFHandler.GetCSRF).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/profile/privacy", httpHandlers.ProfileHandler.GetPrivacySettings).Methods(http.MethodGet)
 	protectedGet.HandleFunc("/users/search", httpHandlers.SearchHandler.SearchSimilar).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/notifications", httpHandlers.NotificationHandler.GetNotifications).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/bookmarks", httpHandlers.FeedHandler.GetBookmarks).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/bookmarks/collections", httpHandlers.BookmarkHandler.GetCollections).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/me/analytics", httpHandlers.AnalyticsHandler.GetAnalytics).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/posts/hidden", httpHandlers.FeedHandler.GetHiddenPosts).Methods(http.MethodGet)
+	protectedGet.HandleFunc("/users/muted", httpHandlers.FeedFilterHandler.GetMutedUsers).Methods(http.MethodGet)
+
+	moderationGet := protectedGet.PathPrefix("/moderation").Subrouter()
+	moderationGet.Use(middleware.PermissionMiddleware(models.PermissionModerateReports))
+	moderationGet.HandleFunc("/reports", httpHandlers.ReportHandler.GetReports).Methods(http.MethodGet)
+	moderationGet.HandleFunc("/reports/{report_id:[0-9a-fA-F-]{36}}", httpHandlers.ReportHandler.GetReport).Methods(http.MethodGet)
+
+	adminGet := protectedGet.PathPrefix("/admin").Subrouter()
+	adminGet.Use(middleware.PermissionMiddleware(models.PermissionManageUsers))
+	adminGet.HandleFunc("/users", httpHandlers.AdminHandler.SearchAccounts).Methods(http.MethodGet)
+	adminGet.HandleFunc("/users/{username:[0-9a-zA-Z-]+}", httpHandlers.AdminHandler.GetAccount).Methods(http.MethodGet)
 
 	wsProtected := protectedGet.PathPrefix("/").Subrouter()
 	wsProtected.Use(middleware.WebSocketMiddleware(wsHandlers.ConnManager, wsHandlers.PingHandler))
@@ -113,11 +227,45 @@ func setupRouters(cfg *config.Config, httpHandlers *factory.HttpHandlerCollectio
 	apiDeleteRouter.Use(middleware.SessionMiddleware(serviceFactory.AuthService()))
 	apiDeleteRouter.Use(middleware.CSRFMiddleware)
 	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}", httpHandlers.PostHandler.DeletePost).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/schedule", httpHandlers.PostHandler.CancelScheduledPost).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/pin", httpHandlers.PostHandler.UnpinPost).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/poll/vote", httpHandlers.PollHandler.RetractVote).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/bookmark", httpHandlers.BookmarkHandler.RemoveBookmark).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/bookmarks/collections/{collection_id:[0-9a-fA-F-]{36}}", httpHandlers.BookmarkHandler.DeleteCollection).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/posts/{post_id:[0-9a-fA-F-]{36}}/hide", httpHandlers.FeedFilterHandler.UnhidePost).Methods(http.MethodDelete)
+	apiDeleteRouter.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/mute", httpHandlers.FeedFilterHandler.UnmuteUser).Methods(http.MethodDelete)
 	apiDeleteRouter.HandleFunc("/friends", httpHandlers.FriendHandler.DeleteFriend).Methods(http.MethodDelete)
 	apiDeleteRouter.HandleFunc("/follow", httpHandlers.FriendHandler.Unfollow).Methods(http.MethodDelete)
 
+	adminDelete := apiDeleteRouter.PathPrefix("/admin").Subrouter()
+	adminDelete.Use(middleware.PermissionMiddleware(models.PermissionManageUsers))
+	adminDelete.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/ban", httpHandlers.AdminHandler.LiftBan).Methods(http.MethodDelete)
+	adminDelete.HandleFunc("/users/{username:[0-9a-zA-Z-]+}/content", httpHandlers.AdminHandler.DeleteUserContent).Methods(http.MethodDelete)
+
 	wsHandlers.WSRouter.RegisterHandler("message", wsHandlers.InternalWSMessageHandler.Handle)
 	wsHandlers.WSRouter.RegisterHandler("message_read", wsHandlers.InternalWSMessageHandler.MarkMessageRead)
 
 	return r, nil
 }
+
+// RunBucketMigration moves files uploaded before buckets were separated to buckets of their purpose.
+func RunBucketMigration(config *config.Config) error {
+	if config == nil {
+		return fmt.Errorf("config is nil")
+	}
+
+	repoFactory, err := factory.NewPGMFactory(config)
+	if err != nil {
+		return fmt.Errorf("could not create repositories: %v", err)
+	}
+	defer repoFactory.Close()
+
+	serviceFactory := factory.NewDefaultServiceFactory(repoFactory, config)
+	report, err := serviceFactory.FileMigrationService().MigrateBuckets(context.Background())
+	if err != nil {
+		return fmt.Errorf("internal.RunBucketMigration: %w", err)
+	}
+
+	fmt.Printf("checked: %d\nmoved: %d\nskipped: %d\nfailed: %d\n", report.Checked, report.Moved, report.Skipped, report.Failed)
+	return nil
+}
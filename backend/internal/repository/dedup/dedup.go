@@ -0,0 +1,123 @@
+package dedup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+// DeduplicatingFileRepository stores files under SHA-256 of their content,
+// so the same content uploaded twice for the same purpose is kept once.
+// Files still referenced by posts, messages or profiles are never deleted.
+type DeduplicatingFileRepository struct {
+	files   usecase.FileRepository
+	storage usecase.UploadStorage
+	index   usecase.FileIndex
+}
+
+// NewDeduplicatingFileRepository wraps file repository with content hash deduplication.
+func NewDeduplicatingFileRepository(files usecase.FileRepository, storage usecase.UploadStorage, index usecase.FileIndex) *DeduplicatingFileRepository {
+	return &DeduplicatingFileRepository{
+		files:   files,
+		storage: storage,
+		index:   index,
+	}
+}
+
+// UploadFile stores the file unless the same content is already stored.
+func (r *DeduplicatingFileRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
+	urls, err := r.UploadManyFiles(ctx, []*models.File{file})
+	if err != nil {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+// UploadManyFiles stores files which content is not stored yet in a single upload of underlying repository.
+func (r *DeduplicatingFileRepository) UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error) {
+	urls := make([]string, len(files))
+	var (
+		toUpload    []*models.File
+		toUploadIdx []int
+	)
+	for i, file := range files {
+		data, err := io.ReadAll(file.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %v: %w", file.Name, err)
+		}
+		sum := sha256.Sum256(data)
+		file.ContentHash = hex.EncodeToString(sum[:])
+		file.Reader = bytes.NewReader(data)
+
+		if url, ok := r.findStored(ctx, file); ok {
+			urls[i] = url
+			continue
+		}
+		toUpload = append(toUpload, file)
+		toUploadIdx = append(toUploadIdx, i)
+	}
+	if len(toUpload) == 0 {
+		return urls, nil
+	}
+
+	uploaded, err := r.files.UploadManyFiles(ctx, toUpload)
+	if err != nil {
+		return nil, err
+	}
+	for j, url := range uploaded {
+		// file without metadata would be deleted while still referenced, so upload fails instead
+		if err = r.index.SaveFile(ctx, url, toUpload[j]); err != nil {
+			return nil, fmt.Errorf("r.index.SaveFile: %w", err)
+		}
+		urls[toUploadIdx[j]] = url
+	}
+	return urls, nil
+}
+
+// findStored returns URL of the stored file with the same content if it still exists.
+// The lookup leases the file, so it is not deleted before the caller references it.
+func (r *DeduplicatingFileRepository) findStored(ctx context.Context, file *models.File) (string, bool) {
+	url, err := r.index.FindFile(ctx, file.ContentHash, file.Purpose)
+	if errors.Is(err, usecase.ErrNotFound) {
+		return "", false
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to find file %v by content hash: %s", file.Name, err.Error()))
+		return "", false
+	}
+
+	// metadata may outlive the object if it was removed by file gc
+	if _, err = r.storage.StatFile(ctx, file.Purpose, path.Base(url)); err != nil {
+		if !errors.Is(err, usecase.ErrNotFound) {
+			logger.Error(ctx, fmt.Sprintf("Unable to check stored file %v: %s", url, err.Error()))
+		}
+		return "", false
+	}
+	return url, true
+}
+
+// GetFileURL returns a public URL for the file.
+func (r *DeduplicatingFileRepository) GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	return r.files.GetFileURL(ctx, purpose, fileName)
+}
+
+// DeleteFile deletes the file unless it is still referenced.
+func (r *DeduplicatingFileRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	deletable, err := r.index.ForgetFile(ctx, purpose, fileName)
+	if err != nil {
+		return fmt.Errorf("r.index.ForgetFile: %w", err)
+	}
+	if !deletable {
+		logger.Info(ctx, fmt.Sprintf("File %v is still referenced or leased, keeping it", fileName))
+		return nil
+	}
+	return r.files.DeleteFile(ctx, purpose, fileName)
+}
@@ -0,0 +1,127 @@
+package dedup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func hashOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDeduplicatingFileRepository_UploadManyFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	files := mocks.NewMockFileRepository(ctrl)
+	storage := mocks.NewMockUploadStorage(ctrl)
+	index := mocks.NewMockFileIndex(ctrl)
+	repo := NewDeduplicatingFileRepository(files, storage, index)
+
+	stored := &models.File{Reader: strings.NewReader("stored"), Name: "a.txt", Ext: ".txt", Purpose: models.FilePurposePost}
+	fresh := &models.File{Reader: strings.NewReader("fresh"), Name: "b.txt", Ext: ".txt", Purpose: models.FilePurposePost}
+	gone := &models.File{Reader: strings.NewReader("gone"), Name: "c.txt", Ext: ".txt", Purpose: models.FilePurposePost}
+
+	index.EXPECT().FindFile(gomock.Any(), hashOf("stored"), models.FilePurposePost).Return("http://minio/posts/stored.txt", nil)
+	storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "stored.txt").Return(models.StoredFile{}, nil)
+	index.EXPECT().FindFile(gomock.Any(), hashOf("fresh"), models.FilePurposePost).Return("", usecase.ErrNotFound)
+	index.EXPECT().FindFile(gomock.Any(), hashOf("gone"), models.FilePurposePost).Return("http://minio/posts/gone.txt", nil)
+	storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "gone.txt").Return(models.StoredFile{}, usecase.ErrNotFound)
+
+	files.EXPECT().UploadManyFiles(gomock.Any(), []*models.File{fresh, gone}).DoAndReturn(
+		func(_ context.Context, toUpload []*models.File) ([]string, error) {
+			data, err := io.ReadAll(toUpload[0].Reader)
+			require.NoError(t, err)
+			assert.Equal(t, "fresh", string(data), "content is passed on after hashing")
+			return []string{"http://minio/posts/fresh.txt", "http://minio/posts/gone.txt"}, nil
+		})
+	index.EXPECT().SaveFile(gomock.Any(), "http://minio/posts/fresh.txt", fresh).Return(nil)
+	index.EXPECT().SaveFile(gomock.Any(), "http://minio/posts/gone.txt", gone).Return(nil)
+
+	urls, err := repo.UploadManyFiles(context.Background(), []*models.File{stored, fresh, gone})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://minio/posts/stored.txt", "http://minio/posts/fresh.txt", "http://minio/posts/gone.txt"}, urls)
+	assert.Equal(t, hashOf("fresh"), fresh.ContentHash)
+}
+
+func TestDeduplicatingFileRepository_UploadFileAlreadyStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := mocks.NewMockFileIndex(ctrl)
+	storage := mocks.NewMockUploadStorage(ctrl)
+	repo := NewDeduplicatingFileRepository(mocks.NewMockFileRepository(ctrl), storage, index)
+
+	index.EXPECT().FindFile(gomock.Any(), hashOf("a"), models.FilePurposeAvatar).Return("http://minio/profiles/h_full.jpg", nil)
+	storage.EXPECT().StatFile(gomock.Any(), models.FilePurposeAvatar, "h_full.jpg").Return(models.StoredFile{}, nil)
+
+	url, err := repo.UploadFile(context.Background(), &models.File{Reader: strings.NewReader("a"), Purpose: models.FilePurposeAvatar})
+	require.NoError(t, err)
+	assert.Equal(t, "http://minio/profiles/h_full.jpg", url)
+}
+
+func TestDeduplicatingFileRepository_UploadFailsWithoutMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	files := mocks.NewMockFileRepository(ctrl)
+	index := mocks.NewMockFileIndex(ctrl)
+	repo := NewDeduplicatingFileRepository(files, mocks.NewMockUploadStorage(ctrl), index)
+
+	index.EXPECT().FindFile(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("db error"))
+	files.EXPECT().UploadManyFiles(gomock.Any(), gomock.Any()).Return([]string{"http://minio/posts/a.txt"}, nil)
+	index.EXPECT().SaveFile(gomock.Any(), "http://minio/posts/a.txt", gomock.Any()).Return(errors.New("db error"))
+
+	_, err := repo.UploadFile(context.Background(), &models.File{Reader: strings.NewReader("a"), Purpose: models.FilePurposePost})
+	assert.Error(t, err)
+}
+
+func TestDeduplicatingFileRepository_DeleteFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		deletable bool
+		forgetErr error
+		wantErr   bool
+	}{
+		{name: "unreferenced file is deleted", deletable: true},
+		{name: "referenced file is kept"},
+		{name: "index error", forgetErr: errors.New("db error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			files := mocks.NewMockFileRepository(ctrl)
+			index := mocks.NewMockFileIndex(ctrl)
+			repo := NewDeduplicatingFileRepository(files, mocks.NewMockUploadStorage(ctrl), index)
+
+			index.EXPECT().ForgetFile(gomock.Any(), models.FilePurposePost, "a.jpg").Return(tt.deletable, tt.forgetErr)
+			if tt.deletable {
+				files.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "a.jpg").Return(nil)
+			}
+
+			err := repo.DeleteFile(context.Background(), models.FilePurposePost, "a.jpg")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
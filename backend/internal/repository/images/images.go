@@ -0,0 +1,140 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	image_config "quickflow/config/image"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+// ImageProcessingRepository converts uploaded images to sized variants
+// without metadata before passing them to underlying file repository.
+// Only URL of the full variant is returned, other variants are derived from it.
+type ImageProcessingRepository struct {
+	files usecase.FileRepository
+	cfg   *image_config.ImageConfig
+}
+
+// NewImageProcessingRepository wraps file repository with image processing.
+func NewImageProcessingRepository(files usecase.FileRepository, cfg *image_config.ImageConfig) *ImageProcessingRepository {
+	return &ImageProcessingRepository{
+		files: files,
+		cfg:   cfg,
+	}
+}
+
+// UploadFile stores all variants of image or file itself if it is not an image.
+func (r *ImageProcessingRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
+	urls, err := r.UploadManyFiles(ctx, []*models.File{file})
+	if err != nil {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+// UploadManyFiles stores files in a single upload of underlying repository,
+// so either every variant of every file is stored or none of them.
+func (r *ImageProcessingRepository) UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error) {
+	processed := make([][]*models.File, len(files))
+
+	wg, _ := errgroup.WithContext(ctx)
+	for i, file := range files {
+		i, file := i, file
+		wg.Go(func() error {
+			variants, err := r.process(file)
+			if err != nil {
+				return err
+			}
+			processed[i] = variants
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// full variant (or unprocessed file) of every source file is the last one in its group
+	var toUpload []*models.File
+	fullIdx := make([]int, len(files))
+	for i, variants := range processed {
+		toUpload = append(toUpload, variants...)
+		fullIdx[i] = len(toUpload) - 1
+	}
+
+	uploaded, err := r.files.UploadManyFiles(ctx, toUpload)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(files))
+	for i, idx := range fullIdx {
+		urls[i] = uploaded[idx]
+	}
+	return urls, nil
+}
+
+// process returns variants to upload for the file from smallest to largest.
+func (r *ImageProcessingRepository) process(file *models.File) ([]*models.File, error) {
+	if !models.IsProcessableImage(file.MimeType) {
+		return []*models.File{file}, nil
+	}
+
+	data, err := io.ReadAll(file.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image %v: %w", file.Name, err)
+	}
+
+	encoded, ext, mimeType, err := processImage(data, r.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v: %v", usecase.ErrInvalidImage, file.Name, err)
+	}
+
+	base := file.ContentHash
+	if len(base) == 0 {
+		base = uuid.New().String()
+	}
+	variants := make([]*models.File, 0, len(encoded))
+	for _, img := range encoded {
+		variants = append(variants, &models.File{
+			Reader:      bytes.NewReader(img.data),
+			Name:        file.Name,
+			Size:        int64(len(img.data)),
+			Ext:         ext,
+			MimeType:    mimeType,
+			StorageName: models.ImageVariantName(base, img.variant, ext),
+			Purpose:     file.Purpose,
+		})
+	}
+	return variants, nil
+}
+
+// GetFileURL returns a public URL for the file.
+func (r *ImageProcessingRepository) GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	return r.files.GetFileURL(ctx, purpose, fileName)
+}
+
+// DeleteFile deletes the file and, if it is a full variant of an image, all smaller variants.
+func (r *ImageProcessingRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	if err := r.files.DeleteFile(ctx, purpose, fileName); err != nil {
+		return err
+	}
+
+	for _, name := range models.ImageVariantNames(fileName) {
+		if name == fileName {
+			continue
+		}
+		if err := r.files.DeleteFile(ctx, purpose, name); err != nil {
+			// full variant is already gone, leftovers will be removed by file gc
+			logger.Error(ctx, fmt.Sprintf("Unable to delete image variant %v: %s", name, err.Error()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	image_config "quickflow/config/image"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func testImageConfig() *image_config.ImageConfig {
+	return &image_config.ImageConfig{
+		ThumbnailSize: 2,
+		FeedSize:      4,
+		FullSize:      8,
+		JPEGQuality:   80,
+		MaxPixels:     1000,
+	}
+}
+
+func encodeJPEG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 10), G: uint8(y * 10), B: 100, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// withExifOrientation inserts APP1 segment with orientation tag right after SOI marker.
+func withExifOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // one IFD entry
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // value padding and next IFD offset
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, jpegMarkerAPP1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	return append(result, data[2:]...)
+}
+
+func TestJpegOrientation(t *testing.T) {
+	data := encodeJPEG(t, 4, 2)
+	assert.Equal(t, 1, jpegOrientation(data))
+	assert.Equal(t, 6, jpegOrientation(withExifOrientation(data, 6)))
+	assert.Equal(t, 1, jpegOrientation(withExifOrientation(data, 42)))
+	assert.Equal(t, 1, jpegOrientation([]byte("not a jpeg")))
+}
+
+func TestApplyOrientation(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	left, right := color.NRGBA{R: 255, A: 255}, color.NRGBA{B: 255, A: 255}
+	src.SetNRGBA(0, 0, left)
+	src.SetNRGBA(1, 0, right)
+
+	// rotated 90 clockwise: left pixel goes to the top
+	rotated := applyOrientation(src, 6).(*image.NRGBA)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Bounds())
+	assert.Equal(t, left, rotated.NRGBAAt(0, 0))
+	assert.Equal(t, right, rotated.NRGBAAt(0, 1))
+
+	mirrored := applyOrientation(src, 2).(*image.NRGBA)
+	assert.Equal(t, right, mirrored.NRGBAAt(0, 0))
+
+	assert.Same(t, src, applyOrientation(src, 1))
+}
+
+func TestFit(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+	assert.Equal(t, image.Rect(0, 0, 100, 50), fit(img, 100).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 25, 50), fit(image.NewNRGBA(image.Rect(0, 0, 200, 400)), 50).Bounds())
+	// small images are not upscaled
+	assert.Same(t, img, fit(img, 1000))
+}
+
+func TestProcessImage(t *testing.T) {
+	data := withExifOrientation(encodeJPEG(t, 16, 8), 6)
+
+	encoded, ext, mimeType, err := processImage(data, testImageConfig())
+	require.NoError(t, err)
+	assert.Equal(t, ".jpg", ext)
+	assert.Equal(t, "image/jpeg", mimeType)
+	require.Len(t, encoded, 3)
+
+	wantSizes := []image.Point{{1, 2}, {2, 4}, {4, 8}}
+	for i, img := range encoded {
+		assert.Equal(t, models.ImageVariantsList[i], img.variant)
+		assert.False(t, bytes.Contains(img.data, []byte("Exif")), "metadata must be stripped")
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(img.data))
+		require.NoError(t, err)
+		assert.Equal(t, wantSizes[i], image.Point{X: cfg.Width, Y: cfg.Height})
+	}
+
+	var png16 bytes.Buffer
+	require.NoError(t, png.Encode(&png16, image.NewNRGBA(image.Rect(0, 0, 16, 16))))
+	_, ext, mimeType, err = processImage(png16.Bytes(), testImageConfig())
+	require.NoError(t, err)
+	assert.Equal(t, ".png", ext)
+	assert.Equal(t, "image/png", mimeType)
+
+	_, _, _, err = processImage(encodeJPEG(t, 100, 100), testImageConfig())
+	assert.ErrorIs(t, err, errTooManyPixels)
+
+	_, _, _, err = processImage([]byte("garbage"), testImageConfig())
+	assert.Error(t, err)
+}
+
+func TestImageProcessingRepository_UploadManyFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	files := mocks.NewMockFileRepository(ctrl)
+	repo := NewImageProcessingRepository(files, testImageConfig())
+
+	doc := &models.File{Reader: strings.NewReader("text"), Name: "doc.txt", Ext: ".txt", MimeType: "text/plain", Purpose: models.FilePurposePost}
+	photo := &models.File{Reader: bytes.NewReader(encodeJPEG(t, 16, 8)), Name: "photo.jpeg", Ext: ".jpeg", MimeType: "image/jpeg", Purpose: models.FilePurposePost, ContentHash: "abc"}
+
+	files.EXPECT().UploadManyFiles(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, toUpload []*models.File) ([]string, error) {
+			require.Len(t, toUpload, 4)
+			assert.Same(t, doc, toUpload[0])
+
+			// variants are named after content hash of the source
+			assert.Equal(t, "abc_thumbnail.jpg", toUpload[1].StorageName)
+			assert.Equal(t, "abc_feed.jpg", toUpload[2].StorageName)
+			assert.Equal(t, "abc_full.jpg", toUpload[3].StorageName)
+			assert.Equal(t, models.FilePurposePost, toUpload[3].Purpose)
+
+			urls := make([]string, len(toUpload))
+			for i, file := range toUpload {
+				urls[i] = "http://minio/posts/" + file.StorageName
+			}
+			return urls, nil
+		})
+
+	urls, err := repo.UploadManyFiles(context.Background(), []*models.File{doc, photo})
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.True(t, strings.HasSuffix(urls[1], "_full.jpg"))
+
+	invalid := &models.File{Reader: strings.NewReader("garbage"), Name: "fake.png", Ext: ".png", MimeType: "image/png"}
+	_, err = repo.UploadManyFiles(context.Background(), []*models.File{invalid})
+	assert.ErrorIs(t, err, usecase.ErrInvalidImage)
+}
+
+func TestImageProcessingRepository_DeleteFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	files := mocks.NewMockFileRepository(ctrl)
+	repo := NewImageProcessingRepository(files, testImageConfig())
+
+	files.EXPECT().DeleteFile(gomock.Any(), models.FilePurposeAvatar, "a_full.jpg").Return(nil)
+	files.EXPECT().DeleteFile(gomock.Any(), models.FilePurposeAvatar, "a_thumbnail.jpg").Return(nil)
+	files.EXPECT().DeleteFile(gomock.Any(), models.FilePurposeAvatar, "a_feed.jpg").Return(nil)
+	require.NoError(t, repo.DeleteFile(context.Background(), models.FilePurposeAvatar, "a_full.jpg"))
+
+	files.EXPECT().DeleteFile(gomock.Any(), models.FilePurposeAvatar, "doc.txt").Return(nil)
+	require.NoError(t, repo.DeleteFile(context.Background(), models.FilePurposeAvatar, "doc.txt"))
+}
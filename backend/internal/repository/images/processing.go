@@ -0,0 +1,101 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	image_config "quickflow/config/image"
+	"quickflow/internal/models"
+)
+
+var errTooManyPixels = errors.New("image has too many pixels")
+
+type encodedImage struct {
+	variant models.ImageVariant
+	data    []byte
+}
+
+// processImage decodes image, applies EXIF orientation and encodes every size variant.
+// Encoding from decoded pixels drops all metadata of the original file.
+// Returns extension and mime type shared by all variants.
+func processImage(data []byte, cfg *image_config.ImageConfig) ([]encodedImage, string, string, error) {
+	imgCfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("image.DecodeConfig: %w", err)
+	}
+	if cfg.MaxPixels > 0 && imgCfg.Width*imgCfg.Height > cfg.MaxPixels {
+		return nil, "", "", errTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("image.Decode: %w", err)
+	}
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	// there is no pure-Go webp encoder, so webp is converted to one of the other formats
+	ext, mimeType := ".png", "image/png"
+	if format == "jpeg" || (format == "webp" && isOpaque(img)) {
+		ext, mimeType = ".jpg", "image/jpeg"
+	}
+
+	sizes := map[models.ImageVariant]int{
+		models.ImageThumbnail: cfg.ThumbnailSize,
+		models.ImageFeed:      cfg.FeedSize,
+		models.ImageFull:      cfg.FullSize,
+	}
+
+	encoded := make([]encodedImage, 0, len(models.ImageVariantsList))
+	for _, variant := range models.ImageVariantsList {
+		var buf bytes.Buffer
+		resized := fit(img, sizes[variant])
+		if mimeType == "image/jpeg" {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: cfg.JPEGQuality})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return nil, "", "", fmt.Errorf("unable to encode %s variant: %w", variant, err)
+		}
+		encoded = append(encoded, encodedImage{variant: variant, data: buf.Bytes()})
+	}
+
+	return encoded, ext, mimeType, nil
+}
+
+// fit scales image down so that its longest side is not greater than maxSide.
+// Images that already fit are never upscaled.
+func fit(img image.Image, maxSide int) image.Image {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if maxSide <= 0 || (w <= maxSide && h <= maxSide) {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSide/w)
+		w = maxSide
+	} else {
+		w = max(1, w*maxSide/h)
+		h = maxSide
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
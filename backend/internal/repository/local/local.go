@@ -0,0 +1,380 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	minioconfig "quickflow/config/minio"
+	storage_config "quickflow/config/storage"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+var errDirectUploadsNotSupported = errors.New("direct uploads are not supported by local file storage")
+
+// bucket describes directory files of one purpose are stored in.
+type bucket struct {
+	name        string
+	maxFileSize int64 // zero means no limit
+}
+
+// LocalFileRepository keeps files in a local directory and serves them itself,
+// so the backend can run without MinIO. Files of each purpose are kept in a
+// subdirectory named as MinIO bucket of that purpose with the same size limits,
+// so stored URLs look the same for both backends.
+type LocalFileRepository struct {
+	root       string
+	publicURL  string
+	publicPath string
+	buckets    map[models.FilePurpose]bucket
+}
+
+// NewLocalFileRepository creates file repository in directory from storage config.
+// Bucket names and size limits are taken from MinIO config.
+func NewLocalFileRepository(cfg *storage_config.StorageConfig, minioCfg *minioconfig.MinioConfig) (*LocalFileRepository, error) {
+	publicURL, err := url.Parse(strings.TrimSuffix(cfg.LocalPublicURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public url of local files %q: %v", cfg.LocalPublicURL, err)
+	}
+
+	repo := &LocalFileRepository{
+		root:       cfg.LocalDir,
+		publicURL:  publicURL.String(),
+		publicPath: publicURL.Path + "/",
+		buckets: map[models.FilePurpose]bucket{
+			models.FilePurposePost:       {name: minioCfg.PostsBucketName, maxFileSize: minioCfg.PostMaxFileSize},
+			models.FilePurposeAttachment: {name: minioCfg.AttachmentsBucketName, maxFileSize: minioCfg.AttachmentMaxFileSize},
+			models.FilePurposeAvatar:     {name: minioCfg.ProfileBucketName, maxFileSize: minioCfg.AvatarMaxFileSize},
+			models.FilePurposeCover:      {name: minioCfg.ProfileBucketName, maxFileSize: minioCfg.CoverMaxFileSize},
+			models.FilePurposeChatAvatar: {name: minioCfg.ProfileBucketName, maxFileSize: minioCfg.ChatAvatarMaxFileSize},
+		},
+	}
+
+	for _, bucketName := range repo.bucketNames() {
+		if err := os.MkdirAll(filepath.Join(repo.root, bucketName), 0o755); err != nil {
+			return nil, fmt.Errorf("could not create directory for bucket %v: %v", bucketName, err)
+		}
+	}
+	return repo, nil
+}
+
+// bucketNames returns distinct names of buckets used for any purpose.
+func (l *LocalFileRepository) bucketNames() []string {
+	var names []string
+	for _, purpose := range models.FilePurposes {
+		if name := l.buckets[purpose].name; !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// bucketFor returns bucket for files of given purpose.
+func (l *LocalFileRepository) bucketFor(purpose models.FilePurpose) (bucket, error) {
+	b, ok := l.buckets[purpose]
+	if !ok {
+		return bucket{}, fmt.Errorf("unknown file purpose %q", purpose)
+	}
+	return b, nil
+}
+
+// filePath returns path of the file in bucket, names with path separators are rejected.
+func (l *LocalFileRepository) filePath(bucketName, fileName string) (string, error) {
+	if len(fileName) == 0 || fileName != path.Base(fileName) || strings.ContainsRune(fileName, '\\') || fileName == ".." {
+		return "", fmt.Errorf("invalid file name %q", fileName)
+	}
+	return filepath.Join(l.root, bucketName, fileName), nil
+}
+
+// storageName returns name the file is stored under. Extension is derived from
+// mime type when file has none, so the file is served with the right content type.
+func storageName(file *models.File) string {
+	if len(file.StorageName) != 0 {
+		return file.StorageName
+	}
+	ext := file.Ext
+	if len(ext) == 0 {
+		if exts, err := mime.ExtensionsByType(file.MimeType); err == nil && len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+	if len(file.ContentHash) != 0 {
+		return file.ContentHash + ext
+	}
+	return uuid.New().String() + ext
+}
+
+// UploadFile writes file to its bucket directory and returns a public URL.
+func (l *LocalFileRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
+	b, err := l.bucketFor(file.Purpose)
+	if err != nil {
+		return "", err
+	}
+	if b.maxFileSize > 0 && file.Size > b.maxFileSize {
+		return "", fmt.Errorf("%w: %v", usecase.ErrFileTooLarge, file.Name)
+	}
+
+	fileName := storageName(file)
+	filePath, err := l.filePath(b.name, fileName)
+	if err != nil {
+		return "", err
+	}
+	if err = writeFile(filePath, file.Reader); err != nil {
+		logger.Error(ctx, fmt.Sprintf("could not upload file %v: %v", file.Name, err))
+		return "", fmt.Errorf("could not upload file: %v", err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", l.publicURL, b.name, fileName), nil
+}
+
+// writeFile stores data under temporary name first, so readers never see a partially written file.
+func writeFile(filePath string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+// UploadManyFiles uploads multiple files and returns their public URLs.
+// Either all files are uploaded or none: on failure already uploaded files are removed.
+func (l *LocalFileRepository) UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error) {
+	for _, file := range files {
+		b, err := l.bucketFor(file.Purpose)
+		if err != nil {
+			return nil, err
+		}
+		if b.maxFileSize > 0 && file.Size > b.maxFileSize {
+			return nil, fmt.Errorf("%w: %v", usecase.ErrFileTooLarge, file.Name)
+		}
+	}
+
+	urls := make([]string, 0, len(files))
+	uploaded := make([]models.StoredFile, 0, len(files))
+	for _, file := range files {
+		url, err := l.UploadFile(ctx, file)
+		if err != nil {
+			for _, stored := range uploaded {
+				if removeErr := l.DeleteStoredFile(ctx, stored); removeErr != nil {
+					logger.Error(ctx, fmt.Sprintf("could not remove partially uploaded file %v: %v", stored.Name, removeErr))
+				}
+			}
+			return nil, err
+		}
+		urls = append(urls, url)
+		uploaded = append(uploaded, models.StoredFile{Bucket: l.buckets[file.Purpose].name, Name: path.Base(url)})
+	}
+	return urls, nil
+}
+
+// GetFileURL returns a public URL for the file of given purpose.
+func (l *LocalFileRepository) GetFileURL(_ context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	b, err := l.bucketFor(purpose)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", l.publicURL, b.name, fileName), nil
+}
+
+// DeleteFile deletes a file of given purpose.
+func (l *LocalFileRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	b, err := l.bucketFor(purpose)
+	if err != nil {
+		return err
+	}
+	return l.DeleteStoredFile(ctx, models.StoredFile{Bucket: b.name, Name: fileName})
+}
+
+// DeleteStoredFile deletes file from the bucket it was listed in.
+// Deleting missing file is not an error, as it is for MinIO.
+func (l *LocalFileRepository) DeleteStoredFile(_ context.Context, file models.StoredFile) error {
+	filePath, err := l.filePath(file.Bucket, file.Name)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not delete file: %v", err)
+	}
+	return nil
+}
+
+// CopyFile copies file from bucket of one purpose to bucket of another one.
+func (l *LocalFileRepository) CopyFile(_ context.Context, fileName string, from, to models.FilePurpose) error {
+	src, err := l.bucketFor(from)
+	if err != nil {
+		return err
+	}
+	dst, err := l.bucketFor(to)
+	if err != nil {
+		return err
+	}
+	srcPath, err := l.filePath(src.name, fileName)
+	if err != nil {
+		return err
+	}
+	dstPath, err := l.filePath(dst.name, fileName)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("could not copy file %v from %v to %v: %v", fileName, src.name, dst.name, err)
+	}
+	defer in.Close()
+
+	if err = writeFile(dstPath, in); err != nil {
+		return fmt.Errorf("could not copy file %v from %v to %v: %v", fileName, src.name, dst.name, err)
+	}
+	return nil
+}
+
+// ListFiles returns all files stored in every bucket.
+func (l *LocalFileRepository) ListFiles(_ context.Context) ([]models.StoredFile, error) {
+	var files []models.StoredFile
+	for _, bucketName := range l.bucketNames() {
+		entries, err := os.ReadDir(filepath.Join(l.root, bucketName))
+		if err != nil {
+			return nil, fmt.Errorf("could not list files in %v: %v", bucketName, err)
+		}
+		for _, entry := range entries {
+			// temporary files of writes in progress are not stored files yet
+			if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, fmt.Errorf("could not list files in %v: %v", bucketName, err)
+			}
+			files = append(files, models.StoredFile{
+				Bucket:       bucketName,
+				Name:         entry.Name(),
+				Size:         info.Size(),
+				MimeType:     contentType(entry.Name()),
+				LastModified: info.ModTime(),
+			})
+		}
+	}
+	return files, nil
+}
+
+// StatFile returns size and content type of the stored file of given purpose.
+func (l *LocalFileRepository) StatFile(_ context.Context, purpose models.FilePurpose, fileName string) (models.StoredFile, error) {
+	b, err := l.bucketFor(purpose)
+	if err != nil {
+		return models.StoredFile{}, err
+	}
+	filePath, err := l.filePath(b.name, fileName)
+	if err != nil {
+		return models.StoredFile{}, err
+	}
+
+	info, err := os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return models.StoredFile{}, usecase.ErrNotFound
+	} else if err != nil {
+		return models.StoredFile{}, fmt.Errorf("could not stat file %v: %v", fileName, err)
+	}
+
+	return models.StoredFile{
+		Bucket:       b.name,
+		Name:         fileName,
+		Size:         info.Size(),
+		MimeType:     contentType(fileName),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// OpenFile returns reader of the stored file of given purpose.
+func (l *LocalFileRepository) OpenFile(_ context.Context, purpose models.FilePurpose, fileName string) (io.ReadCloser, error) {
+	b, err := l.bucketFor(purpose)
+	if err != nil {
+		return nil, err
+	}
+	filePath, err := l.filePath(b.name, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file %v: %v", fileName, err)
+	}
+	return file, nil
+}
+
+// PresignUpload is not supported: there is no storage server clients could upload to directly.
+func (l *LocalFileRepository) PresignUpload(_ context.Context, _ models.FilePurpose, _ string, _ int64, _ string, _ time.Duration) (string, map[string]string, error) {
+	return "", nil, errDirectUploadsNotSupported
+}
+
+// PublicPath returns URL path files are served under, handler expects it to be stripped.
+func (l *LocalFileRepository) PublicPath() string {
+	return l.publicPath
+}
+
+// ServeHTTP serves stored files by <bucket>/<name> path relative to public path.
+// Files are user content served from the API origin, so they are never
+// sniffed or rendered as active documents.
+func (l *LocalFileRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucketName, fileName, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || !slices.Contains(l.bucketNames(), bucketName) {
+		http.NotFound(w, r)
+		return
+	}
+	filePath, err := l.filePath(bucketName, fileName)
+	if err != nil || strings.HasPrefix(fileName, ".") {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(fileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	http.ServeContent(w, r, fileName, info.ModTime(), file)
+}
+
+// contentType returns content type of stored file by its extension.
+func contentType(fileName string) string {
+	if mimeType := mime.TypeByExtension(path.Ext(fileName)); len(mimeType) != 0 {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,121 @@
+package local
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	minioconfig "quickflow/config/minio"
+	storage_config "quickflow/config/storage"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func newTestRepository(t *testing.T) *LocalFileRepository {
+	repo, err := NewLocalFileRepository(
+		&storage_config.StorageConfig{
+			Backend:        storage_config.BackendLocal,
+			LocalDir:       t.TempDir(),
+			LocalPublicURL: "http://localhost:8080/files/",
+		},
+		&minioconfig.MinioConfig{
+			PostsBucketName:       "posts",
+			AttachmentsBucketName: "attachments",
+			ProfileBucketName:     "profiles",
+			AvatarMaxFileSize:     4,
+		})
+	require.NoError(t, err)
+	return repo
+}
+
+func TestLocalFileRepository_UploadAndServe(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	url, err := repo.UploadFile(ctx, &models.File{
+		Reader:   strings.NewReader("hello"),
+		Name:     "hello",
+		Size:     5,
+		MimeType: "image/png",
+		Purpose:  models.FilePurposeAttachment,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, "http://localhost:8080/files/attachments/"))
+	assert.Equal(t, "/files/", repo.PublicPath())
+
+	name := path.Base(url)
+	assert.Equal(t, ".png", path.Ext(name), "extension is derived from mime type")
+
+	handler := http.StripPrefix("/files", repo)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/attachments/"+name, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello", rec.Body.String())
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+
+	for _, target := range []string{"/files/attachments/../posts/" + name, "/files/unknown/" + name, "/files/attachments/"} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, target)
+	}
+
+	stored, err := repo.StatFile(ctx, models.FilePurposeAttachment, name)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stored.Size)
+
+	require.NoError(t, repo.DeleteFile(ctx, models.FilePurposeAttachment, name))
+	_, err = repo.StatFile(ctx, models.FilePurposeAttachment, name)
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	// deleting twice is fine, as for MinIO
+	assert.NoError(t, repo.DeleteFile(ctx, models.FilePurposeAttachment, name))
+}
+
+func TestLocalFileRepository_UploadManyFiles(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	urls, err := repo.UploadManyFiles(ctx, []*models.File{
+		{Reader: strings.NewReader("a"), Name: "a.png", Ext: ".png", Size: 1, Purpose: models.FilePurposePost},
+		{Reader: strings.NewReader("b"), Name: "b.png", Ext: ".png", StorageName: "b_full.png", Size: 1, Purpose: models.FilePurposeAvatar},
+	})
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, "http://localhost:8080/files/profiles/b_full.png", urls[1])
+
+	files, err := repo.ListFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	_, err = repo.UploadManyFiles(ctx, []*models.File{
+		{Reader: strings.NewReader("c"), Name: "c.png", Ext: ".png", Size: 1, Purpose: models.FilePurposePost},
+		{Reader: strings.NewReader("too large"), Name: "d.png", Ext: ".png", Size: 9, Purpose: models.FilePurposeAvatar},
+	})
+	assert.ErrorIs(t, err, usecase.ErrFileTooLarge)
+
+	files, err = repo.ListFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "nothing is stored when any file is rejected")
+}
+
+func TestLocalFileRepository_CopyFile(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo.root, "posts", "a.jpg"), []byte("jpeg"), 0o644))
+	require.NoError(t, repo.CopyFile(ctx, "a.jpg", models.FilePurposePost, models.FilePurposeAvatar))
+
+	data, err := os.ReadFile(filepath.Join(repo.root, "profiles", "a.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	assert.Error(t, repo.CopyFile(ctx, "../a.jpg", models.FilePurposePost, models.FilePurposeAvatar))
+}
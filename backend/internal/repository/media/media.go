@@ -0,0 +1,139 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	validation_config "quickflow/config/validation"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	probe "quickflow/pkg/media"
+)
+
+// sniffLength is how much of the file is enough to detect its type.
+const sniffLength = 512
+
+// MediaValidatingRepository detects real type of uploaded files by their content,
+// extracts playback properties of video and audio and checks limits of the media type
+// before passing files to underlying file repository.
+type MediaValidatingRepository struct {
+	files usecase.FileRepository
+	cfg   *validation_config.ValidationConfig
+}
+
+// NewMediaValidatingRepository wraps file repository with media validation.
+func NewMediaValidatingRepository(files usecase.FileRepository, cfg *validation_config.ValidationConfig) *MediaValidatingRepository {
+	return &MediaValidatingRepository{
+		files: files,
+		cfg:   cfg,
+	}
+}
+
+// UploadFile validates and stores the file.
+func (r *MediaValidatingRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
+	urls, err := r.UploadManyFiles(ctx, []*models.File{file})
+	if err != nil {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+// UploadManyFiles stores files only if every one of them is valid.
+func (r *MediaValidatingRepository) UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error) {
+	for _, file := range files {
+		if err := r.validate(file); err != nil {
+			return nil, err
+		}
+	}
+	return r.files.UploadManyFiles(ctx, files)
+}
+
+// validate fills media info of the file and checks it against limits of its media type.
+// The file is read into memory to be probed, so no more than the size limit of its type is read.
+func (r *MediaValidatingRepository) validate(file *models.File) error {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file.Reader, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("unable to read file %v: %w", file.Name, err)
+	}
+	head = head[:n]
+
+	// declared type is not trusted
+	file.MimeType = probe.DetectContentType(head)
+	file.Media = models.MediaInfo{Type: models.MediaTypeOf(file.MimeType), MimeType: file.MimeType}
+
+	rest := file.Reader
+	sniffedLimits := r.cfg.LimitsFor(string(file.Media.Type))
+	if sniffedLimits.MaxSize > 0 {
+		rest = io.LimitReader(rest, sniffedLimits.MaxSize+1-int64(len(head)))
+	}
+	tail, err := io.ReadAll(rest)
+	if err != nil {
+		return fmt.Errorf("unable to read file %v: %w", file.Name, err)
+	}
+	data := append(head, tail...)
+	if sniffedLimits.MaxSize > 0 && int64(len(data)) > sniffedLimits.MaxSize {
+		return fmt.Errorf("%w: %v is larger than %d bytes", usecase.ErrFileTooLarge, file.Name, sniffedLimits.MaxSize)
+	}
+	file.Reader = bytes.NewReader(data)
+	file.Size = int64(len(data))
+
+	if file.Media.Type.IsPlayable() {
+		info, err := probe.Probe(data)
+		if err != nil {
+			return fmt.Errorf("%w: %v: %v", usecase.ErrInvalidMedia, file.Name, err)
+		}
+		if !info.HasVideo {
+			// containers are shared by audio and video
+			file.Media.Type = models.MediaTypeAudio
+			file.MimeType = audioMimeType(file.MimeType)
+			file.Media.MimeType = file.MimeType
+		}
+		file.Media.Duration = info.Duration
+		file.Media.Width, file.Media.Height = info.Width, info.Height
+		file.Ext = playableExtensions[file.MimeType]
+	}
+
+	// audio in a video container is checked against limits of audio
+	limits := r.cfg.LimitsFor(string(file.Media.Type))
+	if limits.MaxSize > 0 && file.Size > limits.MaxSize {
+		return fmt.Errorf("%w: %v is larger than %d bytes", usecase.ErrFileTooLarge, file.Name, limits.MaxSize)
+	}
+	if limits.MaxDuration > 0 && file.Media.Duration > limits.MaxDuration {
+		return fmt.Errorf("%w: %v is longer than %v", usecase.ErrMediaTooLong, file.Name, limits.MaxDuration)
+	}
+	return nil
+}
+
+// playableExtensions are extensions files of playable types are stored with regardless of their names.
+var playableExtensions = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+	"video/webm":      ".webm",
+	"audio/mp4":       ".m4a",
+	"audio/webm":      ".webm",
+	"audio/ogg":       ".ogg",
+}
+
+func audioMimeType(mimeType string) string {
+	switch mimeType {
+	case "video/mp4", "video/quicktime":
+		return "audio/mp4"
+	case "video/webm":
+		return "audio/webm"
+	}
+	return mimeType
+}
+
+// GetFileURL returns a public URL for the file.
+func (r *MediaValidatingRepository) GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	return r.files.GetFileURL(ctx, purpose, fileName)
+}
+
+// DeleteFile deletes the file.
+func (r *MediaValidatingRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	return r.files.DeleteFile(ctx, purpose, fileName)
+}
@@ -0,0 +1,114 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	validation_config "quickflow/config/validation"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func oggPage(granule int64, payload []byte) []byte {
+	page := make([]byte, 27)
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:], uint64(granule))
+	page[26] = 1
+	page = append(page, byte(len(payload)))
+	return append(page, payload...)
+}
+
+// testOpus returns Ogg Opus audio of given length.
+func testOpus(duration time.Duration) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	return bytes.Join([][]byte{
+		oggPage(0, head),
+		oggPage(int64(duration/time.Millisecond)*48, []byte("audio")),
+	}, nil)
+}
+
+func TestMediaValidatingRepository_UploadManyFiles(t *testing.T) {
+	cfg := &validation_config.ValidationConfig{
+		Audio:    validation_config.MediaLimits{MaxSize: 1024, MaxDuration: time.Minute},
+		Document: validation_config.MediaLimits{MaxSize: 8},
+	}
+
+	tests := []struct {
+		name      string
+		file      *models.File
+		wantMedia models.MediaInfo
+		wantExt   string
+		wantErr   error
+	}{
+		{
+			name:      "audio gets playback properties",
+			file:      &models.File{Reader: bytes.NewReader(testOpus(3 * time.Second)), Name: "voice", MimeType: "video/mp4"},
+			wantMedia: models.MediaInfo{Type: models.MediaTypeAudio, MimeType: "audio/ogg", Duration: 3 * time.Second},
+			wantExt:   ".ogg",
+		},
+		{
+			name:    "audio longer than limit",
+			file:    &models.File{Reader: bytes.NewReader(testOpus(2 * time.Minute)), Name: "podcast.ogg"},
+			wantErr: usecase.ErrMediaTooLong,
+		},
+		{
+			name:    "broken video",
+			file:    &models.File{Reader: strings.NewReader("\x00\x00\x00\x14ftypisom\x00\x00\x00\x00"), Name: "clip.mp4"},
+			wantErr: usecase.ErrInvalidMedia,
+		},
+		{
+			name:      "declared type is ignored",
+			file:      &models.File{Reader: strings.NewReader("notes"), Name: "a.png", Ext: ".png", MimeType: "image/png"},
+			wantMedia: models.MediaInfo{Type: models.MediaTypeDocument, MimeType: "text/plain; charset=utf-8"},
+			wantExt:   ".png",
+		},
+		{
+			name:    "document larger than limit",
+			file:    &models.File{Reader: strings.NewReader("long notes"), Name: "a.txt"},
+			wantErr: usecase.ErrFileTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			files := mocks.NewMockFileRepository(ctrl)
+			if tt.wantErr == nil {
+				files.EXPECT().UploadManyFiles(gomock.Any(), []*models.File{tt.file}).Return([]string{"http://minio/posts/a"}, nil)
+			}
+
+			_, err := NewMediaValidatingRepository(files, cfg).UploadManyFiles(context.Background(), []*models.File{tt.file})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMedia, tt.file.Media)
+			assert.Equal(t, tt.wantMedia.MimeType, tt.file.MimeType)
+			assert.Equal(t, tt.wantExt, tt.file.Ext)
+		})
+	}
+}
+
+func TestMediaValidatingRepository_UploadFile_ReadsNoMoreThanLimit(t *testing.T) {
+	cfg := &validation_config.ValidationConfig{
+		Document: validation_config.MediaLimits{MaxSize: 1024},
+	}
+	reader := strings.NewReader(strings.Repeat("notes ", 1<<20))
+
+	_, err := NewMediaValidatingRepository(nil, cfg).UploadFile(context.Background(), &models.File{Reader: reader, Name: "huge.txt"})
+	require.ErrorIs(t, err, usecase.ErrFileTooLarge)
+	assert.Greater(t, reader.Len(), 6<<20-2048, "file is read past the size limit")
+}
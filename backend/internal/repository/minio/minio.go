@@ -3,6 +3,12 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
@@ -11,12 +17,37 @@ import (
 
 	minioconfig "quickflow/config/minio"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 	"quickflow/pkg/logger"
 	threadsafeslice "quickflow/pkg/thread-safe-slice"
 )
 
+// publicReadPolicy lets anyone download objects but not list or modify bucket.
+const publicReadPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": {"AWS": ["*"]},
+		"Action": ["s3:GetObject"],
+		"Resource": ["arn:aws:s3:::%s/*"]
+	}]
+}`
+
+// presignRegion is region of MinIO deployment. It is set explicitly so presigning
+// does not need to ask public endpoint for bucket location.
+const presignRegion = "us-east-1"
+
+// bucket describes where files of one purpose are stored.
+type bucket struct {
+	name        string
+	maxFileSize int64 // zero means no limit
+}
+
 type MinioRepository struct {
 	client                *minio.Client
+	presignClient         *minio.Client
+	presignPathPrefix     string
+	buckets               map[models.FilePurpose]bucket
 	PostsBucketName       string
 	AttachmentsBucketName string
 	ProfileBucketName     string
@@ -33,33 +64,95 @@ func NewMinioRepository(cfg *minioconfig.MinioConfig) (*MinioRepository, error)
 		return nil, fmt.Errorf("could not create minio client: %v", err)
 	}
 
-	exists, err := client.BucketExists(context.Background(), cfg.PostsBucketName)
+	// clients upload to the public endpoint, so URLs must be signed for its host
+	publicHost, publicPathPrefix, _ := strings.Cut(cfg.MinioPublicEndpoint, "/")
+	presignClient, err := minio.New(publicHost, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.MinioRootUser, cfg.MinioRootPassword, ""),
+		Secure: cfg.Scheme == "https",
+		Region: presignRegion,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("could not check if bucket exists: %v", err)
+		return nil, fmt.Errorf("could not create minio presign client: %v", err)
 	}
 
-	if !exists {
-		err = client.MakeBucket(context.Background(), cfg.PostsBucketName, minio.MakeBucketOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("could not create bucket: %v", err)
-		}
+	buckets := map[models.FilePurpose]bucket{
+		models.FilePurposePost:       {name: cfg.PostsBucketName, maxFileSize: cfg.PostMaxFileSize},
+		models.FilePurposeAttachment: {name: cfg.AttachmentsBucketName, maxFileSize: cfg.AttachmentMaxFileSize},
+		models.FilePurposeAvatar:     {name: cfg.ProfileBucketName, maxFileSize: cfg.AvatarMaxFileSize},
+		models.FilePurposeCover:      {name: cfg.ProfileBucketName, maxFileSize: cfg.CoverMaxFileSize},
+		models.FilePurposeChatAvatar: {name: cfg.ProfileBucketName, maxFileSize: cfg.ChatAvatarMaxFileSize},
 	}
 
-	return &MinioRepository{
+	repo := &MinioRepository{
 		client:                client,
+		presignClient:         presignClient,
+		presignPathPrefix:     publicPathPrefix,
+		buckets:               buckets,
 		PostsBucketName:       cfg.PostsBucketName,
 		AttachmentsBucketName: cfg.AttachmentsBucketName,
 		ProfileBucketName:     cfg.ProfileBucketName,
 		PublicUrlRoot:         fmt.Sprintf("%s://%s", cfg.Scheme, cfg.MinioPublicEndpoint),
-	}, nil
+	}
+
+	for _, bucketName := range repo.bucketNames() {
+		if err = repo.ensureBucket(context.Background(), bucketName); err != nil {
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+// ensureBucket creates bucket with public read policy if it does not exist yet.
+func (m *MinioRepository) ensureBucket(ctx context.Context, bucketName string) error {
+	exists, err := m.client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("could not check if bucket %v exists: %v", bucketName, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err = m.client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("could not create bucket %v: %v", bucketName, err)
+	}
+	if err = m.client.SetBucketPolicy(ctx, bucketName, fmt.Sprintf(publicReadPolicy, bucketName)); err != nil {
+		return fmt.Errorf("could not set policy for bucket %v: %v", bucketName, err)
+	}
+	return nil
+}
+
+// bucketNames returns distinct names of buckets used for any purpose.
+func (m *MinioRepository) bucketNames() []string {
+	var names []string
+	for _, purpose := range models.FilePurposes {
+		if name := m.buckets[purpose].name; !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// bucketFor returns bucket for files of given purpose.
+func (m *MinioRepository) bucketFor(purpose models.FilePurpose) (bucket, error) {
+	b, ok := m.buckets[purpose]
+	if !ok {
+		return bucket{}, fmt.Errorf("unknown file purpose %q", purpose)
+	}
+	return b, nil
 }
 
 // UploadFile uploads file to MinIO and returns a public URL.
 func (m *MinioRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
-	uuID := uuid.New()
-	fileName := uuID.String() + file.Ext
+	b, err := m.bucketFor(file.Purpose)
+	if err != nil {
+		return "", err
+	}
+	if b.maxFileSize > 0 && file.Size > b.maxFileSize {
+		return "", fmt.Errorf("%w: %v", usecase.ErrFileTooLarge, file.Name)
+	}
+	fileName := storageName(file)
 
-	_, err := m.client.PutObject(ctx, m.PostsBucketName, fileName, file.Reader, file.Size, minio.PutObjectOptions{
+	_, err = m.client.PutObject(ctx, b.name, fileName, file.Reader, file.Size, minio.PutObjectOptions{
 		ContentType: file.MimeType,
 	})
 	if err != nil {
@@ -67,35 +160,50 @@ func (m *MinioRepository) UploadFile(ctx context.Context, file *models.File) (st
 		return "", fmt.Errorf("could not upload file: %v", err)
 	}
 
-	publicURL := fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, m.PostsBucketName, fileName)
+	publicURL := fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, b.name, fileName)
 	logger.Info(ctx, fmt.Sprintf("File successfully loaded: %v, url: %v", file.Name, publicURL))
 	return publicURL, nil
 }
 
 // UploadManyFiles uploads multiple files and returns a map of public URLs.
+// Either all files are uploaded or none: on failure already uploaded files are removed.
 func (m *MinioRepository) UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error) {
+	buckets := make([]bucket, len(files))
+	for i, file := range files {
+		b, err := m.bucketFor(file.Purpose)
+		if err != nil {
+			return nil, err
+		}
+		if b.maxFileSize > 0 && file.Size > b.maxFileSize {
+			return nil, fmt.Errorf("%w: %v", usecase.ErrFileTooLarge, file.Name)
+		}
+		buckets[i] = b
+	}
+
 	urls := threadsafeslice.NewThreadSafeSliceN[string](len(files))
+	uploaded := threadsafeslice.NewThreadSafeSlice[models.StoredFile]()
 
-	ctx, cancel := context.WithCancel(ctx)
+	uploadCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	wg, ctx := errgroup.WithContext(ctx)
+	wg, uploadCtx := errgroup.WithContext(uploadCtx)
 
 	for i, file := range files {
 		i := i
 		file := file // https://golang.org/doc/faq#closures_and_goroutines
-		uuID := uuid.New()
-		fileName := uuID.String() + file.Ext
+		fileName := storageName(file)
+		bucketName := buckets[i].name
 
 		wg.Go(func() error {
-			_, err := m.client.PutObject(ctx, m.PostsBucketName, fileName, file.Reader, file.Size, minio.PutObjectOptions{
+			_, err := m.client.PutObject(uploadCtx, bucketName, fileName, file.Reader, file.Size, minio.PutObjectOptions{
 				ContentType: file.MimeType,
 			})
 			if err != nil {
 				return fmt.Errorf("could not upload file: %v, err: %v", file.Name, err)
 			}
+			uploaded.Add(models.StoredFile{Bucket: bucketName, Name: fileName})
 
-			publicURL := fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, m.PostsBucketName, fileName)
+			publicURL := fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, bucketName, fileName)
 			err = urls.SetByIdx(i, publicURL)
 			if err != nil {
 				return fmt.Errorf("could not upload file: %v, err: %v", file.Name, err)
@@ -105,21 +213,158 @@ func (m *MinioRepository) UploadManyFiles(ctx context.Context, files []*models.F
 	}
 
 	if err := wg.Wait(); err != nil {
+		// upload context is already cancelled here
+		cleanupCtx := context.WithoutCancel(ctx)
+		for _, file := range uploaded.GetSliceCopy() {
+			if removeErr := m.DeleteStoredFile(cleanupCtx, file); removeErr != nil {
+				logger.Error(ctx, fmt.Sprintf("could not remove partially uploaded file %v: %v", file.Name, removeErr))
+			}
+		}
 		return nil, err
 	}
 	return urls.GetSliceCopy(), nil
 }
 
-// GetFileURL returns a public URL for the file.
-func (m *MinioRepository) GetFileURL(_ context.Context, fileName string) (string, error) {
-	return fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, m.PostsBucketName, fileName), nil
+// storageName returns name the file is stored under.
+func storageName(file *models.File) string {
+	if len(file.StorageName) != 0 {
+		return file.StorageName
+	}
+	if len(file.ContentHash) != 0 {
+		return file.ContentHash + file.Ext
+	}
+	return uuid.New().String() + file.Ext
+}
+
+// GetFileURL returns a public URL for the file of given purpose.
+func (m *MinioRepository) GetFileURL(_ context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	b, err := m.bucketFor(purpose)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", m.PublicUrlRoot, b.name, fileName), nil
 }
 
-// DeleteFile deletes a file from MinIO.
-func (m *MinioRepository) DeleteFile(ctx context.Context, fileName string) error {
-	err := m.client.RemoveObject(ctx, m.PostsBucketName, fileName, minio.RemoveObjectOptions{})
+// DeleteFile deletes a file of given purpose from MinIO.
+func (m *MinioRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	b, err := m.bucketFor(purpose)
+	if err != nil {
+		return err
+	}
+	return m.DeleteStoredFile(ctx, models.StoredFile{Bucket: b.name, Name: fileName})
+}
+
+// DeleteStoredFile deletes object from the bucket it was listed in.
+func (m *MinioRepository) DeleteStoredFile(ctx context.Context, file models.StoredFile) error {
+	err := m.client.RemoveObject(ctx, file.Bucket, file.Name, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("could not delete file: %v", err)
 	}
 	return nil
 }
+
+// CopyFile copies file from bucket of one purpose to bucket of another one.
+func (m *MinioRepository) CopyFile(ctx context.Context, fileName string, from, to models.FilePurpose) error {
+	src, err := m.bucketFor(from)
+	if err != nil {
+		return err
+	}
+	dst, err := m.bucketFor(to)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: dst.name, Object: fileName},
+		minio.CopySrcOptions{Bucket: src.name, Object: fileName})
+	if err != nil {
+		return fmt.Errorf("could not copy file %v from %v to %v: %v", fileName, src.name, dst.name, err)
+	}
+	return nil
+}
+
+// ListFiles returns all objects stored in every bucket.
+func (m *MinioRepository) ListFiles(ctx context.Context) ([]models.StoredFile, error) {
+	var files []models.StoredFile
+	for _, bucketName := range m.bucketNames() {
+		for object := range m.client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Recursive: true}) {
+			if object.Err != nil {
+				return nil, fmt.Errorf("could not list files in %v: %v", bucketName, object.Err)
+			}
+			files = append(files, models.StoredFile{
+				Bucket:       bucketName,
+				Name:         object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+			})
+		}
+	}
+	return files, nil
+}
+
+// PresignUpload returns URL the file of given purpose can be uploaded to with PUT request
+// and headers that must be sent with it. Size and content type are part of the signature.
+func (m *MinioRepository) PresignUpload(ctx context.Context, purpose models.FilePurpose, fileName string, size int64, mimeType string, expires time.Duration) (string, map[string]string, error) {
+	b, err := m.bucketFor(purpose)
+	if err != nil {
+		return "", nil, err
+	}
+	if b.maxFileSize > 0 && size > b.maxFileSize {
+		return "", nil, fmt.Errorf("%w: %v", usecase.ErrFileTooLarge, fileName)
+	}
+
+	signed := http.Header{}
+	signed.Set("Content-Type", mimeType)
+	signed.Set("Content-Length", strconv.FormatInt(size, 10))
+
+	uploadURL, err := m.presignClient.PresignHeader(ctx, http.MethodPut, b.name, fileName, expires, nil, signed)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not presign upload of %v: %v", fileName, err)
+	}
+	// reverse proxy strips the prefix before passing request to MinIO
+	if len(m.presignPathPrefix) != 0 {
+		uploadURL.Path = "/" + m.presignPathPrefix + uploadURL.Path
+		uploadURL.RawPath = ""
+	}
+
+	// Content-Length is set by HTTP clients themselves
+	return uploadURL.String(), map[string]string{"Content-Type": mimeType}, nil
+}
+
+// StatFile returns size and content type of the stored file of given purpose.
+func (m *MinioRepository) StatFile(ctx context.Context, purpose models.FilePurpose, fileName string) (models.StoredFile, error) {
+	b, err := m.bucketFor(purpose)
+	if err != nil {
+		return models.StoredFile{}, err
+	}
+
+	info, err := m.client.StatObject(ctx, b.name, fileName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return models.StoredFile{}, usecase.ErrNotFound
+		}
+		return models.StoredFile{}, fmt.Errorf("could not stat file %v: %v", fileName, err)
+	}
+
+	return models.StoredFile{
+		Bucket:       b.name,
+		Name:         info.Key,
+		Size:         info.Size,
+		MimeType:     info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// OpenFile returns reader of the stored file of given purpose.
+func (m *MinioRepository) OpenFile(ctx context.Context, purpose models.FilePurpose, fileName string) (io.ReadCloser, error) {
+	b, err := m.bucketFor(purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := m.client.GetObject(ctx, b.name, fileName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not open file %v: %v", fileName, err)
+	}
+	return object, nil
+}
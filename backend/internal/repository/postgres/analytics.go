@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+const analyticsDayLayout = "2006-01-02"
+
+// views of deleted posts are dropped
+const insertPostViewsQuery = `
+	insert into post_view_daily (post_id, day, view_count)
+	select v.post_id, v.day, v.view_count
+	from unnest($1::uuid[], $2::date[], $3::int[]) as v(post_id, day, view_count)
+	join post p on p.id = v.post_id
+	on conflict (post_id, day) do update set view_count = post_view_daily.view_count + excluded.view_count
+`
+
+const addPostViewCountQuery = `
+	update post p
+	set view_count = p.view_count + v.view_count
+	from (
+		select post_id, sum(view_count) as view_count
+		from unnest($1::uuid[], $2::int[]) as v(post_id, view_count)
+		group by post_id
+	) v
+	where p.id = v.post_id
+`
+
+// postActivityCTE lists views, likes and comments of posts of the user $1
+// by UTC days from $2 to $3 inclusive, one row per day of the post and kind of activity.
+const postActivityCTE = `
+	with period as (
+		select $2::date::timestamp at time zone 'UTC' as since, ($3::date + 1)::timestamp at time zone 'UTC' as until
+	),
+	activity as (
+		select v.post_id, v.day, v.view_count as views, 0 as likes, 0 as comments
+		from post_view_daily v
+		join post p on p.id = v.post_id
+		where p.creator_id = $1 and v.day between $2::date and $3::date
+		union all
+		select l.post_id, (l.created_at at time zone 'UTC')::date, 0, count(*), 0
+		from like_post l
+		join post p on p.id = l.post_id
+		cross join period
+		where p.creator_id = $1 and l.created_at >= period.since and l.created_at < period.until
+		group by 1, 2
+		union all
+		select c.post_id, (c.created_at at time zone 'UTC')::date, 0, 0, count(*)
+		from comment c
+		join post p on p.id = c.post_id
+		cross join period
+		where p.creator_id = $1 and c.created_at >= period.since and c.created_at < period.until
+		group by 1, 2
+	)
+`
+
+// followers are counted by the time they followed the user, whether the request was accepted or not
+var getDailyStatsQuery = postActivityCTE + `,
+	days as (
+		select generate_series($2::date, $3::date, interval '1 day')::date as day
+	),
+	followers as (
+		select (f.created_at at time zone 'UTC')::date as day, count(*) as count
+		from friendship f
+		cross join period
+		where ((f.user1_id = $1 and f.status in ($4, $5)) or (f.user2_id = $1 and f.status in ($6, $5)))
+			and f.created_at >= period.since and f.created_at < period.until
+		group by 1
+	)
+	select d.day, coalesce(sum(a.views), 0), coalesce(sum(a.likes), 0), coalesce(sum(a.comments), 0), coalesce(max(f.count), 0)
+	from days d
+	left join activity a on a.day = d.day
+	left join followers f on f.day = d.day
+	group by d.day
+	order by d.day
+`
+
+var getTopPostsQuery = postActivityCTE + `
+	select p.id, p.text, p.created_at, sum(a.views) as views, sum(a.likes) as likes, sum(a.comments) as comments
+	from activity a
+	join post p on p.id = a.post_id
+	group by p.id
+	order by views desc, likes desc, comments desc, p.created_at desc, p.id
+	limit $4
+`
+
+type PostgresAnalyticsRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresAnalyticsRepository creates new repository of views and activity around posts.
+func NewPostgresAnalyticsRepository(connPool *sql.DB) *PostgresAnalyticsRepository {
+	return &PostgresAnalyticsRepository{connPool: connPool}
+}
+
+// AddPostViews adds views to daily views and total view counters of the posts in a single transaction.
+func (a *PostgresAnalyticsRepository) AddPostViews(ctx context.Context, views []models.PostViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	postIds := make([]string, 0, len(views))
+	days := make([]string, 0, len(views))
+	counts := make([]int32, 0, len(views))
+	for _, postViews := range views {
+		postIds = append(postIds, postViews.PostId.String())
+		days = append(days, postViews.Day.UTC().Format(analyticsDayLayout))
+		counts = append(counts, int32(postViews.Count))
+	}
+
+	tx, err := a.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction to add post views: %s", err.Error()))
+		return fmt.Errorf("unable to add post views: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, insertPostViewsQuery, postIds, days, counts); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save daily views of %d posts: %s", len(postIds), err.Error()))
+		return fmt.Errorf("unable to add post views: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, addPostViewCountQuery, postIds, counts); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to update view counters of %d posts: %s", len(postIds), err.Error()))
+		return fmt.Errorf("unable to add post views: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit post views: %s", err.Error()))
+		return fmt.Errorf("unable to add post views: %w", err)
+	}
+	return nil
+}
+
+// GetDailyStats returns activity around posts and profile of the user for every UTC day from from to to inclusive.
+func (a *PostgresAnalyticsRepository) GetDailyStats(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time) ([]models.DailyStats, error) {
+	rows, err := a.connPool.QueryContext(ctx, getDailyStatsQuery, userId,
+		from.UTC().Format(analyticsDayLayout), to.UTC().Format(analyticsDayLayout),
+		models.RelationFollowedBy, models.RelationFriend, models.RelationFollowing)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get daily stats of user %v from database: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get daily stats from database: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.DailyStats
+	for rows.Next() {
+		var day models.DailyStats
+		if err = rows.Scan(&day.Day, &day.Views, &day.Likes, &day.Comments, &day.NewFollowers); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan daily stats of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get daily stats from database: %w", err)
+		}
+		stats = append(stats, day)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to get daily stats from database: %w", err)
+	}
+	return stats, nil
+}
+
+// GetTopPosts returns posts of the user with the most views during UTC days from from to to inclusive.
+// Posts without any activity during the period are not returned.
+func (a *PostgresAnalyticsRepository) GetTopPosts(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time, limit int) ([]models.PostStats, error) {
+	rows, err := a.connPool.QueryContext(ctx, getTopPostsQuery, userId,
+		from.UTC().Format(analyticsDayLayout), to.UTC().Format(analyticsDayLayout), limit)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get top posts of user %v from database: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get top posts from database: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []models.PostStats
+	for rows.Next() {
+		var (
+			post models.PostStats
+			text sql.NullString
+		)
+		if err = rows.Scan(&post.PostId, &text, &post.CreatedAt, &post.Views, &post.Likes, &post.Comments); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan top posts of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get top posts from database: %w", err)
+		}
+		post.Text = text.String
+		posts = append(posts, post)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to get top posts from database: %w", err)
+	}
+	return posts, nil
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+)
+
+func TestAddPostViews(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	views := []models.PostViews{
+		{PostId: first, Day: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Count: 3},
+		{PostId: second, Day: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), Count: 1},
+	}
+	postIds := []string{first.String(), second.String()}
+	counts := []int32{3, 1}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "views are saved in a transaction",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)insert into post_view_daily .* on conflict \(post_id, day\) do update`).
+					WithArgs(postIds, []string{"2025-05-01", "2025-05-02"}, counts).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`(?i)update post p\s+set view_count = p.view_count \+ v.view_count`).
+					WithArgs(postIds, counts).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "daily views are not saved",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)insert into post_view_daily`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			require.NoError(t, err)
+			defer mockDB.Close()
+			tt.mock(mock)
+
+			repo := postgres.NewPostgresAnalyticsRepository(mockDB)
+			err = repo.AddPostViews(context.Background(), views)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetDailyStats(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId := uuid.New()
+	from, to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`(?i)with period as .* from days d\s+left join activity a`).
+		WithArgs(userId, "2025-05-01", "2025-05-02", models.RelationFollowedBy, models.RelationFriend, models.RelationFollowing).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "views", "likes", "comments", "new_followers"}).
+			AddRow(from, 10, 2, 0, 1).
+			AddRow(to, 0, 0, 0, 0))
+
+	repo := postgres.NewPostgresAnalyticsRepository(mockDB)
+	stats, err := repo.GetDailyStats(context.Background(), userId, from, to)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.DailyStats{
+		{Day: from, Views: 10, Likes: 2, NewFollowers: 1},
+		{Day: to},
+	}, stats)
+}
+
+func TestGetTopPosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, first, second := uuid.New(), uuid.New(), uuid.New()
+	from, to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+	createdAt := from.Add(-time.Hour)
+	mock.ExpectQuery(`(?i)order by views desc, likes desc, comments desc`).
+		WithArgs(userId, "2025-05-01", "2025-05-31", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "created_at", "views", "likes", "comments"}).
+			AddRow(first.String(), "first", createdAt, 10, 2, 1).
+			AddRow(second.String(), nil, createdAt, 1, 0, 0))
+
+	repo := postgres.NewPostgresAnalyticsRepository(mockDB)
+	posts, err := repo.GetTopPosts(context.Background(), userId, from, to, 10)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.PostStats{
+		{PostId: first, Text: "first", CreatedAt: createdAt, Views: 10, Likes: 2, Comments: 1},
+		{PostId: second, CreatedAt: createdAt, Views: 1},
+	}, posts)
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+// insertBookmarkQuery saves the bookmark or moves existing one to another collection.
+// Nothing is saved if the collection does not belong to the user.
+const insertBookmarkQuery = `
+	insert into bookmark (user_id, post_id, collection_id, created_at)
+	select $1, $2, $3, $4
+	where $3::uuid is null or exists (select 1 from bookmark_collection where id = $3 and user_id = $1)
+	on conflict (user_id, post_id) do update set collection_id = excluded.collection_id
+`
+
+const deleteBookmarkQuery = `
+	delete from bookmark
+	where user_id = $1 and post_id = $2
+`
+
+// bookmarks of all collections are returned when $2 is null
+const getBookmarksOlderQuery = `
+	select post_id, collection_id, created_at
+	from bookmark
+	where user_id = $1 and ($2::uuid is null or collection_id = $2) and (created_at, post_id) < ($3::timestamptz, $4::uuid)
+	order by created_at desc, post_id desc
+	limit $5
+`
+
+const getBookmarkedPostsQuery = `
+	select post_id
+	from bookmark
+	where user_id = $1 and post_id = any($2::uuid[])
+`
+
+const insertBookmarkCollectionQuery = `
+	insert into bookmark_collection (id, user_id, name, created_at)
+	values ($1, $2, $3, $4)
+	on conflict (user_id, name) do nothing
+`
+
+const getBookmarkCollectionsQuery = `
+	select c.id, c.name, c.created_at, count(b.post_id)
+	from bookmark_collection c
+	left join bookmark b on b.collection_id = c.id
+	where c.user_id = $1
+	group by c.id
+	order by c.created_at, c.id
+`
+
+const deleteBookmarkCollectionQuery = `
+	delete from bookmark_collection
+	where id = $1 and user_id = $2
+`
+
+type PostgresBookmarkRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresBookmarkRepository creates new repository of bookmarks and their collections.
+func NewPostgresBookmarkRepository(connPool *sql.DB) *PostgresBookmarkRepository {
+	return &PostgresBookmarkRepository{connPool: connPool}
+}
+
+// AddBookmark saves the post to bookmarks of the user, bookmarked post is moved to the collection.
+// It returns usecase.ErrCollectionNotFound if the collection does not belong to the user.
+func (b *PostgresBookmarkRepository) AddBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID, collectionId uuid.UUID, now time.Time) error {
+	res, err := b.connPool.ExecContext(ctx, insertBookmarkQuery, userId, postId,
+		uuid.NullUUID{UUID: collectionId, Valid: collectionId != uuid.Nil}, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save bookmark of post %v by user %v: %s", postId, userId, err.Error()))
+		return fmt.Errorf("unable to save bookmark to database: %w", err)
+	}
+
+	saved, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to save bookmark to database: %w", err)
+	}
+	if saved == 0 {
+		return usecase.ErrCollectionNotFound
+	}
+	return nil
+}
+
+// RemoveBookmark removes the post from bookmarks of the user.
+// Removing a post that is not bookmarked is not an error.
+func (b *PostgresBookmarkRepository) RemoveBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	if _, err := b.connPool.ExecContext(ctx, deleteBookmarkQuery, userId, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to remove bookmark of post %v by user %v: %s", postId, userId, err.Error()))
+		return fmt.Errorf("unable to delete bookmark from database: %w", err)
+	}
+	return nil
+}
+
+// GetBookmarks returns bookmarks of the user saved before cursor, latest first.
+// Bookmarks of all collections are returned when collectionId is uuid.Nil.
+func (b *PostgresBookmarkRepository) GetBookmarks(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID, numBookmarks int, cursor models.Cursor) ([]models.Bookmark, error) {
+	rows, err := b.connPool.QueryContext(ctx, getBookmarksOlderQuery, userId,
+		uuid.NullUUID{UUID: collectionId, Valid: collectionId != uuid.Nil}, cursor.Ts, cursor.Id, numBookmarks)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get bookmarks of user %v in collection %v: %s", userId, collectionId, err.Error()))
+		return nil, fmt.Errorf("unable to get bookmarks from database: %w", err)
+	}
+	defer rows.Close()
+
+	var bookmarks []models.Bookmark
+	for rows.Next() {
+		var (
+			bookmark     models.Bookmark
+			collectionId uuid.NullUUID
+		)
+		if err = rows.Scan(&bookmark.PostId, &collectionId, &bookmark.CreatedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan bookmark of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get bookmarks from database: %w", err)
+		}
+		bookmark.CollectionId = collectionId.UUID
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks, rows.Err()
+}
+
+// GetBookmarkedPosts reports which of the given posts the user has bookmarked.
+// Posts that are not bookmarked are omitted.
+func (b *PostgresBookmarkRepository) GetBookmarkedPosts(ctx context.Context, userId uuid.UUID, postIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := b.connPool.QueryContext(ctx, getBookmarkedPostsQuery, userId, uuidsToStrings(postIds))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get bookmarks of user %v among posts %v: %s", userId, postIds, err.Error()))
+		return nil, fmt.Errorf("unable to get bookmarks from database: %w", err)
+	}
+	defer rows.Close()
+
+	bookmarked := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var postId uuid.UUID
+		if err = rows.Scan(&postId); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan bookmark of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get bookmarks from database: %w", err)
+		}
+		bookmarked[postId] = true
+	}
+	return bookmarked, rows.Err()
+}
+
+// CreateCollection saves new collection of bookmarks.
+// It returns usecase.ErrCollectionExists if the user already has a collection with the same name.
+func (b *PostgresBookmarkRepository) CreateCollection(ctx context.Context, collection models.BookmarkCollection) error {
+	res, err := b.connPool.ExecContext(ctx, insertBookmarkCollectionQuery, collection.Id, collection.UserId, collection.Name, collection.CreatedAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save collection %q of user %v: %s", collection.Name, collection.UserId, err.Error()))
+		return fmt.Errorf("unable to save collection to database: %w", err)
+	}
+
+	saved, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to save collection to database: %w", err)
+	}
+	if saved == 0 {
+		return usecase.ErrCollectionExists
+	}
+	return nil
+}
+
+// GetCollections returns collections of the user in order of creation with the number of posts in them.
+func (b *PostgresBookmarkRepository) GetCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error) {
+	rows, err := b.connPool.QueryContext(ctx, getBookmarkCollectionsQuery, userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get collections of user %v: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get collections from database: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []models.BookmarkCollection
+	for rows.Next() {
+		collection := models.BookmarkCollection{UserId: userId}
+		if err = rows.Scan(&collection.Id, &collection.Name, &collection.CreatedAt, &collection.PostCount); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan collection of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get collections from database: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	return collections, rows.Err()
+}
+
+// DeleteCollection removes the collection of the user, its bookmarks are kept outside of collections.
+// It returns usecase.ErrCollectionNotFound if the user has no such collection.
+func (b *PostgresBookmarkRepository) DeleteCollection(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID) error {
+	res, err := b.connPool.ExecContext(ctx, deleteBookmarkCollectionQuery, collectionId, userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete collection %v of user %v: %s", collectionId, userId, err.Error()))
+		return fmt.Errorf("unable to delete collection from database: %w", err)
+	}
+
+	deleted, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to delete collection from database: %w", err)
+	}
+	if deleted == 0 {
+		return usecase.ErrCollectionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+	"quickflow/internal/usecase"
+)
+
+func TestAddBookmark(t *testing.T) {
+	userId, postId, collectionId, now := uuid.New(), uuid.New(), uuid.New(), time.Now()
+
+	tests := []struct {
+		name         string
+		collectionId uuid.UUID
+		collection   interface{}
+		affected     int64
+		wantErr      error
+	}{
+		{name: "without collection", collectionId: uuid.Nil, collection: nil, affected: 1},
+		{name: "to own collection", collectionId: collectionId, collection: collectionId, affected: 1},
+		{name: "to collection of another user", collectionId: collectionId, collection: collectionId, wantErr: usecase.ErrCollectionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectExec(`(?i)insert into bookmark .* on conflict \(user_id, post_id\) do update set collection_id = excluded.collection_id`).
+				WithArgs(userId, postId, tt.collection, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			repo := postgres.NewPostgresBookmarkRepository(mockDB)
+			err = repo.AddBookmark(context.Background(), userId, postId, tt.collectionId, now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBookmarks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, collectionId := uuid.New(), uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+	saved := cursor.Ts.Add(-time.Hour)
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?i)select post_id, collection_id, created_at\s+from bookmark`).
+		WithArgs(userId, nil, cursor.Ts, cursor.Id, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "collection_id", "created_at"}).
+			AddRow(first.String(), collectionId.String(), saved).
+			AddRow(second.String(), nil, saved.Add(-time.Minute)))
+
+	repo := postgres.NewPostgresBookmarkRepository(mockDB)
+	bookmarks, err := repo.GetBookmarks(context.Background(), userId, uuid.Nil, 2, cursor)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.Bookmark{
+		{PostId: first, CollectionId: collectionId, CreatedAt: saved},
+		{PostId: second, CreatedAt: saved.Add(-time.Minute)},
+	}, bookmarks)
+}
+
+func TestGetBookmarkedPosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, bookmarked, other := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?i)select post_id\s+from bookmark`).
+		WithArgs(userId, []string{bookmarked.String(), other.String()}).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(bookmarked.String()))
+
+	repo := postgres.NewPostgresBookmarkRepository(mockDB)
+	got, err := repo.GetBookmarkedPosts(context.Background(), userId, []uuid.UUID{bookmarked, other})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, map[uuid.UUID]bool{bookmarked: true}, got)
+}
+
+func TestBookmarkCollections(t *testing.T) {
+	userId := uuid.New()
+	collection := models.BookmarkCollection{Id: uuid.New(), UserId: userId, Name: "Recipes", CreatedAt: time.Now()}
+
+	t.Run("create existing collection", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectExec(`(?i)insert into bookmark_collection .* on conflict \(user_id, name\) do nothing`).
+			WithArgs(collection.Id, userId, collection.Name, collection.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = postgres.NewPostgresBookmarkRepository(mockDB).CreateCollection(context.Background(), collection)
+		require.ErrorIs(t, err, usecase.ErrCollectionExists)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete collection of another user", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectExec(`(?i)delete from bookmark_collection`).
+			WithArgs(collection.Id, userId).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = postgres.NewPostgresBookmarkRepository(mockDB).DeleteCollection(context.Background(), userId, collection.Id)
+		require.ErrorIs(t, err, usecase.ErrCollectionNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get collections", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`(?i)select c.id, c.name, c.created_at, count\(b.post_id\)`).
+			WithArgs(userId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "count"}).
+				AddRow(collection.Id.String(), collection.Name, collection.CreatedAt, 3))
+
+		collections, err := postgres.NewPostgresBookmarkRepository(mockDB).GetCollections(context.Background(), userId)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		expected := collection
+		expected.PostCount = 3
+		require.Equal(t, []models.BookmarkCollection{expected}, collections)
+	})
+}
@@ -25,8 +25,9 @@ const (
         SELECT c.id, c.name, c.avatar_url, c.type, c.created_at, c.updated_at, cu.last_read
         FROM chat c
         join chat_user cu on c.id = cu.chat_id
-        WHERE cu.user_id = $1
-        ORDER BY c.updated_at DESC
+        WHERE cu.user_id = $1 AND (c.updated_at, c.id) < ($2::timestamptz, $3::uuid)
+        ORDER BY c.updated_at DESC, c.id DESC
+        LIMIT $4
 `
 
 	getChatQuery = `
@@ -91,9 +92,10 @@ func (c *ChatRepository) CreateChat(ctx context.Context, chat models.Chat) error
 	return nil
 }
 
-func (c *ChatRepository) GetUserChats(ctx context.Context, userId uuid.UUID) ([]models.Chat, error) {
+// GetUserChats returns user chats ordered by update time that are older than cursor.
+func (c *ChatRepository) GetUserChats(ctx context.Context, userId uuid.UUID, numChats int, cursor models.Cursor) ([]models.Chat, error) {
 	var chats []models.Chat
-	rows, err := c.ConnPool.QueryContext(ctx, getUserChatsQuery, userId)
+	rows, err := c.ConnPool.QueryContext(ctx, getUserChatsQuery, userId, cursor.Ts, cursor.Id, numChats)
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to get user %v chats from database: %s", userId, err.Error()))
 		return nil, err
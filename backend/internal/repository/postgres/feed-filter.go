@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	pgmodels "quickflow/internal/repository/postgres/postgres-models"
+	"quickflow/pkg/logger"
+)
+
+// hiding the post again keeps the time it was hidden first
+const insertHiddenPostQuery = `
+	insert into hidden_post (user_id, post_id, created_at)
+	values ($1, $2, $3)
+	on conflict (user_id, post_id) do nothing
+`
+
+const deleteHiddenPostQuery = `
+	delete from hidden_post
+	where user_id = $1 and post_id = $2
+`
+
+const getHiddenPostsOlderQuery = `
+	select post_id, created_at
+	from hidden_post
+	where user_id = $1 and (created_at, post_id) < ($2::timestamptz, $3::uuid)
+	order by created_at desc, post_id desc
+	limit $4
+`
+
+const insertMutedUserQuery = `
+	insert into muted_user (user_id, muted_id, created_at)
+	values ($1, $2, $3)
+	on conflict (user_id, muted_id) do nothing
+`
+
+const deleteMutedUserQuery = `
+	delete from muted_user
+	where user_id = $1 and muted_id = $2
+`
+
+const getMutedUsersQuery = `
+	select u.id, p.firstname, p.lastname, p.profile_avatar, u.username, u.last_seen, m.created_at
+	from muted_user m
+	join "user" u on u.id = m.muted_id
+	join profile p on p.id = u.id
+	where m.user_id = $1
+	order by m.created_at desc, u.id
+`
+
+type PostgresFeedFilterRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresFeedFilterRepository creates new repository of posts hidden and authors muted by users.
+func NewPostgresFeedFilterRepository(connPool *sql.DB) *PostgresFeedFilterRepository {
+	return &PostgresFeedFilterRepository{connPool: connPool}
+}
+
+// HidePost hides the post from the feed of the user, hiding a hidden post is not an error.
+func (f *PostgresFeedFilterRepository) HidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID, now time.Time) error {
+	if _, err := f.connPool.ExecContext(ctx, insertHiddenPostQuery, userId, postId, now); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to hide post %v from user %v: %s", postId, userId, err.Error()))
+		return fmt.Errorf("unable to save hidden post to database: %w", err)
+	}
+	return nil
+}
+
+// UnhidePost returns the post to the feed of the user, unhiding a post that is not hidden is not an error.
+func (f *PostgresFeedFilterRepository) UnhidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	if _, err := f.connPool.ExecContext(ctx, deleteHiddenPostQuery, userId, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to unhide post %v from user %v: %s", postId, userId, err.Error()))
+		return fmt.Errorf("unable to delete hidden post from database: %w", err)
+	}
+	return nil
+}
+
+// GetHiddenPosts returns posts hidden by the user before cursor, latest first.
+func (f *PostgresFeedFilterRepository) GetHiddenPosts(ctx context.Context, userId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.HiddenPost, error) {
+	rows, err := f.connPool.QueryContext(ctx, getHiddenPostsOlderQuery, userId, cursor.Ts, cursor.Id, numPosts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts hidden by user %v: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get hidden posts from database: %w", err)
+	}
+	defer rows.Close()
+
+	var hidden []models.HiddenPost
+	for rows.Next() {
+		var post models.HiddenPost
+		if err = rows.Scan(&post.PostId, &post.CreatedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan post hidden by user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get hidden posts from database: %w", err)
+		}
+		hidden = append(hidden, post)
+	}
+	return hidden, rows.Err()
+}
+
+// MuteUser hides posts of the author from the feed of the user, muting a muted author is not an error.
+func (f *PostgresFeedFilterRepository) MuteUser(ctx context.Context, userId uuid.UUID, mutedId uuid.UUID, now time.Time) error {
+	if _, err := f.connPool.ExecContext(ctx, insertMutedUserQuery, userId, mutedId, now); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to mute user %v for user %v: %s", mutedId, userId, err.Error()))
+		return fmt.Errorf("unable to save muted user to database: %w", err)
+	}
+	return nil
+}
+
+// UnmuteUser returns posts of the author to the feed of the user, unmuting an author that is not muted is not an error.
+func (f *PostgresFeedFilterRepository) UnmuteUser(ctx context.Context, userId uuid.UUID, mutedId uuid.UUID) error {
+	if _, err := f.connPool.ExecContext(ctx, deleteMutedUserQuery, userId, mutedId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to unmute user %v for user %v: %s", mutedId, userId, err.Error()))
+		return fmt.Errorf("unable to delete muted user from database: %w", err)
+	}
+	return nil
+}
+
+// GetMutedUsers returns authors muted by the user, latest first.
+func (f *PostgresFeedFilterRepository) GetMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error) {
+	rows, err := f.connPool.QueryContext(ctx, getMutedUsersQuery, userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get users muted by user %v: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get muted users from database: %w", err)
+	}
+	defer rows.Close()
+
+	var muted []models.MutedUser
+	for rows.Next() {
+		var (
+			info      pgmodels.PublicUserInfoPostgres
+			createdAt time.Time
+		)
+		err = rows.Scan(&info.Id, &info.Firstname, &info.Lastname, &info.AvatarURL, &info.Username, &info.LastSeen, &createdAt)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan user muted by user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get muted users from database: %w", err)
+		}
+		muted = append(muted, models.MutedUser{User: info.ConvertToPublicUserInfo(), CreatedAt: createdAt})
+	}
+	return muted, rows.Err()
+}
@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+)
+
+func TestHidePost(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, postId, now := uuid.New(), uuid.New(), time.Now()
+	mock.ExpectExec(`(?i)insert into hidden_post .* on conflict \(user_id, post_id\) do nothing`).
+		WithArgs(userId, postId, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := postgres.NewPostgresFeedFilterRepository(mockDB)
+	require.NoError(t, repo.HidePost(context.Background(), userId, postId, now))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHiddenPosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+	hiddenAt := cursor.Ts.Add(-time.Hour)
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?i)select post_id, created_at\s+from hidden_post`).
+		WithArgs(userId, cursor.Ts, cursor.Id, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "created_at"}).
+			AddRow(first.String(), hiddenAt).
+			AddRow(second.String(), hiddenAt.Add(-time.Minute)))
+
+	repo := postgres.NewPostgresFeedFilterRepository(mockDB)
+	hidden, err := repo.GetHiddenPosts(context.Background(), userId, 2, cursor)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.HiddenPost{
+		{PostId: first, CreatedAt: hiddenAt},
+		{PostId: second, CreatedAt: hiddenAt.Add(-time.Minute)},
+	}, hidden)
+}
+
+func TestGetMutedUsers(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, mutedId := uuid.New(), uuid.New()
+	lastSeen, mutedAt := time.Now().Add(-time.Hour), time.Now().Add(-time.Minute)
+	mock.ExpectQuery(`(?i)from muted_user m\s+join "user" u on u.id = m.muted_id`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "firstname", "lastname", "profile_avatar", "username", "last_seen", "created_at"}).
+			AddRow(mutedId.String(), "Ivan", "Ivanov", nil, "ivan", lastSeen, mutedAt))
+
+	repo := postgres.NewPostgresFeedFilterRepository(mockDB)
+	muted, err := repo.GetMutedUsers(context.Background(), userId)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.MutedUser{{
+		User:      models.PublicUserInfo{Id: mutedId, Username: "ivan", Firstname: "Ivan", Lastname: "Ivanov", LastSeen: lastSeen},
+		CreatedAt: mutedAt,
+	}}, muted)
+}
+
+func TestFeedQueriesSkipHiddenAndMuted(t *testing.T) {
+	viewerId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+
+	tests := []struct {
+		name  string
+		query string
+		fetch func(repo *postgres.PostgresPostRepository) error
+	}{
+		{
+			name:  "feed",
+			query: `(?i)with followed_by_user as .*hidden_post h\s+where h.user_id = \$1 .*muted_user m\s+where m.user_id = \$1 `,
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetPostsForUId(context.Background(), viewerId, 10, cursor)
+				return err
+			},
+		},
+		{
+			name:  "ranked recommendations",
+			query: `(?i)where p.id = any\(\$1::uuid\[\]\) .*hidden_post h\s+where h.user_id = \$2 .*muted_user m\s+where m.user_id = \$2 `,
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetFeedPostsByIds(context.Background(), []uuid.UUID{uuid.New()}, viewerId)
+				return err
+			},
+		},
+		{
+			name:  "chronological recommendations",
+			query: `(?i)p.creator_id <> \$3 .*hidden_post h\s+where h.user_id = \$3 .*muted_user m\s+where m.user_id = \$3 `,
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetRecommendationsForUId(context.Background(), viewerId, 10, cursor)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectQuery(tt.query).WillReturnRows(sqlmock.NewRows(postColumns))
+
+			require.NoError(t, tt.fetch(postgres.NewPostgresPostRepository(mockDB)))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRecommendationCandidatesSkipHiddenAndMuted(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery(`(?i)p.creator_id <> \$1 .*hidden_post h\s+where h.user_id = \$1 .*muted_user m\s+where m.user_id = \$1 `).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "creator_id", "created_at", "like_count", "comment_count", "repost_count", "affinity"}))
+
+	repo := postgres.NewPostgresRecommendationRepository(mockDB)
+	_, err = repo.GetRecommendationCandidates(context.Background(), uuid.New(), time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
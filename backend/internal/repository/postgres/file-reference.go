@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+// getReferencedFilesQuery matches object names against every column that stores file URLs.
+// URLs are saved as <public root>/<bucket>/<name>, so only the last path segment is compared.
+const getReferencedFilesQuery = `
+	with refs as (
+		select file_url as url from post_file
+		union all
+		select file_url from post_revision_file
+		union all
+		select file_url from message_file
+		union all
+		select profile_avatar from profile where profile_avatar is not null
+		union all
+		select profile_background from profile where profile_background is not null
+		union all
+		select avatar_url from chat where avatar_url is not null
+		union all
+		-- deduplicated files that were just reused are not referenced yet
+		select url from stored_file where used_at >= now() - ` + storedFileLease + `
+	)
+	select distinct regexp_replace(url, '^.*/', '') as name
+	from refs
+	where regexp_replace(url, '^.*/', '') = any($1::text[]);
+`
+
+// fileURLColumns maps purpose of files to table and column their URLs are stored in.
+var fileURLColumns = map[models.FilePurpose]struct{ table, column string }{
+	models.FilePurposePost:       {table: "post_file", column: "file_url"},
+	models.FilePurposeAttachment: {table: "message_file", column: "file_url"},
+	models.FilePurposeAvatar:     {table: "profile", column: "profile_avatar"},
+	models.FilePurposeCover:      {table: "profile", column: "profile_background"},
+	models.FilePurposeChatAvatar: {table: "chat", column: "avatar_url"},
+}
+
+type PostgresFileReferenceRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresFileReferenceRepository creates new file reference repository.
+func NewPostgresFileReferenceRepository(connPool *sql.DB) *PostgresFileReferenceRepository {
+	return &PostgresFileReferenceRepository{connPool: connPool}
+}
+
+// GetReferencedFiles returns names among fileNames that are still used by posts, post revisions, messages, profiles or chats.
+func (r *PostgresFileReferenceRepository) GetReferencedFiles(ctx context.Context, fileNames []string) ([]string, error) {
+	if len(fileNames) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.connPool.QueryContext(ctx, getReferencedFilesQuery, fileNames)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get referenced files from database: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get referenced files from database: %w", err)
+	}
+	defer rows.Close()
+
+	var referenced []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan referenced file name: %s", err.Error()))
+			return nil, fmt.Errorf("unable to scan referenced file name: %w", err)
+		}
+		referenced = append(referenced, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to iterate referenced files: %w", err)
+	}
+
+	return referenced, nil
+}
+
+// GetFileURLs returns distinct URLs of all files of given purpose.
+func (r *PostgresFileReferenceRepository) GetFileURLs(ctx context.Context, purpose models.FilePurpose) ([]string, error) {
+	ref, ok := fileURLColumns[purpose]
+	if !ok {
+		return nil, fmt.Errorf("unknown file purpose %q", purpose)
+	}
+
+	query := fmt.Sprintf(`select distinct %[2]s from %[1]s where %[2]s is not null and %[2]s <> ''`, ref.table, ref.column)
+	rows, err := r.connPool.QueryContext(ctx, query)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get %s file urls from database: %s", purpose, err.Error()))
+		return nil, fmt.Errorf("unable to get file urls from database: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err = rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("unable to scan file url: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to iterate file urls: %w", err)
+	}
+	return urls, nil
+}
+
+// ReplaceFileURL changes every reference to file of given purpose from oldURL to newURL.
+func (r *PostgresFileReferenceRepository) ReplaceFileURL(ctx context.Context, purpose models.FilePurpose, oldURL, newURL string) error {
+	ref, ok := fileURLColumns[purpose]
+	if !ok {
+		return fmt.Errorf("unknown file purpose %q", purpose)
+	}
+
+	query := fmt.Sprintf(`update %[1]s set %[2]s = $2 where %[2]s = $1`, ref.table, ref.column)
+	if _, err := r.connPool.ExecContext(ctx, query, oldURL, newURL); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to replace %s file url %v: %s", purpose, oldURL, err.Error()))
+		return fmt.Errorf("unable to replace file url: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+)
+
+func TestGetReferencedFiles(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(relationsArrayConverter{}))
+	if err != nil {
+		t.Fatalf("Failed to open mock DB: %v", err)
+	}
+	defer mockDB.Close()
+
+	repo := NewPostgresFileReferenceRepository(mockDB)
+
+	mock.ExpectQuery(`select distinct regexp_replace`).
+		WithArgs([]string{"a.jpg", "b.png"}).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a.jpg"))
+
+	got, err := repo.GetReferencedFiles(context.Background(), []string{"a.jpg", "b.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.jpg"}, got)
+
+	// file kept only by revision history of an edited post is still referenced,
+	// as well as a deduplicated file that is leased but not referenced yet
+	mock.ExpectQuery(`(?s)select file_url from post_revision_file.*select url from stored_file where used_at >= now\(\) - interval '1 hour'.*select distinct regexp_replace`).
+		WithArgs([]string{"old.jpg"}).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("old.jpg"))
+
+	got, err = repo.GetReferencedFiles(context.Background(), []string{"old.jpg"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old.jpg"}, got)
+
+	mock.ExpectQuery(`select distinct regexp_replace`).
+		WithArgs([]string{"c.jpg"}).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetReferencedFiles(context.Background(), []string{"c.jpg"})
+	assert.Error(t, err)
+
+	// empty input does not touch database
+	got, err = repo.GetReferencedFiles(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileURLs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to open mock DB: %v", err)
+	}
+	defer mockDB.Close()
+
+	repo := NewPostgresFileReferenceRepository(mockDB)
+
+	mock.ExpectQuery(`select distinct profile_avatar from profile`).
+		WillReturnRows(sqlmock.NewRows([]string{"profile_avatar"}).AddRow("http://minio/posts/a.jpg"))
+	urls, err := repo.GetFileURLs(context.Background(), models.FilePurposeAvatar)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://minio/posts/a.jpg"}, urls)
+
+	mock.ExpectExec(`update message_file set file_url = \$2 where file_url = \$1`).
+		WithArgs("http://minio/posts/b.pdf", "http://minio/attachments/b.pdf").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = repo.ReplaceFileURL(context.Background(), models.FilePurposeAttachment, "http://minio/posts/b.pdf", "http://minio/attachments/b.pdf")
+	require.NoError(t, err)
+
+	_, err = repo.GetFileURLs(context.Background(), models.FilePurpose("unknown"))
+	assert.Error(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
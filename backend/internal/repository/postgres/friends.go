@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"quickflow/internal/models"
 	postgresModels "quickflow/internal/repository/postgres/postgres-models"
@@ -33,12 +34,13 @@ const (
 			p.profile_avatar, 
 			univ.name
 		from "user" u
+		join friends fr on fr.friend_id = u.id
 		join profile p on u.id = p.id
 		left join education e on e.profile_id = p.id
 		left join faculty f on f.id = e.faculty_id
 		left join university univ on f.university_id = univ.id
-		where u.id in (select friend_id from friends)
-		order by p.lastname, p.firstname
+		where $7::uuid is null or (p.lastname, p.firstname, u.id) > ($5::text, $6::text, $7::uuid)
+		order by p.lastname, p.firstname, u.id
 		limit $2
 		offset $3
 	`
@@ -54,6 +56,12 @@ const (
 		where (user1_id = $1 and user2_id = $2) or (user1_id = $2 and user2_id = $1)
 	`
 
+	GetUserRelationsQuery = `
+		select user1_id, user2_id, status
+		from friendship
+		where (user1_id = $1 and user2_id = any($2::uuid[])) or (user2_id = $1 and user1_id = any($2::uuid[]))
+	`
+
 	UpdateFriendRequestQuery = `
 		update friendship
 		set status = $3
@@ -93,10 +101,12 @@ func (p *PostgresFriendsRepository) Close() {
 }
 
 // GetFriendsPublicInfo Отдает структуру с информацией по друзьям + флаг hasMore, который говорит - остались ли еще друзья + ошибку
-func (p *PostgresFriendsRepository) GetFriendsPublicInfo(ctx context.Context, userID string, limit int, offset int) ([]models.FriendInfo, bool, int, error) {
+// Друзья отдаются по алфавиту, страница задается курсором (если он не пустой) либо смещением
+func (p *PostgresFriendsRepository) GetFriendsPublicInfo(ctx context.Context, userID string, limit int, offset int, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error) {
 	logger.Info(ctx, fmt.Sprintf("Trying to get friends info for user %s", userID))
 
-	rows, err := p.connPool.QueryContext(ctx, GetFriendsInfoQuery, userID, limit+1, offset, models.RelationFriend)
+	rows, err := p.connPool.QueryContext(ctx, GetFriendsInfoQuery, userID, limit+1, offset, models.RelationFriend,
+		cursor.Lastname, cursor.Firstname, pgtype.UUID{Bytes: cursor.Id, Valid: !cursor.IsZero()})
 	friendsInfo := make([]models.FriendInfo, 0)
 
 	if err != nil {
@@ -285,3 +295,45 @@ func (p *PostgresFriendsRepository) GetUserRelation(ctx context.Context, user1 u
 	}
 	return status, nil
 }
+
+// GetUserRelations returns relations of user to each of others using one query.
+// Users that have no relation with user are absent in the result.
+func (p *PostgresFriendsRepository) GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error) {
+	ids := make([]string, 0, len(others))
+	for _, id := range others {
+		ids = append(ids, id.String())
+	}
+
+	rows, err := p.connPool.QueryContext(ctx, GetUserRelationsQuery, user, ids)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get relations of user %s: %v", user, err))
+		return nil, fmt.Errorf("unable to get relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := make(map[uuid.UUID]models.UserRelation, len(others))
+	for rows.Next() {
+		var (
+			user1, user2 uuid.UUID
+			status       models.UserRelation
+		)
+		if err = rows.Scan(&user1, &user2, &status); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan relation of user %s: %v", user, err))
+			return nil, fmt.Errorf("unable to get relations: %w", err)
+		}
+
+		// status is stored from the point of view of user1
+		other := user2
+		if user1 != user {
+			other = user1
+			if status == models.RelationFollowedBy {
+				status = models.RelationFollowing
+			} else if status == models.RelationFollowing {
+				status = models.RelationFollowedBy
+			}
+		}
+		relations[other] = status
+	}
+
+	return relations, rows.Err()
+}
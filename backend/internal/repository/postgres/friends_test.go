@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"testing"
 
@@ -32,7 +33,7 @@ func TestGetFriendsPublicInfo(t *testing.T) {
 			offset: 0,
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(`with friends as \(.*\)`).
-					WithArgs("user1", 6, 0, models.RelationFriend).
+					WithArgs("user1", 6, 0, models.RelationFriend, "", "", sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "firstname", "lastname", "profile_avatar", "name"}).
 						AddRow(uuid_, "johndoe", "John", "Doe", "http://avatar.url", "Some University"))
 
@@ -62,7 +63,7 @@ func TestGetFriendsPublicInfo(t *testing.T) {
 			offset: 0,
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(`with friends as \(.*\)`).
-					WithArgs("user1", 6, 0, models.RelationFriend).
+					WithArgs("user1", 6, 0, models.RelationFriend, "", "", sqlmock.AnyArg()).
 					WillReturnError(fmt.Errorf("query failed"))
 			},
 			want:        []models.FriendInfo{},
@@ -83,7 +84,7 @@ func TestGetFriendsPublicInfo(t *testing.T) {
 			repo := &PostgresFriendsRepository{connPool: mockDB}
 			tt.mock(mock)
 
-			got, hasMore, count, err := repo.GetFriendsPublicInfo(context.Background(), tt.userID, tt.limit, tt.offset)
+			got, hasMore, count, err := repo.GetFriendsPublicInfo(context.Background(), tt.userID, tt.limit, tt.offset, models.NameCursor{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetFriendsPublicInfo() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -269,3 +270,44 @@ func TestUnfollow(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserRelations(t *testing.T) {
+	user := uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	lower := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	higher := uuid.MustParse("00000000-0000-0000-0000-000000000009")
+	friend := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(relationsArrayConverter{}))
+	if err != nil {
+		t.Fatalf("Failed to open mock DB: %v", err)
+	}
+	defer mockDB.Close()
+
+	// statuses are stored from the point of view of user1
+	mock.ExpectQuery(`select user1_id, user2_id, status`).
+		WithArgs(user, []string{lower.String(), higher.String(), friend.String()}).
+		WillReturnRows(sqlmock.NewRows([]string{"user1_id", "user2_id", "status"}).
+			AddRow(lower.String(), user.String(), models.RelationFollowing).
+			AddRow(user.String(), higher.String(), models.RelationFollowing).
+			AddRow(friend.String(), user.String(), models.RelationFriend))
+
+	repo := &PostgresFriendsRepository{connPool: mockDB}
+	got, err := repo.GetUserRelations(context.Background(), user, []uuid.UUID{lower, higher, friend})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]models.UserRelation{
+		lower:  models.RelationFollowedBy,
+		higher: models.RelationFollowing,
+		friend: models.RelationFriend,
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type relationsArrayConverter struct{}
+
+func (relationsArrayConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if values, ok := v.([]string); ok {
+		return values, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
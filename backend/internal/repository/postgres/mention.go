@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+const insertPostMentionsQuery = `
+	insert into post_mention (post_id, user_id, "offset", length)
+	select $1, m.user_id, m."offset", m.length
+	from unnest($2::uuid[], $3::int[], $4::int[]) as m(user_id, "offset", length)
+	on conflict do nothing
+`
+
+const insertMessageMentionsQuery = `
+	insert into message_mention (message_id, user_id, "offset", length)
+	select $1, m.user_id, m."offset", m.length
+	from unnest($2::uuid[], $3::int[], $4::int[]) as m(user_id, "offset", length)
+	on conflict do nothing
+`
+
+// mentioned users are joined to render mentions with their current usernames
+const getPostsMentionsQuery = `
+	select pm.post_id, pm.user_id, u.username, pm."offset", pm.length
+	from post_mention pm
+	join "user" u on u.id = pm.user_id
+	where pm.post_id = any($1::uuid[])
+	order by pm."offset"
+`
+
+const getMessagesMentionsQuery = `
+	select mm.message_id, mm.user_id, u.username, mm."offset", mm.length
+	from message_mention mm
+	join "user" u on u.id = mm.user_id
+	where mm.message_id = any($1::uuid[])
+	order by mm."offset"
+`
+
+// execer is satisfied by both connection pool and transaction.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertMentions saves mentions of the post or message with given id by insert*MentionsQuery.
+func insertMentions(ctx context.Context, db execer, query string, id uuid.UUID, mentions []models.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	userIds := make([]string, 0, len(mentions))
+	offsets := make([]int32, 0, len(mentions))
+	lengths := make([]int32, 0, len(mentions))
+	for _, m := range mentions {
+		userIds = append(userIds, m.UserId.String())
+		offsets = append(offsets, int32(m.Offset))
+		lengths = append(lengths, int32(m.Length))
+	}
+
+	_, err := db.ExecContext(ctx, query, id, userIds, offsets, lengths)
+	return err
+}
+
+// getMentions returns mentions of posts or messages with given ids by get*MentionsQuery,
+// grouped by the id of the text they are in.
+func getMentions(ctx context.Context, db *sql.DB, query string, ids []string) (map[uuid.UUID][]models.Mention, error) {
+	rows, err := db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mentions := make(map[uuid.UUID][]models.Mention)
+	for rows.Next() {
+		var (
+			id uuid.UUID
+			m  models.Mention
+		)
+		if err = rows.Scan(&id, &m.UserId, &m.Username, &m.Offset, &m.Length); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		mentions[id] = append(mentions[id], m)
+	}
+	return mentions, rows.Err()
+}
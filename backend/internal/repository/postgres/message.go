@@ -21,15 +21,16 @@ const (
     getMessagesForChatOlderQuery = `
         SELECT id, chat_id, sender_id, text, created_at, updated_at
         FROM message
-        WHERE chat_id = $1 AND created_at < $2
-        ORDER BY created_at desc 
+        WHERE chat_id = $1 AND (created_at, id) < ($2::timestamptz, $4::uuid)
+        ORDER BY created_at desc, id desc
         LIMIT $3
     `
 
     getFilesQuery = `
-        SELECT file_url
-        FROM message_file
-        WHERE message_id = $1
+        SELECT mf.file_url, sf.media_type, sf.mime_type, sf.duration_ms, sf.width, sf.height
+        FROM message_file mf
+        LEFT JOIN stored_file sf ON sf.url = mf.file_url
+        WHERE mf.message_id = $1
 `
     saveMessageQuery = `
         INSERT INTO message (id, chat_id, sender_id, text, created_at, updated_at)
@@ -76,9 +77,9 @@ func (m *MessageRepository) Close() {
 }
 
 func (m *MessageRepository) GetMessagesForChatOlder(ctx context.Context, chatId uuid.UUID,
-    numMessages int, timestamp time.Time) ([]models.Message, error) {
+    numMessages int, cursor models.Cursor) ([]models.Message, error) {
     rows, err := m.connPool.QueryContext(ctx, getMessagesForChatOlderQuery, pgtype.UUID{Bytes: chatId, Valid: true},
-        pgtype.Timestamptz{Time: timestamp, Valid: true}, numMessages)
+        pgtype.Timestamptz{Time: cursor.Ts, Valid: true}, numMessages, pgtype.UUID{Bytes: cursor.Id, Valid: true})
     if err != nil {
         return nil, err
     }
@@ -89,8 +90,8 @@ func (m *MessageRepository) GetMessagesForChatOlder(ctx context.Context, chatId
         var messagePostgres pgmodels.MessagePostgres
         if err := rows.Scan(&messagePostgres.ID, &messagePostgres.ChatID, &messagePostgres.SenderID,
             &messagePostgres.Text, &messagePostgres.CreatedAt, &messagePostgres.UpdatedAt); err != nil {
-            logger.Error(ctx, fmt.Sprintf("Unable to scan message from database for chat %v, numMessages %v, timestamp %v: %v",
-                chatId, numMessages, timestamp, err))
+            logger.Error(ctx, fmt.Sprintf("Unable to scan message from database for chat %v, numMessages %v, cursor %v: %v",
+                chatId, numMessages, cursor, err))
             return nil, err
         }
 
@@ -101,20 +102,44 @@ func (m *MessageRepository) GetMessagesForChatOlder(ctx context.Context, chatId
             return nil, err
         }
         for files.Next() {
-            var fileURL pgtype.Text
-            err = files.Scan(&fileURL)
+            var (
+                fileURL pgtype.Text
+                media   pgmodels.MediaPostgres
+            )
+            err = files.Scan(&fileURL, &media.MediaType, &media.MimeType, &media.DurationMs, &media.Width, &media.Height)
             if err != nil {
                 logger.Error(ctx, fmt.Sprintf("Unable to scan file URL for message %v: %v", messagePostgres.ID, err))
                 return nil, err
             }
             if fileURL.Valid {
                 message.AttachmentURLs = append(message.AttachmentURLs, fileURL.String)
+                if info, ok := media.ToMediaInfo(); ok {
+                    if message.Media == nil {
+                        message.Media = make(map[string]models.MediaInfo)
+                    }
+                    message.Media[fileURL.String] = info
+                }
             }
         }
         files.Close()
 
         messages = slices.Insert(messages, 0, message)
     }
+
+    if len(messages) > 0 {
+        ids := make([]string, 0, len(messages))
+        for _, message := range messages {
+            ids = append(ids, message.ID.String())
+        }
+        mentions, err := getMentions(ctx, m.connPool, getMessagesMentionsQuery, ids)
+        if err != nil {
+            logger.Error(ctx, fmt.Sprintf("Unable to get mentions of messages for chat %v: %v", chatId, err))
+            return nil, err
+        }
+        for i := range messages {
+            messages[i].Mentions = mentions[messages[i].ID]
+        }
+    }
     logger.Info(ctx, fmt.Sprintf("Fetched %d messages for chat %s", len(messages), chatId))
 
     return messages, nil
@@ -138,6 +163,12 @@ func (m *MessageRepository) SaveMessage(ctx context.Context, message models.Mess
         }
     }
 
+    err = insertMentions(ctx, m.connPool, insertMessageMentionsQuery, message.ID, message.Mentions)
+    if err != nil {
+        logger.Error(ctx, fmt.Sprintf("Unable to save mentions of message %v to database: %s", message.ID, err.Error()))
+        return fmt.Errorf("unable to save message mentions to database: %w", err)
+    }
+
     _, err = m.connPool.ExecContext(ctx, `update chat set updated_at = $1 where id = $2`,
         messagePostgres.UpdatedAt, messagePostgres.ChatID)
     if err != nil {
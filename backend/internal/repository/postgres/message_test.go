@@ -171,7 +171,7 @@ func TestMessageRepository(t *testing.T) {
 			case "success mark message as read", "db error on mark message as read":
 				err = repo.UpdateLastMessageRead(ctx, tt.message.ID)
 			case "success get messages for chat", "db error on get messages for chat":
-				_, err = repo.GetMessagesForChatOlder(ctx, tt.message.ChatID, 10, time.Now())
+				_, err = repo.GetMessagesForChatOlder(ctx, tt.message.ChatID, 10, models.CursorFromTs(time.Now()))
 			case "success get last chat message", "db error on get last chat message":
 				_, err = repo.GetLastChatMessage(ctx, tt.message.ChatID)
 			case "success delete message", "db error on delete message":
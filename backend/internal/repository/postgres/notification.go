@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	pgmodels "quickflow/internal/repository/postgres/postgres-models"
+	"quickflow/pkg/logger"
+)
+
+// notifyPostMentionsQuery notifies users mentioned in published posts, except their authors.
+// Users already notified about a post are skipped, so edits only notify newly mentioned users.
+const notifyPostMentionsQuery = `
+	insert into notification (id, user_id, type, actor_id, post_id, created_at)
+	select gen_random_uuid(), pm.user_id, 'post_mention', p.creator_id, p.id, $2
+	from (select distinct post_id, user_id from post_mention where post_id = any($1::uuid[])) pm
+	join post p on p.id = pm.post_id
+	where p.status = 'published' and pm.user_id <> p.creator_id
+	on conflict (user_id, post_id) where type = 'post_mention' do nothing
+`
+
+const notifyMessageMentionsQuery = `
+	insert into notification (id, user_id, type, actor_id, message_id, created_at)
+	select gen_random_uuid(), mm.user_id, 'message_mention', m.sender_id, m.id, $2
+	from (select distinct message_id, user_id from message_mention where message_id = $1) mm
+	join message m on m.id = mm.message_id
+	where mm.user_id <> m.sender_id
+`
+
+const getNotificationsOlderQuery = `
+	select n.id, n.user_id, n.type, n.actor_id, n.post_id, n.message_id, m.chat_id, n.created_at, n.read_at
+	from notification n
+	left join message m on m.id = n.message_id
+	where n.user_id = $1 and (n.created_at, n.id) < ($2::timestamptz, $4::uuid)
+	order by n.created_at desc, n.id desc
+	limit $3
+`
+
+const countUnreadNotificationsQuery = `
+	select count(*) from notification where user_id = $1 and read_at is null
+`
+
+const markNotificationsReadQuery = `
+	update notification
+	set read_at = $3
+	where user_id = $1 and read_at is null and created_at <= $2
+`
+
+type PostgresNotificationRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresNotificationRepository creates new notification repository.
+func NewPostgresNotificationRepository(connPool *sql.DB) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{connPool: connPool}
+}
+
+// NotifyPostMentions notifies users mentioned in given published posts and returns the number of notifications created.
+func (n *PostgresNotificationRepository) NotifyPostMentions(ctx context.Context, postIds []uuid.UUID, now time.Time) (int, error) {
+	res, err := n.connPool.ExecContext(ctx, notifyPostMentionsQuery, uuidsToStrings(postIds), now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to notify users mentioned in posts %v: %s", postIds, err.Error()))
+		return 0, fmt.Errorf("unable to save notifications to database: %w", err)
+	}
+	return affectedRows(res)
+}
+
+// NotifyMessageMentions notifies users mentioned in the message and returns the number of notifications created.
+func (n *PostgresNotificationRepository) NotifyMessageMentions(ctx context.Context, messageId uuid.UUID, now time.Time) (int, error) {
+	res, err := n.connPool.ExecContext(ctx, notifyMessageMentionsQuery, messageId, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to notify users mentioned in message %v: %s", messageId, err.Error()))
+		return 0, fmt.Errorf("unable to save notifications to database: %w", err)
+	}
+	return affectedRows(res)
+}
+
+func affectedRows(res sql.Result) (int, error) {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get affected rows: %w", err)
+	}
+	return int(affected), nil
+}
+
+// GetNotifications returns notifications of the user older than cursor, newest first.
+func (n *PostgresNotificationRepository) GetNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error) {
+	rows, err := n.connPool.QueryContext(ctx, getNotificationsOlderQuery, userId, cursor.Ts, numNotifications, cursor.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get notifications of user %v, cursor %v: %s", userId, cursor, err.Error()))
+		return nil, fmt.Errorf("unable to get notifications from database: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var notification pgmodels.NotificationPostgres
+		err = rows.Scan(&notification.Id, &notification.UserId, &notification.Type, &notification.ActorId,
+			&notification.PostId, &notification.MessageId, &notification.ChatId, &notification.CreatedAt, &notification.ReadAt)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan notification of user %v: %s", userId, err.Error()))
+			return nil, fmt.Errorf("unable to get notifications from database: %w", err)
+		}
+		notifications = append(notifications, notification.ToNotification())
+	}
+	return notifications, rows.Err()
+}
+
+// CountUnreadNotifications returns the number of notifications the user has not read yet.
+func (n *PostgresNotificationRepository) CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error) {
+	var count int
+	if err := n.connPool.QueryRowContext(ctx, countUnreadNotificationsQuery, userId).Scan(&count); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to count unread notifications of user %v: %s", userId, err.Error()))
+		return 0, fmt.Errorf("unable to count notifications in database: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationsRead marks unread notifications of the user created up to upTo as read at readAt.
+func (n *PostgresNotificationRepository) MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo time.Time, readAt time.Time) error {
+	if _, err := n.connPool.ExecContext(ctx, markNotificationsReadQuery, userId, upTo, readAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to mark notifications of user %v read: %s", userId, err.Error()))
+		return fmt.Errorf("unable to update notifications in database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+)
+
+var notificationColumns = []string{"id", "user_id", "type", "actor_id", "post_id", "message_id", "chat_id", "created_at", "read_at"}
+
+func TestNotifyPostMentions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	postId, now := uuid.New(), time.Now()
+	mock.ExpectExec(`(?i)insert into notification .* from \(select distinct post_id, user_id from post_mention .*\) pm\s+join post p .*on conflict \(user_id, post_id\) where type = 'post_mention' do nothing`).
+		WithArgs([]string{postId.String()}, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := postgres.NewPostgresNotificationRepository(mockDB)
+	created, err := repo.NotifyPostMentions(context.Background(), []uuid.UUID{postId}, now)
+	require.NoError(t, err)
+	require.Equal(t, 2, created)
+
+	mock.ExpectExec(`(?i)insert into notification`).WillReturnError(errors.New("db error"))
+	_, err = repo.NotifyPostMentions(context.Background(), []uuid.UUID{postId}, now)
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNotifications(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, actorId, messageId, chatId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	postNotification := models.Notification{
+		Id: uuid.New(), UserId: userId, Type: models.NotificationPostMention, ActorId: actorId, PostId: uuid.New(),
+		CreatedAt: time.Now().UTC().Truncate(time.Second), ReadAt: time.Now().UTC().Truncate(time.Second),
+	}
+	messageNotification := models.Notification{
+		Id: uuid.New(), UserId: userId, Type: models.NotificationMessageMention, ActorId: actorId, MessageId: messageId, ChatId: chatId,
+		CreatedAt: time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+	}
+
+	cursor := models.CursorFromTs(time.Now())
+	mock.ExpectQuery(`(?i)select n.id, .* from notification n\s+left join message m on m.id = n.message_id\s+where n.user_id = \$1`).
+		WithArgs(userId, cursor.Ts, 10, cursor.Id).
+		WillReturnRows(sqlmock.NewRows(notificationColumns).
+			AddRow(postNotification.Id.String(), userId.String(), "post_mention", actorId.String(), postNotification.PostId.String(), nil, nil,
+				postNotification.CreatedAt, postNotification.ReadAt).
+			AddRow(messageNotification.Id.String(), userId.String(), "message_mention", actorId.String(), nil, messageId.String(), chatId.String(),
+				messageNotification.CreatedAt, nil))
+
+	repo := postgres.NewPostgresNotificationRepository(mockDB)
+	got, err := repo.GetNotifications(context.Background(), userId, 10, cursor)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, got, 2)
+	require.Equal(t, postNotification, got[0])
+	require.Equal(t, messageNotification, got[1])
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	userId, upTo, readAt := uuid.New(), time.Now().Add(-time.Minute), time.Now()
+	mock.ExpectExec(`(?i)update notification\s+set read_at = \$3\s+where user_id = \$1 and read_at is null and created_at <= \$2`).
+		WithArgs(userId, upTo, readAt).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := postgres.NewPostgresNotificationRepository(mockDB)
+	require.NoError(t, repo.MarkNotificationsRead(context.Background(), userId, upTo, readAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+const insertPollQuery = `
+	insert into poll (post_id, question, multiple_choice, anonymous, deadline)
+	values ($1, $2, $3, $4, $5)
+`
+
+const insertPollOptionsQuery = `
+	insert into poll_option (post_id, position, text)
+	select $1, o.position - 1, o.text
+	from unnest($2::text[]) with ordinality as o(text, position)
+`
+
+// options are returned in order with the number of votes for each of them
+const getPollsQuery = `
+	select p.post_id, p.question, p.multiple_choice, p.anonymous, p.deadline,
+		(select count(*) from poll_voter pv where pv.post_id = p.post_id),
+		o.text,
+		(select count(*) from poll_vote v where v.post_id = o.post_id and v.position = o.position)
+	from poll p
+	join poll_option o on o.post_id = p.post_id
+	where p.post_id = any($1::uuid[])
+	order by p.post_id, o.position
+`
+
+// insertPollVoterQuery saves the voter unless the poll is closed or the user has voted already.
+const insertPollVoterQuery = `
+	insert into poll_voter (post_id, user_id, voted_at)
+	select post_id, $2, $3
+	from poll
+	where post_id = $1 and (deadline is null or deadline > $3)
+	on conflict do nothing
+`
+
+const hasVotedQuery = `
+	select exists(select 1 from poll_voter where post_id = $1 and user_id = $2)
+`
+
+const insertPollVotesQuery = `
+	insert into poll_vote (post_id, user_id, position)
+	select $1, $2, unnest($3::smallint[])
+`
+
+// choices of the voter are removed with the voter
+const deletePollVoterQuery = `
+	delete from poll_voter pv
+	using poll p
+	where pv.post_id = $1 and pv.user_id = $2 and p.post_id = pv.post_id and (p.deadline is null or p.deadline > $3)
+`
+
+const getViewerVotesQuery = `
+	select post_id, position
+	from poll_vote
+	where post_id = any($1::uuid[]) and user_id = $2
+	order by post_id, position
+`
+
+const getPollVotersOlderQuery = `
+	select pv.user_id, pv.voted_at
+	from poll_vote v
+	join poll_voter pv on pv.post_id = v.post_id and pv.user_id = v.user_id
+	where v.post_id = $1 and v.position = $2 and (pv.voted_at, pv.user_id) < ($3::timestamptz, $5::uuid)
+	order by pv.voted_at desc, pv.user_id desc
+	limit $4
+`
+
+type PostgresPollRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresPollRepository creates new repository of poll votes.
+func NewPostgresPollRepository(connPool *sql.DB) *PostgresPollRepository {
+	return &PostgresPollRepository{connPool: connPool}
+}
+
+// insertPoll saves the poll of the post with its options.
+func insertPoll(ctx context.Context, tx *sql.Tx, postId uuid.UUID, poll *models.Poll) error {
+	_, err := tx.ExecContext(ctx, insertPollQuery, postId, poll.Question, poll.MultipleChoice, poll.Anonymous,
+		pgtype.Timestamptz{Time: poll.Deadline, Valid: !poll.Deadline.IsZero()})
+	if err != nil {
+		return err
+	}
+
+	options := make([]string, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		options = append(options, option.Text)
+	}
+	_, err = tx.ExecContext(ctx, insertPollOptionsQuery, postId, options)
+	return err
+}
+
+// getPolls returns polls of posts with given ids and the number of votes for their options.
+func getPolls(ctx context.Context, db *sql.DB, ids []string) (map[uuid.UUID]*models.Poll, error) {
+	rows, err := db.QueryContext(ctx, getPollsQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	polls := make(map[uuid.UUID]*models.Poll)
+	for rows.Next() {
+		var (
+			postId   uuid.UUID
+			poll     models.Poll
+			deadline pgtype.Timestamptz
+			option   models.PollOption
+		)
+		if err = rows.Scan(&postId, &poll.Question, &poll.MultipleChoice, &poll.Anonymous, &deadline,
+			&poll.VoterCount, &option.Text, &option.VoteCount); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		if _, ok := polls[postId]; !ok {
+			poll.Deadline = deadline.Time
+			polls[postId] = &poll
+		}
+		polls[postId].Options = append(polls[postId].Options, option)
+	}
+	return polls, rows.Err()
+}
+
+// Vote saves options chosen by the user in a single transaction.
+// It returns usecase.ErrAlreadyVoted if the user has voted already and usecase.ErrPollClosed if the poll is closed.
+func (p *PostgresPollRepository) Vote(ctx context.Context, postId uuid.UUID, userId uuid.UUID, options []int, now time.Time) error {
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction for vote of user %v in poll %v: %s", userId, postId, err.Error()))
+		return fmt.Errorf("unable to save vote to database: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, insertPollVoterQuery, postId, userId, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save voter %v of poll %v: %s", userId, postId, err.Error()))
+		return fmt.Errorf("unable to save vote to database: %w", err)
+	}
+	inserted, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to save vote to database: %w", err)
+	}
+	if inserted == 0 {
+		var voted bool
+		if err = tx.QueryRowContext(ctx, hasVotedQuery, postId, userId).Scan(&voted); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to check vote of user %v in poll %v: %s", userId, postId, err.Error()))
+			return fmt.Errorf("unable to save vote to database: %w", err)
+		}
+		if voted {
+			return usecase.ErrAlreadyVoted
+		}
+		return usecase.ErrPollClosed
+	}
+
+	positions := make([]int32, 0, len(options))
+	for _, option := range options {
+		positions = append(positions, int32(option))
+	}
+	if _, err = tx.ExecContext(ctx, insertPollVotesQuery, postId, userId, positions); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save options %v chosen by user %v in poll %v: %s", options, userId, postId, err.Error()))
+		return fmt.Errorf("unable to save vote to database: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit vote of user %v in poll %v: %s", userId, postId, err.Error()))
+		return fmt.Errorf("unable to save vote to database: %w", err)
+	}
+	return nil
+}
+
+// RetractVote removes the vote of the user unless the poll is closed.
+// Retracting a vote that does not exist is not an error.
+func (p *PostgresPollRepository) RetractVote(ctx context.Context, postId uuid.UUID, userId uuid.UUID, now time.Time) error {
+	if _, err := p.connPool.ExecContext(ctx, deletePollVoterQuery, postId, userId, now); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to retract vote of user %v in poll %v: %s", userId, postId, err.Error()))
+		return fmt.Errorf("unable to delete vote from database: %w", err)
+	}
+	return nil
+}
+
+// GetViewerVotes returns positions of options the viewer has chosen in polls of given posts.
+// Polls the viewer has not voted in are omitted.
+func (p *PostgresPollRepository) GetViewerVotes(ctx context.Context, postIds []uuid.UUID, viewerId uuid.UUID) (map[uuid.UUID][]int, error) {
+	rows, err := p.connPool.QueryContext(ctx, getViewerVotesQuery, uuidsToStrings(postIds), viewerId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get votes of user %v in polls %v: %s", viewerId, postIds, err.Error()))
+		return nil, fmt.Errorf("unable to get votes from database: %w", err)
+	}
+	defer rows.Close()
+
+	votes := make(map[uuid.UUID][]int)
+	for rows.Next() {
+		var (
+			postId   uuid.UUID
+			position int
+		)
+		if err = rows.Scan(&postId, &position); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan vote of user %v: %s", viewerId, err.Error()))
+			return nil, fmt.Errorf("unable to get votes from database: %w", err)
+		}
+		votes[postId] = append(votes[postId], position)
+	}
+	return votes, rows.Err()
+}
+
+// GetPollVoters returns users who chose the option of the poll before cursor, latest first.
+func (p *PostgresPollRepository) GetPollVoters(ctx context.Context, postId uuid.UUID, option int, numVoters int, cursor models.Cursor) ([]models.PollVoter, error) {
+	rows, err := p.connPool.QueryContext(ctx, getPollVotersOlderQuery, postId, option, cursor.Ts, numVoters, cursor.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get voters of option %d in poll %v: %s", option, postId, err.Error()))
+		return nil, fmt.Errorf("unable to get voters from database: %w", err)
+	}
+	defer rows.Close()
+
+	var voters []models.PollVoter
+	for rows.Next() {
+		var voter models.PollVoter
+		if err = rows.Scan(&voter.UserId, &voter.VotedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan voter of poll %v: %s", postId, err.Error()))
+			return nil, fmt.Errorf("unable to get voters from database: %w", err)
+		}
+		voters = append(voters, voter)
+	}
+	return voters, rows.Err()
+}
@@ -0,0 +1,129 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+	"quickflow/internal/usecase"
+)
+
+func TestGetPostWithPoll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	post := newTestPost()
+	deadline := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	mock.ExpectQuery(`(?i)select p.id, creator_id`).
+		WithArgs(post.Id).
+		WillReturnRows(sqlmock.NewRows(postColumns).AddRow(post.Id.String(), post.CreatorId.String(), post.Desc, post.CreatedAt, post.UpdatedAt,
+			post.LikeCount, post.RepostCount, post.CommentCount, post.IsRepost, string(post.Visibility), "published", nil, nil, 0))
+	mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url`).WillReturnRows(sqlmock.NewRows(fileColumns))
+	mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).WillReturnRows(sqlmock.NewRows(mentionColumns))
+	mock.ExpectQuery(`(?i)select p.post_id, p.question, .* from poll p\s+join poll_option o`).
+		WithArgs([]string{post.Id.String()}).
+		WillReturnRows(sqlmock.NewRows(pollColumns).
+			AddRow(post.Id.String(), "Tea or coffee?", true, false, deadline, 3, "Tea", 2).
+			AddRow(post.Id.String(), "Tea or coffee?", true, false, deadline, 3, "Coffee", 2))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetPost(context.Background(), post.Id)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, &models.Poll{
+		Question:       "Tea or coffee?",
+		Options:        []models.PollOption{{Text: "Tea", VoteCount: 2}, {Text: "Coffee", VoteCount: 2}},
+		MultipleChoice: true,
+		Deadline:       deadline,
+		VoterCount:     3,
+	}, got.Poll)
+}
+
+func TestVote(t *testing.T) {
+	postId, userId, now := uuid.New(), uuid.New(), time.Now()
+
+	tests := []struct {
+		name      string
+		mockSetup func(mock sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)insert into poll_voter .* where post_id = \$1 and \(deadline is null or deadline > \$3\)\s+on conflict do nothing`).
+					WithArgs(postId, userId, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`(?i)insert into poll_vote \(post_id, user_id, position\)`).
+					WithArgs(postId, userId, []int32{0, 2}).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "already voted",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)insert into poll_voter`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)select exists\(select 1 from poll_voter`).
+					WithArgs(postId, userId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: usecase.ErrAlreadyVoted,
+		},
+		{
+			name: "poll is closed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)insert into poll_voter`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)select exists\(select 1 from poll_voter`).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			wantErr: usecase.ErrPollClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			require.NoError(t, err)
+			defer mockDB.Close()
+			tt.mockSetup(mock)
+
+			repo := postgres.NewPostgresPollRepository(mockDB)
+			err = repo.Vote(context.Background(), postId, userId, []int{0, 2}, now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetViewerVotes(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	voted, notVoted, viewerId := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?i)select post_id, position\s+from poll_vote`).
+		WithArgs([]string{voted.String(), notVoted.String()}, viewerId).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "position"}).AddRow(voted.String(), 0).AddRow(voted.String(), 3))
+
+	repo := postgres.NewPostgresPollRepository(mockDB)
+	votes, err := repo.GetViewerVotes(context.Background(), []uuid.UUID{voted, notVoted}, viewerId)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, map[uuid.UUID][]int{voted: {0, 3}}, votes)
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,11 +12,12 @@ import (
 
 	"quickflow/internal/models"
 	pgmodels "quickflow/internal/repository/postgres/postgres-models"
+	"quickflow/internal/usecase"
 	"quickflow/pkg/logger"
 )
 
 const getPostsQuery = `
-	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
 	from post p
 	where p.id = $1
 `
@@ -25,24 +27,88 @@ const getPhotosQuery = `
 	where post_id = $1
 	order by added_at;
 `
+const getPhotosForPostsQuery = `
+	select pf.post_id, pf.file_url, sf.media_type, sf.mime_type, sf.duration_ms, sf.width, sf.height
+	from post_file pf
+	left join stored_file sf on sf.url = pf.file_url
+	where pf.post_id = any($1::uuid[])
+	order by pf.added_at;
+`
+
+// postVisibleToViewer restricts posts "p" to the published ones the viewer passed as
+// parameter $%[1]d is allowed to see according to post visibility and posts privacy level of the author.
+// Authors without privacy settings show posts to everyone.
+// Drafts and scheduled posts are never listed, not even to their authors.
+const postVisibleToViewer = `p.status = 'published' and (
+		p.creator_id = $%[1]d
+		or case coalesce((select ps.posts from privacy_settings ps where ps.profile_id = p.creator_id), 'everyone')
+			when 'everyone' then p.visibility = 'public' or (p.visibility = 'friends' and exists (
+				select 1
+				from friendship f
+				where f.status = 'friend' and (
+					(f.user1_id = p.creator_id and f.user2_id = $%[1]d) or
+					(f.user1_id = $%[1]d and f.user2_id = p.creator_id)
+				)
+			))
+			when 'friends' then p.visibility in ('public', 'friends') and exists (
+				select 1
+				from friendship f
+				where f.status = 'friend' and (
+					(f.user1_id = p.creator_id and f.user2_id = $%[1]d) or
+					(f.user1_id = $%[1]d and f.user2_id = p.creator_id)
+				)
+			)
+			else false
+		end
+	)`
+
+// postNotFilteredByViewer excludes posts "p" the viewer passed as parameter $%[1]d
+// has hidden and posts of authors the viewer has muted.
+const postNotFilteredByViewer = `not exists (
+		select 1
+		from hidden_post h
+		where h.user_id = $%[1]d and h.post_id = p.id
+	) and not exists (
+		select 1
+		from muted_user m
+		where m.user_id = $%[1]d and m.muted_id = p.creator_id
+	)`
 
-const getRecommendationsForUserOlder = `
-	select id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost
-	from post 
-	where created_at < $1 
-	order by created_at desc
+var getRecommendationsForUserOlder = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where (p.created_at, p.id) < ($1::timestamptz, $4::uuid) and p.creator_id <> $3 and %s and %s
+	order by p.created_at desc, p.id desc
 	limit $2;
-`
+`, fmt.Sprintf(postVisibleToViewer, 3), fmt.Sprintf(postNotFilteredByViewer, 3))
 
-const getUserPostsOlder = `
-	select id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost
-	from post
-	where creator_id = $1 and created_at < $2
-	order by created_at desc
+var getPostsByIdsQuery = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where p.id = any($1::uuid[]) and %s
+`, fmt.Sprintf(postVisibleToViewer, 2))
+
+var getFeedPostsByIdsQuery = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where p.id = any($1::uuid[]) and %s and %s
+`, fmt.Sprintf(postVisibleToViewer, 2), fmt.Sprintf(postNotFilteredByViewer, 2))
+
+var getUserPostsOlder = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where creator_id = $1 and (p.created_at, p.id) < ($2::timestamptz, $5::uuid) and not p.pinned and %s
+	order by p.created_at desc, p.id desc
 	limit $3;
-`
+`, fmt.Sprintf(postVisibleToViewer, 4))
 
-const getPostsForUserOlder = `
+var getPinnedPostQuery = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where creator_id = $1 and p.pinned and %s
+`, fmt.Sprintf(postVisibleToViewer, 2))
+
+var getPostsForUserOlder = fmt.Sprintf(`
 	with followed_by_user as (
 		select user1_id as id
 		from friendship
@@ -54,17 +120,176 @@ const getPostsForUserOlder = `
 		union
 		select $1 as id
 	)
-	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
 	from post p
 	join followed_by_user fbu on p.creator_id = fbu.id
-	where created_at < $2
-	order by created_at desc
+	where (p.created_at, p.id) < ($2::timestamptz, $7::uuid) and %s and %s
+	order by p.created_at desc, p.id desc
 	limit $3;
-`
+`, fmt.Sprintf(postVisibleToViewer, 1), fmt.Sprintf(postNotFilteredByViewer, 1))
 
 const insertPostQuery = `
-	insert into post (id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost)
-	values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	insert into post (id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+`
+
+const getUnpublishedUserPosts = `
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	where creator_id = $1 and status <> 'published'
+	order by publish_at nulls last, created_at desc, p.id desc;
+`
+
+// schedulePostQuery turns unpublished post into a draft when $2 is null.
+const schedulePostQuery = `
+	update post
+	set status = case when $2::timestamptz is null then 'draft' else 'scheduled' end, publish_at = $2, updated_at = now()
+	where id = $1 and status <> 'published'
+`
+
+const unpinUserPostsQuery = `
+	update post
+	set pinned = false
+	where creator_id = $1 and pinned and id <> $2
+`
+
+const pinPostQuery = `
+	update post
+	set pinned = true
+	where id = $2 and creator_id = $1 and status = 'published'
+`
+
+const unpinPostQuery = `
+	update post
+	set pinned = false
+	where id = $2 and creator_id = $1
+`
+
+// published posts take place in feeds by the time they were published at
+const publishPostQuery = `
+	update post
+	set status = 'published', created_at = $2, publish_at = null
+	where id = $1 and status <> 'published'
+`
+
+const publishDuePostsQuery = `
+	update post
+	set status = 'published', created_at = publish_at, publish_at = null
+	where status = 'scheduled' and publish_at <= $1
+	returning id
+`
+
+// snapshotPostQuery keeps published post as it was before the first edit.
+const snapshotPostQuery = `
+	with original as (
+		insert into post_revision (id, post_id, editor_id, text, created_at)
+		select $2, p.id, p.creator_id, p.text, p.created_at
+		from post p
+		where p.id = $1 and p.status = 'published' and not exists(select 1 from post_revision r where r.post_id = p.id)
+		returning id
+	)
+	insert into post_revision_file (revision_id, file_url)
+	select o.id, pf.file_url
+	from original o
+	join post_file pf on pf.post_id = $1
+	order by pf.added_at, pf.id
+`
+
+// only edits of published posts are kept in history
+const updatePostTextQuery = `
+	update post
+	set text = $1, updated_at = $2, edited_at = case when status = 'published' then $2 else edited_at end
+	where id = $3
+	returning status
+`
+
+const insertRevisionQuery = `
+	insert into post_revision (id, post_id, editor_id, text, created_at)
+	values ($1, $2, $3, $4, $5)
+`
+
+const insertRevisionFileQuery = `
+	insert into post_revision_file (revision_id, file_url)
+	values ($1, $2)
+`
+
+const getPostRevisionsQuery = `
+	select id, post_id, editor_id, text, created_at
+	from post_revision
+	where post_id = $1
+	order by created_at, id
+`
+
+const getRevisionFilesQuery = `
+	select revision_id, file_url
+	from post_revision_file
+	where revision_id = any($1::uuid[])
+	order by id
+`
+
+const getPostRevisionFilesQuery = `
+	select distinct rf.file_url
+	from post_revision_file rf
+	join post_revision r on r.id = rf.revision_id
+	where r.post_id = $1
+`
+
+const getUserPostRevisionFilesQuery = `
+	select distinct rf.file_url
+	from post_revision_file rf
+	join post_revision r on r.id = rf.revision_id
+	join post p on p.id = r.post_id
+	where p.creator_id = $1
+`
+
+const deleteUserCommentsQuery = `
+	with deleted as (
+		delete from comment
+		where user_id = $1
+		returning post_id
+	), counted as (
+		update post
+		set comment_count = comment_count - d.count
+		from (select post_id, count(*) as count from deleted group by post_id) d
+		where post.id = d.post_id
+	)
+	select count(*) from deleted
+`
+
+const insertPostTagsQuery = `
+	insert into post_tag (post_id, tag)
+	select $1, tag from unnest($2::text[]) as tag
+	on conflict do nothing
+`
+
+var getTagPostsOlder = fmt.Sprintf(`
+	select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost, visibility, status, publish_at, edited_at, view_count
+	from post p
+	join post_tag pt on pt.post_id = p.id
+	where pt.tag = $1 and (p.created_at, p.id) < ($2::timestamptz, $5::uuid) and %s
+	order by p.created_at desc, p.id desc
+	limit $3;
+`, fmt.Sprintf(postVisibleToViewer, 4))
+
+// getTrendingTagsQuery counts public posts by tags within [$2, $3) and within the window
+// of the same length [$1, $2) preceding it. Tags used by more authors go first,
+// ties are broken by growth over the previous window.
+const getTrendingTagsQuery = `
+	with tag_posts as (
+		select pt.tag, p.creator_id, p.created_at >= $2 as in_window
+		from post_tag pt
+		join post p on p.id = pt.post_id
+		where p.status = 'published' and p.visibility = 'public' and p.created_at >= $1 and p.created_at < $3
+	)
+	select tag,
+		count(*) filter (where in_window) as post_count,
+		count(distinct creator_id) filter (where in_window) as author_count,
+		count(*) filter (where not in_window) as previous_post_count
+	from tag_posts
+	group by tag
+	having count(*) filter (where in_window) > 0
+	order by author_count desc, post_count - previous_post_count desc, post_count desc, tag
+	limit $4
 `
 
 const insertPhotoQuery = `
@@ -87,20 +312,28 @@ func (p *PostgresPostRepository) Close() {
 	p.connPool.Close()
 }
 
-// AddPost adds post to the repository.
+// AddPost adds post with its files to the repository in a single transaction.
 func (p *PostgresPostRepository) AddPost(ctx context.Context, post models.Post) error {
 	postPostgres := pgmodels.ConvertPostToPostgres(post)
-	_, err := p.connPool.ExecContext(ctx, insertPostQuery,
+
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction for post %v: %s", post.Id, err.Error()))
+		return fmt.Errorf("unable to save post to database: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, insertPostQuery,
 		postPostgres.Id, postPostgres.CreatorId, postPostgres.Desc,
 		postPostgres.CreatedAt, postPostgres.UpdatedAt, postPostgres.LikeCount, postPostgres.RepostCount,
-		postPostgres.CommentCount, postPostgres.IsRepost)
+		postPostgres.CommentCount, postPostgres.IsRepost, postPostgres.Visibility, postPostgres.Status, postPostgres.PublishAt)
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to save post %v to database: %s", post, err.Error()))
 		return fmt.Errorf("unable to save post to database: %w", err)
 	}
 
 	for _, picture := range postPostgres.ImagesURLs {
-		_, err = p.connPool.ExecContext(ctx, insertPhotoQuery,
+		_, err = tx.ExecContext(ctx, insertPhotoQuery,
 			postPostgres.Id, picture)
 		if err != nil {
 			logger.Error(ctx, fmt.Sprintf("Unable to save post pictures %v for post %v to database: %s",
@@ -109,18 +342,157 @@ func (p *PostgresPostRepository) AddPost(ctx context.Context, post models.Post)
 		}
 	}
 
+	if len(post.Tags) > 0 {
+		if _, err = tx.ExecContext(ctx, insertPostTagsQuery, post.Id, post.Tags); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to save tags %v of post %v to database: %s", post.Tags, post.Id, err.Error()))
+			return fmt.Errorf("unable to save post tags to database: %w", err)
+		}
+	}
+
+	if err = insertMentions(ctx, tx, insertPostMentionsQuery, post.Id, post.Mentions); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save mentions of post %v to database: %s", post.Id, err.Error()))
+		return fmt.Errorf("unable to save post mentions to database: %w", err)
+	}
+
+	if post.Poll != nil {
+		if err = insertPoll(ctx, tx, post.Id, post.Poll); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to save poll of post %v to database: %s", post.Id, err.Error()))
+			return fmt.Errorf("unable to save post poll to database: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit post %v: %s", post.Id, err.Error()))
+		return fmt.Errorf("unable to save post to database: %w", err)
+	}
 	return nil
 }
 
-// DeletePost removes post from the repository.
-func (p *PostgresPostRepository) DeletePost(ctx context.Context, postId uuid.UUID) error {
-	_, err := p.connPool.ExecContext(ctx, "delete from post cascade where id = $1", pgtype.UUID{Bytes: postId, Valid: true})
+// DeletePost removes post from the repository and returns URLs of its files that are no longer referenced.
+func (p *PostgresPostRepository) DeletePost(ctx context.Context, postId uuid.UUID) ([]string, error) {
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction for post %v: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to delete post from database: %w", err)
+	}
+	defer tx.Rollback()
+
+	fileURLs, err := deletePostFiles(ctx, tx, postId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete post pictures %v from database: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to delete post pictures from database: %w", err)
+	}
+
+	// files of previous versions are released together with the post
+	revisionURLs, err := queryStrings(ctx, tx, getPostRevisionFilesQuery, postId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get post %v revision files from database: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to delete post from database: %w", err)
+	}
+	fileURLs = append(fileURLs, revisionURLs...)
+
+	_, err = tx.ExecContext(ctx, "delete from post cascade where id = $1", pgtype.UUID{Bytes: postId, Valid: true})
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to delete post %v from database: %s", postId, err.Error()))
-		return fmt.Errorf("unable to delete post from database: %w", err)
+		return nil, fmt.Errorf("unable to delete post from database: %w", err)
 	}
 
-	return nil
+	unreferenced, err := unreferencedFiles(ctx, tx, fileURLs)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to check references of post %v files: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to delete post from database: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit deletion of post %v: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to delete post from database: %w", err)
+	}
+	return unreferenced, nil
+}
+
+// DeleteUserContent removes every post, comment and message of the user in a single transaction.
+// Like DeletePost, it returns URLs of post files that are no longer referenced by anything,
+// files of messages are left to the garbage collector.
+func (p *PostgresPostRepository) DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, []string, error) {
+	var deleted models.DeletedContent
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction for content of user %v: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete content of user from database: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = tx.QueryRowContext(ctx, deleteUserCommentsQuery, userId).Scan(&deleted.Comments); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete comments of user %v from database: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete comments of user from database: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, "delete from message where sender_id = $1", userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete messages of user %v from database: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete messages of user from database: %w", err)
+	}
+	if deleted.Messages, err = affectedRows(res); err != nil {
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete messages of user from database: %w", err)
+	}
+
+	fileURLs, err := queryStrings(ctx, tx, "delete from post_file where post_id in (select id from post where creator_id = $1) returning file_url", userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete post pictures of user %v from database: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete post pictures from database: %w", err)
+	}
+
+	revisionURLs, err := queryStrings(ctx, tx, getUserPostRevisionFilesQuery, userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get post revision files of user %v from database: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete posts of user from database: %w", err)
+	}
+	fileURLs = append(fileURLs, revisionURLs...)
+
+	res, err = tx.ExecContext(ctx, "delete from post where creator_id = $1", userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete posts of user %v from database: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete posts of user from database: %w", err)
+	}
+	if deleted.Posts, err = affectedRows(res); err != nil {
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete posts of user from database: %w", err)
+	}
+
+	unreferenced, err := unreferencedFiles(ctx, tx, fileURLs)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to check references of post files of user %v: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete posts of user from database: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit deletion of content of user %v: %s", userId, err.Error()))
+		return models.DeletedContent{}, nil, fmt.Errorf("unable to delete content of user from database: %w", err)
+	}
+	return deleted, unreferenced, nil
+}
+
+// deletePostFiles removes files of the post and returns their URLs.
+func deletePostFiles(ctx context.Context, tx *sql.Tx, postId uuid.UUID) ([]string, error) {
+	return queryStrings(ctx, tx, "delete from post_file where post_id = $1 returning file_url", postId)
+}
+
+// queryStrings returns single text column of rows returned by query.
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, rows.Err()
 }
 
 func (p *PostgresPostRepository) BelongsTo(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (bool, error) {
@@ -140,192 +512,569 @@ func (p *PostgresPostRepository) GetPost(ctx context.Context, postId uuid.UUID)
 	err := row.Scan(
 		&postPostgres.Id, &postPostgres.CreatorId, &postPostgres.Desc,
 		&postPostgres.CreatedAt, &postPostgres.UpdatedAt, &postPostgres.LikeCount,
-		&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost)
-	if err != nil {
+		&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost, &postPostgres.Visibility,
+		&postPostgres.Status, &postPostgres.PublishAt, &postPostgres.EditedAt, &postPostgres.ViewCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Error(ctx, fmt.Sprintf("Post with id %s not found", postId))
+		return models.Post{}, usecase.ErrPostNotFound
+	} else if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to get post %v from database: %s", postId, err.Error()))
 		return models.Post{}, fmt.Errorf("unable to get post from database: %w", err)
 	}
 
-	pics, err := p.connPool.QueryContext(ctx, getPhotosQuery, postId)
+	posts := []pgmodels.PostPostgres{postPostgres}
+	if err = p.loadPostsFiles(ctx, posts); err != nil {
+		return models.Post{}, err
+	}
+
+	result := []models.Post{posts[0].ToPost()}
+	if err = p.loadPostsMentions(ctx, result); err != nil {
+		return models.Post{}, err
+	}
+	if err = p.loadPostsPolls(ctx, result); err != nil {
+		return models.Post{}, err
+	}
+	return result[0], nil
+}
+
+// GetUserPosts returns posts of the user that are visible to the viewer.
+// The pinned post is never listed here, it is requested with GetPinnedPost.
+func (p *PostgresPostRepository) GetUserPosts(ctx context.Context, id uuid.UUID, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getUserPostsOlder, id, cursor.Ts, numPosts, viewerId, cursor.Id)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to get post pictures %v from database: %s", postId, err.Error()))
-		return models.Post{}, fmt.Errorf("unable to get post pictures from database: %w", err)
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, cursor %v: %s",
+			id, numPosts, cursor, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
 	}
 
-	for pics.Next() {
-		var pic pgtype.Text
-		err = pics.Scan(&pic)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to scan post picture %v from database: %s", pic, err.Error()))
-			return models.Post{}, fmt.Errorf("unable to get post pictures from database: %w", err)
-		}
+	return p.scanPosts(ctx, rows)
+}
+
+func (p *PostgresPostRepository) GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getRecommendationsForUserOlder, cursor.Ts, numPosts, uid, cursor.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, cursor %v: %s",
+			uid, numPosts, cursor, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+	}
+
+	return p.scanPosts(ctx, rows)
+}
+
+// GetPostsByIds returns posts with given ids that are visible to the viewer.
+// Order of the result is not specified.
+func (p *PostgresPostRepository) GetPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getPostsByIdsQuery, uuidsToStrings(ids), viewerId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts %v from database: %s", ids, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+	}
+
+	return p.scanPosts(ctx, rows)
+}
 
-		postPostgres.ImagesURLs = append(postPostgres.ImagesURLs, pic)
+// GetFeedPostsByIds returns posts with given ids that are visible to the viewer,
+// except the ones the viewer has hidden or muted. Order of the result is not specified.
+func (p *PostgresPostRepository) GetFeedPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getFeedPostsByIdsQuery, uuidsToStrings(ids), viewerId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get feed posts %v from database: %s", ids, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
 	}
-	pics.Close()
 
-	return postPostgres.ToPost(), nil
+	return p.scanPosts(ctx, rows)
 }
 
-func (p *PostgresPostRepository) GetUserPosts(ctx context.Context, id uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
-	rows, err := p.connPool.QueryContext(ctx, getUserPostsOlder, id, timestamp, numPosts)
+func (p *PostgresPostRepository) GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getPostsForUserOlder, uid, cursor.Ts, numPosts,
+		models.RelationFriend, models.RelationFollowedBy, models.RelationFollowing, cursor.Id)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, timestamp %v: %s",
-			id, numPosts, timestamp, err.Error()))
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, cursor %v: %s",
+			uid, numPosts, cursor, err.Error()))
 		return nil, fmt.Errorf("unable to get posts from database: %w", err)
 	}
+
+	return p.scanPosts(ctx, rows)
+}
+
+// GetTagPosts returns posts with the tag the viewer is allowed to see, newest first.
+func (p *PostgresPostRepository) GetTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getTagPostsOlder, tag, cursor.Ts, numPosts, viewerId, cursor.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get posts with tag %v from database, numPosts %v, cursor %v: %s",
+			tag, numPosts, cursor, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+	}
+
+	return p.scanPosts(ctx, rows)
+}
+
+// GetTrendingTags returns tags used the most in public posts created within window before now.
+func (p *PostgresPostRepository) GetTrendingTags(ctx context.Context, now time.Time, window time.Duration, limit int) ([]models.TrendingTag, error) {
+	rows, err := p.connPool.QueryContext(ctx, getTrendingTagsQuery, now.Add(-2*window), now.Add(-window), now, limit)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get trending tags for window %v from database: %s", window, err.Error()))
+		return nil, fmt.Errorf("unable to get trending tags from database: %w", err)
+	}
 	defer rows.Close()
 
-	var result []models.Post
+	var tags []models.TrendingTag
 	for rows.Next() {
-		var postPostgres pgmodels.PostPostgres
-		err = rows.Scan(
-			&postPostgres.Id, &postPostgres.CreatorId, &postPostgres.Desc,
-			&postPostgres.CreatedAt, &postPostgres.UpdatedAt, &postPostgres.LikeCount,
-			&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to scan post %v from database: %s", postPostgres.Id, err.Error()))
-			return nil, fmt.Errorf("unable to get posts from database: %w", err)
+		var tag models.TrendingTag
+		if err = rows.Scan(&tag.Tag, &tag.PostCount, &tag.AuthorCount, &tag.PreviousPostCount); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan trending tag: %s", err.Error()))
+			return nil, fmt.Errorf("unable to get trending tags from database: %w", err)
 		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
 
-		pics, err := p.connPool.QueryContext(ctx, getPhotosQuery, postPostgres.Id)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to get post pictures %v from database: %s", postPostgres.Id, err.Error()))
-			return nil, fmt.Errorf("unable to get posts from database: %w", err)
-		}
+// GetUnpublishedPosts returns drafts and scheduled posts of the user, the ones published sooner go first.
+func (p *PostgresPostRepository) GetUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getUnpublishedUserPosts, userId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get unpublished posts of user %v from database: %s", userId, err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+	}
 
-		for pics.Next() {
-			var pic pgtype.Text
-			err = pics.Scan(&pic)
-			if err != nil {
-				logger.Error(ctx, fmt.Sprintf("Unable to scan post picture %v from database: %s", pic, err.Error()))
-				return nil, fmt.Errorf("unable to get posts from database: %w", err)
-			}
+	return p.scanPosts(ctx, rows)
+}
 
-			postPostgres.ImagesURLs = append(postPostgres.ImagesURLs, pic)
-		}
-		pics.Close()
-		result = append(result, postPostgres.ToPost())
+// SchedulePost sets time the unpublished post is published at, zero time turns it into a draft.
+func (p *PostgresPostRepository) SchedulePost(ctx context.Context, postId uuid.UUID, publishAt time.Time) error {
+	res, err := p.connPool.ExecContext(ctx, schedulePostQuery, postId,
+		pgtype.Timestamptz{Time: publishAt, Valid: !publishAt.IsZero()})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to schedule post %v at %v: %s", postId, publishAt, err.Error()))
+		return fmt.Errorf("unable to schedule post: %w", err)
 	}
+	return expectUnpublishedPost(res)
+}
 
-	return result, nil
+// PublishPost publishes unpublished post as if it was created at publishedAt.
+func (p *PostgresPostRepository) PublishPost(ctx context.Context, postId uuid.UUID, publishedAt time.Time) error {
+	res, err := p.connPool.ExecContext(ctx, publishPostQuery, postId, publishedAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to publish post %v: %s", postId, err.Error()))
+		return fmt.Errorf("unable to publish post: %w", err)
+	}
+	return expectUnpublishedPost(res)
 }
 
-func (p *PostgresPostRepository) GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
-	rows, err := p.connPool.QueryContext(ctx, getRecommendationsForUserOlder, timestamp, numPosts)
+// GetPinnedPost returns the post the user pinned to the top of the profile if the viewer is allowed to see it.
+// It returns usecase.ErrPostNotFound if there is no such post.
+func (p *PostgresPostRepository) GetPinnedPost(ctx context.Context, userId uuid.UUID, viewerId uuid.UUID) (models.Post, error) {
+	rows, err := p.connPool.QueryContext(ctx, getPinnedPostQuery, userId, viewerId)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, timestamp %v: %s",
-			uid, numPosts, timestamp, err.Error()))
-		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+		logger.Error(ctx, fmt.Sprintf("Unable to get pinned post of user %v from database: %s", userId, err.Error()))
+		return models.Post{}, fmt.Errorf("unable to get pinned post from database: %w", err)
+	}
+
+	posts, err := p.scanPosts(ctx, rows)
+	if err != nil {
+		return models.Post{}, err
+	}
+	if len(posts) == 0 {
+		return models.Post{}, usecase.ErrPostNotFound
+	}
+	return posts[0], nil
+}
+
+// PinPost pins the published post of the user instead of the post pinned before.
+// It returns usecase.ErrPostNotFound if the user has no such published post.
+func (p *PostgresPostRepository) PinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction to pin post %v: %s", postId, err.Error()))
+		return fmt.Errorf("unable to pin post: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the previous post is unpinned first, so the user never has two pinned posts
+	if _, err = tx.ExecContext(ctx, unpinUserPostsQuery, userId, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to unpin posts of user %v: %s", userId, err.Error()))
+		return fmt.Errorf("unable to pin post: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, pinPostQuery, userId, postId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to pin post %v: %s", postId, err.Error()))
+		return fmt.Errorf("unable to pin post: %w", err)
+	}
+	pinned, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to pin post: %w", err)
+	}
+	if pinned == 0 {
+		return usecase.ErrPostNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit pin of post %v: %s", postId, err.Error()))
+		return fmt.Errorf("unable to pin post: %w", err)
+	}
+	return nil
+}
+
+// UnpinPost unpins the post of the user, unpinning a post that is not pinned is not an error.
+func (p *PostgresPostRepository) UnpinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	if _, err := p.connPool.ExecContext(ctx, unpinPostQuery, userId, postId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to unpin post %v: %s", postId, err.Error()))
+		return fmt.Errorf("unable to unpin post: %w", err)
+	}
+	return nil
+}
+
+// expectUnpublishedPost reports ErrPostNotFound if the post was deleted or published in the meantime.
+func expectUnpublishedPost(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrPostNotFound
+	}
+	return nil
+}
+
+// PublishDuePosts publishes scheduled posts which time has come and returns their ids.
+func (p *PostgresPostRepository) PublishDuePosts(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	rows, err := p.connPool.QueryContext(ctx, publishDuePostsQuery, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to publish scheduled posts: %s", err.Error()))
+		return nil, fmt.Errorf("unable to publish scheduled posts: %w", err)
 	}
 	defer rows.Close()
 
-	var result []models.Post
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan published post id: %s", err.Error()))
+			return nil, fmt.Errorf("unable to publish scheduled posts: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// scanPosts reads posts from rows and loads their files, mentions and polls with an extra query each,
+// so the number of queries does not depend on the page size. Rows are closed.
+func (p *PostgresPostRepository) scanPosts(ctx context.Context, rows *sql.Rows) ([]models.Post, error) {
+	defer rows.Close()
+
+	var posts []pgmodels.PostPostgres
 	for rows.Next() {
 		var postPostgres pgmodels.PostPostgres
-		err = rows.Scan(
+		err := rows.Scan(
 			&postPostgres.Id, &postPostgres.CreatorId, &postPostgres.Desc,
 			&postPostgres.CreatedAt, &postPostgres.UpdatedAt, &postPostgres.LikeCount,
-			&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost)
+			&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost, &postPostgres.Visibility,
+			&postPostgres.Status, &postPostgres.PublishAt, &postPostgres.EditedAt, &postPostgres.ViewCount)
 		if err != nil {
 			logger.Error(ctx, fmt.Sprintf("Unable to scan post %v from database: %s", postPostgres.Id, err.Error()))
 			return nil, fmt.Errorf("unable to get posts from database: %w", err)
 		}
+		posts = append(posts, postPostgres)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to read posts from database: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+	}
+	// connection is released before files are requested
+	rows.Close()
 
-		pics, err := p.connPool.QueryContext(ctx, getPhotosQuery, postPostgres.Id)
-		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to get post pictures %v from database: %s", postPostgres.Id, err.Error()))
-			return nil, fmt.Errorf("unable to get posts from database: %w", err)
-		}
-
-		for pics.Next() {
-			var pic pgtype.Text
-			err = pics.Scan(&pic)
-			if err != nil {
-				logger.Error(ctx, fmt.Sprintf("Unable to scan post picture %v from database: %s", pic, err.Error()))
-				return nil, fmt.Errorf("unable to get posts from database: %w", err)
-			}
+	if err := p.loadPostsFiles(ctx, posts); err != nil {
+		return nil, err
+	}
 
-			postPostgres.ImagesURLs = append(postPostgres.ImagesURLs, pic)
-		}
-		pics.Close()
+	var result []models.Post
+	for _, postPostgres := range posts {
 		result = append(result, postPostgres.ToPost())
 	}
-
+	if err := p.loadPostsMentions(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := p.loadPostsPolls(ctx, result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-func (p *PostgresPostRepository) GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
-	rows, err := p.connPool.QueryContext(ctx, getPostsForUserOlder, uid, timestamp, numPosts,
-		models.RelationFriend, models.RelationFollowedBy, models.RelationFollowing)
+// loadPostsMentions fills mentions of all given posts in one query.
+func (p *PostgresPostRepository) loadPostsMentions(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id.String())
+	}
+
+	mentions, err := getMentions(ctx, p.connPool, getPostsMentionsQuery, ids)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to get posts from database for user %v, numPosts %v, timestamp %v: %s",
-			uid, numPosts, timestamp, err.Error()))
-		return nil, fmt.Errorf("unable to get posts from database: %w", err)
+		logger.Error(ctx, fmt.Sprintf("Unable to get mentions of posts %v from database: %s", ids, err.Error()))
+		return fmt.Errorf("unable to get posts from database: %w", err)
+	}
+	for i := range posts {
+		posts[i].Mentions = mentions[posts[i].Id]
+	}
+	return nil
+}
+
+// loadPostsPolls fills polls of all given posts in one query.
+// Votes of the viewer are not loaded.
+func (p *PostgresPostRepository) loadPostsPolls(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id.String())
+	}
+
+	polls, err := getPolls(ctx, p.connPool, ids)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get polls of posts %v from database: %s", ids, err.Error()))
+		return fmt.Errorf("unable to get posts from database: %w", err)
+	}
+	for i := range posts {
+		posts[i].Poll = polls[posts[i].Id]
+	}
+	return nil
+}
+
+// loadPostsFiles fills files of all given posts in one query.
+func (p *PostgresPostRepository) loadPostsFiles(ctx context.Context, posts []pgmodels.PostPostgres) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	byId := make(map[[16]byte]int, len(posts))
+	for i, post := range posts {
+		ids = append(ids, uuid.UUID(post.Id.Bytes).String())
+		byId[post.Id.Bytes] = i
+	}
+
+	rows, err := p.connPool.QueryContext(ctx, getPhotosForPostsQuery, ids)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get pictures of posts %v from database: %s", ids, err.Error()))
+		return fmt.Errorf("unable to get posts from database: %w", err)
 	}
 	defer rows.Close()
 
-	var result []models.Post
 	for rows.Next() {
-		var postPostgres pgmodels.PostPostgres
-		err = rows.Scan(
-			&postPostgres.Id, &postPostgres.CreatorId, &postPostgres.Desc,
-			&postPostgres.CreatedAt, &postPostgres.UpdatedAt, &postPostgres.LikeCount,
-			&postPostgres.RepostCount, &postPostgres.CommentCount, &postPostgres.IsRepost)
+		var (
+			postId pgtype.UUID
+			pic    pgtype.Text
+			media  pgmodels.MediaPostgres
+		)
+		if err = rows.Scan(&postId, &pic, &media.MediaType, &media.MimeType,
+			&media.DurationMs, &media.Width, &media.Height); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan post picture %v from database: %s", pic, err.Error()))
+			return fmt.Errorf("unable to get posts from database: %w", err)
+		}
+
+		if i, ok := byId[postId.Bytes]; ok {
+			posts[i].ImagesURLs = append(posts[i].ImagesURLs, pic)
+			posts[i].Media = append(posts[i].Media, media)
+		}
+	}
+
+	return rows.Err()
+}
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
+
+// UpdatePost changes text, visibility (if set) and replaces files of the post in a single transaction.
+// Edits of published posts are kept as revisions, so their replaced files stay referenced.
+// It returns URLs of replaced files that are no longer referenced.
+func (p *PostgresPostRepository) UpdatePost(ctx context.Context, update models.PostUpdate, fileURLs []string) ([]string, error) {
+	tx, err := p.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction for post %v: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post in database: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, snapshotPostQuery, update.Id, uuid.New())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to keep original version of post %v in database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post in database: %w", err)
+	}
+
+	now := time.Now()
+	var status pgtype.Text
+	err = tx.QueryRowContext(ctx, updatePostTextQuery, update.Desc, now, update.Id).Scan(&status)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to update post %v in database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post in database: %w", err)
+	}
+
+	if update.Visibility != "" {
+		_, err = tx.ExecContext(ctx, "update post set visibility = $1 where id = $2", update.Visibility, update.Id)
 		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to scan post %v from database: %s", postPostgres.Id, err.Error()))
-			return nil, fmt.Errorf("unable to get posts from database: %w", err)
+			logger.Error(ctx, fmt.Sprintf("Unable to update post %v visibility in database: %s", update.Id, err.Error()))
+			return nil, fmt.Errorf("unable to update post visibility in database: %w", err)
+		}
+	}
+
+	// tags follow the text, so they are replaced as a whole
+	if _, err = tx.ExecContext(ctx, "delete from post_tag where post_id = $1", update.Id); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete tags of post %v from database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post tags in database: %w", err)
+	}
+	if len(update.Tags) > 0 {
+		if _, err = tx.ExecContext(ctx, insertPostTagsQuery, update.Id, update.Tags); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to save tags %v of post %v to database: %s", update.Tags, update.Id, err.Error()))
+			return nil, fmt.Errorf("unable to update post tags in database: %w", err)
 		}
+	}
+
+	// mentions follow the text as well
+	if _, err = tx.ExecContext(ctx, "delete from post_mention where post_id = $1", update.Id); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete mentions of post %v from database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post mentions in database: %w", err)
+	}
+	if err = insertMentions(ctx, tx, insertPostMentionsQuery, update.Id, update.Mentions); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save mentions of post %v to database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post mentions in database: %w", err)
+	}
 
-		pics, err := p.connPool.QueryContext(ctx, getPhotosQuery, postPostgres.Id)
+	oldURLs, err := deletePostFiles(ctx, tx, update.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to delete post pictures %v from database: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to delete post pictures from database: %w", err)
+	}
+
+	for _, fileURL := range fileURLs {
+		_, err = tx.ExecContext(ctx, insertPhotoQuery, update.Id, fileURL)
 		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to get post pictures %v from database: %s", postPostgres.Id, err.Error()))
-			return nil, fmt.Errorf("unable to get posts from database: %w", err)
+			logger.Error(ctx, fmt.Sprintf("Unable to insert post picture %v into database: %s", fileURL, err.Error()))
+			return nil, fmt.Errorf("unable to insert post picture into database: %w", err)
 		}
+	}
 
-		for pics.Next() {
-			var pic pgtype.Text
-			err = pics.Scan(&pic)
-			if err != nil {
-				logger.Error(ctx, fmt.Sprintf("Unable to scan post picture %v from database: %s", pic, err.Error()))
-				return nil, fmt.Errorf("unable to get posts from database: %w", err)
-			}
+	if models.PostStatus(status.String).IsPublished() {
+		if err = insertRevision(ctx, tx, update, fileURLs, now); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to insert revision of post %v into database: %s", update.Id, err.Error()))
+			return nil, fmt.Errorf("unable to update post in database: %w", err)
+		}
+		if err = tx.Commit(); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to commit update of post %v: %s", update.Id, err.Error()))
+			return nil, fmt.Errorf("unable to update post in database: %w", err)
+		}
+		// replaced files are kept by previous revisions
+		return nil, nil
+	}
 
-			postPostgres.ImagesURLs = append(postPostgres.ImagesURLs, pic)
+	// files kept by the update are referenced by the post itself
+	kept := make(map[string]bool, len(fileURLs))
+	for _, fileURL := range fileURLs {
+		kept[fileURL] = true
+	}
+	var replaced []string
+	for _, fileURL := range oldURLs {
+		if !kept[fileURL] {
+			replaced = append(replaced, fileURL)
 		}
-		pics.Close()
-		result = append(result, postPostgres.ToPost())
 	}
 
-	return result, nil
+	unreferenced, err := unreferencedFiles(ctx, tx, replaced)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to check references of post %v files: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post in database: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit update of post %v: %s", update.Id, err.Error()))
+		return nil, fmt.Errorf("unable to update post in database: %w", err)
+	}
+	return unreferenced, nil
 }
 
-func (p *PostgresPostRepository) UpdatePostText(ctx context.Context, postId uuid.UUID, text string) error {
-	_, err := p.connPool.ExecContext(ctx, "update post set text = $1, updated_at = $2 where id = $3", text, time.Now(), postId)
+// insertRevision adds version of the post made by the update.
+func insertRevision(ctx context.Context, tx *sql.Tx, update models.PostUpdate, fileURLs []string, editedAt time.Time) error {
+	revisionId := uuid.New()
+	_, err := tx.ExecContext(ctx, insertRevisionQuery, revisionId, update.Id,
+		pgtype.UUID{Bytes: update.EditorId, Valid: update.EditorId != uuid.Nil}, update.Desc, editedAt)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to update post %v in database: %s", postId, err.Error()))
-		return fmt.Errorf("unable to update post in database: %w", err)
+		return err
 	}
 
+	for _, fileURL := range fileURLs {
+		if _, err = tx.ExecContext(ctx, insertRevisionFileQuery, revisionId, fileURL); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (p *PostgresPostRepository) UpdatePostFiles(ctx context.Context, postId uuid.UUID, fileURLs []string) error {
-	_, err := p.connPool.ExecContext(ctx, "delete from post_file where post_id = $1", postId)
+// GetPostRevisions returns versions of the post from the original one to the latest.
+// Posts that were never edited after publication have no revisions.
+func (p *PostgresPostRepository) GetPostRevisions(ctx context.Context, postId uuid.UUID) ([]models.PostRevision, error) {
+	rows, err := p.connPool.QueryContext(ctx, getPostRevisionsQuery, postId)
 	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to delete post pictures %v from database: %s", postId, err.Error()))
-		return fmt.Errorf("unable to delete post pictures from database: %w", err)
+		logger.Error(ctx, fmt.Sprintf("Unable to get revisions of post %v from database: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to get post revisions from database: %w", err)
 	}
+	defer rows.Close()
 
-	for _, fileURL := range fileURLs {
-		_, err = p.connPool.ExecContext(ctx, "insert into post_file (post_id, file_url) values ($1, $2)", postId, fileURL)
+	var (
+		revisions []models.PostRevision
+		ids       []string
+	)
+	for rows.Next() {
+		var revision pgmodels.PostRevisionPostgres
+		err = rows.Scan(&revision.Id, &revision.PostId, &revision.EditorId, &revision.Desc, &revision.CreatedAt)
 		if err != nil {
-			logger.Error(ctx, fmt.Sprintf("Unable to insert post picture %v into database: %s", fileURL, err.Error()))
-			return fmt.Errorf("unable to insert post picture into database: %w", err)
+			logger.Error(ctx, fmt.Sprintf("Unable to scan revision of post %v: %s", postId, err.Error()))
+			return nil, fmt.Errorf("unable to get post revisions from database: %w", err)
 		}
+		revisions = append(revisions, revision.ToPostRevision())
+		ids = append(ids, uuid.UUID(revision.Id.Bytes).String())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to get post revisions from database: %w", err)
+	}
+	if len(revisions) == 0 {
+		return revisions, nil
 	}
 
-	return nil
+	fileRows, err := p.connPool.QueryContext(ctx, getRevisionFilesQuery, ids)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get revision files of post %v from database: %s", postId, err.Error()))
+		return nil, fmt.Errorf("unable to get post revisions from database: %w", err)
+	}
+	defer fileRows.Close()
+
+	byId := make(map[uuid.UUID]int, len(revisions))
+	for i, revision := range revisions {
+		byId[revision.Id] = i
+	}
+	for fileRows.Next() {
+		var (
+			revisionId uuid.UUID
+			fileURL    string
+		)
+		if err = fileRows.Scan(&revisionId, &fileURL); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan revision file of post %v: %s", postId, err.Error()))
+			return nil, fmt.Errorf("unable to get post revisions from database: %w", err)
+		}
+		if i, ok := byId[revisionId]; ok {
+			revisions[i].ImagesURL = append(revisions[i].ImagesURL, fileURL)
+		}
+	}
+	return revisions, fileRows.Err()
 }
 
 func (p *PostgresPostRepository) GetPostFiles(ctx context.Context, postId uuid.UUID) ([]string, error) {
@@ -2,13 +2,17 @@ package postgres_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
+	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 	"quickflow/internal/models"
 	"quickflow/internal/repository/postgres"
 	postgresmodels "quickflow/internal/repository/postgres/postgres-models"
+	"quickflow/internal/usecase"
 	"testing"
 	"time"
 )
@@ -20,6 +24,7 @@ func TestPostRepository(t *testing.T) {
 		name      string
 		post      models.Post
 		mockSetup func(mock sqlmock.Sqlmock, post models.Post)
+		wantFiles []string
 		wantErr   bool
 	}{
 		{
@@ -27,6 +32,7 @@ func TestPostRepository(t *testing.T) {
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
 				pgPost := postgresmodels.ConvertPostToPostgres(post)
+				mock.ExpectBegin()
 				mock.ExpectExec(`(?i)INSERT INTO post`).
 					WithArgs(
 						pgPost.Id,
@@ -38,6 +44,9 @@ func TestPostRepository(t *testing.T) {
 						pgPost.RepostCount,
 						pgPost.CommentCount,
 						pgPost.IsRepost,
+						pgPost.Visibility,
+						pgPost.Status,
+						pgPost.PublishAt,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -46,6 +55,52 @@ func TestPostRepository(t *testing.T) {
 						WithArgs(pgPost.Id, pic).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 				}
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success add post with tags",
+			post: newTaggedPost(),
+			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)INSERT INTO post \(`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)INSERT INTO post_file`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)insert into post_tag \(post_id, tag\)\s+select \$1, tag from unnest\(\$2::text\[\]\)`).
+					WithArgs(post.Id, post.Tags).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(post.Tags))))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success add post with mentions",
+			post: newMentioningPost(),
+			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)INSERT INTO post \(`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)INSERT INTO post_file`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)insert into post_mention \(post_id, user_id, "offset", length\)`).
+					WithArgs(post.Id, []string{post.Mentions[0].UserId.String()}, []int32{6}, []int32{5}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success add post with poll",
+			post: newPollPost(),
+			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)INSERT INTO post \(`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)INSERT INTO post_file`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)insert into poll \(post_id, question, multiple_choice, anonymous, deadline\)`).
+					WithArgs(post.Id, post.Poll.Question, true, false, pgtype.Timestamptz{Time: post.Poll.Deadline, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`(?i)insert into poll_option .* from unnest\(\$2::text\[\]\) with ordinality`).
+					WithArgs(post.Id, []string{"Tea", "Coffee"}).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
@@ -54,9 +109,22 @@ func TestPostRepository(t *testing.T) {
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
 				pgPost := postgresmodels.ConvertPostToPostgres(post)
+				mock.ExpectBegin()
 				mock.ExpectExec(`(?i)INSERT INTO post`).
-					WithArgs(pgPost.Id, pgPost.CreatorId, pgPost.Desc, pgPost.CreatedAt, pgPost.UpdatedAt, pgPost.LikeCount, pgPost.RepostCount, pgPost.CommentCount, pgPost.IsRepost).
+					WithArgs(pgPost.Id, pgPost.CreatorId, pgPost.Desc, pgPost.CreatedAt, pgPost.UpdatedAt, pgPost.LikeCount, pgPost.RepostCount, pgPost.CommentCount, pgPost.IsRepost, pgPost.Visibility, pgPost.Status, pgPost.PublishAt).
 					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "add post files error rolls back post",
+			post: newTestPost(),
+			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)INSERT INTO post`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)INSERT INTO post_file`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -64,19 +132,38 @@ func TestPostRepository(t *testing.T) {
 			name: "success delete post",
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`(?i)DELETE FROM post_file where post_id = \$1 returning file_url`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow("http://example.com/a.jpg").AddRow("http://example.com/shared.jpg"))
+				mock.ExpectQuery(`(?i)select distinct rf.file_url from post_revision_file`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow("http://example.com/original.jpg"))
 				mock.ExpectExec(`(?i)DELETE FROM post`).
 					WithArgs(post.Id).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`(?i)select url from stored_file`).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("http://example.com/shared.jpg"))
+				mock.ExpectCommit()
 			},
-			wantErr: false,
+			wantFiles: []string{"http://example.com/a.jpg", "http://example.com/original.jpg"},
+			wantErr:   false,
 		},
 		{
 			name: "db error on delete post",
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`(?i)DELETE FROM post_file`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}))
+				mock.ExpectQuery(`(?i)select distinct rf.file_url from post_revision_file`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}))
 				mock.ExpectExec(`(?i)DELETE FROM post`).
 					WithArgs(post.Id).
 					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -87,13 +174,20 @@ func TestPostRepository(t *testing.T) {
 				pgPost := postgresmodels.ConvertPostToPostgres(post)
 				mock.ExpectQuery(`(?i)select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost`).
 					WithArgs(pgPost.Id).
-					WillReturnRows(sqlmock.NewRows([]string{
-						"id", "creator_id", "text", "created_at", "updated_at", "like_count", "repost_count", "comment_count", "is_repost",
-					}).AddRow(pgPost.Id, pgPost.CreatorId, pgPost.Desc, pgPost.CreatedAt, pgPost.UpdatedAt, pgPost.LikeCount, pgPost.RepostCount, pgPost.CommentCount, pgPost.IsRepost))
+					WillReturnRows(sqlmock.NewRows(postColumns).
+						AddRow(pgPost.Id, pgPost.CreatorId, pgPost.Desc, pgPost.CreatedAt, pgPost.UpdatedAt, pgPost.LikeCount, pgPost.RepostCount, pgPost.CommentCount, pgPost.IsRepost, pgPost.Visibility, pgPost.Status, pgPost.PublishAt, pgPost.EditedAt, pgPost.ViewCount))
 
-				mock.ExpectQuery(`(?i)SELECT file_url`).
-					WithArgs(pgPost.Id).
-					WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow(post.ImagesURL[0]))
+				mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url`).
+					WithArgs([]string{post.Id.String()}).
+					WillReturnRows(sqlmock.NewRows(fileColumns).AddRow(post.Id.String(), post.ImagesURL[0], nil, nil, nil, nil, nil))
+				mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).
+					WithArgs([]string{post.Id.String()}).
+					WillReturnRows(sqlmock.NewRows(mentionColumns))
+				mock.ExpectQuery(`(?i)select p.post_id, p.question`).
+					WithArgs([]string{post.Id.String()}).
+					WillReturnRows(sqlmock.NewRows(pollColumns).
+						AddRow(post.Id.String(), "Tea or coffee?", false, true, nil, 3, "Tea", 2).
+						AddRow(post.Id.String(), "Tea or coffee?", false, true, nil, 3, "Coffee", 1))
 			},
 			wantErr: false,
 		},
@@ -101,55 +195,132 @@ func TestPostRepository(t *testing.T) {
 			name: "db error on get post",
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
-				mock.ExpectQuery(`(?i)SELECT id, creator_id, Desc, created_at, updated_at, like_count, repost_count, comment_count, is_repost`).
+				mock.ExpectQuery(`(?i)select p.id, creator_id, text, created_at, updated_at, like_count, repost_count, comment_count, is_repost`).
 					WithArgs(post.Id).
 					WillReturnError(errors.New("db error"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "success update post Desc",
-			post: newTestPost(),
+			name: "success update published post",
+			post: newTaggedPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
-				mock.ExpectExec(`(?i)UPDATE post`).
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)with original as \(\s*insert into post_revision`).
+					WithArgs(post.Id, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(`(?i)update post\s+set text`).
 					WithArgs(post.Desc, sqlmock.AnyArg(), post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("published"))
+				mock.ExpectExec(`(?i)UPDATE post set visibility`).
+					WithArgs(post.Visibility, post.Id).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)delete from post_tag`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`(?i)insert into post_tag`).
+					WithArgs(post.Id, post.Tags).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(post.Tags))))
+				mock.ExpectExec(`(?i)delete from post_mention`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)DELETE FROM post_file`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow("http://example.com/old.jpg"))
+				for _, fileURL := range post.ImagesURL {
+					mock.ExpectExec(`(?i)INSERT INTO post_file`).
+						WithArgs(post.Id, fileURL).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectExec(`(?i)insert into post_revision \(`).
+					WithArgs(sqlmock.AnyArg(), post.Id, pgtype.UUID{Bytes: post.CreatorId, Valid: true}, post.Desc, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				for _, fileURL := range post.ImagesURL {
+					mock.ExpectExec(`(?i)insert into post_revision_file`).
+						WithArgs(sqlmock.AnyArg(), fileURL).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				// replaced file is kept by the original revision
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
-			name: "db error on update post Desc",
-			post: newTestPost(),
+			name: "success update draft",
+			post: newDraftPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
-				mock.ExpectExec(`(?i)UPDATE post`).
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)with original as \(\s*insert into post_revision`).
+					WithArgs(post.Id, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)update post\s+set text`).
 					WithArgs(post.Desc, sqlmock.AnyArg(), post.Id).
-					WillReturnError(errors.New("db error"))
-			},
-			wantErr: true,
-		},
-		{
-			name: "success update post files",
-			post: newTestPost(),
-			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
-				mock.ExpectExec(`(?i)DELETE FROM post_file`).
-					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(string(post.Status)))
+				mock.ExpectExec(`(?i)UPDATE post set visibility`).
+					WithArgs(post.Visibility, post.Id).
 					WillReturnResult(sqlmock.NewResult(1, 1))
-
+				mock.ExpectExec(`(?i)delete from post_tag`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`(?i)delete from post_mention`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)DELETE FROM post_file`).
+					WithArgs(post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow(post.ImagesURL[0]).AddRow("http://example.com/old.jpg"))
 				for _, fileURL := range post.ImagesURL {
 					mock.ExpectExec(`(?i)INSERT INTO post_file`).
 						WithArgs(post.Id, fileURL).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 				}
+				// kept file is not checked
+				mock.ExpectQuery(`(?i)select url from stored_file`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}))
+				mock.ExpectCommit()
 			},
-			wantErr: false,
+			wantFiles: []string{"http://example.com/old.jpg"},
+			wantErr:   false,
 		},
 		{
-			name: "db error on update post files",
+			name: "db error on update post text",
 			post: newTestPost(),
 			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
-				mock.ExpectExec(`(?i)DELETE FROM post_file`).
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)with original as \(\s*insert into post_revision`).
+					WithArgs(post.Id, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)update post\s+set text`).
+					WithArgs(post.Desc, sqlmock.AnyArg(), post.Id).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db error on update post files rolls back text",
+			post: newTestPost(),
+			mockSetup: func(mock sqlmock.Sqlmock, post models.Post) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?i)with original as \(\s*insert into post_revision`).
+					WithArgs(post.Id, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)update post\s+set text`).
+					WithArgs(post.Desc, sqlmock.AnyArg(), post.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(string(post.Status)))
+				mock.ExpectExec(`(?i)UPDATE post set visibility`).
+					WithArgs(post.Visibility, post.Id).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?i)delete from post_tag`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`(?i)delete from post_mention`).
+					WithArgs(post.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`(?i)DELETE FROM post_file`).
 					WithArgs(post.Id).
 					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -157,40 +328,36 @@ func TestPostRepository(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockDB, mock, err := sqlmock.New()
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
 			require.NoError(t, err)
 
 			repo := postgres.NewPostgresPostRepository(mockDB)
 			tt.mockSetup(mock, tt.post)
 
+			var files []string
 			switch tt.name {
-			case "success add post":
+			case "success add post", "success add post with tags", "success add post with mentions", "success add post with poll":
 				err = repo.AddPost(ctx, tt.post)
-			case "db error on add post":
+			case "db error on add post", "add post files error rolls back post":
 				err = repo.AddPost(ctx, tt.post)
-			case "success delete post":
-				err = repo.DeletePost(ctx, tt.post.Id)
-			case "db error on delete post":
-				err = repo.DeletePost(ctx, tt.post.Id)
+			case "success delete post", "db error on delete post":
+				files, err = repo.DeletePost(ctx, tt.post.Id)
 			case "success get post":
 				_, err = repo.GetPost(ctx, tt.post.Id)
 			case "db error on get post":
 				_, err = repo.GetPost(ctx, tt.post.Id)
-			case "success update post Desc":
-				err = repo.UpdatePostText(ctx, tt.post.Id, tt.post.Desc)
-			case "db error on update post Desc":
-				err = repo.UpdatePostText(ctx, tt.post.Id, tt.post.Desc)
-			case "success update post files":
-				err = repo.UpdatePostFiles(ctx, tt.post.Id, tt.post.ImagesURL)
-			case "db error on update post files":
-				err = repo.UpdatePostFiles(ctx, tt.post.Id, tt.post.ImagesURL)
+			case "success update published post", "success update draft", "db error on update post text", "db error on update post files rolls back text":
+				update := models.PostUpdate{Id: tt.post.Id, Desc: tt.post.Desc, Visibility: tt.post.Visibility, EditorId: tt.post.CreatorId, Tags: tt.post.Tags}
+				files, err = repo.UpdatePost(ctx, update, tt.post.ImagesURL)
 			}
 
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.wantFiles, files)
 			}
+			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -206,6 +373,449 @@ func newTestPost() models.Post {
 		RepostCount:  5,
 		CommentCount: 2,
 		IsRepost:     false,
+		Visibility:   models.VisibilityPublic,
+		Status:       models.PostStatusPublished,
 		ImagesURL:    []string{"http://example.com/image1.jpg"},
 	}
 }
+
+func newTaggedPost() models.Post {
+	post := newTestPost()
+	post.Desc = "Test #post about #go"
+	post.Tags = []string{"post", "go"}
+	return post
+}
+
+func newMentioningPost() models.Post {
+	post := newTestPost()
+	post.Desc = "hello @anna"
+	post.Mentions = []models.Mention{{UserId: uuid.New(), Username: "anna", Offset: 6, Length: 5}}
+	return post
+}
+
+func newPollPost() models.Post {
+	post := newTestPost()
+	post.Poll = &models.Poll{
+		Question:       "Tea or coffee?",
+		Options:        []models.PollOption{{Text: "Tea"}, {Text: "Coffee"}},
+		MultipleChoice: true,
+		Deadline:       time.Now().Add(time.Hour),
+	}
+	return post
+}
+
+func newDraftPost() models.Post {
+	post := newTestPost()
+	post.Status = models.PostStatusDraft
+	return post
+}
+
+// arrayConverter lets sqlmock accept slices that are passed to postgres as arrays.
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	switch values := v.(type) {
+	case []string, []int32:
+		return values, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+var postColumns = []string{
+	"id", "creator_id", "text", "created_at", "updated_at", "like_count", "repost_count", "comment_count", "is_repost", "visibility", "status", "publish_at", "edited_at", "view_count",
+}
+
+var fileColumns = []string{"post_id", "file_url", "media_type", "mime_type", "duration_ms", "width", "height"}
+
+var mentionColumns = []string{"post_id", "user_id", "username", "offset", "length"}
+
+var pollColumns = []string{"post_id", "question", "multiple_choice", "anonymous", "deadline", "voter_count", "text", "vote_count"}
+
+// expectFeedPage expects exactly one query for posts and one for each of their files, mentions and polls.
+// Any additional query makes sqlmock fail, so the test fails if queries grow with page size.
+func expectFeedPage(mock sqlmock.Sqlmock, numPosts int) []models.Post {
+	posts := make([]models.Post, 0, numPosts)
+	postRows := sqlmock.NewRows(postColumns)
+	fileRows := sqlmock.NewRows(fileColumns)
+	for i := 0; i < numPosts; i++ {
+		post := newTestPost()
+		post.ImagesURL = []string{fmt.Sprintf("http://example.com/%d-1.mp4", i), fmt.Sprintf("http://example.com/%d-2.jpg", i)}
+		// the second file was stored before media types were tracked
+		post.Media = map[string]models.MediaInfo{post.ImagesURL[0]: {
+			Type: models.MediaTypeVideo, MimeType: "video/mp4", Duration: 1500 * time.Millisecond, Width: 640, Height: 360,
+		}}
+		posts = append(posts, post)
+
+		postRows.AddRow(post.Id.String(), post.CreatorId.String(), post.Desc, post.CreatedAt, post.UpdatedAt,
+			post.LikeCount, post.RepostCount, post.CommentCount, post.IsRepost, string(post.Visibility), "published", nil, nil, 0)
+		fileRows.AddRow(post.Id.String(), post.ImagesURL[0], "video", "video/mp4", 1500, 640, 360)
+		fileRows.AddRow(post.Id.String(), post.ImagesURL[1], nil, nil, nil, nil, nil)
+	}
+
+	mock.ExpectQuery(`(?i)with followed_by_user as`).WillReturnRows(postRows)
+	if numPosts > 0 {
+		mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url, sf.media_type.* from post_file pf left join stored_file sf`).WillReturnRows(fileRows)
+		mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).WillReturnRows(sqlmock.NewRows(mentionColumns))
+		mock.ExpectQuery(`(?i)select p.post_id, p.question`).WillReturnRows(sqlmock.NewRows(pollColumns))
+	}
+	return posts
+}
+
+func TestGetPostsForUId_ConstantQueries(t *testing.T) {
+	for _, numPosts := range []int{0, 1, 10, 100} {
+		t.Run(fmt.Sprintf("%d posts", numPosts), func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			want := expectFeedPage(mock, numPosts)
+			repo := postgres.NewPostgresPostRepository(mockDB)
+			got, err := repo.GetPostsForUId(context.Background(), uuid.New(), numPosts, models.CursorFromTs(time.Now()))
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			require.Len(t, got, numPosts)
+			for i := range want {
+				require.Equal(t, want[i].Id, got[i].Id)
+				require.Equal(t, want[i].ImagesURL, got[i].ImagesURL)
+				require.Equal(t, want[i].Media, got[i].Media)
+			}
+		})
+	}
+}
+
+// BenchmarkGetPostsForUId fails on any query that is not expected by expectFeedPage,
+// so every page size is served by exactly four queries.
+func BenchmarkGetPostsForUId(b *testing.B) {
+	for _, numPosts := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d posts", numPosts), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+				require.NoError(b, err)
+				expectFeedPage(mock, numPosts)
+				repo := postgres.NewPostgresPostRepository(mockDB)
+				b.StartTimer()
+
+				_, err = repo.GetPostsForUId(context.Background(), uuid.New(), numPosts, models.CursorFromTs(time.Now()))
+
+				b.StopTimer()
+				require.NoError(b, err)
+				require.NoError(b, mock.ExpectationsWereMet())
+				mockDB.Close()
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+func TestGetUnpublishedPosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	post := newTestPost()
+	post.Status = models.PostStatusScheduled
+	post.PublishAt = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	mock.ExpectQuery(`(?i)select p.id, .* from post p\s+where creator_id = \$1 and status <> 'published'`).
+		WithArgs(post.CreatorId).
+		WillReturnRows(sqlmock.NewRows(postColumns).AddRow(post.Id.String(), post.CreatorId.String(), post.Desc, post.CreatedAt, post.UpdatedAt,
+			post.LikeCount, post.RepostCount, post.CommentCount, post.IsRepost, string(post.Visibility), "scheduled", post.PublishAt, nil, 0))
+	mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url`).
+		WillReturnRows(sqlmock.NewRows(fileColumns))
+	mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).
+		WillReturnRows(sqlmock.NewRows(mentionColumns))
+	mock.ExpectQuery(`(?i)select p.post_id, p.question`).
+		WillReturnRows(sqlmock.NewRows(pollColumns))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetUnpublishedPosts(context.Background(), post.CreatorId)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, got, 1)
+	require.Equal(t, models.PostStatusScheduled, got[0].Status)
+	require.True(t, post.PublishAt.Equal(got[0].PublishAt))
+}
+
+func TestSchedulePost(t *testing.T) {
+	postId := uuid.New()
+	publishAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		publishAt time.Time
+		affected  int64
+		wantErr   error
+	}{
+		{name: "schedule", publishAt: publishAt, affected: 1},
+		{name: "turn into draft", affected: 1},
+		{name: "published in the meantime", publishAt: publishAt, affected: 0, wantErr: usecase.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectExec(`(?i)update post\s+set status = case`).
+				WithArgs(postId, pgtype.Timestamptz{Time: tt.publishAt, Valid: !tt.publishAt.IsZero()}).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			repo := postgres.NewPostgresPostRepository(mockDB)
+			err = repo.SchedulePost(context.Background(), postId, tt.publishAt)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublishDuePosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	now := time.Now()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	mock.ExpectQuery(`(?i)update post\s+set status = 'published', created_at = publish_at`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[0].String()).AddRow(ids[1].String()))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.PublishDuePosts(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, ids, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPostRevisions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	postId := uuid.New()
+	original := models.PostRevision{Id: uuid.New(), PostId: postId, EditorId: uuid.New(), Desc: "original",
+		ImagesURL: []string{"http://example.com/a.jpg", "http://example.com/b.jpg"}, CreatedAt: time.Now().Add(-time.Hour).UTC()}
+	// the editor was deleted
+	edited := models.PostRevision{Id: uuid.New(), PostId: postId, Desc: "edited", CreatedAt: time.Now().UTC()}
+
+	mock.ExpectQuery(`(?i)select id, post_id, editor_id, text, created_at\s+from post_revision`).
+		WithArgs(postId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "editor_id", "text", "created_at"}).
+			AddRow(original.Id.String(), postId.String(), original.EditorId.String(), original.Desc, original.CreatedAt).
+			AddRow(edited.Id.String(), postId.String(), nil, edited.Desc, edited.CreatedAt))
+	mock.ExpectQuery(`(?i)select revision_id, file_url\s+from post_revision_file`).
+		WithArgs([]string{original.Id.String(), edited.Id.String()}).
+		WillReturnRows(sqlmock.NewRows([]string{"revision_id", "file_url"}).
+			AddRow(original.Id.String(), original.ImagesURL[0]).
+			AddRow(original.Id.String(), original.ImagesURL[1]))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetPostRevisions(context.Background(), postId)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.PostRevision{original, edited}, got)
+}
+
+func TestGetTagPosts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	viewerId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+	post := newTaggedPost()
+	mock.ExpectQuery(`(?i)join post_tag pt on pt.post_id = p.id\s+where pt.tag = \$1`).
+		WithArgs("go", cursor.Ts, 10, viewerId, cursor.Id).
+		WillReturnRows(sqlmock.NewRows(postColumns).AddRow(post.Id.String(), post.CreatorId.String(), post.Desc, post.CreatedAt, post.UpdatedAt,
+			post.LikeCount, post.RepostCount, post.CommentCount, post.IsRepost, string(post.Visibility), "published", nil, nil, 0))
+	mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url`).
+		WillReturnRows(sqlmock.NewRows(fileColumns))
+	mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).
+		WillReturnRows(sqlmock.NewRows(mentionColumns))
+	mock.ExpectQuery(`(?i)select p.post_id, p.question`).
+		WillReturnRows(sqlmock.NewRows(pollColumns))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetTagPosts(context.Background(), "go", viewerId, 10, cursor)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, got, 1)
+	require.Equal(t, post.Id, got[0].Id)
+}
+
+func TestGetTrendingTags(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`(?i)with tag_posts as`).
+		WithArgs(now.Add(-2*time.Hour), now.Add(-time.Hour), now, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "post_count", "author_count", "previous_post_count"}).
+			AddRow("go", 4, 3, 1).
+			AddRow("rust", 2, 2, 0))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetTrendingTags(context.Background(), now, time.Hour, 5)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.TrendingTag{
+		{Tag: "go", PostCount: 4, AuthorCount: 3, PreviousPostCount: 1},
+		{Tag: "rust", PostCount: 2, AuthorCount: 2},
+	}, got)
+}
+
+func TestGetPinnedPost(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	post, viewerId := newTestPost(), uuid.New()
+	mock.ExpectQuery(`(?i)select p.id, creator_id.* from post p\s+where creator_id = \$1 and p.pinned`).
+		WithArgs(post.CreatorId, viewerId).
+		WillReturnRows(sqlmock.NewRows(postColumns).AddRow(post.Id.String(), post.CreatorId.String(), post.Desc, post.CreatedAt, post.UpdatedAt,
+			post.LikeCount, post.RepostCount, post.CommentCount, post.IsRepost, string(post.Visibility), "published", nil, nil, 0))
+	mock.ExpectQuery(`(?i)select pf.post_id, pf.file_url`).WillReturnRows(sqlmock.NewRows(fileColumns))
+	mock.ExpectQuery(`(?i)select pm.post_id, pm.user_id`).WillReturnRows(sqlmock.NewRows(mentionColumns))
+	mock.ExpectQuery(`(?i)select p.post_id, p.question`).WillReturnRows(sqlmock.NewRows(pollColumns))
+	mock.ExpectQuery(`(?i)select p.id, creator_id.* from post p\s+where creator_id = \$1 and p.pinned`).
+		WithArgs(post.CreatorId, uuid.Nil).
+		WillReturnRows(sqlmock.NewRows(postColumns))
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	got, err := repo.GetPinnedPost(context.Background(), post.CreatorId, viewerId)
+	require.NoError(t, err)
+	require.Equal(t, post.Id, got.Id)
+
+	// the pinned post is hidden from the viewer or there is none
+	_, err = repo.GetPinnedPost(context.Background(), post.CreatorId, uuid.Nil)
+	require.ErrorIs(t, err, usecase.ErrPostNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPinPost(t *testing.T) {
+	userId, postId := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		pinned  int64
+		wantErr error
+	}{
+		{name: "success", pinned: 1},
+		{name: "no published post of the user", pinned: 0, wantErr: usecase.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(`(?i)update post\s+set pinned = false\s+where creator_id = \$1 and pinned and id <> \$2`).
+				WithArgs(userId, postId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(`(?i)update post\s+set pinned = true\s+where id = \$2 and creator_id = \$1 and status = 'published'`).
+				WithArgs(userId, postId).
+				WillReturnResult(sqlmock.NewResult(0, tt.pinned))
+			if tt.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			repo := postgres.NewPostgresPostRepository(mockDB)
+			err = repo.PinPost(context.Background(), userId, postId)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteUserContent(t *testing.T) {
+	userId := uuid.New()
+
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?i)with deleted as \(\s+delete from comment\s+where user_id = \$1`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectExec(`(?i)delete from message where sender_id = \$1`).
+		WithArgs(userId).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectQuery(`(?i)delete from post_file where post_id in \(select id from post where creator_id = \$1\) returning file_url`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow("http://example.com/a.jpg").AddRow("http://example.com/shared.jpg"))
+	mock.ExpectQuery(`(?i)select distinct rf.file_url from post_revision_file.*where p.creator_id = \$1`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"file_url"}).AddRow("http://example.com/original.jpg"))
+	mock.ExpectExec(`(?i)delete from post where creator_id = \$1`).
+		WithArgs(userId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`(?i)select url from stored_file`).
+		WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("http://example.com/shared.jpg"))
+	mock.ExpectCommit()
+
+	repo := postgres.NewPostgresPostRepository(mockDB)
+	deleted, files, err := repo.DeleteUserContent(context.Background(), userId)
+
+	require.NoError(t, err)
+	require.Equal(t, models.DeletedContent{Posts: 2, Comments: 3, Messages: 4}, deleted)
+	require.Equal(t, []string{"http://example.com/a.jpg", "http://example.com/original.jpg"}, files)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostQueriesRespectPostsPrivacy(t *testing.T) {
+	viewerId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+	// friends only authors show posts to friends, authors hiding posts show them to nobody
+	privacy := `(?s)privacy_settings ps where ps.profile_id = p.creator_id\), 'everyone'\).*when 'friends' then .*else false`
+
+	tests := []struct {
+		name  string
+		fetch func(repo *postgres.PostgresPostRepository) error
+	}{
+		{
+			name: "tag posts",
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetTagPosts(context.Background(), "go", viewerId, 10, cursor)
+				return err
+			},
+		},
+		{
+			name: "recommendations",
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetRecommendationsForUId(context.Background(), viewerId, 10, cursor)
+				return err
+			},
+		},
+		{
+			name: "feed",
+			fetch: func(repo *postgres.PostgresPostRepository) error {
+				_, err := repo.GetPostsForUId(context.Background(), viewerId, 10, cursor)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectQuery(privacy).WillReturnRows(sqlmock.NewRows(postColumns))
+
+			require.NoError(t, tt.fetch(postgres.NewPostgresPostRepository(mockDB)))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
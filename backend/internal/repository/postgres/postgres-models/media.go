@@ -0,0 +1,33 @@
+package postgres_models
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"quickflow/internal/models"
+)
+
+// MediaPostgres is media info of a stored file joined to its reference,
+// columns are null for files stored before media types were tracked.
+type MediaPostgres struct {
+	MediaType  pgtype.Text
+	MimeType   pgtype.Text
+	DurationMs pgtype.Int8
+	Width      pgtype.Int4
+	Height     pgtype.Int4
+}
+
+// ToMediaInfo converts MediaPostgres to models.MediaInfo, ok is false when the file has no media info.
+func (m *MediaPostgres) ToMediaInfo() (models.MediaInfo, bool) {
+	if !m.MediaType.Valid || m.MediaType.String == "" {
+		return models.MediaInfo{}, false
+	}
+	return models.MediaInfo{
+		Type:     models.MediaType(m.MediaType.String),
+		MimeType: m.MimeType.String,
+		Duration: time.Duration(m.DurationMs.Int64) * time.Millisecond,
+		Width:    int(m.Width.Int32),
+		Height:   int(m.Height.Int32),
+	}, true
+}
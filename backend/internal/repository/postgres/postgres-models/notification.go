@@ -0,0 +1,35 @@
+package postgres_models
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"quickflow/internal/models"
+)
+
+type NotificationPostgres struct {
+	Id        pgtype.UUID
+	UserId    pgtype.UUID
+	Type      pgtype.Text
+	ActorId   pgtype.UUID
+	PostId    pgtype.UUID
+	MessageId pgtype.UUID
+	ChatId    pgtype.UUID
+	CreatedAt pgtype.Timestamptz
+	ReadAt    pgtype.Timestamptz
+}
+
+// ToNotification converts NotificationPostgres to models.Notification.
+// Missing ids become uuid.Nil and missing read time becomes zero time.
+func (n *NotificationPostgres) ToNotification() models.Notification {
+	return models.Notification{
+		Id:        n.Id.Bytes,
+		UserId:    n.UserId.Bytes,
+		Type:      models.NotificationType(n.Type.String),
+		ActorId:   n.ActorId.Bytes,
+		PostId:    n.PostId.Bytes,
+		MessageId: n.MessageId.Bytes,
+		ChatId:    n.ChatId.Bytes,
+		CreatedAt: n.CreatedAt.Time,
+		ReadAt:    n.ReadAt.Time,
+	}
+}
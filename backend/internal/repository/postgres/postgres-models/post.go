@@ -11,12 +11,18 @@ type PostPostgres struct {
 	CreatorId    pgtype.UUID
 	Desc         pgtype.Text
 	ImagesURLs   []pgtype.Text
+	Media        []MediaPostgres // parallel to ImagesURLs
 	CreatedAt    pgtype.Timestamptz
 	UpdatedAt    pgtype.Timestamptz
 	LikeCount    pgtype.Int8
 	RepostCount  pgtype.Int8
 	CommentCount pgtype.Int8
+	ViewCount    pgtype.Int8
 	IsRepost     pgtype.Bool
+	Visibility   pgtype.Text
+	Status       pgtype.Text
+	PublishAt    pgtype.Timestamptz
+	EditedAt     pgtype.Timestamptz
 }
 
 // ConvertPostToPostgres converts models.Post to PostPostgres.
@@ -38,7 +44,12 @@ func ConvertPostToPostgres(post models.Post) PostPostgres {
 		LikeCount:    pgtype.Int8{Int64: int64(post.LikeCount), Valid: true},
 		RepostCount:  pgtype.Int8{Int64: int64(post.RepostCount), Valid: true},
 		CommentCount: pgtype.Int8{Int64: int64(post.CommentCount), Valid: true},
+		ViewCount:    pgtype.Int8{Int64: int64(post.ViewCount), Valid: true},
 		IsRepost:     pgtype.Bool{Bool: post.IsRepost, Valid: true},
+		Visibility:   convertStringToPostgresText(string(post.Visibility)),
+		Status:       convertStringToPostgresText(string(post.Status)),
+		PublishAt:    pgtype.Timestamptz{Time: post.PublishAt, Valid: !post.PublishAt.IsZero()},
+		EditedAt:     pgtype.Timestamptz{Time: post.EditedAt, Valid: !post.EditedAt.IsZero()},
 	}
 }
 
@@ -46,8 +57,19 @@ func ConvertPostToPostgres(post models.Post) PostPostgres {
 func (p *PostPostgres) ToPost() models.Post {
 	var picsSlice []string
 
-	for _, pics := range p.ImagesURLs {
+	var media map[string]models.MediaInfo
+
+	for i, pics := range p.ImagesURLs {
 		picsSlice = append(picsSlice, pics.String)
+		if i >= len(p.Media) {
+			continue
+		}
+		if info, ok := p.Media[i].ToMediaInfo(); ok {
+			if media == nil {
+				media = make(map[string]models.MediaInfo)
+			}
+			media[pics.String] = info
+		}
 	}
 
 	return models.Post{
@@ -55,11 +77,36 @@ func (p *PostPostgres) ToPost() models.Post {
 		CreatorId:    p.CreatorId.Bytes,
 		Desc:         p.Desc.String,
 		ImagesURL:    picsSlice,
+		Media:        media,
 		CreatedAt:    p.CreatedAt.Time,
 		UpdatedAt:    p.UpdatedAt.Time,
 		LikeCount:    int(p.LikeCount.Int64),
 		RepostCount:  int(p.RepostCount.Int64),
 		CommentCount: int(p.CommentCount.Int64),
+		ViewCount:    int(p.ViewCount.Int64),
 		IsRepost:     p.IsRepost.Bool,
+		Visibility:   models.PostVisibility(p.Visibility.String),
+		Status:       models.PostStatus(p.Status.String),
+		PublishAt:    p.PublishAt.Time,
+		EditedAt:     p.EditedAt.Time,
+	}
+}
+
+type PostRevisionPostgres struct {
+	Id        pgtype.UUID
+	PostId    pgtype.UUID
+	EditorId  pgtype.UUID
+	Desc      pgtype.Text
+	CreatedAt pgtype.Timestamptz
+}
+
+// ToPostRevision converts PostRevisionPostgres to models.PostRevision without files.
+func (r *PostRevisionPostgres) ToPostRevision() models.PostRevision {
+	return models.PostRevision{
+		Id:        r.Id.Bytes,
+		PostId:    r.PostId.Bytes,
+		EditorId:  r.EditorId.Bytes,
+		Desc:      r.Desc.String,
+		CreatedAt: r.CreatedAt.Time,
 	}
 }
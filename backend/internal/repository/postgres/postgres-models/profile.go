@@ -125,3 +125,28 @@ func (f *FriendInfoPostgres) ConvertToFriendInfo() models.FriendInfo {
 		University: f.University.String,
 	}
 }
+
+type PrivacySettingsPostgres struct {
+	ContactInfo pgtype.Text
+	BirthDate   pgtype.Text
+	Education   pgtype.Text
+	Posts       pgtype.Text
+}
+
+// ConvertToPrivacySettings converts PrivacySettingsPostgres to models.PrivacySettings.
+func (p *PrivacySettingsPostgres) ConvertToPrivacySettings() models.PrivacySettings {
+	settings := models.DefaultPrivacySettings()
+	if p.ContactInfo.Valid {
+		settings.ContactInfo = models.PrivacyLevel(p.ContactInfo.String)
+	}
+	if p.BirthDate.Valid {
+		settings.BirthDate = models.PrivacyLevel(p.BirthDate.String)
+	}
+	if p.Education.Valid {
+		settings.Education = models.PrivacyLevel(p.Education.String)
+	}
+	if p.Posts.Valid {
+		settings.Posts = models.PrivacyLevel(p.Posts.String)
+	}
+	return settings
+}
@@ -11,6 +11,7 @@ type UserPostgres struct {
 	Username pgtype.Text
 	Password pgtype.Text
 	Salt     pgtype.Text
+	Role     pgtype.Text
 }
 
 // ConvertToUser converts UserPostgres to models.User.
@@ -20,6 +21,7 @@ func (u *UserPostgres) ConvertToUser() models.User {
 		Username: u.Username.String,
 		Password: u.Password.String,
 		Salt:     u.Salt.String,
+		Role:     models.Role(u.Role.String),
 	}
 }
 
@@ -92,6 +92,20 @@ const updateLastSeenQuery = `
 	where id = $1
 `
 
+const getPrivacySettingsQuery = `
+	select contact_info, birth_date, education, posts
+	from privacy_settings
+	where profile_id = $1
+`
+
+const upsertPrivacySettingsQuery = `
+	insert into privacy_settings (profile_id, contact_info, birth_date, education, posts)
+	values ($1, $2, $3, $4, $5)
+	on conflict (profile_id) do update
+	set contact_info = excluded.contact_info, birth_date = excluded.birth_date,
+	    education = excluded.education, posts = excluded.posts
+`
+
 type PostgresProfileRepository struct {
 	connPool *sql.DB
 }
@@ -312,6 +326,32 @@ func (p *PostgresProfileRepository) UpdateLastSeen(ctx context.Context, userId u
 	return nil
 }
 
+// GetPrivacySettings returns privacy settings of the user or defaults if they were never set.
+func (p *PostgresProfileRepository) GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error) {
+	var settings pgmodels.PrivacySettingsPostgres
+	err := p.connPool.QueryRowContext(ctx, getPrivacySettingsQuery, userId).Scan(
+		&settings.ContactInfo, &settings.BirthDate, &settings.Education, &settings.Posts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DefaultPrivacySettings(), nil
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get privacy settings of user %v: %v", userId, err))
+		return models.PrivacySettings{}, fmt.Errorf("unable to get privacy settings: %w", err)
+	}
+
+	return settings.ConvertToPrivacySettings(), nil
+}
+
+// UpdatePrivacySettings saves privacy settings of the user.
+func (p *PostgresProfileRepository) UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, settings models.PrivacySettings) error {
+	_, err := p.connPool.ExecContext(ctx, upsertPrivacySettingsQuery, userId,
+		settings.ContactInfo, settings.BirthDate, settings.Education, settings.Posts)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to update privacy settings of user %v: %v", userId, err))
+		return fmt.Errorf("unable to update privacy settings: %w", err)
+	}
+	return nil
+}
+
 func updateContactInfo(ctx context.Context, tx *sql.Tx, contactInfo models.ContactInfo) (pgtype.Int4, error) {
 	var contactInfoID pgtype.Int4
 
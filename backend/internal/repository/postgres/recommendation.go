@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+// getRecommendationCandidatesQuery selects fresh posts of other users visible to $1
+// and not filtered out by them together with affinity of their authors:
+// 3 - friend, 2 - followed by user, 1 - friend of a friend, 0 - stranger.
+var getRecommendationCandidatesQuery = fmt.Sprintf(`
+	with relations as (
+		select
+			case when user1_id = $1 then user2_id else user1_id end as id,
+			case
+				when status = $4 then 3
+				when (user1_id = $1 and status = $5) or (user2_id = $1 and status = $6) then 2
+				else 0
+			end as affinity
+		from friendship
+		where user1_id = $1 or user2_id = $1
+	),
+	friends_of_friends as (
+		select distinct case when f.user1_id = r.id then f.user2_id else f.user1_id end as id
+		from friendship f
+		join relations r on r.affinity = 3 and (f.user1_id = r.id or f.user2_id = r.id)
+		where f.status = $4
+	)
+	select
+		p.id, p.creator_id, p.created_at, p.like_count, p.comment_count, p.repost_count,
+		greatest(
+			coalesce((select max(r.affinity) from relations r where r.id = p.creator_id), 0),
+			case when p.creator_id in (select id from friends_of_friends) then 1 else 0 end
+		) as affinity
+	from post p
+	where p.creator_id <> $1 and p.created_at > $2 and %s and %s
+	order by p.created_at desc
+	limit $3;
+`, fmt.Sprintf(postVisibleToViewer, 1), fmt.Sprintf(postNotFilteredByViewer, 1))
+
+type PostgresRecommendationRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresRecommendationRepository creates new recommendation repository.
+func NewPostgresRecommendationRepository(connPool *sql.DB) *PostgresRecommendationRepository {
+	return &PostgresRecommendationRepository{connPool: connPool}
+}
+
+// GetRecommendationCandidates returns posts created after since that may be recommended to the user.
+func (r *PostgresRecommendationRepository) GetRecommendationCandidates(ctx context.Context, uid uuid.UUID, since time.Time, limit int) ([]models.RecommendationCandidate, error) {
+	rows, err := r.connPool.QueryContext(ctx, getRecommendationCandidatesQuery, uid, since, limit,
+		models.RelationFriend, models.RelationFollowing, models.RelationFollowedBy)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get recommendation candidates for user %v: %s", uid, err.Error()))
+		return nil, fmt.Errorf("unable to get recommendation candidates from database: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.RecommendationCandidate
+	for rows.Next() {
+		var (
+			id, creatorId                        pgtype.UUID
+			createdAt                            pgtype.Timestamptz
+			likeCount, commentCount, repostCount pgtype.Int4
+			affinity                             int
+		)
+		if err = rows.Scan(&id, &creatorId, &createdAt, &likeCount, &commentCount, &repostCount, &affinity); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan recommendation candidate: %s", err.Error()))
+			return nil, fmt.Errorf("unable to get recommendation candidates from database: %w", err)
+		}
+
+		result = append(result, models.RecommendationCandidate{
+			PostId:       id.Bytes,
+			CreatorId:    creatorId.Bytes,
+			CreatedAt:    createdAt.Time,
+			LikeCount:    int(likeCount.Int32),
+			CommentCount: int(commentCount.Int32),
+			RepostCount:  int(repostCount.Int32),
+			Affinity:     models.AuthorAffinity(affinity),
+		})
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,372 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+// reportTargetOwnerQueries return the author of the content with id $1 if the reporter $2 is allowed to see it.
+var reportTargetOwnerQueries = map[models.ReportTarget]string{
+	models.ReportTargetPost: fmt.Sprintf(`
+	select p.creator_id
+	from post p
+	where p.id = $1 and %s
+`, fmt.Sprintf(postVisibleToViewer, 2)),
+	models.ReportTargetComment: fmt.Sprintf(`
+	select c.user_id
+	from comment c
+	join post p on p.id = c.post_id
+	where c.id = $1 and %s
+`, fmt.Sprintf(postVisibleToViewer, 2)),
+	models.ReportTargetMessage: `
+	select m.sender_id
+	from message m
+	where m.id = $1 and exists (
+		select 1
+		from chat_user cu
+		where cu.chat_id = m.chat_id and cu.user_id = $2
+	)
+`,
+	models.ReportTargetProfile: `
+	select id
+	from "user"
+	where id = $1
+`,
+}
+
+// reporting the same content twice is reported to the user instead of updating the first report
+const insertReportQuery = `
+	insert into report (id, reporter_id, target_type, target_id, target_owner_id, reason, details, status, created_at, updated_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	on conflict (reporter_id, target_type, target_id) do nothing
+`
+
+const reportColumns = `id, reporter_id, target_type, target_id, target_owner_id, reason, details, status, moderator_id, created_at, updated_at`
+
+var getReportQuery = fmt.Sprintf(`
+	select %s
+	from report
+	where id = $1
+`, reportColumns)
+
+var getReportsOlderQuery = fmt.Sprintf(`
+	select %s
+	from report
+	where status = $1 and (created_at, id) < ($2::timestamptz, $3::uuid)
+	order by created_at desc, id desc
+	limit $4
+`, reportColumns)
+
+const getModerationDecisionsQuery = `
+	select id, report_id, moderator_id, status, action, note, created_at
+	from moderation_decision
+	where report_id = $1
+	order by created_at, id
+`
+
+const reviewReportQuery = `
+	update report
+	set status = 'in_review', moderator_id = $2, updated_at = $3
+	where id = $1 and status <> 'resolved'
+`
+
+const insertReviewDecisionQuery = `
+	insert into moderation_decision (id, report_id, moderator_id, status, created_at)
+	values (gen_random_uuid(), $1, $2, 'in_review', $3)
+`
+
+const resolveReportsQuery = `
+	update report
+	set status = 'resolved', moderator_id = $3, updated_at = $4
+	where target_type = $1 and target_id = $2 and status <> 'resolved'
+	returning id
+`
+
+const insertResolveDecisionsQuery = `
+	insert into moderation_decision (id, report_id, moderator_id, status, action, note, created_at)
+	select gen_random_uuid(), report_id, $2, 'resolved', $3, $4, $5
+	from unnest($1::uuid[]) as report_id
+`
+
+var removeReportedContentQueries = map[models.ReportTarget]string{
+	models.ReportTargetComment: `
+	with deleted as (
+		delete from comment
+		where id = $1
+		returning post_id
+	)
+	update post
+	set comment_count = comment_count - 1
+	where id in (select post_id from deleted)
+`,
+	models.ReportTargetMessage: `
+	delete from message
+	where id = $1
+`,
+}
+
+// the warning links the reported content while it still exists
+const insertModerationWarningQuery = `
+	insert into notification (id, user_id, type, post_id, message_id, created_at)
+	select gen_random_uuid(), $1, 'moderation_warning',
+		case $2::text
+			when 'post' then (select id from post where id = $3)
+			when 'comment' then (select post_id from comment where id = $3)
+		end,
+		case $2::text
+			when 'message' then (select id from message where id = $3)
+		end,
+		$4
+`
+
+const insertUserBanQuery = `
+	insert into user_ban (id, user_id, reason, banned_by, until, created_at)
+	values (gen_random_uuid(), $1, $2, $3, $4, $5)
+`
+
+type PostgresReportRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresReportRepository creates new repository of reports and decisions of moderators.
+func NewPostgresReportRepository(connPool *sql.DB) *PostgresReportRepository {
+	return &PostgresReportRepository{connPool: connPool}
+}
+
+// GetReportTargetOwner returns the author of the content the reporter is allowed to see.
+// It returns usecase.ErrReportTargetNotFound if there is no such content or the reporter can't see it.
+func (r *PostgresReportRepository) GetReportTargetOwner(ctx context.Context, reporterId uuid.UUID, targetType models.ReportTarget, targetId uuid.UUID) (uuid.UUID, error) {
+	query, ok := reportTargetOwnerQueries[targetType]
+	if !ok {
+		return uuid.Nil, usecase.ErrReportTargetNotFound
+	}
+	args := []any{targetId}
+	if targetType != models.ReportTargetProfile {
+		args = append(args, reporterId)
+	}
+
+	var ownerId uuid.UUID
+	err := r.connPool.QueryRowContext(ctx, query, args...).Scan(&ownerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, usecase.ErrReportTargetNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get author of %s %v: %s", targetType, targetId, err.Error()))
+		return uuid.Nil, fmt.Errorf("unable to get reported content from database: %w", err)
+	}
+	return ownerId, nil
+}
+
+// AddReport saves new report.
+// It returns usecase.ErrAlreadyReported if the reporter has already reported the content.
+func (r *PostgresReportRepository) AddReport(ctx context.Context, report models.Report) error {
+	res, err := r.connPool.ExecContext(ctx, insertReportQuery, report.Id, report.ReporterId, report.TargetType, report.TargetId,
+		uuid.NullUUID{UUID: report.TargetOwnerId, Valid: report.TargetOwnerId != uuid.Nil},
+		report.Reason, report.Details, report.Status, report.CreatedAt, report.UpdatedAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save report of user %v about %s %v: %s", report.ReporterId, report.TargetType, report.TargetId, err.Error()))
+		return fmt.Errorf("unable to save report to database: %w", err)
+	}
+
+	saved, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to save report to database: %w", err)
+	}
+	if saved == 0 {
+		return usecase.ErrAlreadyReported
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReport(row rowScanner) (models.Report, error) {
+	var (
+		report      models.Report
+		ownerId     uuid.NullUUID
+		moderatorId uuid.NullUUID
+	)
+	if err := row.Scan(&report.Id, &report.ReporterId, &report.TargetType, &report.TargetId, &ownerId,
+		&report.Reason, &report.Details, &report.Status, &moderatorId, &report.CreatedAt, &report.UpdatedAt); err != nil {
+		return models.Report{}, err
+	}
+	report.TargetOwnerId = ownerId.UUID
+	report.ModeratorId = moderatorId.UUID
+	return report, nil
+}
+
+// GetReport returns the report.
+// It returns usecase.ErrReportNotFound if there is no such report.
+func (r *PostgresReportRepository) GetReport(ctx context.Context, reportId uuid.UUID) (models.Report, error) {
+	report, err := scanReport(r.connPool.QueryRowContext(ctx, getReportQuery, reportId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Report{}, usecase.ErrReportNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get report %v: %s", reportId, err.Error()))
+		return models.Report{}, fmt.Errorf("unable to get report from database: %w", err)
+	}
+	return report, nil
+}
+
+// GetReports returns reports with the status older than cursor, newest first.
+func (r *PostgresReportRepository) GetReports(ctx context.Context, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error) {
+	rows, err := r.connPool.QueryContext(ctx, getReportsOlderQuery, status, cursor.Ts, cursor.Id, numReports)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get %s reports, cursor %v: %s", status, cursor, err.Error()))
+		return nil, fmt.Errorf("unable to get reports from database: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []models.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan %s report: %s", status, err.Error()))
+			return nil, fmt.Errorf("unable to get reports from database: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// GetDecisions returns the audit trail of the report, oldest decisions first.
+func (r *PostgresReportRepository) GetDecisions(ctx context.Context, reportId uuid.UUID) ([]models.ModerationDecision, error) {
+	rows, err := r.connPool.QueryContext(ctx, getModerationDecisionsQuery, reportId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get decisions on report %v: %s", reportId, err.Error()))
+		return nil, fmt.Errorf("unable to get moderation decisions from database: %w", err)
+	}
+	defer rows.Close()
+
+	var decisions []models.ModerationDecision
+	for rows.Next() {
+		var (
+			decision    models.ModerationDecision
+			moderatorId uuid.NullUUID
+		)
+		if err = rows.Scan(&decision.Id, &decision.ReportId, &moderatorId, &decision.Status, &decision.Action,
+			&decision.Note, &decision.CreatedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan decision on report %v: %s", reportId, err.Error()))
+			return nil, fmt.Errorf("unable to get moderation decisions from database: %w", err)
+		}
+		decision.ModeratorId = moderatorId.UUID
+		decisions = append(decisions, decision)
+	}
+	return decisions, rows.Err()
+}
+
+// ReviewReport takes the report into review by the moderator and records it in the audit trail.
+// It returns usecase.ErrReportResolved if the report is resolved.
+func (r *PostgresReportRepository) ReviewReport(ctx context.Context, reportId uuid.UUID, moderatorId uuid.UUID, now time.Time) error {
+	tx, err := r.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction to review report %v: %s", reportId, err.Error()))
+		return fmt.Errorf("unable to review report: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, reviewReportQuery, reportId, moderatorId, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to take report %v into review by %v: %s", reportId, moderatorId, err.Error()))
+		return fmt.Errorf("unable to review report: %w", err)
+	}
+	updated, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to review report: %w", err)
+	}
+	if updated == 0 {
+		return usecase.ErrReportResolved
+	}
+
+	if _, err = tx.ExecContext(ctx, insertReviewDecisionQuery, reportId, moderatorId, now); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save review of report %v: %s", reportId, err.Error()))
+		return fmt.Errorf("unable to review report: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit review of report %v: %s", reportId, err.Error()))
+		return fmt.Errorf("unable to review report: %w", err)
+	}
+	return nil
+}
+
+// ResolveReports resolves every unresolved report of the content of the report, records the decision
+// in their audit trails and applies the action of the moderator.
+// It returns usecase.ErrReportResolved if there are no unresolved reports of the content.
+func (r *PostgresReportRepository) ResolveReports(ctx context.Context, report models.Report, moderatorId uuid.UUID, resolution models.ReportResolution, now time.Time) error {
+	tx, err := r.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to begin transaction to resolve report %v: %s", report.Id, err.Error()))
+		return fmt.Errorf("unable to resolve reports: %w", err)
+	}
+	defer tx.Rollback()
+
+	reportIds, err := resolveReports(ctx, tx, report, moderatorId, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to resolve reports of %s %v: %s", report.TargetType, report.TargetId, err.Error()))
+		return fmt.Errorf("unable to resolve reports: %w", err)
+	}
+	if len(reportIds) == 0 {
+		return usecase.ErrReportResolved
+	}
+
+	if _, err = tx.ExecContext(ctx, insertResolveDecisionsQuery, reportIds, moderatorId, resolution.Action, resolution.Note, now); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save decisions on %d reports: %s", len(reportIds), err.Error()))
+		return fmt.Errorf("unable to resolve reports: %w", err)
+	}
+
+	switch resolution.Action {
+	case models.ModerationRemoveContent:
+		// posts are removed by the post service along with their files
+		if query, ok := removeReportedContentQueries[report.TargetType]; ok {
+			_, err = tx.ExecContext(ctx, query, report.TargetId)
+		}
+	case models.ModerationWarnUser:
+		_, err = tx.ExecContext(ctx, insertModerationWarningQuery, report.TargetOwnerId, report.TargetType, report.TargetId, now)
+	case models.ModerationBanUser:
+		_, err = tx.ExecContext(ctx, insertUserBanQuery, report.TargetOwnerId, resolution.Note, moderatorId,
+			pgtype.Timestamptz{Time: resolution.BanUntil, Valid: !resolution.BanUntil.IsZero()}, now)
+	}
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to %s for %s %v: %s", resolution.Action, report.TargetType, report.TargetId, err.Error()))
+		return fmt.Errorf("unable to resolve reports: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to commit resolution of report %v: %s", report.Id, err.Error()))
+		return fmt.Errorf("unable to resolve reports: %w", err)
+	}
+	return nil
+}
+
+// resolveReports marks unresolved reports of the content resolved and returns their ids.
+func resolveReports(ctx context.Context, tx *sql.Tx, report models.Report, moderatorId uuid.UUID, now time.Time) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, resolveReportsQuery, report.TargetType, report.TargetId, moderatorId, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reportIds []string
+	for rows.Next() {
+		var reportId uuid.UUID
+		if err = rows.Scan(&reportId); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		reportIds = append(reportIds, reportId.String())
+	}
+	return reportIds, rows.Err()
+}
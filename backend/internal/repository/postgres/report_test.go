@@ -0,0 +1,198 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/repository/postgres"
+	"quickflow/internal/usecase"
+)
+
+func TestGetReportTargetOwner(t *testing.T) {
+	reporterId, ownerId, targetId := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		targetType  models.ReportTarget
+		query       string
+		args        []driver.Value
+		found       bool
+		expectedErr error
+	}{
+		{
+			name:       "visible post",
+			targetType: models.ReportTargetPost,
+			query:      `(?i)select p.creator_id\s+from post p\s+where p.id = \$1 and p.status = 'published'`,
+			args:       []driver.Value{targetId, reporterId},
+			found:      true,
+		},
+		{
+			name:        "comment under invisible post",
+			targetType:  models.ReportTargetComment,
+			query:       `(?i)select c.user_id\s+from comment c\s+join post p`,
+			args:        []driver.Value{targetId, reporterId},
+			expectedErr: usecase.ErrReportTargetNotFound,
+		},
+		{
+			name:       "message of joined chat",
+			targetType: models.ReportTargetMessage,
+			query:      `(?i)select m.sender_id\s+from message m .*cu.user_id = \$2`,
+			args:       []driver.Value{targetId, reporterId},
+			found:      true,
+		},
+		{
+			name:       "profile",
+			targetType: models.ReportTargetProfile,
+			query:      `(?i)select id\s+from "user"\s+where id = \$1`,
+			args:       []driver.Value{targetId},
+			found:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			rows := sqlmock.NewRows([]string{"owner_id"})
+			if tt.found {
+				rows.AddRow(ownerId.String())
+			}
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(rows)
+
+			repo := postgres.NewPostgresReportRepository(mockDB)
+			got, err := repo.GetReportTargetOwner(context.Background(), reporterId, tt.targetType, targetId)
+			require.NoError(t, mock.ExpectationsWereMet())
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ownerId, got)
+		})
+	}
+}
+
+func TestAddReport_AlreadyReported(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	now := time.Now()
+	report := models.Report{
+		Id: uuid.New(), ReporterId: uuid.New(), TargetType: models.ReportTargetPost, TargetId: uuid.New(),
+		Reason: models.ReportReasonSpam, Status: models.ReportStatusOpen, CreatedAt: now, UpdatedAt: now,
+	}
+	mock.ExpectExec(`(?i)insert into report .* on conflict \(reporter_id, target_type, target_id\) do nothing`).
+		WithArgs(report.Id, report.ReporterId, report.TargetType, report.TargetId, uuid.NullUUID{},
+			report.Reason, report.Details, report.Status, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := postgres.NewPostgresReportRepository(mockDB)
+	require.ErrorIs(t, repo.AddReport(context.Background(), report), usecase.ErrAlreadyReported)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReports(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	cursor := models.CursorFromTs(time.Now())
+	createdAt := cursor.Ts.Add(-time.Hour)
+	report := models.Report{
+		Id: uuid.New(), ReporterId: uuid.New(), TargetType: models.ReportTargetComment, TargetId: uuid.New(),
+		Reason: models.ReportReasonHate, Details: "slur", Status: models.ReportStatusInReview,
+		ModeratorId: uuid.New(), CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Minute),
+	}
+	mock.ExpectQuery(`(?i)from report\s+where status = \$1 and \(created_at, id\) < `).
+		WithArgs(models.ReportStatusInReview, cursor.Ts, cursor.Id, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reporter_id", "target_type", "target_id", "target_owner_id", "reason", "details", "status", "moderator_id", "created_at", "updated_at"}).
+			// the author of the comment is deleted
+			AddRow(report.Id.String(), report.ReporterId.String(), "comment", report.TargetId.String(), nil,
+				"hate", "slur", "in_review", report.ModeratorId.String(), report.CreatedAt, report.UpdatedAt))
+
+	repo := postgres.NewPostgresReportRepository(mockDB)
+	reports, err := repo.GetReports(context.Background(), models.ReportStatusInReview, 20, cursor)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, []models.Report{report}, reports)
+}
+
+func TestReviewReport_Resolved(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	reportId, moderatorId, now := uuid.New(), uuid.New(), time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`(?i)update report\s+set status = 'in_review'`).
+		WithArgs(reportId, moderatorId, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := postgres.NewPostgresReportRepository(mockDB)
+	require.ErrorIs(t, repo.ReviewReport(context.Background(), reportId, moderatorId, now), usecase.ErrReportResolved)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveReports(t *testing.T) {
+	moderatorId, ownerId, now := uuid.New(), uuid.New(), time.Now()
+	first, second := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		report     models.Report
+		resolution models.ReportResolution
+		action     string
+	}{
+		{
+			name:       "remove comment",
+			report:     models.Report{Id: first, TargetType: models.ReportTargetComment, TargetId: uuid.New(), TargetOwnerId: ownerId},
+			resolution: models.ReportResolution{Action: models.ModerationRemoveContent},
+			action:     `(?i)delete from comment\s+where id = \$1\s+returning post_id .*set comment_count = comment_count - 1`,
+		},
+		{
+			name:       "warn author of message",
+			report:     models.Report{Id: first, TargetType: models.ReportTargetMessage, TargetId: uuid.New(), TargetOwnerId: ownerId},
+			resolution: models.ReportResolution{Action: models.ModerationWarnUser, Note: "be nice"},
+			action:     `(?i)insert into notification .*'moderation_warning'`,
+		},
+		{
+			name:       "permanent ban",
+			report:     models.Report{Id: first, TargetType: models.ReportTargetProfile, TargetId: ownerId, TargetOwnerId: ownerId},
+			resolution: models.ReportResolution{Action: models.ModerationBanUser, Note: "hate"},
+			action:     `(?i)insert into user_ban`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`(?i)update report\s+set status = 'resolved'`).
+				WithArgs(tt.report.TargetType, tt.report.TargetId, moderatorId, now).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(first.String()).AddRow(second.String()))
+			mock.ExpectExec(`(?i)insert into moderation_decision .* from unnest\(\$1::uuid\[\]\)`).
+				WithArgs([]string{first.String(), second.String()}, moderatorId, tt.resolution.Action, tt.resolution.Note, now).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec(tt.action).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			repo := postgres.NewPostgresReportRepository(mockDB)
+			require.NoError(t, repo.ResolveReports(context.Background(), tt.report, moderatorId, tt.resolution, now))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
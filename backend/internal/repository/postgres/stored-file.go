@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+// storedFileLease is how long a found or saved file is kept without references,
+// so the caller has time to reference it before it is forgotten or collected.
+const storedFileLease = `interval '1 hour'`
+
+// findStoredFileQuery renews the lease of the file. The update locks the row,
+// so a concurrent forget either removes it first or sees the renewed lease.
+const findStoredFileQuery = `
+	update stored_file set used_at = now()
+	where content_hash = $1 and purpose = $2
+	returning url;
+`
+
+// saveStoredFileQuery replaces metadata of the same content stored under another name,
+// which only happens when that object is gone.
+const saveStoredFileQuery = `
+	with stale as (
+		delete from stored_file where content_hash = $4 and purpose = $2 and url <> $1
+	)
+	insert into stored_file (url, purpose, name, content_hash, size, mime_type, media_type, duration_ms, width, height)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	on conflict (url) do update set used_at = now();
+`
+
+const forgetStoredFileQuery = `
+	with forgotten as (
+		delete from stored_file
+		where purpose = $1 and name = $2 and ref_count = 0 and used_at < now() - ` + storedFileLease + `
+		returning url
+	)
+	-- the outer query sees stored_file as it was before the delete
+	select
+		exists(select 1 from forgotten),
+		exists(select 1 from stored_file where purpose = $1 and name = $2);
+`
+
+const getReferencedStoredFilesQuery = `
+	select url from stored_file where url = any($1::text[]) and ref_count > 0;
+`
+
+// PostgresStoredFileRepository keeps metadata of files stored by content hash.
+// Reference counts are updated by triggers on tables that store file URLs.
+type PostgresStoredFileRepository struct {
+	connPool *sql.DB
+}
+
+// NewPostgresStoredFileRepository creates new stored file repository.
+func NewPostgresStoredFileRepository(connPool *sql.DB) *PostgresStoredFileRepository {
+	return &PostgresStoredFileRepository{connPool: connPool}
+}
+
+// FindFile returns URL of the file with given content hash and renews its lease.
+func (r *PostgresStoredFileRepository) FindFile(ctx context.Context, contentHash string, purpose models.FilePurpose) (string, error) {
+	var url string
+	err := r.connPool.QueryRowContext(ctx, findStoredFileQuery, contentHash, purpose).Scan(&url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", usecase.ErrNotFound
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to find stored file %v: %s", contentHash, err.Error()))
+		return "", fmt.Errorf("unable to find stored file: %w", err)
+	}
+	return url, nil
+}
+
+// SaveFile remembers the file stored under url.
+func (r *PostgresStoredFileRepository) SaveFile(ctx context.Context, url string, file *models.File) error {
+	_, err := r.connPool.ExecContext(ctx, saveStoredFileQuery,
+		url, file.Purpose, path.Base(url), file.ContentHash, file.Size, file.MimeType,
+		file.Media.Type, file.Media.Duration.Milliseconds(), file.Media.Width, file.Media.Height)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save stored file %v: %s", url, err.Error()))
+		return fmt.Errorf("unable to save stored file: %w", err)
+	}
+	return nil
+}
+
+// ForgetFile removes metadata of the file unless it is still referenced or leased.
+// Files that were never indexed can always be deleted.
+func (r *PostgresStoredFileRepository) ForgetFile(ctx context.Context, purpose models.FilePurpose, fileName string) (bool, error) {
+	var forgotten, indexed bool
+	err := r.connPool.QueryRowContext(ctx, forgetStoredFileQuery, purpose, fileName).Scan(&forgotten, &indexed)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to forget stored file %v: %s", fileName, err.Error()))
+		return false, fmt.Errorf("unable to forget stored file: %w", err)
+	}
+	return forgotten || !indexed, nil
+}
+
+// unreferencedFiles returns urls that are no longer referenced by anything.
+// Files stored before deduplication have no metadata, each of them had a single reference.
+func unreferencedFiles(ctx context.Context, tx *sql.Tx, urls []string) ([]string, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, getReferencedStoredFilesQuery, urls)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get referenced files: %w", err)
+	}
+	defer rows.Close()
+
+	referenced := make(map[string]bool)
+	for rows.Next() {
+		var url string
+		if err = rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("unable to scan referenced file: %w", err)
+		}
+		referenced[url] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to iterate referenced files: %w", err)
+	}
+
+	var unreferenced []string
+	for _, url := range urls {
+		if !referenced[url] {
+			unreferenced = append(unreferenced, url)
+			referenced[url] = true // report duplicates once
+		}
+	}
+	return unreferenced, nil
+}
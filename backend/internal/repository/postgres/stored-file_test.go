@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestStoredFileRepository_FindAndSaveFile(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := NewPostgresStoredFileRepository(mockDB)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`update stored_file set used_at = now\(\)`).
+		WithArgs("hash", models.FilePurposePost).
+		WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("http://minio/posts/hash.txt"))
+	url, err := repo.FindFile(ctx, "hash", models.FilePurposePost)
+	require.NoError(t, err)
+	assert.Equal(t, "http://minio/posts/hash.txt", url)
+
+	mock.ExpectQuery(`update stored_file set used_at = now\(\)`).
+		WithArgs("other", models.FilePurposePost).
+		WillReturnError(sql.ErrNoRows)
+	_, err = repo.FindFile(ctx, "other", models.FilePurposePost)
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+
+	mock.ExpectExec(`insert into stored_file`).
+		WithArgs("http://minio/posts/hash.txt", models.FilePurposePost, "hash.txt", "hash", int64(4), "text/plain",
+			models.MediaTypeDocument, int64(0), 0, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = repo.SaveFile(ctx, "http://minio/posts/hash.txt", &models.File{
+		ContentHash: "hash", Size: 4, MimeType: "text/plain", Purpose: models.FilePurposePost,
+		Media: models.MediaInfo{Type: models.MediaTypeDocument, MimeType: "text/plain"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoredFileRepository_ForgetFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		forgotten     bool
+		indexed       bool
+		wantDeletable bool
+	}{
+		{name: "unreferenced file is forgotten", forgotten: true, indexed: true, wantDeletable: true},
+		{name: "referenced file is kept", indexed: true},
+		{name: "file that was never indexed", wantDeletable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			mock.ExpectQuery(`(?s)delete from stored_file.*ref_count = 0 and used_at < now\(\) - interval '1 hour'`).
+				WithArgs(models.FilePurposePost, "a.jpg").
+				WillReturnRows(sqlmock.NewRows([]string{"forgotten", "indexed"}).AddRow(tt.forgotten, tt.indexed))
+
+			deletable, err := NewPostgresStoredFileRepository(mockDB).ForgetFile(context.Background(), models.FilePurposePost, "a.jpg")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeletable, deletable)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
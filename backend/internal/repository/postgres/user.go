@@ -5,12 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"quickflow/internal/models"
 	pgmodels "quickflow/internal/repository/postgres/postgres-models"
 	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
 	"quickflow/utils/validation"
 )
 
@@ -21,17 +25,68 @@ const (
 `
 
 	getUserByUsername = `
-	select id, username, psw_hash, salt
+	select id, username, psw_hash, salt, role
 	from "user" 
 	where username = $1
 `
 
 	getUserByUIdQuery = `
-	select id, username, psw_hash, salt 
+	select id, username, psw_hash, salt, role
 	from "user"
 	where id = $1
 `
 
+	updateUserRoleQuery = `
+	update "user"
+	set role = $2
+	where id = $1
+`
+
+	// accountQuery returns users with their active ban with the latest end, $1 is the current time
+	accountQuery = `
+	select u.id, u.username, u.role, coalesce(p.firstname, ''), coalesce(p.lastname, ''),
+		b.id, b.reason, b.banned_by, b.until, b.created_at
+	from "user" u
+	left join profile p on p.id = u.id
+	left join lateral (
+		select id, reason, banned_by, until, created_at
+		from user_ban
+		where user_id = u.id and (until is null or until > $1)
+		order by until desc nulls first
+		limit 1
+	) b on true
+`
+
+	searchAccountsQuery = accountQuery + `
+	where u.username ilike $2 or p.firstname || ' ' || p.lastname ilike $2
+	order by u.username
+	limit $3
+`
+
+	getAccountQuery = accountQuery + `
+	where u.username = $2
+`
+
+	saveUserBanQuery = `
+	insert into user_ban (id, user_id, reason, banned_by, until, created_at)
+	values ($1, $2, $3, $4, $5, $6)
+`
+
+	getActiveBanQuery = `
+	select id, user_id, reason, banned_by, until, created_at
+	from user_ban
+	where user_id = $1 and (until is null or until > $2)
+	order by until desc nulls first
+	limit 1
+`
+
+	// lifted bans are kept as the history of the user
+	liftUserBansQuery = `
+	update user_ban
+	set until = $2
+	where user_id = $1 and (until is null or until > $2)
+`
+
 	searchSimilarUsersQuery = `
 	SELECT id, username, firstname, lastname, profile_avatar
 	FROM (
@@ -98,7 +153,7 @@ func (u *PostgresUserRepository) GetUser(ctx context.Context, loginData models.L
 
 	err := u.connPool.QueryRowContext(ctx, getUserByUsername, loginData.Login).Scan(
 		&userPostgres.Id, &userPostgres.Username,
-		&userPostgres.Password, &userPostgres.Salt)
+		&userPostgres.Password, &userPostgres.Salt, &userPostgres.Role)
 	if err != nil {
 		return models.User{}, errors.New("user not found")
 	}
@@ -116,7 +171,7 @@ func (u *PostgresUserRepository) GetUserByUId(ctx context.Context, userId uuid.U
 
 	err := u.connPool.QueryRowContext(ctx, getUserByUIdQuery,
 		userId).Scan(&userPostgres.Id, &userPostgres.Username,
-		&userPostgres.Password, &userPostgres.Salt)
+		&userPostgres.Password, &userPostgres.Salt, &userPostgres.Role)
 	if err != nil {
 		return models.User{}, errors.New("user not found")
 	}
@@ -126,7 +181,7 @@ func (u *PostgresUserRepository) GetUserByUId(ctx context.Context, userId uuid.U
 
 func (u *PostgresUserRepository) GetUserByUsername(ctx context.Context, username string) (models.User, error) {
 	var user pgmodels.UserPostgres
-	err := u.connPool.QueryRowContext(ctx, getUserByUsername, username).Scan(&user.Id, &user.Username, &user.Password, &user.Salt)
+	err := u.connPool.QueryRowContext(ctx, getUserByUsername, username).Scan(&user.Id, &user.Username, &user.Password, &user.Salt, &user.Role)
 	if errors.Is(err, sql.ErrNoRows) {
 		return models.User{}, usecase.ErrNotFound
 	} else if err != nil {
@@ -136,6 +191,24 @@ func (u *PostgresUserRepository) GetUserByUsername(ctx context.Context, username
 	return user.ConvertToUser(), nil
 }
 
+// SetUserRole replaces the role of the user.
+// It returns usecase.ErrNotFound if there is no such user.
+func (u *PostgresUserRepository) SetUserRole(ctx context.Context, userId uuid.UUID, role models.Role) error {
+	res, err := u.connPool.ExecContext(ctx, updateUserRoleQuery, userId, role)
+	if err != nil {
+		return fmt.Errorf("unable to save role of user to database: %w", err)
+	}
+
+	updated, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to save role of user to database: %w", err)
+	}
+	if updated == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
 func (u *PostgresUserRepository) SearchSimilar(ctx context.Context, toSearch string, postsCount uint) ([]models.PublicUserInfo, error) {
 	rows, err := u.connPool.QueryContext(ctx, searchSimilarUsersQuery, toSearch, postsCount)
 	if err != nil {
@@ -156,3 +229,122 @@ func (u *PostgresUserRepository) SearchSimilar(ctx context.Context, toSearch str
 
 	return users, nil
 }
+
+// likePattern matches strings containing s, wildcards of s are matched literally.
+func likePattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+func scanAccount(row rowScanner) (models.Account, error) {
+	var (
+		account   models.Account
+		banId     uuid.NullUUID
+		reason    pgtype.Text
+		bannedBy  uuid.NullUUID
+		until     pgtype.Timestamptz
+		createdAt pgtype.Timestamptz
+	)
+	if err := row.Scan(&account.Id, &account.Username, &account.Role, &account.Firstname, &account.Lastname,
+		&banId, &reason, &bannedBy, &until, &createdAt); err != nil {
+		return models.Account{}, err
+	}
+	if banId.Valid {
+		account.Ban = models.Ban{
+			Id:        banId.UUID,
+			UserId:    account.Id,
+			Reason:    reason.String,
+			BannedBy:  bannedBy.UUID,
+			Until:     until.Time,
+			CreatedAt: createdAt.Time,
+		}
+	}
+	return account, nil
+}
+
+// SearchAccounts returns accounts whose username or full name contains toSearch, ordered by username.
+func (u *PostgresUserRepository) SearchAccounts(ctx context.Context, toSearch string, count int, now time.Time) ([]models.Account, error) {
+	rows, err := u.connPool.QueryContext(ctx, searchAccountsQuery, now, likePattern(toSearch), count)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to search accounts by %q: %s", toSearch, err.Error()))
+		return nil, fmt.Errorf("unable to get accounts from database: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []models.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to scan account: %s", err.Error()))
+			return nil, fmt.Errorf("unable to get accounts from database: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+// GetAccount returns the account of the user.
+// It returns usecase.ErrNotFound if there is no such user.
+func (u *PostgresUserRepository) GetAccount(ctx context.Context, username string, now time.Time) (models.Account, error) {
+	account, err := scanAccount(u.connPool.QueryRowContext(ctx, getAccountQuery, now, username))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Account{}, usecase.ErrNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get account %s: %s", username, err.Error()))
+		return models.Account{}, fmt.Errorf("unable to get account from database: %w", err)
+	}
+	return account, nil
+}
+
+// SaveBan saves the ban of the user, the ban without end is permanent.
+func (u *PostgresUserRepository) SaveBan(ctx context.Context, ban models.Ban) error {
+	_, err := u.connPool.ExecContext(ctx, saveUserBanQuery, ban.Id, ban.UserId, ban.Reason,
+		uuid.NullUUID{UUID: ban.BannedBy, Valid: ban.BannedBy != uuid.Nil},
+		pgtype.Timestamptz{Time: ban.Until, Valid: !ban.Until.IsZero()}, ban.CreatedAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to save ban of user %v: %s", ban.UserId, err.Error()))
+		return fmt.Errorf("unable to save ban to database: %w", err)
+	}
+	return nil
+}
+
+// GetActiveBan returns the active ban of the user with the latest end.
+// It returns usecase.ErrNotFound if the user is not banned.
+func (u *PostgresUserRepository) GetActiveBan(ctx context.Context, userId uuid.UUID, now time.Time) (models.Ban, error) {
+	var (
+		ban      models.Ban
+		bannedBy uuid.NullUUID
+		until    pgtype.Timestamptz
+	)
+	err := u.connPool.QueryRowContext(ctx, getActiveBanQuery, userId, now).Scan(
+		&ban.Id, &ban.UserId, &ban.Reason, &bannedBy, &until, &ban.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Ban{}, usecase.ErrNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to get ban of user %v: %s", userId, err.Error()))
+		return models.Ban{}, fmt.Errorf("unable to get ban from database: %w", err)
+	}
+	ban.BannedBy = bannedBy.UUID
+	ban.Until = until.Time
+	return ban, nil
+}
+
+// LiftBans ends active bans of the user at now.
+// It returns usecase.ErrNotFound if the user is not banned.
+func (u *PostgresUserRepository) LiftBans(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	res, err := u.connPool.ExecContext(ctx, liftUserBansQuery, userId, now)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to lift bans of user %v: %s", userId, err.Error()))
+		return fmt.Errorf("unable to lift bans in database: %w", err)
+	}
+
+	updated, err := affectedRows(res)
+	if err != nil {
+		return fmt.Errorf("unable to lift bans in database: %w", err)
+	}
+	if updated == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
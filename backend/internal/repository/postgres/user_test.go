@@ -9,7 +9,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"quickflow/internal/models"
+	"quickflow/internal/usecase"
 	"testing"
+	"time"
 )
 
 func TestSaveUser(t *testing.T) {
@@ -94,10 +96,10 @@ func TestGetUser(t *testing.T) {
 				Password: "hashed_password",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`select id, username, psw_hash, salt`).
+				mock.ExpectQuery(`select id, username, psw_hash, salt, role`).
 					WithArgs("johndoe").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt"}).
-						AddRow(uuid_new, "johndoe", hex.EncodeToString(hash[:]), "salt123"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt", "role"}).
+						AddRow(uuid_new, "johndoe", hex.EncodeToString(hash[:]), "salt123", "user"))
 
 			},
 			want: models.User{
@@ -105,6 +107,7 @@ func TestGetUser(t *testing.T) {
 				Username: "johndoe",
 				Password: hex.EncodeToString(hash[:]),
 				Salt:     "salt123",
+				Role:     models.RoleUser,
 			},
 			wantErr: false,
 		},
@@ -115,10 +118,10 @@ func TestGetUser(t *testing.T) {
 				Password: "wrongpassword",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`select id, username, psw_hash, salt`).
+				mock.ExpectQuery(`select id, username, psw_hash, salt, role`).
 					WithArgs("johndoe").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt"}).
-						AddRow(uuid.New(), "johndoe", "hashed_password", "salt123"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt", "role"}).
+						AddRow(uuid.New(), "johndoe", "hashed_password", "salt123", "user"))
 			},
 			want:    models.User{},
 			wantErr: true,
@@ -130,7 +133,7 @@ func TestGetUser(t *testing.T) {
 				Password: "password123",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`select id, username, psw_hash, salt`).
+				mock.ExpectQuery(`select id, username, psw_hash, salt, role`).
 					WithArgs("nonexistentuser").
 					WillReturnError(fmt.Errorf("user not found"))
 			},
@@ -178,16 +181,17 @@ func TestGetUserByUId(t *testing.T) {
 			name:   "Successfully get user by UID",
 			userId: uuid_, // Используем сгенерированный UUID
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`select id, username, psw_hash, salt`).
+				mock.ExpectQuery(`select id, username, psw_hash, salt, role`).
 					WithArgs(uuid_). // UUID для запроса
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt"}).
-						AddRow(uuid_, "johndoe", "hashed_password", "salt123")) // Данные в мок-результате
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "psw_hash", "salt", "role"}).
+						AddRow(uuid_, "johndoe", "hashed_password", "salt123", "admin")) // Данные в мок-результате
 			},
 			want: models.User{
 				Id:       uuid_,
 				Username: "johndoe",
 				Password: "hashed_password",
 				Salt:     "salt123",
+				Role:     models.RoleAdmin,
 			},
 			wantErr: false,
 		},
@@ -195,7 +199,7 @@ func TestGetUserByUId(t *testing.T) {
 			name:   "Failed to get user by UID",
 			userId: uuid_,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`select id, username, psw_hash, salt`).
+				mock.ExpectQuery(`select id, username, psw_hash, salt, role`).
 					WithArgs(uuid_).
 					WillReturnError(fmt.Errorf("user not found"))
 			},
@@ -229,6 +233,171 @@ func TestGetUserByUId(t *testing.T) {
 	}
 }
 
+func TestSetUserRole(t *testing.T) {
+	userId := uuid.New()
+	tests := []struct {
+		name    string
+		updated int64
+		wantErr error
+	}{
+		{name: "Successfully set role", updated: 1},
+		{name: "Failed to set role of unknown user", updated: 0, wantErr: usecase.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to open mock DB: %v", err)
+			}
+			defer mockDB.Close()
+
+			mock.ExpectExec(`update "user"\s+set role = \$2\s+where id = \$1`).
+				WithArgs(userId, models.RoleModerator).
+				WillReturnResult(sqlmock.NewResult(0, tt.updated))
+
+			userRepo := &PostgresUserRepository{connPool: mockDB}
+			err = userRepo.SetUserRole(context.Background(), userId, models.RoleModerator)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestLikePattern(t *testing.T) {
+	assert.Equal(t, "%john%", likePattern("john"))
+	assert.Equal(t, `%100\%\_off\\%`, likePattern(`100%_off\`))
+}
+
+func TestSearchAccounts(t *testing.T) {
+	now := time.Now()
+	userId, bannedId, banId, adminId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	until := now.Add(time.Hour)
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to open mock DB: %v", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectQuery(`select u.id, u.username, u.role`).
+		WithArgs(now, "%jo%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role", "firstname", "lastname", "ban_id", "reason", "banned_by", "until", "created_at"}).
+			AddRow(userId, "johndoe", "user", "John", "Doe", nil, nil, nil, nil, nil).
+			AddRow(bannedId, "joker", "user", "", "", banId, "spam", adminId, until, now))
+
+	userRepo := &PostgresUserRepository{connPool: mockDB}
+	accounts, err := userRepo.SearchAccounts(context.Background(), "jo", 10, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Account{
+		{Id: userId, Username: "johndoe", Role: models.RoleUser, Firstname: "John", Lastname: "Doe"},
+		{Id: bannedId, Username: "joker", Role: models.RoleUser, Ban: models.Ban{
+			Id: banId, UserId: bannedId, Reason: "spam", BannedBy: adminId, Until: until, CreatedAt: now,
+		}},
+	}, accounts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAccount_NotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to open mock DB: %v", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectQuery(`select u.id, u.username, u.role`).
+		WithArgs(sqlmock.AnyArg(), "nobody").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	userRepo := &PostgresUserRepository{connPool: mockDB}
+	_, err = userRepo.GetAccount(context.Background(), "nobody", time.Now())
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActiveBan(t *testing.T) {
+	now := time.Now()
+	userId, banId := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    models.Ban
+		wantErr error
+	}{
+		{
+			name: "Permanent ban by deleted admin",
+			rows: sqlmock.NewRows([]string{"id", "user_id", "reason", "banned_by", "until", "created_at"}).
+				AddRow(banId, userId, "spam", nil, nil, now),
+			want: models.Ban{Id: banId, UserId: userId, Reason: "spam", CreatedAt: now},
+		},
+		{
+			name:    "Not banned",
+			rows:    sqlmock.NewRows([]string{"id", "user_id", "reason", "banned_by", "until", "created_at"}),
+			wantErr: usecase.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to open mock DB: %v", err)
+			}
+			defer mockDB.Close()
+
+			mock.ExpectQuery(`select id, user_id, reason, banned_by, until, created_at\s+from user_ban`).
+				WithArgs(userId, now).
+				WillReturnRows(tt.rows)
+
+			userRepo := &PostgresUserRepository{connPool: mockDB}
+			ban, err := userRepo.GetActiveBan(context.Background(), userId, now)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, ban)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLiftBans(t *testing.T) {
+	now := time.Now()
+	userId := uuid.New()
+	tests := []struct {
+		name    string
+		updated int64
+		wantErr error
+	}{
+		{name: "Successfully lift bans", updated: 2},
+		{name: "User is not banned", updated: 0, wantErr: usecase.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to open mock DB: %v", err)
+			}
+			defer mockDB.Close()
+
+			mock.ExpectExec(`update user_ban\s+set until = \$2`).
+				WithArgs(userId, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.updated))
+
+			userRepo := &PostgresUserRepository{connPool: mockDB}
+			err = userRepo.LiftBans(context.Background(), userId, now)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestSearchSimilar(t *testing.T) {
 	uuid_ := uuid.New()
 	tests := []struct {
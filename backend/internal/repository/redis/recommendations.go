@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	redis2 "quickflow/config/redis"
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+const (
+	recommendationsKeyPrefix = "recommendations:"
+	seenKeyPrefix            = "recommendations:seen:"
+	activeUsersKey           = "recommendations:active"
+)
+
+type RedisRecommendationRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisRecommendationRepository() *RedisRecommendationRepository {
+	redisCfg := redis2.NewRedisConfig()
+
+	return &RedisRecommendationRepository{
+		rdb: redis.NewClient(&redis.Options{
+			Addr:     redisCfg.GetURL(),
+			Password: redisCfg.GetPass(),
+		}),
+	}
+}
+
+// SaveRecommendations replaces cached scored posts of the user.
+func (r *RedisRecommendationRepository) SaveRecommendations(ctx context.Context, uid uuid.UUID, posts []models.ScoredPost, ttl time.Duration) error {
+	key := recommendationsKeyPrefix + uid.String()
+
+	members := make([]redis.Z, 0, len(posts))
+	for _, post := range posts {
+		members = append(members, redis.Z{Score: post.Score, Member: post.PostId.String()})
+	}
+
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(members) > 0 {
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to save recommendations to redis for user %s: %s", uid, err.Error()))
+		return fmt.Errorf("saving recommendations error: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecommendations returns cached scored posts of the user ordered by score.
+// Empty result means that nothing is cached.
+func (r *RedisRecommendationRepository) GetRecommendations(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error) {
+	members, err := r.rdb.ZRevRangeWithScores(ctx, recommendationsKeyPrefix+uid.String(), 0, -1).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get recommendations from redis for user %s: %s", uid, err.Error()))
+		return nil, fmt.Errorf("unable to get recommendations: %w", err)
+	}
+
+	result := make([]models.ScoredPost, 0, len(members))
+	for _, member := range members {
+		postId, err := uuid.Parse(fmt.Sprint(member.Member))
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to parse cached post id %v: %s", member.Member, err.Error()))
+			continue
+		}
+		result = append(result, models.ScoredPost{PostId: postId, Score: member.Score})
+	}
+
+	return result, nil
+}
+
+// MarkSeen remembers posts that were already shown to the user.
+func (r *RedisRecommendationRepository) MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID, ttl time.Duration) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	key := seenKeyPrefix + uid.String()
+	ids := make([]interface{}, 0, len(postIds))
+	for _, id := range postIds {
+		ids = append(ids, id.String())
+	}
+
+	pipe := r.rdb.TxPipeline()
+	pipe.SAdd(ctx, key, ids...)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to mark posts seen in redis for user %s: %s", uid, err.Error()))
+		return fmt.Errorf("unable to mark posts seen: %w", err)
+	}
+
+	return nil
+}
+
+// GetSeen returns posts that were already shown to the user.
+func (r *RedisRecommendationRepository) GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error) {
+	members, err := r.rdb.SMembers(ctx, seenKeyPrefix+uid.String()).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get seen posts from redis for user %s: %s", uid, err.Error()))
+		return nil, fmt.Errorf("unable to get seen posts: %w", err)
+	}
+
+	result := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+// TouchActiveUser remembers the last time user requested recommendations.
+func (r *RedisRecommendationRepository) TouchActiveUser(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	err := r.rdb.ZAdd(ctx, activeUsersKey, redis.Z{Score: float64(at.Unix()), Member: uid.String()}).Err()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to mark user %s active in redis: %s", uid, err.Error()))
+		return fmt.Errorf("unable to mark user active: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveUsers returns users that requested recommendations after since
+// and forgets users that were inactive for a longer time.
+func (r *RedisRecommendationRepository) GetActiveUsers(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	min := strconv.FormatInt(since.Unix(), 10)
+	if err := r.rdb.ZRemRangeByScore(ctx, activeUsersKey, "-inf", "("+min).Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to remove inactive users from redis: %s", err.Error()))
+		return nil, fmt.Errorf("unable to remove inactive users: %w", err)
+	}
+
+	members, err := r.rdb.ZRangeByScore(ctx, activeUsersKey, &redis.ZRangeBy{Min: min, Max: "+inf"}).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get active users from redis: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get active users: %w", err)
+	}
+
+	result := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+func (r *RedisRecommendationRepository) Close() {
+	err := r.rdb.Close()
+	if err != nil {
+		log.Fatal("unable to close Redis connection:", err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"quickflow/internal/models"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	uid := uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a")
+	postId := uuid.MustParse("22896b51-8736-42dc-bf6f-b438c1ad3aa5")
+
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		want    []models.ScoredPost
+		wantErr bool
+	}{
+		{
+			name: "Successfully get recommendations",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRevRangeWithScores("recommendations:"+uid.String(), 0, -1).
+					SetVal([]redis.Z{{Score: 1.5, Member: postId.String()}})
+			},
+			want: []models.ScoredPost{{PostId: postId, Score: 1.5}},
+		},
+		{
+			name: "Failed to get recommendations",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRevRangeWithScores("recommendations:"+uid.String(), 0, -1).
+					SetErr(fmt.Errorf("failed to get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock := redismock.NewClientMock()
+			tt.mock(mock)
+
+			repo := &RedisRecommendationRepository{rdb: mockDB}
+
+			got, err := repo.GetRecommendations(context.Background(), uid)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMarkSeen(t *testing.T) {
+	uid := uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a")
+	postId := uuid.MustParse("22896b51-8736-42dc-bf6f-b438c1ad3aa5")
+
+	mockDB, mock := redismock.NewClientMock()
+	mock.ExpectTxPipeline()
+	mock.ExpectSAdd("recommendations:seen:"+uid.String(), postId.String()).SetVal(1)
+	mock.ExpectExpire("recommendations:seen:"+uid.String(), time.Hour).SetVal(true)
+	mock.ExpectTxPipelineExec()
+
+	repo := &RedisRecommendationRepository{rdb: mockDB}
+
+	err := repo.MarkSeen(context.Background(), uid, []uuid.UUID{postId}, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -14,7 +14,17 @@ import (
 	"quickflow/internal/models"
 )
 
+// ignoreArgs matches commands whose arguments depend on the current time.
+func ignoreArgs(expected, actual []interface{}) error {
+	if expected[0] != actual[0] {
+		return fmt.Errorf("expected %v, got %v", expected[0], actual[0])
+	}
+	return nil
+}
+
 func TestSaveSession(t *testing.T) {
+	expireDate := time.Now().Add(24 * time.Hour)
+
 	tests := []struct {
 		name    string
 		userId  uuid.UUID
@@ -27,11 +37,18 @@ func TestSaveSession(t *testing.T) {
 			userId: uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a"),
 			session: models.Session{
 				SessionId:  uuid.MustParse("22896b51-8736-42dc-bf6f-b438c1ad3aa5"),
-				ExpireDate: time.Now().Add(24 * time.Hour),
+				ExpireDate: expireDate,
 			},
 			mock: func(mock redismock.ClientMock) {
-				mock.ExpectSet("22896b51-8736-42dc-bf6f-b438c1ad3aa5", "9e49c172-8626-4c60-8240-6b8e774e0a4a", time.Until(time.Now().Add(24*time.Hour))).
+				mock.ExpectTxPipeline()
+				mock.ExpectSet("22896b51-8736-42dc-bf6f-b438c1ad3aa5", "9e49c172-8626-4c60-8240-6b8e774e0a4a", time.Until(expireDate)).
 					SetVal("OK")
+				mock.ExpectZAdd("sessions:user:9e49c172-8626-4c60-8240-6b8e774e0a4a",
+					redis.Z{Score: float64(expireDate.Unix()), Member: "22896b51-8736-42dc-bf6f-b438c1ad3aa5"}).SetVal(1)
+				mock.CustomMatch(ignoreArgs).ExpectZRemRangeByScore("sessions:user:9e49c172-8626-4c60-8240-6b8e774e0a4a", "-inf", "").
+					SetVal(0)
+				mock.ExpectExpireAt("sessions:user:9e49c172-8626-4c60-8240-6b8e774e0a4a", expireDate).SetVal(true)
+				mock.ExpectTxPipelineExec()
 			},
 			wantErr: false,
 		},
@@ -40,10 +57,11 @@ func TestSaveSession(t *testing.T) {
 			userId: uuid.MustParse("331b7880-4f48-4925-a312-67a848e631f2"),
 			session: models.Session{
 				SessionId:  uuid.MustParse("5998eccb-91e1-40a5-8b02-9883cb0ac95d"),
-				ExpireDate: time.Now().Add(24 * time.Hour),
+				ExpireDate: expireDate,
 			},
 			mock: func(mock redismock.ClientMock) {
-				mock.ExpectSet("5998eccb-91e1-40a5-8b02-9883cb0ac95d", "331b7880-4f48-4925-a312-67a848e631f2", time.Until(time.Now().Add(24*time.Hour))).
+				mock.ExpectTxPipeline()
+				mock.ExpectSet("5998eccb-91e1-40a5-8b02-9883cb0ac95d", "331b7880-4f48-4925-a312-67a848e631f2", time.Until(expireDate)).
 					SetErr(fmt.Errorf("failed to save"))
 			},
 			wantErr: true,
@@ -216,3 +234,77 @@ func TestDeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func TestCountUserSessions(t *testing.T) {
+	userId := uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a")
+
+	mockDB, mock := redismock.NewClientMock()
+	mock.CustomMatch(ignoreArgs).ExpectZCount("sessions:user:"+userId.String(), "", "+inf").SetVal(2)
+
+	repo := &RedisSessionRepository{rdb: mockDB}
+	count, err := repo.CountUserSessions(context.Background(), userId)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	userId := uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a")
+	indexKey := "sessions:user:" + userId.String()
+
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Successfully delete sessions",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRange(indexKey, 0, -1).SetVal([]string{"session1", "session2"})
+				// session2 is already expired
+				mock.ExpectDel("session1", "session2", indexKey).SetVal(2)
+			},
+			want: 1,
+		},
+		{
+			name: "No sessions",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRange(indexKey, 0, -1).SetVal([]string{})
+			},
+			want: 0,
+		},
+		{
+			name: "Failed to get sessions",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRange(indexKey, 0, -1).SetErr(fmt.Errorf("failed to get"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed to delete sessions",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectZRange(indexKey, 0, -1).SetVal([]string{"session1"})
+				mock.ExpectDel("session1", indexKey).SetErr(fmt.Errorf("failed to delete"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock := redismock.NewClientMock()
+			tt.mock(mock)
+
+			repo := &RedisSessionRepository{rdb: mockDB}
+
+			got, err := repo.DeleteUserSessions(context.Background(), userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteUserSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,10 @@ import (
 	"quickflow/pkg/logger"
 )
 
+// userSessionsKeyPrefix indexes sessions of the user by their expiration time,
+// so that all of them can be found without scanning the whole keyspace.
+const userSessionsKeyPrefix = "sessions:user:"
+
 type RedisSessionRepository struct {
 	rdb *redis.Client
 }
@@ -33,7 +38,14 @@ func NewRedisSessionRepository() *RedisSessionRepository {
 func (r *RedisSessionRepository) SaveSession(ctx context.Context, userId uuid.UUID, session models.Session) error {
 	logger.Info(ctx, fmt.Sprintf("Trying to save session in Redis for userId: %s", userId.String()))
 
-	if err := r.rdb.Set(ctx, session.SessionId.String(), userId.String(), time.Until(session.ExpireDate)).Err(); err != nil {
+	indexKey := userSessionsKeyPrefix + userId.String()
+	pipe := r.rdb.TxPipeline()
+	pipe.Set(ctx, session.SessionId.String(), userId.String(), time.Until(session.ExpireDate))
+	pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(session.ExpireDate.Unix()), Member: session.SessionId.String()})
+	// expired sessions are forgotten by the index on the next login
+	pipe.ZRemRangeByScore(ctx, indexKey, "-inf", "("+strconv.FormatInt(time.Now().Unix(), 10))
+	pipe.ExpireAt(ctx, indexKey, session.ExpireDate)
+	if _, err := pipe.Exec(ctx); err != nil {
 		logger.Error(ctx, "Failed to save session to redis")
 		return fmt.Errorf("saving session error: %w", err)
 	}
@@ -97,6 +109,45 @@ func (r *RedisSessionRepository) DeleteSession(ctx context.Context, session stri
 	return nil
 }
 
+// CountUserSessions returns the number of sessions of the user that are not expired yet.
+// Sessions ended by logout are counted until they expire.
+func (r *RedisSessionRepository) CountUserSessions(ctx context.Context, userId uuid.UUID) (int, error) {
+	count, err := r.rdb.ZCount(ctx, userSessionsKeyPrefix+userId.String(), "("+strconv.FormatInt(time.Now().Unix(), 10), "+inf").Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to count sessions in Redis for userId %s: %s", userId, err.Error()))
+		return 0, fmt.Errorf("unable to count sessions: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// DeleteUserSessions logs the user out of every session and returns the number of deleted sessions.
+func (r *RedisSessionRepository) DeleteUserSessions(ctx context.Context, userId uuid.UUID) (int, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to delete all sessions in Redis for userId: %s", userId.String()))
+
+	indexKey := userSessionsKeyPrefix + userId.String()
+	sessions, err := r.rdb.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get sessions from Redis for userId %s: %s", userId, err.Error()))
+		return 0, fmt.Errorf("unable to get sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := r.rdb.Del(ctx, append(sessions, indexKey)...).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to delete sessions from Redis for userId %s: %s", userId, err.Error()))
+		return 0, fmt.Errorf("unable to delete sessions: %w", err)
+	}
+	// the index itself is not a session
+	deleted--
+
+	logger.Info(ctx, fmt.Sprintf("Successfully deleted %d sessions in Redis for userId: %s", deleted, userId.String()))
+
+	return int(deleted), nil
+}
+
 func (r *RedisSessionRepository) Close() {
 	err := r.rdb.Close()
 	if err != nil {
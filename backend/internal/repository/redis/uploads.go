@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	redis2 "quickflow/config/redis"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/pkg/logger"
+)
+
+const uploadsKeyPrefix = "uploads:"
+
+type RedisUploadSessionRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisUploadSessionRepository() *RedisUploadSessionRepository {
+	redisCfg := redis2.NewRedisConfig()
+
+	return &RedisUploadSessionRepository{
+		rdb: redis.NewClient(&redis.Options{
+			Addr:     redisCfg.GetURL(),
+			Password: redisCfg.GetPass(),
+		}),
+	}
+}
+
+// SaveUploadSession stores upload session until it expires.
+func (r *RedisUploadSessionRepository) SaveUploadSession(ctx context.Context, session models.UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("unable to marshal upload session: %w", err)
+	}
+
+	if err = r.rdb.Set(ctx, uploadsKeyPrefix+session.Key, data, time.Until(session.ExpiresAt)).Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to save upload session %s to redis: %s", session.Key, err.Error()))
+		return fmt.Errorf("saving upload session error: %w", err)
+	}
+	return nil
+}
+
+// TakeUploadSession atomically removes upload session and returns it, so concurrent commits
+// of the same object can't both succeed. Returns usecase.ErrNotFound if the session has expired or is taken.
+func (r *RedisUploadSessionRepository) TakeUploadSession(ctx context.Context, key string) (models.UploadSession, error) {
+	data, err := r.rdb.GetDel(ctx, uploadsKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return models.UploadSession{}, usecase.ErrNotFound
+	} else if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to take upload session %s from redis: %s", key, err.Error()))
+		return models.UploadSession{}, fmt.Errorf("unable to take upload session: %w", err)
+	}
+
+	var session models.UploadSession
+	if err = json.Unmarshal(data, &session); err != nil {
+		return models.UploadSession{}, fmt.Errorf("unable to unmarshal upload session: %w", err)
+	}
+	return session, nil
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+)
+
+func TestTakeUploadSession(t *testing.T) {
+	session := models.UploadSession{
+		Key:       "b1a7c2a6-5d0e-4c1b-8a61-6d5f1e1b2c3d.mp4",
+		UserId:    uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a"),
+		Purpose:   models.FilePurposeAttachment,
+		Size:      1024,
+		MimeType:  "video/mp4",
+		ExpiresAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data, err := json.Marshal(session)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		want    models.UploadSession
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "Successfully take upload session",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGetDel("uploads:" + session.Key).SetVal(string(data))
+			},
+			want: session,
+		},
+		{
+			name: "Expired or taken upload session",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGetDel("uploads:" + session.Key).RedisNil()
+			},
+			wantErr: true,
+			errIs:   usecase.ErrNotFound,
+		},
+		{
+			name: "Failed to take upload session",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGetDel("uploads:" + session.Key).SetErr(fmt.Errorf("failed to take"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock := redismock.NewClientMock()
+			tt.mock(mock)
+
+			repo := &RedisUploadSessionRepository{rdb: mockDB}
+
+			got, err := repo.TakeUploadSession(context.Background(), session.Key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
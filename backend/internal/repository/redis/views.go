@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	redis2 "quickflow/config/redis"
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+const (
+	viewersKeyPrefix = "views:viewers:"
+	pendingViewsKey  = "views:pending"
+	flushingViewsKey = "views:flushing"
+	viewDayLayout    = "2006-01-02"
+
+	// viewers of the day are kept a bit longer than the day, so views near midnight are not counted twice
+	viewersTTL = 48 * time.Hour
+)
+
+type RedisViewCounterRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisViewCounterRepository() *RedisViewCounterRepository {
+	redisCfg := redis2.NewRedisConfig()
+
+	return &RedisViewCounterRepository{
+		rdb: redis.NewClient(&redis.Options{
+			Addr:     redisCfg.GetURL(),
+			Password: redisCfg.GetPass(),
+		}),
+	}
+}
+
+// RecordViews counts views of the posts by the viewer, each viewer is counted once a UTC day.
+// Viewers are kept in HyperLogLogs, so a small share of new viewers of popular posts may be missed.
+func (r *RedisViewCounterRepository) RecordViews(ctx context.Context, viewerId uuid.UUID, postIds []uuid.UUID, at time.Time) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+	day := at.UTC().Format(viewDayLayout)
+
+	pipe := r.rdb.Pipeline()
+	added := make([]*redis.IntCmd, 0, len(postIds))
+	for _, postId := range postIds {
+		key := viewersKeyPrefix + day + ":" + postId.String()
+		added = append(added, pipe.PFAdd(ctx, key, viewerId.String()))
+		pipe.Expire(ctx, key, viewersTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to record viewer %s of posts in redis: %s", viewerId, err.Error()))
+		return fmt.Errorf("unable to record views: %w", err)
+	}
+
+	pipe = r.rdb.Pipeline()
+	for i, cmd := range added {
+		if cmd.Val() == 1 {
+			pipe.HIncrBy(ctx, pendingViewsKey, postIds[i].String()+":"+day, 1)
+		}
+	}
+	if pipe.Len() == 0 {
+		return nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to count views of viewer %s in redis: %s", viewerId, err.Error()))
+		return fmt.Errorf("unable to record views: %w", err)
+	}
+	return nil
+}
+
+// TakePendingViews returns views counted since the previous flush. Views are returned again
+// until AckPendingViews is called, while new views are counted for the next flush.
+func (r *RedisViewCounterRepository) TakePendingViews(ctx context.Context) ([]models.PostViews, error) {
+	// views left by a failed flush are kept in place and taken first
+	err := r.rdb.RenameNX(ctx, pendingViewsKey, flushingViewsKey).Err()
+	if err != nil && !strings.Contains(err.Error(), "no such key") {
+		logger.Error(ctx, fmt.Sprintf("Failed to take pending views in redis: %s", err.Error()))
+		return nil, fmt.Errorf("unable to take pending views: %w", err)
+	}
+
+	counts, err := r.rdb.HGetAll(ctx, flushingViewsKey).Result()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to get pending views from redis: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get pending views: %w", err)
+	}
+
+	views := make([]models.PostViews, 0, len(counts))
+	for field, value := range counts {
+		postViews, err := parsePendingViews(field, value)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Failed to parse pending views %s=%s: %s", field, value, err.Error()))
+			continue
+		}
+		views = append(views, postViews)
+	}
+	return views, nil
+}
+
+// AckPendingViews forgets views returned by TakePendingViews once they are saved.
+func (r *RedisViewCounterRepository) AckPendingViews(ctx context.Context) error {
+	if err := r.rdb.Del(ctx, flushingViewsKey).Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to remove flushed views from redis: %s", err.Error()))
+		return fmt.Errorf("unable to remove flushed views: %w", err)
+	}
+	return nil
+}
+
+// parsePendingViews parses views counted in the hash field "<post id>:<day>".
+func parsePendingViews(field string, value string) (models.PostViews, error) {
+	postId, day, ok := strings.Cut(field, ":")
+	if !ok {
+		return models.PostViews{}, fmt.Errorf("unexpected field %q", field)
+	}
+
+	var (
+		views models.PostViews
+		err   error
+	)
+	if views.PostId, err = uuid.Parse(postId); err != nil {
+		return models.PostViews{}, err
+	}
+	if views.Day, err = time.Parse(viewDayLayout, day); err != nil {
+		return models.PostViews{}, err
+	}
+	if views.Count, err = strconv.Atoi(value); err != nil {
+		return models.PostViews{}, err
+	}
+	return views, nil
+}
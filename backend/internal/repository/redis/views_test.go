@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"quickflow/internal/models"
+)
+
+func TestRecordViews(t *testing.T) {
+	viewerId := uuid.MustParse("9e49c172-8626-4c60-8240-6b8e774e0a4a")
+	newPostId := uuid.MustParse("22896b51-8736-42dc-bf6f-b438c1ad3aa5")
+	seenPostId := uuid.MustParse("b3c1e8a2-6b0f-4f5e-9d2a-1f1b8c7d6e5a")
+	at := time.Date(2025, 5, 1, 23, 30, 0, 0, time.UTC)
+
+	mockDB, mock := redismock.NewClientMock()
+	mock.ExpectPFAdd("views:viewers:2025-05-01:"+newPostId.String(), viewerId.String()).SetVal(1)
+	mock.ExpectExpire("views:viewers:2025-05-01:"+newPostId.String(), 48*time.Hour).SetVal(true)
+	mock.ExpectPFAdd("views:viewers:2025-05-01:"+seenPostId.String(), viewerId.String()).SetVal(0)
+	mock.ExpectExpire("views:viewers:2025-05-01:"+seenPostId.String(), 48*time.Hour).SetVal(true)
+	// only the post the viewer has not seen today gets a new view
+	mock.ExpectHIncrBy("views:pending", newPostId.String()+":2025-05-01", 1).SetVal(1)
+
+	repo := &RedisViewCounterRepository{rdb: mockDB}
+
+	err := repo.RecordViews(context.Background(), viewerId, []uuid.UUID{newPostId, seenPostId}, at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakePendingViews(t *testing.T) {
+	postId := uuid.MustParse("22896b51-8736-42dc-bf6f-b438c1ad3aa5")
+
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		want    []models.PostViews
+		wantErr bool
+	}{
+		{
+			name: "Successfully take pending views",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectRenameNX("views:pending", "views:flushing").SetVal(true)
+				mock.ExpectHGetAll("views:flushing").SetVal(map[string]string{
+					postId.String() + ":2025-05-01": "3",
+					"broken":                        "1",
+				})
+			},
+			want: []models.PostViews{{PostId: postId, Day: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Count: 3}},
+		},
+		{
+			name: "No views since the previous flush",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectRenameNX("views:pending", "views:flushing").SetErr(fmt.Errorf("ERR no such key"))
+				mock.ExpectHGetAll("views:flushing").SetVal(map[string]string{})
+			},
+			want: []models.PostViews{},
+		},
+		{
+			name: "Failed to get pending views",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectRenameNX("views:pending", "views:flushing").SetVal(true)
+				mock.ExpectHGetAll("views:flushing").SetErr(fmt.Errorf("failed to get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock := redismock.NewClientMock()
+			tt.mock(mock)
+
+			repo := &RedisViewCounterRepository{rdb: mockDB}
+
+			got, err := repo.TakePendingViews(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
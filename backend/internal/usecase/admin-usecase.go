@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+var (
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrCannotChangeOwnRole = errors.New("users can not change their own role")
+	ErrInvalidBan          = errors.New("invalid ban")
+	ErrCannotBanSelf       = errors.New("admins can not ban themselves")
+	ErrInvalidNumAccounts  = errors.New("invalid number of accounts")
+)
+
+const (
+	maxBanReasonLength = 1000
+	maxAccountsPerPage = 100
+)
+
+// UserContentRemover removes all content of the user along with its files.
+type UserContentRemover interface {
+	DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, error)
+}
+
+type AdminService struct {
+	userRepo    UserRepository
+	sessionRepo SessionRepository
+	content     UserContentRemover
+}
+
+// NewAdminService creates new service of administration of users.
+func NewAdminService(userRepo UserRepository, sessionRepo SessionRepository, content UserContentRemover) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		content:     content,
+	}
+}
+
+// SetUserRole grants the role to the user with the username instead of their current one.
+// Admins can't change their own role, so the service never loses its last admin by mistake.
+func (a *AdminService) SetUserRole(ctx context.Context, admin models.User, username string, role models.Role) (models.User, error) {
+	if err := Authorize(admin, models.PermissionManageRoles); err != nil {
+		return models.User{}, err
+	}
+	if !role.IsValid() {
+		return models.User{}, fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	user, err := a.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.User{}, fmt.Errorf("a.userRepo.GetUserByUsername: %w", err)
+	}
+	if user.Id == admin.Id {
+		return models.User{}, ErrCannotChangeOwnRole
+	}
+
+	if err = a.userRepo.SetUserRole(ctx, user.Id, role); err != nil {
+		return models.User{}, fmt.Errorf("a.userRepo.SetUserRole: %w", err)
+	}
+	user.Role = role
+	return user, nil
+}
+
+// GrantAdmin makes the user with the username an admin without authorization.
+// It bootstraps the first admin from the command line and must not be exposed to clients.
+func (a *AdminService) GrantAdmin(ctx context.Context, username string) (models.User, error) {
+	user, err := a.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.User{}, fmt.Errorf("a.userRepo.GetUserByUsername: %w", err)
+	}
+
+	if err = a.userRepo.SetUserRole(ctx, user.Id, models.RoleAdmin); err != nil {
+		return models.User{}, fmt.Errorf("a.userRepo.SetUserRole: %w", err)
+	}
+	user.Role = models.RoleAdmin
+	return user, nil
+}
+
+// SearchAccounts returns accounts whose username or full name contains the query, ordered by username.
+func (a *AdminService) SearchAccounts(ctx context.Context, admin models.User, query string, count int) ([]models.Account, error) {
+	if err := Authorize(admin, models.PermissionManageUsers); err != nil {
+		return nil, err
+	}
+	if count <= 0 || count > maxAccountsPerPage {
+		return nil, fmt.Errorf("%w: must be from 1 to %d", ErrInvalidNumAccounts, maxAccountsPerPage)
+	}
+
+	accounts, err := a.userRepo.SearchAccounts(ctx, strings.TrimSpace(query), count, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("a.userRepo.SearchAccounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// GetAccount returns the account of the user with the username and the number of their active sessions.
+func (a *AdminService) GetAccount(ctx context.Context, admin models.User, username string) (models.AccountDetails, error) {
+	if err := Authorize(admin, models.PermissionManageUsers); err != nil {
+		return models.AccountDetails{}, err
+	}
+
+	account, err := a.userRepo.GetAccount(ctx, username, time.Now())
+	if err != nil {
+		return models.AccountDetails{}, fmt.Errorf("a.userRepo.GetAccount: %w", err)
+	}
+
+	sessions, err := a.sessionRepo.CountUserSessions(ctx, account.Id)
+	if err != nil {
+		return models.AccountDetails{}, fmt.Errorf("a.sessionRepo.CountUserSessions: %w", err)
+	}
+	return models.AccountDetails{Account: account, ActiveSessions: sessions}, nil
+}
+
+// BanUser forbids the user with the username to use the service until the time, zero until bans them permanently.
+// The user is logged out of every session. The reason is trimmed.
+func (a *AdminService) BanUser(ctx context.Context, admin models.User, username string, reason string, until time.Time) (models.Ban, error) {
+	user, err := a.userToManage(ctx, admin, username)
+	if err != nil {
+		return models.Ban{}, err
+	}
+
+	now := time.Now()
+	reason = strings.TrimSpace(reason)
+	switch {
+	case len(reason) == 0:
+		return models.Ban{}, fmt.Errorf("%w: reason is required", ErrInvalidBan)
+	case utf8.RuneCountInString(reason) > maxBanReasonLength:
+		return models.Ban{}, fmt.Errorf("%w: reason can not be longer than %d characters", ErrInvalidBan, maxBanReasonLength)
+	case !until.IsZero() && !until.After(now):
+		return models.Ban{}, fmt.Errorf("%w: ban must end in the future", ErrInvalidBan)
+	case user.Id == admin.Id:
+		return models.Ban{}, ErrCannotBanSelf
+	}
+
+	ban := models.Ban{
+		Id:        uuid.New(),
+		UserId:    user.Id,
+		Reason:    reason,
+		BannedBy:  admin.Id,
+		Until:     until,
+		CreatedAt: now,
+	}
+	if err = a.userRepo.SaveBan(ctx, ban); err != nil {
+		return models.Ban{}, fmt.Errorf("a.userRepo.SaveBan: %w", err)
+	}
+
+	// sessions that are left are rejected anyway, so the ban is not undone
+	if _, err = a.sessionRepo.DeleteUserSessions(ctx, user.Id); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to log out banned user %s: %s", user.Username, err.Error()))
+	}
+	return ban, nil
+}
+
+// LiftBan ends active bans of the user with the username.
+// It returns ErrNotFound if the user is not banned.
+func (a *AdminService) LiftBan(ctx context.Context, admin models.User, username string) error {
+	user, err := a.userToManage(ctx, admin, username)
+	if err != nil {
+		return err
+	}
+
+	if err = a.userRepo.LiftBans(ctx, user.Id, time.Now()); err != nil {
+		return fmt.Errorf("a.userRepo.LiftBans: %w", err)
+	}
+	return nil
+}
+
+// LogoutUser ends every session of the user with the username and returns their number.
+func (a *AdminService) LogoutUser(ctx context.Context, admin models.User, username string) (int, error) {
+	user, err := a.userToManage(ctx, admin, username)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := a.sessionRepo.DeleteUserSessions(ctx, user.Id)
+	if err != nil {
+		return 0, fmt.Errorf("a.sessionRepo.DeleteUserSessions: %w", err)
+	}
+	return deleted, nil
+}
+
+// DeleteUserContent removes every post, comment and message of the user with the username.
+func (a *AdminService) DeleteUserContent(ctx context.Context, admin models.User, username string) (models.DeletedContent, error) {
+	user, err := a.userToManage(ctx, admin, username)
+	if err != nil {
+		return models.DeletedContent{}, err
+	}
+
+	deleted, err := a.content.DeleteUserContent(ctx, user.Id)
+	if err != nil {
+		return models.DeletedContent{}, fmt.Errorf("a.content.DeleteUserContent: %w", err)
+	}
+	return deleted, nil
+}
+
+// userToManage returns the user with the username if the admin is allowed to manage users
+// and the user has a lower role. Admins may manage their own account, banning it is checked by BanUser.
+func (a *AdminService) userToManage(ctx context.Context, admin models.User, username string) (models.User, error) {
+	if err := Authorize(admin, models.PermissionManageUsers); err != nil {
+		return models.User{}, err
+	}
+
+	user, err := a.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.User{}, fmt.Errorf("a.userRepo.GetUserByUsername: %w", err)
+	}
+	if user.Id != admin.Id && !admin.Role.Outranks(user.Role) {
+		return models.User{}, fmt.Errorf("%w: %s has equal or higher role", ErrForbidden, user.Username)
+	}
+	return user, nil
+}
@@ -0,0 +1,287 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		role       models.Role
+		permission models.Permission
+		allowed    bool
+	}{
+		{role: models.RoleUser, permission: models.PermissionModerateContent},
+		{role: models.RoleModerator, permission: models.PermissionModerateContent, allowed: true},
+		{role: models.RoleModerator, permission: models.PermissionModerateReports, allowed: true},
+		{role: models.RoleModerator, permission: models.PermissionManageUsers},
+		{role: models.RoleModerator, permission: models.PermissionManageRoles},
+		{role: models.RoleAdmin, permission: models.PermissionManageUsers, allowed: true},
+		{role: models.RoleAdmin, permission: models.PermissionManageRoles, allowed: true},
+		{role: "", permission: models.PermissionModerateContent},
+		{role: "superuser", permission: models.PermissionManageRoles},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.permission), func(t *testing.T) {
+			err := usecase.Authorize(models.User{Id: uuid.New(), Role: tt.role}, tt.permission)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, usecase.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestAdminService_SetUserRole(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	target := models.User{Id: uuid.New(), Username: "johndoe", Role: models.RoleUser}
+
+	tests := []struct {
+		name        string
+		admin       models.User
+		username    string
+		role        models.Role
+		user        models.User
+		getErr      error
+		expectGet   bool
+		expectSet   bool
+		expectedErr error
+	}{
+		{name: "grant moderator", admin: admin, username: target.Username, role: models.RoleModerator, user: target, expectGet: true, expectSet: true},
+		{name: "moderator can't grant roles", admin: moderator, username: target.Username, role: models.RoleAdmin, expectedErr: usecase.ErrForbidden},
+		{name: "unknown role", admin: admin, username: target.Username, role: "owner", expectedErr: usecase.ErrInvalidRole},
+		{name: "unknown user", admin: admin, username: "nobody", role: models.RoleModerator, getErr: usecase.ErrNotFound, expectGet: true, expectedErr: usecase.ErrNotFound},
+		{name: "own role", admin: admin, username: admin.Username, role: models.RoleUser, user: admin, expectGet: true, expectedErr: usecase.ErrCannotChangeOwnRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			if tt.expectGet {
+				mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), tt.username).Return(tt.user, tt.getErr)
+			}
+			if tt.expectSet {
+				mockUserRepo.EXPECT().SetUserRole(gomock.Any(), tt.user.Id, tt.role).Return(nil)
+			}
+
+			adminService := usecase.NewAdminService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockUserContentRemover(ctrl))
+			user, err := adminService.SetUserRole(context.Background(), tt.admin, tt.username, tt.role)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, user.Role)
+		})
+	}
+}
+
+func TestAdminService_GrantAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.New(), Username: "founder", Role: models.RoleUser}
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil)
+	mockUserRepo.EXPECT().SetUserRole(gomock.Any(), user.Id, models.RoleAdmin).Return(nil)
+
+	adminService := usecase.NewAdminService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockUserContentRemover(ctrl))
+	granted, err := adminService.GrantAdmin(context.Background(), user.Username)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, granted.Role)
+}
+
+func TestAdminService_SearchAccounts(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	accounts := []models.Account{{Id: uuid.New(), Username: "johndoe", Role: models.RoleUser}}
+
+	tests := []struct {
+		name        string
+		admin       models.User
+		count       int
+		expectRepo  bool
+		expectedErr error
+	}{
+		{name: "success", admin: admin, count: 10, expectRepo: true},
+		{name: "moderator can't search accounts", admin: moderator, count: 10, expectedErr: usecase.ErrForbidden},
+		{name: "zero count", admin: admin, count: 0, expectedErr: usecase.ErrInvalidNumAccounts},
+		{name: "too many", admin: admin, count: 101, expectedErr: usecase.ErrInvalidNumAccounts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			if tt.expectRepo {
+				mockUserRepo.EXPECT().SearchAccounts(gomock.Any(), "john", tt.count, gomock.Any()).Return(accounts, nil)
+			}
+
+			adminService := usecase.NewAdminService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockUserContentRemover(ctrl))
+			result, err := adminService.SearchAccounts(context.Background(), tt.admin, " john ", tt.count)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, accounts, result)
+		})
+	}
+}
+
+func TestAdminService_GetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	account := models.Account{Id: uuid.New(), Username: "johndoe", Role: models.RoleUser}
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo.EXPECT().GetAccount(gomock.Any(), account.Username, gomock.Any()).Return(account, nil)
+	mockSessionRepo.EXPECT().CountUserSessions(gomock.Any(), account.Id).Return(3, nil)
+
+	adminService := usecase.NewAdminService(mockUserRepo, mockSessionRepo, mocks.NewMockUserContentRemover(ctrl))
+	details, err := adminService.GetAccount(context.Background(), admin, account.Username)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.AccountDetails{Account: account, ActiveSessions: 3}, details)
+}
+
+func TestAdminService_BanUser(t *testing.T) {
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	target := models.User{Id: uuid.New(), Username: "johndoe", Role: models.RoleUser}
+
+	tests := []struct {
+		name          string
+		admin         models.User
+		username      string
+		reason        string
+		until         time.Time
+		user          models.User
+		expectGet     bool
+		expectSave    bool
+		logoutErr     error
+		expectedErr   error
+		expectedUntil time.Time
+	}{
+		{name: "permanent ban", admin: admin, username: target.Username, reason: " spam ", user: target, expectGet: true, expectSave: true},
+		{name: "suspension", admin: admin, username: target.Username, reason: "spam", until: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			user: target, expectGet: true, expectSave: true, expectedUntil: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "sessions are not deleted", admin: admin, username: target.Username, reason: "spam", user: target, expectGet: true, expectSave: true,
+			logoutErr: errors.New("redis error")},
+		{name: "moderator can't ban from admin api", admin: moderator, username: target.Username, reason: "spam", expectedErr: usecase.ErrForbidden},
+		{name: "empty reason", admin: admin, username: target.Username, reason: "  ", user: target, expectGet: true, expectedErr: usecase.ErrInvalidBan},
+		{name: "ban in the past", admin: admin, username: target.Username, reason: "spam", until: time.Now().Add(-time.Hour),
+			user: target, expectGet: true, expectedErr: usecase.ErrInvalidBan},
+		{name: "own account", admin: admin, username: admin.Username, reason: "spam", user: admin, expectGet: true, expectedErr: usecase.ErrCannotBanSelf},
+		{name: "another admin", admin: admin, username: "root", reason: "spam", user: models.User{Id: uuid.New(), Username: "root", Role: models.RoleAdmin},
+			expectGet: true, expectedErr: usecase.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			if tt.expectGet {
+				mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), tt.username).Return(tt.user, nil)
+			}
+			if tt.expectSave {
+				mockUserRepo.EXPECT().SaveBan(gomock.Any(), gomock.Any()).Return(nil)
+				mockSessionRepo.EXPECT().DeleteUserSessions(gomock.Any(), tt.user.Id).Return(2, tt.logoutErr)
+			}
+
+			adminService := usecase.NewAdminService(mockUserRepo, mockSessionRepo, mocks.NewMockUserContentRemover(ctrl))
+			ban, err := adminService.BanUser(context.Background(), tt.admin, tt.username, tt.reason, tt.until)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, target.Id, ban.UserId)
+			assert.Equal(t, admin.Id, ban.BannedBy)
+			assert.Equal(t, "spam", ban.Reason)
+			assert.Equal(t, tt.expectedUntil, ban.Until)
+		})
+	}
+}
+
+func TestAdminService_LiftBan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	user := models.User{Id: uuid.New(), Username: "johndoe"}
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil)
+	mockUserRepo.EXPECT().LiftBans(gomock.Any(), user.Id, gomock.Any()).Return(usecase.ErrNotFound)
+
+	adminService := usecase.NewAdminService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockUserContentRemover(ctrl))
+	err := adminService.LiftBan(context.Background(), admin, user.Username)
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func TestAdminService_LogoutUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	user := models.User{Id: uuid.New(), Username: "johndoe"}
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil)
+	mockSessionRepo.EXPECT().DeleteUserSessions(gomock.Any(), user.Id).Return(2, nil)
+
+	adminService := usecase.NewAdminService(mockUserRepo, mockSessionRepo, mocks.NewMockUserContentRemover(ctrl))
+	deleted, err := adminService.LogoutUser(context.Background(), admin, user.Username)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestAdminService_DeleteUserContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+	user := models.User{Id: uuid.New(), Username: "johndoe"}
+	content := models.DeletedContent{Posts: 2, Comments: 5, Messages: 1}
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockContent := mocks.NewMockUserContentRemover(ctrl)
+	mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil)
+	mockContent.EXPECT().DeleteUserContent(gomock.Any(), user.Id).Return(content, nil)
+
+	adminService := usecase.NewAdminService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), mockContent)
+	deleted, err := adminService.DeleteUserContent(context.Background(), admin, user.Username)
+
+	require.NoError(t, err)
+	assert.Equal(t, content, deleted)
+}
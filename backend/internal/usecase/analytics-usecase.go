@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+const (
+	maxAnalyticsDays  = 366
+	analyticsTopPosts = 10
+)
+
+type ViewCounter interface {
+	// RecordViews counts views of the posts by the viewer, each viewer is counted once a UTC day.
+	RecordViews(ctx context.Context, viewerId uuid.UUID, postIds []uuid.UUID, at time.Time) error
+	// TakePendingViews returns views counted since the previous flush.
+	// The same views are returned until AckPendingViews is called.
+	TakePendingViews(ctx context.Context) ([]models.PostViews, error)
+	AckPendingViews(ctx context.Context) error
+}
+
+type AnalyticsRepository interface {
+	AddPostViews(ctx context.Context, views []models.PostViews) error
+	GetDailyStats(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time) ([]models.DailyStats, error)
+	GetTopPosts(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time, limit int) ([]models.PostStats, error)
+}
+
+type AnalyticsService struct {
+	analyticsRepo AnalyticsRepository
+	views         ViewCounter
+}
+
+// NewAnalyticsService creates new service of post views and author analytics.
+func NewAnalyticsService(analyticsRepo AnalyticsRepository, views ViewCounter) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		views:         views,
+	}
+}
+
+// recordViews counts views of published posts of other users by the viewer.
+// Anonymous viewers can't be told apart, so their views are not counted.
+func recordViews(ctx context.Context, views ViewCounter, posts []models.Post, viewerId uuid.UUID) error {
+	if viewerId == uuid.Nil {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	for _, post := range posts {
+		if post.CreatorId != viewerId && post.Status.IsPublished() {
+			ids = append(ids, post.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := views.RecordViews(ctx, viewerId, ids, time.Now()); err != nil {
+		return fmt.Errorf("views.RecordViews: %w", err)
+	}
+	return nil
+}
+
+// FlushViews moves views counted since the previous flush to the repository.
+// Views are kept in the counter if they can't be saved and are flushed next time.
+func (a *AnalyticsService) FlushViews(ctx context.Context) error {
+	views, err := a.views.TakePendingViews(ctx)
+	if err != nil {
+		return fmt.Errorf("a.views.TakePendingViews: %w", err)
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	if err = a.analyticsRepo.AddPostViews(ctx, views); err != nil {
+		return fmt.Errorf("a.analyticsRepo.AddPostViews: %w", err)
+	}
+	if err = a.views.AckPendingViews(ctx); err != nil {
+		return fmt.Errorf("a.views.AckPendingViews: %w", err)
+	}
+	return nil
+}
+
+// FetchAnalytics returns daily activity around posts and profile of the user and the posts
+// with the most views for UTC days from from to to inclusive.
+func (a *AnalyticsService) FetchAnalytics(ctx context.Context, userId uuid.UUID, from time.Time, to time.Time) (models.Analytics, error) {
+	from, to = truncateToDay(from), truncateToDay(to)
+	if to.Before(from) {
+		return models.Analytics{}, fmt.Errorf("%w: %s is after %s", ErrInvalidDateRange, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	if days := int(to.Sub(from)/(24*time.Hour)) + 1; days > maxAnalyticsDays {
+		return models.Analytics{}, fmt.Errorf("%w: range can not be longer than %d days", ErrInvalidDateRange, maxAnalyticsDays)
+	}
+
+	days, err := a.analyticsRepo.GetDailyStats(ctx, userId, from, to)
+	if err != nil {
+		return models.Analytics{}, fmt.Errorf("a.analyticsRepo.GetDailyStats: %w", err)
+	}
+	topPosts, err := a.analyticsRepo.GetTopPosts(ctx, userId, from, to, analyticsTopPosts)
+	if err != nil {
+		return models.Analytics{}, fmt.Errorf("a.analyticsRepo.GetTopPosts: %w", err)
+	}
+
+	return models.Analytics{
+		From:     from,
+		To:       to,
+		Days:     days,
+		TopPosts: topPosts,
+	}, nil
+}
+
+// truncateToDay returns the start of the UTC day of t.
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestAnalyticsService_FlushViews(t *testing.T) {
+	views := []models.PostViews{{PostId: uuid.New(), Day: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Count: 3}}
+
+	tests := []struct {
+		name      string
+		pending   []models.PostViews
+		saveErr   error
+		wantErr   bool
+		wantSaved bool
+	}{
+		{name: "nothing to flush"},
+		{name: "views are saved and forgotten", pending: views, wantSaved: true},
+		{name: "views are kept when they are not saved", pending: views, saveErr: errors.New("db error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockViews := mocks.NewMockViewCounter(ctrl)
+			mockAnalyticsRepo := mocks.NewMockAnalyticsRepository(ctrl)
+			mockViews.EXPECT().TakePendingViews(gomock.Any()).Return(tt.pending, nil)
+			if len(tt.pending) > 0 {
+				mockAnalyticsRepo.EXPECT().AddPostViews(gomock.Any(), tt.pending).Return(tt.saveErr)
+			}
+			if tt.wantSaved {
+				mockViews.EXPECT().AckPendingViews(gomock.Any()).Return(nil)
+			}
+
+			service := usecase.NewAnalyticsService(mockAnalyticsRepo, mockViews)
+			err := service.FlushViews(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_FetchAnalytics(t *testing.T) {
+	userId := uuid.New()
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("range of whole days", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		days := []models.DailyStats{{Day: day(1), Views: 10, Likes: 2}, {Day: day(2), Comments: 1, NewFollowers: 1}}
+		top := []models.PostStats{{PostId: uuid.New(), Views: 10, Likes: 2}}
+		mockAnalyticsRepo := mocks.NewMockAnalyticsRepository(ctrl)
+		mockAnalyticsRepo.EXPECT().GetDailyStats(gomock.Any(), userId, day(1), day(2)).Return(days, nil)
+		mockAnalyticsRepo.EXPECT().GetTopPosts(gomock.Any(), userId, day(1), day(2), 10).Return(top, nil)
+
+		service := usecase.NewAnalyticsService(mockAnalyticsRepo, mocks.NewMockViewCounter(ctrl))
+		analytics, err := service.FetchAnalytics(context.Background(), userId, day(1).Add(13*time.Hour), day(2).Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, models.Analytics{From: day(1), To: day(2), Days: days, TopPosts: top}, analytics)
+	})
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+	}{
+		{name: "end before start", from: day(2), to: day(1)},
+		{name: "range is too long", from: day(1).AddDate(-1, 0, -1), to: day(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := usecase.NewAnalyticsService(mocks.NewMockAnalyticsRepository(ctrl), mocks.NewMockViewCounter(ctrl))
+			_, err := service.FetchAnalytics(context.Background(), userId, tt.from, tt.to)
+			assert.ErrorIs(t, err, usecase.ErrInvalidDateRange)
+		})
+	}
+}
+
+func TestPostService_FetchUserPosts_Views(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	author := models.User{Id: uuid.New()}
+	viewerId := uuid.New()
+	cursor := models.Cursor{Ts: time.Now(), Id: uuid.New()}
+	published := models.Post{Id: uuid.New(), CreatorId: author.Id, Status: models.PostStatusPublished}
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+	mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+	mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+	mockViews := mocks.NewMockViewCounter(ctrl)
+	mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), viewerId, author.Id).Return(models.RelationStranger, nil)
+	mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), author.Id).Return(models.DefaultPrivacySettings(), nil)
+	mockPostRepo.EXPECT().GetUserPosts(gomock.Any(), author.Id, viewerId, 10, cursor).Return([]models.Post{published}, nil)
+	mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), viewerId, gomock.Any()).Return(map[uuid.UUID]bool{}, nil)
+	// views are statistics only, so the posts are returned even if they are not counted
+	mockViews.EXPECT().RecordViews(gomock.Any(), viewerId, []uuid.UUID{published.Id}, gomock.Any()).Return(errors.New("redis error"))
+
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mockProfileRepo, mockFriendsRepo, mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mockViews, mocks.NewMockFeedFilterRepository(ctrl))
+	posts, err := postService.FetchUserPosts(context.Background(), author, viewerId, 10, cursor, false)
+	require.NoError(t, err)
+	published.CreatorRelation = models.RelationStranger
+	assert.Equal(t, []models.Post{published}, posts)
+}
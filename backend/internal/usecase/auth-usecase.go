@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
+	time2 "quickflow/config/time"
 	"quickflow/internal/models"
 )
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrUserBanned    = errors.New("user is banned")
 )
 
 type UserRepository interface {
@@ -21,6 +24,14 @@ type UserRepository interface {
 	GetUserByUsername(ctx context.Context, username string) (models.User, error)
 	GetUserByUId(ctx context.Context, uid uuid.UUID) (models.User, error)
 	IsExists(ctx context.Context, login string) (bool, error)
+	SetUserRole(ctx context.Context, userId uuid.UUID, role models.Role) error
+	SearchAccounts(ctx context.Context, toSearch string, count int, now time.Time) ([]models.Account, error)
+	// GetAccount returns ErrNotFound if there is no such user.
+	GetAccount(ctx context.Context, username string, now time.Time) (models.Account, error)
+	SaveBan(ctx context.Context, ban models.Ban) error
+	// GetActiveBan and LiftBans return ErrNotFound if the user is not banned.
+	GetActiveBan(ctx context.Context, userId uuid.UUID, now time.Time) (models.Ban, error)
+	LiftBans(ctx context.Context, userId uuid.UUID, now time.Time) error
 
 	SearchSimilar(ctx context.Context, toSearch string, postsCount uint) ([]models.PublicUserInfo, error)
 }
@@ -30,6 +41,8 @@ type SessionRepository interface {
 	LookupUserSession(ctx context.Context, session models.Session) (uuid.UUID, error)
 	IsExists(ctx context.Context, sessionId uuid.UUID) (bool, error)
 	DeleteSession(ctx context.Context, sessionId string) error
+	CountUserSessions(ctx context.Context, userId uuid.UUID) (int, error)
+	DeleteUserSessions(ctx context.Context, userId uuid.UUID) (int, error)
 }
 
 type AuthService struct {
@@ -91,12 +104,17 @@ func (a *AuthService) CreateUser(ctx context.Context, user models.User, profile
 }
 
 // AuthUser checks if user exists and creates session.
+// It returns ErrUserBanned if the user is banned.
 func (a *AuthService) AuthUser(ctx context.Context, authData models.LoginData) (models.Session, error) {
 	user, err := a.userRepo.GetUser(ctx, authData)
 	if err != nil {
 		return models.Session{}, fmt.Errorf("a.userRepo.GetUser: %w", err)
 	}
 
+	if err = a.checkBan(ctx, user.Id); err != nil {
+		return models.Session{}, err
+	}
+
 	session := models.CreateSession()
 	exists, err := a.sessionRepo.IsExists(ctx, session.SessionId)
 	if err != nil {
@@ -124,6 +142,7 @@ func (a *AuthService) GetUserByUsername(ctx context.Context, username string) (m
 }
 
 // LookupUserSession returns user by session.
+// It returns ErrUserBanned if the user is banned.
 func (a *AuthService) LookupUserSession(ctx context.Context, session models.Session) (models.User, error) {
 	userID, err := a.sessionRepo.LookupUserSession(ctx, session)
 	if err != nil {
@@ -135,9 +154,28 @@ func (a *AuthService) LookupUserSession(ctx context.Context, session models.Sess
 		return models.User{}, fmt.Errorf("a.userRepo.GetUserByUId: %w", err)
 	}
 
+	if err = a.checkBan(ctx, user.Id); err != nil {
+		return models.User{}, err
+	}
+
 	return user, nil
 }
 
+// checkBan returns ErrUserBanned with the end and the reason of the ban if the user is banned.
+func (a *AuthService) checkBan(ctx context.Context, userId uuid.UUID) error {
+	ban, err := a.userRepo.GetActiveBan(ctx, userId, time.Now())
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("a.userRepo.GetActiveBan: %w", err)
+	}
+
+	if ban.Until.IsZero() {
+		return fmt.Errorf("%w permanently: %s", ErrUserBanned, ban.Reason)
+	}
+	return fmt.Errorf("%w until %s: %s", ErrUserBanned, ban.Until.UTC().Format(time2.TimeStampLayout), ban.Reason)
+}
+
 // DeleteUserSession deletes user session.
 func (a *AuthService) DeleteUserSession(ctx context.Context, sessionId string) error {
 	return a.sessionRepo.DeleteSession(ctx, sessionId)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -143,6 +144,7 @@ func TestAuthService_AuthUser(t *testing.T) {
 			name: "Success",
 			mockSetup: func() {
 				userRepo.EXPECT().GetUser(ctx, testAuthData).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{}, usecase.ErrNotFound)
 				sessionRepo.EXPECT().IsExists(ctx, gomock.Any()).Return(false, nil)
 				sessionRepo.EXPECT().SaveSession(ctx, testUser.Id, gomock.Any()).Return(nil)
 			},
@@ -163,6 +165,7 @@ func TestAuthService_AuthUser(t *testing.T) {
 			name: "Error checking session existence",
 			mockSetup: func() {
 				userRepo.EXPECT().GetUser(ctx, testAuthData).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{}, usecase.ErrNotFound)
 				sessionRepo.EXPECT().IsExists(ctx, gomock.Any()).Return(false, errors.New("session error"))
 			},
 			authData:     testAuthData,
@@ -173,6 +176,7 @@ func TestAuthService_AuthUser(t *testing.T) {
 			name: "Error saving session",
 			mockSetup: func() {
 				userRepo.EXPECT().GetUser(ctx, testAuthData).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{}, usecase.ErrNotFound)
 				sessionRepo.EXPECT().IsExists(ctx, gomock.Any()).Return(false, nil)
 				sessionRepo.EXPECT().SaveSession(ctx, testUser.Id, gomock.Any()).Return(errors.New("save error"))
 			},
@@ -180,6 +184,27 @@ func TestAuthService_AuthUser(t *testing.T) {
 			expectedErr:  errors.New("a.sessionRepo.SaveSession: save error"),
 			expectedSess: false,
 		},
+		{
+			name: "Permanently banned user",
+			mockSetup: func() {
+				userRepo.EXPECT().GetUser(ctx, testAuthData).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{Reason: "spam"}, nil)
+			},
+			authData:     testAuthData,
+			expectedErr:  errors.New("user is banned permanently: spam"),
+			expectedSess: false,
+		},
+		{
+			name: "Suspended user",
+			mockSetup: func() {
+				userRepo.EXPECT().GetUser(ctx, testAuthData).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).
+					Return(models.Ban{Reason: "spam", Until: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}, nil)
+			},
+			authData:     testAuthData,
+			expectedErr:  errors.New("user is banned until 2030-01-02T03:04:05Z: spam"),
+			expectedSess: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -228,10 +253,31 @@ func TestAuthService_LookupUserSession(t *testing.T) {
 			mockSetup: func() {
 				sessionRepo.EXPECT().LookupUserSession(ctx, testSession).Return(testUser.Id, nil)
 				userRepo.EXPECT().GetUserByUId(ctx, testUser.Id).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{}, usecase.ErrNotFound)
 			},
 			session:     testSession,
 			expectedErr: nil,
 		},
+		{
+			name: "Banned user",
+			mockSetup: func() {
+				sessionRepo.EXPECT().LookupUserSession(ctx, testSession).Return(testUser.Id, nil)
+				userRepo.EXPECT().GetUserByUId(ctx, testUser.Id).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{Reason: "spam"}, nil)
+			},
+			session:     testSession,
+			expectedErr: usecase.ErrUserBanned,
+		},
+		{
+			name: "Error checking ban",
+			mockSetup: func() {
+				sessionRepo.EXPECT().LookupUserSession(ctx, testSession).Return(testUser.Id, nil)
+				userRepo.EXPECT().GetUserByUId(ctx, testUser.Id).Return(testUser, nil)
+				userRepo.EXPECT().GetActiveBan(ctx, testUser.Id, gomock.Any()).Return(models.Ban{}, errors.New("db error"))
+			},
+			session:     testSession,
+			expectedErr: errors.New("a.userRepo.GetActiveBan: db error"),
+		},
 		{
 			name: "Session not found",
 			mockSetup: func() {
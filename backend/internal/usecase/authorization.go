@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"quickflow/internal/models"
+)
+
+var ErrForbidden = errors.New("permission denied")
+
+// Authorize checks that the role of the user grants the permission.
+// It returns ErrForbidden otherwise, so callers may hide resources from users without access.
+func Authorize(user models.User, permission models.Permission) error {
+	if !user.Role.Can(permission) {
+		return fmt.Errorf("%w: %s", ErrForbidden, permission)
+	}
+	return nil
+}
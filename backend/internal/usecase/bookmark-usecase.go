@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var (
+	ErrCollectionNotFound    = errors.New("bookmark collection not found")
+	ErrCollectionExists      = errors.New("bookmark collection already exists")
+	ErrInvalidCollectionName = errors.New("invalid bookmark collection name")
+	ErrTooManyCollections    = errors.New("too many bookmark collections")
+)
+
+const (
+	maxCollectionNameLength = 100
+	maxCollectionsPerUser   = 50
+)
+
+type BookmarkRepository interface {
+	// AddBookmark returns ErrCollectionNotFound if the collection does not belong to the user.
+	AddBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID, collectionId uuid.UUID, now time.Time) error
+	RemoveBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	GetBookmarks(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID, numBookmarks int, cursor models.Cursor) ([]models.Bookmark, error)
+	GetBookmarkedPosts(ctx context.Context, userId uuid.UUID, postIds []uuid.UUID) (map[uuid.UUID]bool, error)
+	// CreateCollection returns ErrCollectionExists if the user has a collection with the same name.
+	CreateCollection(ctx context.Context, collection models.BookmarkCollection) error
+	GetCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error)
+	// DeleteCollection returns ErrCollectionNotFound if the user has no such collection.
+	DeleteCollection(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID) error
+}
+
+type BookmarkService struct {
+	bookmarkRepo BookmarkRepository
+	postRepo     PostRepository
+	friendsRepo  FriendsRepository
+	profileRepo  ProfileRepository
+}
+
+// NewBookmarkService creates new service of bookmarked posts.
+func NewBookmarkService(bookmarkRepo BookmarkRepository, postRepo PostRepository, friendsRepo FriendsRepository, profileRepo ProfileRepository) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		postRepo:     postRepo,
+		friendsRepo:  friendsRepo,
+		profileRepo:  profileRepo,
+	}
+}
+
+// attachBookmarks marks posts the viewer has bookmarked.
+// Anonymous viewers have no bookmarks, so nothing is requested for them.
+func attachBookmarks(ctx context.Context, bookmarkRepo BookmarkRepository, posts []models.Post, viewerId uuid.UUID) error {
+	if viewerId == uuid.Nil || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+	}
+	bookmarked, err := bookmarkRepo.GetBookmarkedPosts(ctx, viewerId, ids)
+	if err != nil {
+		return fmt.Errorf("bookmarkRepo.GetBookmarkedPosts: %w", err)
+	}
+	for i := range posts {
+		posts[i].IsBookmarked = bookmarked[posts[i].Id]
+	}
+	return nil
+}
+
+// AddBookmark saves the post the user is allowed to see to bookmarks.
+// Bookmarked post is moved to the collection, uuid.Nil collection takes it out of collections.
+func (b *BookmarkService) AddBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID, collectionId uuid.UUID) error {
+	post, err := b.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("b.postRepo.GetPost: %w", err)
+	}
+
+	visible, err := canViewPost(ctx, b.friendsRepo, b.profileRepo, post, userId)
+	if err != nil {
+		return fmt.Errorf("canViewPost: %w", err)
+	}
+	if !visible || !post.Status.IsPublished() {
+		// do not reveal existence of the post
+		return ErrPostNotFound
+	}
+
+	if err = b.bookmarkRepo.AddBookmark(ctx, userId, postId, collectionId, time.Now()); err != nil {
+		return fmt.Errorf("b.bookmarkRepo.AddBookmark: %w", err)
+	}
+	return nil
+}
+
+// RemoveBookmark removes the post from bookmarks of the user.
+func (b *BookmarkService) RemoveBookmark(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	if err := b.bookmarkRepo.RemoveBookmark(ctx, userId, postId); err != nil {
+		return fmt.Errorf("b.bookmarkRepo.RemoveBookmark: %w", err)
+	}
+	return nil
+}
+
+// CreateCollection creates named collection of bookmarks of the user, name is trimmed.
+func (b *BookmarkService) CreateCollection(ctx context.Context, userId uuid.UUID, name string) (models.BookmarkCollection, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || utf8.RuneCountInString(name) > maxCollectionNameLength {
+		return models.BookmarkCollection{}, fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidCollectionName, maxCollectionNameLength)
+	}
+
+	collections, err := b.bookmarkRepo.GetCollections(ctx, userId)
+	if err != nil {
+		return models.BookmarkCollection{}, fmt.Errorf("b.bookmarkRepo.GetCollections: %w", err)
+	}
+	if len(collections) >= maxCollectionsPerUser {
+		return models.BookmarkCollection{}, ErrTooManyCollections
+	}
+
+	collection := models.BookmarkCollection{
+		Id:        uuid.New(),
+		UserId:    userId,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err = b.bookmarkRepo.CreateCollection(ctx, collection); err != nil {
+		return models.BookmarkCollection{}, fmt.Errorf("b.bookmarkRepo.CreateCollection: %w", err)
+	}
+	return collection, nil
+}
+
+// FetchCollections returns collections of bookmarks of the user.
+func (b *BookmarkService) FetchCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error) {
+	collections, err := b.bookmarkRepo.GetCollections(ctx, userId)
+	if err != nil {
+		return []models.BookmarkCollection{}, fmt.Errorf("b.bookmarkRepo.GetCollections: %w", err)
+	}
+	return collections, nil
+}
+
+// DeleteCollection removes the collection of the user, its posts stay bookmarked.
+func (b *BookmarkService) DeleteCollection(ctx context.Context, userId uuid.UUID, collectionId uuid.UUID) error {
+	if err := b.bookmarkRepo.DeleteCollection(ctx, userId, collectionId); err != nil {
+		return fmt.Errorf("b.bookmarkRepo.DeleteCollection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestPostService_FetchBookmarks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.New()}
+	collectionId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+	first, hidden, last := uuid.New(), uuid.New(), uuid.New()
+	bookmarks := []models.Bookmark{
+		{PostId: first, CollectionId: collectionId, CreatedAt: cursor.Ts.Add(-time.Minute)},
+		{PostId: hidden, CollectionId: collectionId, CreatedAt: cursor.Ts.Add(-2 * time.Minute)},
+		{PostId: last, CollectionId: collectionId, CreatedAt: cursor.Ts.Add(-3 * time.Minute)},
+	}
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+	mockBookmarkRepo.EXPECT().GetBookmarks(gomock.Any(), user.Id, collectionId, 3, cursor).Return(bookmarks, nil)
+	// post hidden since it was bookmarked is not returned, order of ids is not kept
+	mockPostRepo.EXPECT().GetPostsByIds(gomock.Any(), []uuid.UUID{first, hidden, last}, user.Id).
+		Return([]models.Post{{Id: last}, {Id: first}}, nil)
+
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+	posts, next, err := postService.FetchBookmarks(context.Background(), user, collectionId, 3, cursor)
+
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, []uuid.UUID{first, last}, []uuid.UUID{posts[0].Id, posts[1].Id})
+	assert.True(t, posts[0].IsBookmarked && posts[1].IsBookmarked)
+	// next page starts after the last bookmark, even if its post is hidden
+	assert.Equal(t, models.Cursor{Ts: bookmarks[2].CreatedAt, Id: last}, next)
+}
+
+func TestPostService_FetchBookmarks_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.New()}
+	cursor := models.CursorFromTs(time.Now())
+	postId := uuid.New()
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+	mockBookmarkRepo.EXPECT().GetBookmarks(gomock.Any(), user.Id, uuid.Nil, 10, cursor).
+		Return([]models.Bookmark{{PostId: postId, CreatedAt: cursor.Ts.Add(-time.Minute)}}, nil)
+	mockPostRepo.EXPECT().GetPostsByIds(gomock.Any(), []uuid.UUID{postId}, user.Id).Return([]models.Post{{Id: postId}}, nil)
+
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+	posts, next, err := postService.FetchBookmarks(context.Background(), user, uuid.Nil, 10, cursor)
+
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, models.Cursor{}, next)
+}
+
+func TestBookmarkService_AddBookmark(t *testing.T) {
+	userId, collectionId := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		post        models.Post
+		relation    models.UserRelation
+		repoErr     error
+		expectSave  bool
+		expectedErr error
+	}{
+		{
+			name:       "public post",
+			post:       models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityPublic},
+			relation:   models.RelationStranger,
+			expectSave: true,
+		},
+		{
+			name:        "friends post of stranger",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityFriends},
+			relation:    models.RelationStranger,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "own draft",
+			post:        models.Post{Id: uuid.New(), CreatorId: userId, Visibility: models.VisibilityPublic, Status: models.PostStatusDraft},
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "collection of another user",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityPublic},
+			relation:    models.RelationStranger,
+			repoErr:     usecase.ErrCollectionNotFound,
+			expectSave:  true,
+			expectedErr: usecase.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+			mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			if tt.post.CreatorId != userId {
+				mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), userId, tt.post.CreatorId).Return(tt.relation, nil)
+			}
+			if tt.expectSave {
+				mockBookmarkRepo.EXPECT().AddBookmark(gomock.Any(), userId, tt.post.Id, collectionId, gomock.Any()).Return(tt.repoErr)
+			}
+
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), tt.post.CreatorId).Return(models.DefaultPrivacySettings(), nil).AnyTimes()
+
+			bookmarkService := usecase.NewBookmarkService(mockBookmarkRepo, mockPostRepo, mockFriendsRepo, mockProfileRepo)
+			err := bookmarkService.AddBookmark(context.Background(), userId, tt.post.Id, collectionId)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBookmarkService_CreateCollection(t *testing.T) {
+	userId := uuid.New()
+
+	t.Run("name is trimmed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+		mockBookmarkRepo.EXPECT().GetCollections(gomock.Any(), userId).Return(nil, nil)
+		mockBookmarkRepo.EXPECT().CreateCollection(gomock.Any(), gomock.Any()).Return(nil)
+
+		bookmarkService := usecase.NewBookmarkService(mockBookmarkRepo, mocks.NewMockPostRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockProfileRepository(ctrl))
+		collection, err := bookmarkService.CreateCollection(context.Background(), userId, "  Recipes ")
+		require.NoError(t, err)
+		assert.Equal(t, "Recipes", collection.Name)
+		assert.Equal(t, userId, collection.UserId)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		bookmarkService := usecase.NewBookmarkService(mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockPostRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockProfileRepository(ctrl))
+		_, err := bookmarkService.CreateCollection(context.Background(), userId, "   ")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCollectionName)
+	})
+
+	t.Run("too many collections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+		mockBookmarkRepo.EXPECT().GetCollections(gomock.Any(), userId).Return(make([]models.BookmarkCollection, 50), nil)
+
+		bookmarkService := usecase.NewBookmarkService(mockBookmarkRepo, mocks.NewMockPostRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockProfileRepository(ctrl))
+		_, err := bookmarkService.CreateCollection(context.Background(), userId, "Recipes")
+		assert.ErrorIs(t, err, usecase.ErrTooManyCollections)
+	})
+}
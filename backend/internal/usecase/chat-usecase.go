@@ -17,11 +17,12 @@ var (
     ErrInvalidChatCreationInfo = fmt.Errorf("invalid chat creation info")
     ErrAlreadyInChat           = fmt.Errorf("user already in chat")
     ErrInvalidChatType         = fmt.Errorf("invalid chat type")
+    ErrInvalidNumChats         = fmt.Errorf("numChats must be greater than 0")
 )
 
 type ChatRepository interface {
     CreateChat(ctx context.Context, chat models.Chat) error
-    GetUserChats(ctx context.Context, userId uuid.UUID) ([]models.Chat, error)
+    GetUserChats(ctx context.Context, userId uuid.UUID, numChats int, cursor models.Cursor) ([]models.Chat, error)
     GetChatParticipants(ctx context.Context, chatId uuid.UUID) ([]models.User, error)
     GetChat(ctx context.Context, chatId uuid.UUID) (models.Chat, error)
     GetPrivateChat(ctx context.Context, senderId, receiverId uuid.UUID) (models.Chat, error)
@@ -66,6 +67,9 @@ func (c *ChatService) CreateChat(ctx context.Context, chatInfo models.ChatCreati
             UpdatedAt: time.Now(),
         }
     case models.ChatTypeGroup:
+        if chatInfo.Avatar != nil {
+            chatInfo.Avatar.Purpose = models.FilePurposeChatAvatar
+        }
         imageURL, err := c.fileRepo.UploadFile(ctx, chatInfo.Avatar)
         if err != nil {
             return models.Chat{}, ErrUploadFile
@@ -87,8 +91,13 @@ func (c *ChatService) CreateChat(ctx context.Context, chatInfo models.ChatCreati
     return chat, nil
 }
 
-func (c *ChatService) GetUserChats(ctx context.Context, userId uuid.UUID) ([]models.Chat, error) {
-    chats, err := c.chatRepo.GetUserChats(ctx, userId)
+// GetUserChats returns page of user chats that were updated before cursor.
+func (c *ChatService) GetUserChats(ctx context.Context, userId uuid.UUID, numChats int, cursor models.Cursor) ([]models.Chat, error) {
+    if numChats <= 0 {
+        return nil, ErrInvalidNumChats
+    }
+
+    chats, err := c.chatRepo.GetUserChats(ctx, userId, numChats, cursor)
     if err != nil {
         return nil, fmt.Errorf("c.chatRepo.GetUserChats: %w", err)
     }
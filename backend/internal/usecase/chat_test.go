@@ -84,9 +84,10 @@ func TestGetUserChats(t *testing.T) {
 	usecase := NewChatUseCase(mockChatRepo, mockFileRepo, mockProfileRepo, mockMessageRepo)
 
 	userId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
 
 	// Мокируем репозиторий, чтобы вернуть список чатов
-	mockChatRepo.EXPECT().GetUserChats(gomock.Any(), userId).Return([]models.Chat{
+	mockChatRepo.EXPECT().GetUserChats(gomock.Any(), userId, 10, cursor).Return([]models.Chat{
 		{ID: uuid.New(), Type: models.ChatTypeGroup, Name: "Group Chat", CreatedAt: time.Now(), LastMessage: models.Message{Text: "hi"}},
 		{ID: uuid.New(), Type: models.ChatTypePrivate, CreatedAt: time.Now()},
 	}, nil)
@@ -105,7 +106,7 @@ func TestGetUserChats(t *testing.T) {
 	mockMessageRepo.EXPECT().GetLastChatMessage(gomock.Any(), gomock.Any()).Return(&models.Message{Text: "Hello!"}, nil)
 
 	// Проверяем результат
-	chats, err := usecase.GetUserChats(context.Background(), userId)
+	chats, err := usecase.GetUserChats(context.Background(), userId, 10, cursor)
 	assert.NoError(t, err)
 	assert.Len(t, chats, 2)
 	assert.Equal(t, chats[0].Name, "Group Chat")
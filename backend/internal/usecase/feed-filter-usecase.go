@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var ErrCannotMuteSelf = errors.New("user can not mute themselves")
+
+type FeedFilterRepository interface {
+	HidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID, now time.Time) error
+	UnhidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	GetHiddenPosts(ctx context.Context, userId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.HiddenPost, error)
+	MuteUser(ctx context.Context, userId uuid.UUID, mutedId uuid.UUID, now time.Time) error
+	UnmuteUser(ctx context.Context, userId uuid.UUID, mutedId uuid.UUID) error
+	GetMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error)
+}
+
+type FeedFilterService struct {
+	feedFilterRepo FeedFilterRepository
+	postRepo       PostRepository
+	friendsRepo    FriendsRepository
+	userRepo       UserRepository
+	profileRepo    ProfileRepository
+}
+
+// NewFeedFilterService creates new service of posts hidden and authors muted by users.
+// Posts hidden from the user are listed by PostService.
+func NewFeedFilterService(feedFilterRepo FeedFilterRepository, postRepo PostRepository, friendsRepo FriendsRepository, userRepo UserRepository, profileRepo ProfileRepository) *FeedFilterService {
+	return &FeedFilterService{
+		feedFilterRepo: feedFilterRepo,
+		postRepo:       postRepo,
+		friendsRepo:    friendsRepo,
+		userRepo:       userRepo,
+		profileRepo:    profileRepo,
+	}
+}
+
+// HidePost hides the post the user is allowed to see from their feed and recommendations.
+func (f *FeedFilterService) HidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	post, err := f.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("f.postRepo.GetPost: %w", err)
+	}
+
+	visible, err := canViewPost(ctx, f.friendsRepo, f.profileRepo, post, userId)
+	if err != nil {
+		return fmt.Errorf("canViewPost: %w", err)
+	}
+	if !visible || !post.Status.IsPublished() {
+		// do not reveal existence of the post
+		return ErrPostNotFound
+	}
+
+	if err = f.feedFilterRepo.HidePost(ctx, userId, postId, time.Now()); err != nil {
+		return fmt.Errorf("f.feedFilterRepo.HidePost: %w", err)
+	}
+	return nil
+}
+
+// UnhidePost returns the post to the feed and recommendations of the user.
+func (f *FeedFilterService) UnhidePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	if err := f.feedFilterRepo.UnhidePost(ctx, userId, postId); err != nil {
+		return fmt.Errorf("f.feedFilterRepo.UnhidePost: %w", err)
+	}
+	return nil
+}
+
+// MuteUser hides posts of the author from the feed and recommendations of the user
+// without unfollowing them. It returns ErrNotFound if there is no such author.
+func (f *FeedFilterService) MuteUser(ctx context.Context, userId uuid.UUID, username string) error {
+	author, err := f.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("f.userRepo.GetUserByUsername: %w", err)
+	}
+	if author.Id == userId {
+		return ErrCannotMuteSelf
+	}
+
+	if err = f.feedFilterRepo.MuteUser(ctx, userId, author.Id, time.Now()); err != nil {
+		return fmt.Errorf("f.feedFilterRepo.MuteUser: %w", err)
+	}
+	return nil
+}
+
+// UnmuteUser returns posts of the author to the feed and recommendations of the user.
+// It returns ErrNotFound if there is no such author.
+func (f *FeedFilterService) UnmuteUser(ctx context.Context, userId uuid.UUID, username string) error {
+	author, err := f.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("f.userRepo.GetUserByUsername: %w", err)
+	}
+
+	if err = f.feedFilterRepo.UnmuteUser(ctx, userId, author.Id); err != nil {
+		return fmt.Errorf("f.feedFilterRepo.UnmuteUser: %w", err)
+	}
+	return nil
+}
+
+// FetchMutedUsers returns authors muted by the user, latest muted first.
+func (f *FeedFilterService) FetchMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error) {
+	muted, err := f.feedFilterRepo.GetMutedUsers(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("f.feedFilterRepo.GetMutedUsers: %w", err)
+	}
+	return muted, nil
+}
@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestPostService_FetchHiddenPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.New()}
+	cursor := models.CursorFromTs(time.Now())
+	first, deleted := uuid.New(), uuid.New()
+	hidden := []models.HiddenPost{
+		{PostId: first, CreatedAt: cursor.Ts.Add(-time.Minute)},
+		{PostId: deleted, CreatedAt: cursor.Ts.Add(-2 * time.Minute)},
+	}
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockFeedFilterRepo := mocks.NewMockFeedFilterRepository(ctrl)
+	mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+	mockFeedFilterRepo.EXPECT().GetHiddenPosts(gomock.Any(), user.Id, 2, cursor).Return(hidden, nil)
+	// hidden posts are listed by plain visibility, not filtered out like the feed
+	mockPostRepo.EXPECT().GetPostsByIds(gomock.Any(), []uuid.UUID{first, deleted}, user.Id).Return([]models.Post{{Id: first}}, nil)
+	mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), user.Id, []uuid.UUID{first}).Return(map[uuid.UUID]bool{first: true}, nil)
+
+	// views of hidden posts are not recorded, so the view counter expects no calls
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mocks.NewMockViewCounter(ctrl), mockFeedFilterRepo)
+	posts, next, err := postService.FetchHiddenPosts(context.Background(), user, 2, cursor)
+
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, first, posts[0].Id)
+	assert.True(t, posts[0].IsBookmarked)
+	assert.Equal(t, models.Cursor{Ts: hidden[1].CreatedAt, Id: deleted}, next)
+}
+
+func TestFeedFilterService_HidePost(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name        string
+		post        models.Post
+		relation    models.UserRelation
+		expectSave  bool
+		expectedErr error
+	}{
+		{
+			name:       "public post",
+			post:       models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityPublic},
+			relation:   models.RelationFollowing,
+			expectSave: true,
+		},
+		{
+			name:        "friends post of stranger",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityFriends},
+			relation:    models.RelationStranger,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "scheduled post",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Visibility: models.VisibilityPublic, Status: models.PostStatusScheduled},
+			relation:    models.RelationFriend,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+			mockFeedFilterRepo := mocks.NewMockFeedFilterRepository(ctrl)
+
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), userId, tt.post.CreatorId).Return(tt.relation, nil).AnyTimes()
+			if tt.expectSave {
+				mockFeedFilterRepo.EXPECT().HidePost(gomock.Any(), userId, tt.post.Id, gomock.Any()).Return(nil)
+			}
+
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), tt.post.CreatorId).Return(models.DefaultPrivacySettings(), nil).AnyTimes()
+
+			feedFilterService := usecase.NewFeedFilterService(mockFeedFilterRepo, mockPostRepo, mockFriendsRepo, mocks.NewMockUserRepository(ctrl), mockProfileRepo)
+			err := feedFilterService.HidePost(context.Background(), userId, tt.post.Id)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeedFilterService_MuteUser(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "reader"}
+	author := models.User{Id: uuid.New(), Username: "author"}
+
+	tests := []struct {
+		name        string
+		username    string
+		found       models.User
+		findErr     error
+		expectSave  bool
+		expectedErr error
+	}{
+		{name: "followed author", username: author.Username, found: author, expectSave: true},
+		{name: "unknown author", username: "nobody", findErr: usecase.ErrNotFound, expectedErr: usecase.ErrNotFound},
+		{name: "themselves", username: user.Username, found: user, expectedErr: usecase.ErrCannotMuteSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockFeedFilterRepo := mocks.NewMockFeedFilterRepository(ctrl)
+			mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), tt.username).Return(tt.found, tt.findErr)
+			if tt.expectSave {
+				mockFeedFilterRepo.EXPECT().MuteUser(gomock.Any(), user.Id, author.Id, gomock.Any()).Return(nil)
+			}
+
+			feedFilterService := usecase.NewFeedFilterService(mockFeedFilterRepo, mocks.NewMockPostRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mockUserRepo, mocks.NewMockProfileRepository(ctrl))
+			err := feedFilterService.MuteUser(context.Background(), user.Id, tt.username)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	gc_config "quickflow/config/gc"
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+type FileStorage interface {
+	ListFiles(ctx context.Context) ([]models.StoredFile, error)
+	DeleteStoredFile(ctx context.Context, file models.StoredFile) error
+}
+
+type FileReferenceRepository interface {
+	// GetReferencedFiles returns names among fileNames that are referenced by any table.
+	GetReferencedFiles(ctx context.Context, fileNames []string) ([]string, error)
+}
+
+type FileGCService struct {
+	storage FileStorage
+	refRepo FileReferenceRepository
+	cfg     *gc_config.FileGCConfig
+}
+
+// NewFileGCService creates new orphaned files collector.
+func NewFileGCService(storage FileStorage, refRepo FileReferenceRepository, cfg *gc_config.FileGCConfig) *FileGCService {
+	return &FileGCService{
+		storage: storage,
+		refRepo: refRepo,
+		cfg:     cfg,
+	}
+}
+
+// Collect removes stored files that are not referenced from database.
+// Files younger than grace period are skipped since they may belong to uploads
+// that are not committed yet. In dry run mode nothing is deleted.
+func (g *FileGCService) Collect(ctx context.Context, dryRun bool) (models.FileGCReport, error) {
+	report := models.FileGCReport{
+		StartedAt: time.Now(),
+		DryRun:    dryRun,
+	}
+
+	files, err := g.storage.ListFiles(ctx)
+	if err != nil {
+		return report, fmt.Errorf("g.storage.ListFiles: %w", err)
+	}
+	report.Scanned = len(files)
+
+	threshold := report.StartedAt.Add(-g.cfg.GracePeriod)
+	candidates := make([]models.StoredFile, 0, len(files))
+	for _, file := range files {
+		if file.LastModified.After(threshold) {
+			report.TooYoung++
+			continue
+		}
+		candidates = append(candidates, file)
+	}
+
+	batchSize := g.cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(candidates)
+	}
+
+	for start := 0; start < len(candidates); start += batchSize {
+		batch := candidates[start:min(start+batchSize, len(candidates))]
+		// smaller image variants are not referenced directly, they live as long as the full one
+		names := make([]string, 0, len(batch))
+		for _, file := range batch {
+			names = append(names, models.ImageVariantOwner(file.Name))
+		}
+		slices.Sort(names)
+		names = slices.Compact(names)
+
+		referenced, err := g.refRepo.GetReferencedFiles(ctx, names)
+		if err != nil {
+			return report, fmt.Errorf("g.refRepo.GetReferencedFiles: %w", err)
+		}
+		referencedSet := make(map[string]struct{}, len(referenced))
+		for _, name := range referenced {
+			referencedSet[name] = struct{}{}
+		}
+
+		for _, file := range batch {
+			if _, ok := referencedSet[models.ImageVariantOwner(file.Name)]; ok {
+				report.Referenced++
+				continue
+			}
+
+			report.Orphaned++
+			report.OrphanedBytes += file.Size
+			report.OrphanedFiles = append(report.OrphanedFiles, file.Name)
+			if dryRun {
+				continue
+			}
+
+			if err = g.storage.DeleteStoredFile(ctx, file); err != nil {
+				// one broken object should not stop the whole collection
+				logger.Error(ctx, fmt.Sprintf("Unable to delete orphaned file %v: %s", file.Name, err.Error()))
+				report.Failed++
+				continue
+			}
+			report.Deleted++
+			report.FreedBytes += file.Size
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	logger.Info(ctx, fmt.Sprintf("File gc finished: scanned %d, referenced %d, too young %d, orphaned %d, deleted %d, failed %d, dry run %v",
+		report.Scanned, report.Referenced, report.TooYoung, report.Orphaned, report.Deleted, report.Failed, dryRun))
+	return report, nil
+}
+
+// CollectScheduled runs collection with dry run mode taken from config.
+func (g *FileGCService) CollectScheduled(ctx context.Context) error {
+	_, err := g.Collect(ctx, g.cfg.DryRun)
+	return err
+}
@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gc_config "quickflow/config/gc"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestFileGCService_Collect(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	files := []models.StoredFile{
+		{Bucket: "posts", Name: "used.jpg", Size: 10, LastModified: old},
+		{Bucket: "posts", Name: "orphan.jpg", Size: 20, LastModified: old},
+		{Bucket: "attachments", Name: "broken.jpg", Size: 30, LastModified: old},
+		{Bucket: "posts", Name: "fresh.jpg", Size: 40, LastModified: time.Now()},
+	}
+	variants := []models.StoredFile{
+		{Bucket: "profiles", Name: "a_feed.jpg", Size: 1, LastModified: old},
+		{Bucket: "profiles", Name: "a_full.jpg", Size: 1, LastModified: old},
+		{Bucket: "profiles", Name: "b_thumbnail.jpg", Size: 1, LastModified: old},
+	}
+
+	tests := []struct {
+		name       string
+		dryRun     bool
+		setupMocks func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository)
+		want       models.FileGCReport
+		wantErr    bool
+	}{
+		{
+			name: "orphaned files are deleted",
+			setupMocks: func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository) {
+				storage.EXPECT().ListFiles(gomock.Any()).Return(files, nil)
+				// batch size is 2, fresh file is never checked
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"orphan.jpg", "used.jpg"}).Return([]string{"used.jpg"}, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"broken.jpg"}).Return(nil, nil)
+				storage.EXPECT().DeleteStoredFile(gomock.Any(), files[1]).Return(nil)
+				storage.EXPECT().DeleteStoredFile(gomock.Any(), files[2]).Return(errors.New("storage error"))
+			},
+			want: models.FileGCReport{
+				Scanned: 4, Referenced: 1, TooYoung: 1, Orphaned: 2, Deleted: 1, Failed: 1,
+				OrphanedBytes: 50, FreedBytes: 20, OrphanedFiles: []string{"orphan.jpg", "broken.jpg"},
+			},
+		},
+		{
+			name:   "dry run does not delete",
+			dryRun: true,
+			setupMocks: func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository) {
+				storage.EXPECT().ListFiles(gomock.Any()).Return(files, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"orphan.jpg", "used.jpg"}).Return([]string{"used.jpg"}, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"broken.jpg"}).Return(nil, nil)
+			},
+			want: models.FileGCReport{
+				DryRun: true, Scanned: 4, Referenced: 1, TooYoung: 1, Orphaned: 2,
+				OrphanedBytes: 50, OrphanedFiles: []string{"orphan.jpg", "broken.jpg"},
+			},
+		},
+		{
+			name: "image variants live as long as full variant",
+			setupMocks: func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository) {
+				storage.EXPECT().ListFiles(gomock.Any()).Return(variants, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"a_full.jpg"}).Return([]string{"a_full.jpg"}, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), []string{"b_full.jpg"}).Return(nil, nil)
+				storage.EXPECT().DeleteStoredFile(gomock.Any(), variants[2]).Return(nil)
+			},
+			want: models.FileGCReport{
+				Scanned: 3, Referenced: 2, Orphaned: 1, Deleted: 1,
+				OrphanedBytes: 1, FreedBytes: 1, OrphanedFiles: []string{"b_thumbnail.jpg"},
+			},
+		},
+		{
+			name: "list error",
+			setupMocks: func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository) {
+				storage.EXPECT().ListFiles(gomock.Any()).Return(nil, errors.New("storage error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "reference error stops collection",
+			setupMocks: func(storage *mocks.MockFileStorage, refs *mocks.MockFileReferenceRepository) {
+				storage.EXPECT().ListFiles(gomock.Any()).Return(files, nil)
+				refs.EXPECT().GetReferencedFiles(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := mocks.NewMockFileStorage(ctrl)
+			refs := mocks.NewMockFileReferenceRepository(ctrl)
+			tt.setupMocks(storage, refs)
+
+			service := usecase.NewFileGCService(storage, refs, &gc_config.FileGCConfig{
+				GracePeriod: 24 * time.Hour,
+				BatchSize:   2,
+			})
+
+			report, err := service.Collect(context.Background(), tt.dryRun)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// timestamps are not interesting here
+			report.StartedAt, report.FinishedAt = time.Time{}, time.Time{}
+			assert.Equal(t, tt.want, report)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+type FileMover interface {
+	CopyFile(ctx context.Context, fileName string, from, to models.FilePurpose) error
+	GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error)
+	DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error
+}
+
+type FileURLRepository interface {
+	GetFileURLs(ctx context.Context, purpose models.FilePurpose) ([]string, error)
+	ReplaceFileURL(ctx context.Context, purpose models.FilePurpose, oldURL, newURL string) error
+}
+
+type FileMigrationService struct {
+	mover   FileMover
+	urlRepo FileURLRepository
+}
+
+// NewFileMigrationService creates new service that moves files to buckets of their purpose.
+func NewFileMigrationService(mover FileMover, urlRepo FileURLRepository) *FileMigrationService {
+	return &FileMigrationService{
+		mover:   mover,
+		urlRepo: urlRepo,
+	}
+}
+
+// MigrateBuckets moves files that were uploaded before buckets were separated
+// out of the posts bucket. Files are copied first, then references are updated
+// and only after that old copies are removed, so a failure never leaves broken URLs.
+func (m *FileMigrationService) MigrateBuckets(ctx context.Context) (models.FileMigrationReport, error) {
+	var report models.FileMigrationReport
+
+	for _, purpose := range models.FilePurposes {
+		if purpose == models.FilePurposePost {
+			continue
+		}
+
+		urls, err := m.urlRepo.GetFileURLs(ctx, purpose)
+		if err != nil {
+			return report, fmt.Errorf("m.urlRepo.GetFileURLs: %w", err)
+		}
+
+		for _, url := range urls {
+			report.Checked++
+			moved, err := m.migrateFile(ctx, purpose, url)
+			switch {
+			case err != nil:
+				logger.Error(ctx, fmt.Sprintf("Unable to move %s file %v: %s", purpose, url, err.Error()))
+				report.Failed++
+			case moved:
+				report.Moved++
+			default:
+				report.Skipped++
+			}
+		}
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Bucket migration finished: checked %d, moved %d, skipped %d, failed %d",
+		report.Checked, report.Moved, report.Skipped, report.Failed))
+	return report, nil
+}
+
+func (m *FileMigrationService) migrateFile(ctx context.Context, purpose models.FilePurpose, url string) (bool, error) {
+	name := path.Base(url)
+	legacyURL, err := m.mover.GetFileURL(ctx, models.FilePurposePost, name)
+	if err != nil {
+		return false, fmt.Errorf("m.mover.GetFileURL: %w", err)
+	}
+	newURL, err := m.mover.GetFileURL(ctx, purpose, name)
+	if err != nil {
+		return false, fmt.Errorf("m.mover.GetFileURL: %w", err)
+	}
+	// file is already in place or was never stored in the posts bucket
+	if url != legacyURL || url == newURL {
+		return false, nil
+	}
+
+	names := models.ImageVariantNames(name)
+	for _, variant := range names {
+		if err = m.mover.CopyFile(ctx, variant, models.FilePurposePost, purpose); err != nil {
+			return false, fmt.Errorf("m.mover.CopyFile: %w", err)
+		}
+	}
+
+	if err = m.urlRepo.ReplaceFileURL(ctx, purpose, url, newURL); err != nil {
+		return false, fmt.Errorf("m.urlRepo.ReplaceFileURL: %w", err)
+	}
+
+	for _, variant := range names {
+		if err = m.mover.DeleteFile(ctx, models.FilePurposePost, variant); err != nil {
+			// not referenced anymore, file gc will remove it later
+			logger.Error(ctx, fmt.Sprintf("Unable to delete migrated file %v: %s", variant, err.Error()))
+		}
+	}
+	return true, nil
+}
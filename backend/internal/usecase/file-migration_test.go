@@ -0,0 +1,56 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestFileMigrationService_MigrateBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mover := mocks.NewMockFileMover(ctrl)
+	urlRepo := mocks.NewMockFileURLRepository(ctrl)
+
+	buckets := map[models.FilePurpose]string{
+		models.FilePurposePost:       "posts",
+		models.FilePurposeAttachment: "attachments",
+		models.FilePurposeAvatar:     "profiles",
+		models.FilePurposeCover:      "profiles",
+		models.FilePurposeChatAvatar: "profiles",
+	}
+	mover.EXPECT().GetFileURL(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, purpose models.FilePurpose, name string) (string, error) {
+			return "http://minio/" + buckets[purpose] + "/" + name, nil
+		}).AnyTimes()
+
+	// legacy image with variants is moved together with them
+	urlRepo.EXPECT().GetFileURLs(gomock.Any(), models.FilePurposeAvatar).
+		Return([]string{"http://minio/posts/a_full.jpg", "http://minio/profiles/b.jpg"}, nil)
+	for _, name := range []string{"a_thumbnail.jpg", "a_feed.jpg", "a_full.jpg"} {
+		mover.EXPECT().CopyFile(gomock.Any(), name, models.FilePurposePost, models.FilePurposeAvatar).Return(nil)
+		mover.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, name).Return(nil)
+	}
+	urlRepo.EXPECT().ReplaceFileURL(gomock.Any(), models.FilePurposeAvatar,
+		"http://minio/posts/a_full.jpg", "http://minio/profiles/a_full.jpg").Return(nil)
+
+	// failed copy keeps old url
+	urlRepo.EXPECT().GetFileURLs(gomock.Any(), models.FilePurposeAttachment).Return([]string{"http://minio/posts/c.pdf"}, nil)
+	mover.EXPECT().CopyFile(gomock.Any(), "c.pdf", models.FilePurposePost, models.FilePurposeAttachment).Return(errors.New("minio error"))
+
+	urlRepo.EXPECT().GetFileURLs(gomock.Any(), models.FilePurposeCover).Return(nil, nil)
+	urlRepo.EXPECT().GetFileURLs(gomock.Any(), models.FilePurposeChatAvatar).Return([]string{"https://external/d.png"}, nil)
+
+	report, err := usecase.NewFileMigrationService(mover, urlRepo).MigrateBuckets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.FileMigrationReport{Checked: 4, Moved: 1, Skipped: 2, Failed: 1}, report)
+}
@@ -12,13 +12,14 @@ import (
 )
 
 type FriendsRepository interface {
-	GetFriendsPublicInfo(ctx context.Context, userID string, amount int, startPos int) ([]models.FriendInfo, bool, int, error)
+	GetFriendsPublicInfo(ctx context.Context, userID string, amount int, startPos int, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error)
 	SendFriendRequest(ctx context.Context, senderID string, receiverID string) error
 	AcceptFriendRequest(ctx context.Context, senderID string, receiverID string) error
 	DeleteFriend(ctx context.Context, senderID string, receiverID string) error
 	Unfollow(ctx context.Context, userID string, friendID string) error
 	IsExistsFriendRequest(ctx context.Context, senderID string, receiverID string) (bool, error)
 	GetUserRelation(ctx context.Context, user1 uuid.UUID, user2 uuid.UUID) (models.UserRelation, error)
+	GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error)
 }
 
 type FriendsService struct {
@@ -32,20 +33,25 @@ func NewFriendsService(friendsRepo FriendsRepository) *FriendsService {
 	}
 }
 
-func (f *FriendsService) GetFriendsInfo(ctx context.Context, userID string, limit string, offset string) ([]models.FriendInfo, bool, int, error) {
+// GetFriendsInfo returns page of user friends ordered by last and first name.
+// Offset is ignored when cursor is given.
+func (f *FriendsService) GetFriendsInfo(ctx context.Context, userID string, limit string, offset string, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error) {
 	amount, err := strconv.Atoi(limit)
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Unable to parse count. Given value %s: %s", limit, err.Error()))
 		return nil, false, 0, err
 	}
 
-	startPos, err := strconv.Atoi(offset)
-	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Unable to parse offset. Given value %s: %s", offset, err.Error()))
-		return nil, false, 0, err
+	var startPos int
+	if cursor.IsZero() {
+		startPos, err = strconv.Atoi(offset)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to parse offset. Given value %s: %s", offset, err.Error()))
+			return nil, false, 0, err
+		}
 	}
 
-	friendsIds, hasMore, friendsCount, err := f.friendsRepo.GetFriendsPublicInfo(ctx, userID, amount, startPos)
+	friendsIds, hasMore, friendsCount, err := f.friendsRepo.GetFriendsPublicInfo(ctx, userID, amount, startPos, cursor)
 	if err != nil {
 		return []models.FriendInfo{}, false, 0, err
 	}
@@ -103,6 +109,36 @@ func (f *FriendsService) GetUserRelation(ctx context.Context, user1 uuid.UUID, u
 	return relation, nil
 }
 
+// GetUserRelations returns relation of user to every user in others.
+// Users without any relation are reported as strangers.
+func (f *FriendsService) GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error) {
+	if user == uuid.Nil {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	relations := make(map[uuid.UUID]models.UserRelation, len(others))
+	if len(others) == 0 {
+		return relations, nil
+	}
+
+	found, err := f.friendsRepo.GetUserRelations(ctx, user, others)
+	if err != nil {
+		return nil, fmt.Errorf("f.friendsRepo.GetUserRelations: %w", err)
+	}
+
+	for _, other := range others {
+		switch relation, ok := found[other]; {
+		case other == user:
+			relations[other] = models.RelationSelf
+		case ok:
+			relations[other] = relation
+		default:
+			relations[other] = models.RelationStranger
+		}
+	}
+	return relations, nil
+}
+
 func (f *FriendsService) Unfollow(ctx context.Context, userID string, friendID string) error {
 	if err := f.friendsRepo.Unfollow(ctx, userID, friendID); err != nil {
 		return err
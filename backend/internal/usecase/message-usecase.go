@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"quickflow/internal/models"
+	"quickflow/pkg/logger"
 	"quickflow/utils/validation"
 )
 
@@ -19,7 +20,7 @@ var (
 
 type MessageRepository interface {
 	GetMessageById(ctx context.Context, messageId uuid.UUID) (models.Message, error)
-	GetMessagesForChatOlder(ctx context.Context, chatId uuid.UUID, numMessages int, timestamp time.Time) ([]models.Message, error)
+	GetMessagesForChatOlder(ctx context.Context, chatId uuid.UUID, numMessages int, cursor models.Cursor) ([]models.Message, error)
 	GetLastChatMessage(ctx context.Context, chatId uuid.UUID) (*models.Message, error)
 
 	SaveMessage(ctx context.Context, message models.Message) error
@@ -34,17 +35,22 @@ type MessageService struct {
 	fileRepo    FileRepository
 	messageRepo MessageRepository
 	chatRepo    ChatRepository
+	uploads     UploadCommitter
+	mentions    MessageMentioner
 }
 
-func NewMessageService(messageRepo MessageRepository, fileRepo FileRepository, chatRepo ChatRepository) *MessageService {
+func NewMessageService(messageRepo MessageRepository, fileRepo FileRepository, chatRepo ChatRepository, uploads UploadCommitter, mentions MessageMentioner) *MessageService {
 	return &MessageService{
 		fileRepo:    fileRepo,
 		messageRepo: messageRepo,
 		chatRepo:    chatRepo,
+		uploads:     uploads,
+		mentions:    mentions,
 	}
 }
 
-func (m *MessageService) GetMessagesForChat(ctx context.Context, chatId uuid.UUID, userId uuid.UUID, numMessages int, timestamp time.Time) ([]models.Message, error) {
+// GetMessagesForChat returns messages older than cursor in chronological order.
+func (m *MessageService) GetMessagesForChat(ctx context.Context, chatId uuid.UUID, userId uuid.UUID, numMessages int, cursor models.Cursor) ([]models.Message, error) {
 	// validation
 	if numMessages <= 0 {
 		return nil, ErrInvalidNumMessages
@@ -59,7 +65,7 @@ func (m *MessageService) GetMessagesForChat(ctx context.Context, chatId uuid.UUI
 		return nil, ErrNotParticipant
 	}
 
-	messages, err := m.messageRepo.GetMessagesForChatOlder(ctx, chatId, numMessages, timestamp)
+	messages, err := m.messageRepo.GetMessagesForChatOlder(ctx, chatId, numMessages, cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +73,19 @@ func (m *MessageService) GetMessagesForChat(ctx context.Context, chatId uuid.UUI
 	return messages, nil
 }
 
-func (m *MessageService) SaveMessage(ctx context.Context, message models.Message) (uuid.UUID, error) {
+// SaveMessage saves message, creating private chat if needed, and returns it
+// with chat and attachment URLs filled in.
+func (m *MessageService) SaveMessage(ctx context.Context, message models.Message) (models.Message, error) {
 	// validate
 	err := validation.ValidateMessage(message)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("validation.ValidateMessage: %w", err)
+		return models.Message{}, fmt.Errorf("validation.ValidateMessage: %w", err)
 	}
 
 	// check if chat exists and create if it doesn't
 	if message.ChatID == uuid.Nil {
 		if message.ReceiverID == uuid.Nil {
-			return uuid.Nil, fmt.Errorf("both chatId and receiverId are empty")
+			return models.Message{}, fmt.Errorf("both chatId and receiverId are empty")
 		}
 
 		chat, err := m.chatRepo.GetPrivateChat(ctx, message.SenderID, message.ReceiverID)
@@ -91,41 +99,62 @@ func (m *MessageService) SaveMessage(ctx context.Context, message models.Message
 
 			err = m.chatRepo.CreateChat(ctx, newChat)
 			if err != nil {
-				return uuid.Nil, fmt.Errorf("m.chatRepo.CreateChat: %w", err)
+				return models.Message{}, fmt.Errorf("m.chatRepo.CreateChat: %w", err)
 			}
 			err = m.chatRepo.JoinChat(ctx, newChat.ID, message.SenderID)
 			if err != nil {
-				return uuid.Nil, fmt.Errorf("m.chatRepo.JoinChat: %w", err)
+				return models.Message{}, fmt.Errorf("m.chatRepo.JoinChat: %w", err)
 			}
 			err = m.chatRepo.JoinChat(ctx, newChat.ID, message.ReceiverID)
 			if err != nil {
 				m.chatRepo.LeaveChat(ctx, newChat.ID, message.SenderID)
-				return uuid.Nil, fmt.Errorf("m.chatRepo.JoinChat: %w", err)
+				return models.Message{}, fmt.Errorf("m.chatRepo.JoinChat: %w", err)
 			}
 			message.ChatID = newChat.ID
 		} else if err != nil {
-			return uuid.Nil, fmt.Errorf("m.chatRepo.GetChat: %w", err)
+			return models.Message{}, fmt.Errorf("m.chatRepo.GetChat: %w", err)
 		} else {
 			message.ChatID = chat.ID
 		}
 	}
 
+	// only participants of the chat can be mentioned
+	message.Mentions, err = m.mentions.ResolveMessageMentions(ctx, message.Text, message.ChatID)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("m.mentions.ResolveMessageMentions: %w", err)
+	}
+
 	// Upload files to storage
 	if len(message.Attachments) > 0 {
+		for _, attachment := range message.Attachments {
+			attachment.Purpose = models.FilePurposeAttachment
+		}
 		filesURLs, err := m.fileRepo.UploadManyFiles(ctx, message.Attachments)
 		if err != nil {
-			return uuid.Nil, fmt.Errorf("m.fileRepo.UploadManyFiles: %w", err)
+			return models.Message{}, fmt.Errorf("m.fileRepo.UploadManyFiles: %w", err)
 		}
 		message.AttachmentURLs = filesURLs
 	}
+	if len(message.UploadKeys) > 0 {
+		committed, err := m.uploads.CommitUploads(ctx, message.SenderID, models.FilePurposeAttachment, message.UploadKeys)
+		if err != nil {
+			return models.Message{}, fmt.Errorf("m.uploads.CommitUploads: %w", err)
+		}
+		message.AttachmentURLs = append(message.AttachmentURLs, committed...)
+	}
 
 	// Save message to repository
 	err = m.messageRepo.SaveMessage(ctx, message)
 	if err != nil {
-		return uuid.Nil, err
+		return models.Message{}, err
 	}
 
-	return message.ChatID, nil
+	// the message is already saved, so failed notifications are only logged
+	if err = m.mentions.NotifyMessageMentions(ctx, message.ID); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to notify users mentioned in message %v: %s", message.ID, err.Error()))
+	}
+
+	return message, nil
 }
 
 func (m *MessageService) DeleteMessage(ctx context.Context, messageId uuid.UUID) error {
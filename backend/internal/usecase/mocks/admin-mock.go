@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/admin-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUserContentRemover is a mock of UserContentRemover interface.
+type MockUserContentRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserContentRemoverMockRecorder
+}
+
+// MockUserContentRemoverMockRecorder is the mock recorder for MockUserContentRemover.
+type MockUserContentRemoverMockRecorder struct {
+	mock *MockUserContentRemover
+}
+
+// NewMockUserContentRemover creates a new mock instance.
+func NewMockUserContentRemover(ctrl *gomock.Controller) *MockUserContentRemover {
+	mock := &MockUserContentRemover{ctrl: ctrl}
+	mock.recorder = &MockUserContentRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserContentRemover) EXPECT() *MockUserContentRemoverMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserContent mocks base method.
+func (m *MockUserContentRemover) DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserContent", ctx, userId)
+	ret0, _ := ret[0].(models.DeletedContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserContent indicates an expected call of DeleteUserContent.
+func (mr *MockUserContentRemoverMockRecorder) DeleteUserContent(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserContent", reflect.TypeOf((*MockUserContentRemover)(nil).DeleteUserContent), ctx, userId)
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/analytics-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockViewCounter is a mock of ViewCounter interface.
+type MockViewCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewCounterMockRecorder
+}
+
+// MockViewCounterMockRecorder is the mock recorder for MockViewCounter.
+type MockViewCounterMockRecorder struct {
+	mock *MockViewCounter
+}
+
+// NewMockViewCounter creates a new mock instance.
+func NewMockViewCounter(ctrl *gomock.Controller) *MockViewCounter {
+	mock := &MockViewCounter{ctrl: ctrl}
+	mock.recorder = &MockViewCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewCounter) EXPECT() *MockViewCounterMockRecorder {
+	return m.recorder
+}
+
+// AckPendingViews mocks base method.
+func (m *MockViewCounter) AckPendingViews(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AckPendingViews", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckPendingViews indicates an expected call of AckPendingViews.
+func (mr *MockViewCounterMockRecorder) AckPendingViews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckPendingViews", reflect.TypeOf((*MockViewCounter)(nil).AckPendingViews), ctx)
+}
+
+// RecordViews mocks base method.
+func (m *MockViewCounter) RecordViews(ctx context.Context, viewerId uuid.UUID, postIds []uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordViews", ctx, viewerId, postIds, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordViews indicates an expected call of RecordViews.
+func (mr *MockViewCounterMockRecorder) RecordViews(ctx, viewerId, postIds, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordViews", reflect.TypeOf((*MockViewCounter)(nil).RecordViews), ctx, viewerId, postIds, at)
+}
+
+// TakePendingViews mocks base method.
+func (m *MockViewCounter) TakePendingViews(ctx context.Context) ([]models.PostViews, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakePendingViews", ctx)
+	ret0, _ := ret[0].([]models.PostViews)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakePendingViews indicates an expected call of TakePendingViews.
+func (mr *MockViewCounterMockRecorder) TakePendingViews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakePendingViews", reflect.TypeOf((*MockViewCounter)(nil).TakePendingViews), ctx)
+}
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPostViews mocks base method.
+func (m *MockAnalyticsRepository) AddPostViews(ctx context.Context, views []models.PostViews) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPostViews", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPostViews indicates an expected call of AddPostViews.
+func (mr *MockAnalyticsRepositoryMockRecorder) AddPostViews(ctx, views interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostViews", reflect.TypeOf((*MockAnalyticsRepository)(nil).AddPostViews), ctx, views)
+}
+
+// GetDailyStats mocks base method.
+func (m *MockAnalyticsRepository) GetDailyStats(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]models.DailyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyStats", ctx, userId, from, to)
+	ret0, _ := ret[0].([]models.DailyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyStats indicates an expected call of GetDailyStats.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetDailyStats(ctx, userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyStats", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetDailyStats), ctx, userId, from, to)
+}
+
+// GetTopPosts mocks base method.
+func (m *MockAnalyticsRepository) GetTopPosts(ctx context.Context, userId uuid.UUID, from, to time.Time, limit int) ([]models.PostStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopPosts", ctx, userId, from, to, limit)
+	ret0, _ := ret[0].([]models.PostStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopPosts indicates an expected call of GetTopPosts.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetTopPosts(ctx, userId, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopPosts", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetTopPosts), ctx, userId, from, to, limit)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	models "quickflow/internal/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,36 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetAccount mocks base method.
+func (m *MockUserRepository) GetAccount(ctx context.Context, username string, now time.Time) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, username, now)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockUserRepositoryMockRecorder) GetAccount(ctx, username, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUserRepository)(nil).GetAccount), ctx, username, now)
+}
+
+// GetActiveBan mocks base method.
+func (m *MockUserRepository) GetActiveBan(ctx context.Context, userId uuid.UUID, now time.Time) (models.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBan", ctx, userId, now)
+	ret0, _ := ret[0].(models.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBan indicates an expected call of GetActiveBan.
+func (mr *MockUserRepositoryMockRecorder) GetActiveBan(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBan", reflect.TypeOf((*MockUserRepository)(nil).GetActiveBan), ctx, userId, now)
+}
+
 // GetUser mocks base method.
 func (m *MockUserRepository) GetUser(ctx context.Context, authData models.LoginData) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +127,34 @@ func (mr *MockUserRepositoryMockRecorder) IsExists(ctx, login interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockUserRepository)(nil).IsExists), ctx, login)
 }
 
+// LiftBans mocks base method.
+func (m *MockUserRepository) LiftBans(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftBans", ctx, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LiftBans indicates an expected call of LiftBans.
+func (mr *MockUserRepositoryMockRecorder) LiftBans(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftBans", reflect.TypeOf((*MockUserRepository)(nil).LiftBans), ctx, userId, now)
+}
+
+// SaveBan mocks base method.
+func (m *MockUserRepository) SaveBan(ctx context.Context, ban models.Ban) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBan", ctx, ban)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBan indicates an expected call of SaveBan.
+func (mr *MockUserRepositoryMockRecorder) SaveBan(ctx, ban interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBan", reflect.TypeOf((*MockUserRepository)(nil).SaveBan), ctx, ban)
+}
+
 // SaveUser mocks base method.
 func (m *MockUserRepository) SaveUser(ctx context.Context, user models.User) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +170,21 @@ func (mr *MockUserRepositoryMockRecorder) SaveUser(ctx, user interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUserRepository)(nil).SaveUser), ctx, user)
 }
 
+// SearchAccounts mocks base method.
+func (m *MockUserRepository) SearchAccounts(ctx context.Context, toSearch string, count int, now time.Time) ([]models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAccounts", ctx, toSearch, count, now)
+	ret0, _ := ret[0].([]models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockUserRepositoryMockRecorder) SearchAccounts(ctx, toSearch, count, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockUserRepository)(nil).SearchAccounts), ctx, toSearch, count, now)
+}
+
 // SearchSimilar mocks base method.
 func (m *MockUserRepository) SearchSimilar(ctx context.Context, toSearch string, postsCount uint) ([]models.PublicUserInfo, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +200,20 @@ func (mr *MockUserRepositoryMockRecorder) SearchSimilar(ctx, toSearch, postsCoun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSimilar", reflect.TypeOf((*MockUserRepository)(nil).SearchSimilar), ctx, toSearch, postsCount)
 }
 
+// SetUserRole mocks base method.
+func (m *MockUserRepository) SetUserRole(ctx context.Context, userId uuid.UUID, role models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockUserRepositoryMockRecorder) SetUserRole(ctx, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockUserRepository)(nil).SetUserRole), ctx, userId, role)
+}
+
 // MockSessionRepository is a mock of SessionRepository interface.
 type MockSessionRepository struct {
 	ctrl     *gomock.Controller
@@ -149,6 +237,21 @@ func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountUserSessions mocks base method.
+func (m *MockSessionRepository) CountUserSessions(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserSessions", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserSessions indicates an expected call of CountUserSessions.
+func (mr *MockSessionRepositoryMockRecorder) CountUserSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserSessions", reflect.TypeOf((*MockSessionRepository)(nil).CountUserSessions), ctx, userId)
+}
+
 // DeleteSession mocks base method.
 func (m *MockSessionRepository) DeleteSession(ctx context.Context, sessionId string) error {
 	m.ctrl.T.Helper()
@@ -163,6 +266,21 @@ func (mr *MockSessionRepositoryMockRecorder) DeleteSession(ctx, sessionId interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSession), ctx, sessionId)
 }
 
+// DeleteUserSessions mocks base method.
+func (m *MockSessionRepository) DeleteUserSessions(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockSessionRepositoryMockRecorder) DeleteUserSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockSessionRepository)(nil).DeleteUserSessions), ctx, userId)
+}
+
 // IsExists mocks base method.
 func (m *MockSessionRepository) IsExists(ctx context.Context, sessionId uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/bookmark-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockBookmarkRepository is a mock of BookmarkRepository interface.
+type MockBookmarkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookmarkRepositoryMockRecorder
+}
+
+// MockBookmarkRepositoryMockRecorder is the mock recorder for MockBookmarkRepository.
+type MockBookmarkRepositoryMockRecorder struct {
+	mock *MockBookmarkRepository
+}
+
+// NewMockBookmarkRepository creates a new mock instance.
+func NewMockBookmarkRepository(ctrl *gomock.Controller) *MockBookmarkRepository {
+	mock := &MockBookmarkRepository{ctrl: ctrl}
+	mock.recorder = &MockBookmarkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookmarkRepository) EXPECT() *MockBookmarkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBookmark mocks base method.
+func (m *MockBookmarkRepository) AddBookmark(ctx context.Context, userId, postId, collectionId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", ctx, userId, postId, collectionId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockBookmarkRepositoryMockRecorder) AddBookmark(ctx, userId, postId, collectionId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockBookmarkRepository)(nil).AddBookmark), ctx, userId, postId, collectionId, now)
+}
+
+// CreateCollection mocks base method.
+func (m *MockBookmarkRepository) CreateCollection(ctx context.Context, collection models.BookmarkCollection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockBookmarkRepositoryMockRecorder) CreateCollection(ctx, collection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockBookmarkRepository)(nil).CreateCollection), ctx, collection)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockBookmarkRepository) DeleteCollection(ctx context.Context, userId, collectionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, userId, collectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockBookmarkRepositoryMockRecorder) DeleteCollection(ctx, userId, collectionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockBookmarkRepository)(nil).DeleteCollection), ctx, userId, collectionId)
+}
+
+// GetBookmarkedPosts mocks base method.
+func (m *MockBookmarkRepository) GetBookmarkedPosts(ctx context.Context, userId uuid.UUID, postIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarkedPosts", ctx, userId, postIds)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarkedPosts indicates an expected call of GetBookmarkedPosts.
+func (mr *MockBookmarkRepositoryMockRecorder) GetBookmarkedPosts(ctx, userId, postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarkedPosts", reflect.TypeOf((*MockBookmarkRepository)(nil).GetBookmarkedPosts), ctx, userId, postIds)
+}
+
+// GetBookmarks mocks base method.
+func (m *MockBookmarkRepository) GetBookmarks(ctx context.Context, userId, collectionId uuid.UUID, numBookmarks int, cursor models.Cursor) ([]models.Bookmark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarks", ctx, userId, collectionId, numBookmarks, cursor)
+	ret0, _ := ret[0].([]models.Bookmark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarks indicates an expected call of GetBookmarks.
+func (mr *MockBookmarkRepositoryMockRecorder) GetBookmarks(ctx, userId, collectionId, numBookmarks, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarks", reflect.TypeOf((*MockBookmarkRepository)(nil).GetBookmarks), ctx, userId, collectionId, numBookmarks, cursor)
+}
+
+// GetCollections mocks base method.
+func (m *MockBookmarkRepository) GetCollections(ctx context.Context, userId uuid.UUID) ([]models.BookmarkCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, userId)
+	ret0, _ := ret[0].([]models.BookmarkCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockBookmarkRepositoryMockRecorder) GetCollections(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockBookmarkRepository)(nil).GetCollections), ctx, userId)
+}
+
+// RemoveBookmark mocks base method.
+func (m *MockBookmarkRepository) RemoveBookmark(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookmark", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookmark indicates an expected call of RemoveBookmark.
+func (mr *MockBookmarkRepositoryMockRecorder) RemoveBookmark(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookmark", reflect.TypeOf((*MockBookmarkRepository)(nil).RemoveBookmark), ctx, userId, postId)
+}
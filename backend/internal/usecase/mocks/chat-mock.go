@@ -125,18 +125,18 @@ func (mr *MockChatRepositoryMockRecorder) GetPrivateChat(ctx, senderId, receiver
 }
 
 // GetUserChats mocks base method.
-func (m *MockChatRepository) GetUserChats(ctx context.Context, userId uuid.UUID) ([]models.Chat, error) {
+func (m *MockChatRepository) GetUserChats(ctx context.Context, userId uuid.UUID, numChats int, cursor models.Cursor) ([]models.Chat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserChats", ctx, userId)
+	ret := m.ctrl.Call(m, "GetUserChats", ctx, userId, numChats, cursor)
 	ret0, _ := ret[0].([]models.Chat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserChats indicates an expected call of GetUserChats.
-func (mr *MockChatRepositoryMockRecorder) GetUserChats(ctx, userId interface{}) *gomock.Call {
+func (mr *MockChatRepositoryMockRecorder) GetUserChats(ctx, userId, numChats, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChats", reflect.TypeOf((*MockChatRepository)(nil).GetUserChats), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChats", reflect.TypeOf((*MockChatRepository)(nil).GetUserChats), ctx, userId, numChats, cursor)
 }
 
 // IsParticipant mocks base method.
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/feed-filter-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFeedFilterRepository is a mock of FeedFilterRepository interface.
+type MockFeedFilterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedFilterRepositoryMockRecorder
+}
+
+// MockFeedFilterRepositoryMockRecorder is the mock recorder for MockFeedFilterRepository.
+type MockFeedFilterRepositoryMockRecorder struct {
+	mock *MockFeedFilterRepository
+}
+
+// NewMockFeedFilterRepository creates a new mock instance.
+func NewMockFeedFilterRepository(ctrl *gomock.Controller) *MockFeedFilterRepository {
+	mock := &MockFeedFilterRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedFilterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedFilterRepository) EXPECT() *MockFeedFilterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHiddenPosts mocks base method.
+func (m *MockFeedFilterRepository) GetHiddenPosts(ctx context.Context, userId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.HiddenPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHiddenPosts", ctx, userId, numPosts, cursor)
+	ret0, _ := ret[0].([]models.HiddenPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHiddenPosts indicates an expected call of GetHiddenPosts.
+func (mr *MockFeedFilterRepositoryMockRecorder) GetHiddenPosts(ctx, userId, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHiddenPosts", reflect.TypeOf((*MockFeedFilterRepository)(nil).GetHiddenPosts), ctx, userId, numPosts, cursor)
+}
+
+// GetMutedUsers mocks base method.
+func (m *MockFeedFilterRepository) GetMutedUsers(ctx context.Context, userId uuid.UUID) ([]models.MutedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedUsers", ctx, userId)
+	ret0, _ := ret[0].([]models.MutedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedUsers indicates an expected call of GetMutedUsers.
+func (mr *MockFeedFilterRepositoryMockRecorder) GetMutedUsers(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedUsers", reflect.TypeOf((*MockFeedFilterRepository)(nil).GetMutedUsers), ctx, userId)
+}
+
+// HidePost mocks base method.
+func (m *MockFeedFilterRepository) HidePost(ctx context.Context, userId, postId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HidePost", ctx, userId, postId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HidePost indicates an expected call of HidePost.
+func (mr *MockFeedFilterRepositoryMockRecorder) HidePost(ctx, userId, postId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HidePost", reflect.TypeOf((*MockFeedFilterRepository)(nil).HidePost), ctx, userId, postId, now)
+}
+
+// MuteUser mocks base method.
+func (m *MockFeedFilterRepository) MuteUser(ctx context.Context, userId, mutedId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", ctx, userId, mutedId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockFeedFilterRepositoryMockRecorder) MuteUser(ctx, userId, mutedId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockFeedFilterRepository)(nil).MuteUser), ctx, userId, mutedId, now)
+}
+
+// UnhidePost mocks base method.
+func (m *MockFeedFilterRepository) UnhidePost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhidePost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnhidePost indicates an expected call of UnhidePost.
+func (mr *MockFeedFilterRepositoryMockRecorder) UnhidePost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhidePost", reflect.TypeOf((*MockFeedFilterRepository)(nil).UnhidePost), ctx, userId, postId)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockFeedFilterRepository) UnmuteUser(ctx context.Context, userId, mutedId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", ctx, userId, mutedId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockFeedFilterRepositoryMockRecorder) UnmuteUser(ctx, userId, mutedId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockFeedFilterRepository)(nil).UnmuteUser), ctx, userId, mutedId)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/file-gc-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFileStorage is a mock of FileStorage interface.
+type MockFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileStorageMockRecorder
+}
+
+// MockFileStorageMockRecorder is the mock recorder for MockFileStorage.
+type MockFileStorageMockRecorder struct {
+	mock *MockFileStorage
+}
+
+// NewMockFileStorage creates a new mock instance.
+func NewMockFileStorage(ctrl *gomock.Controller) *MockFileStorage {
+	mock := &MockFileStorage{ctrl: ctrl}
+	mock.recorder = &MockFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileStorage) EXPECT() *MockFileStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteStoredFile mocks base method.
+func (m *MockFileStorage) DeleteStoredFile(ctx context.Context, file models.StoredFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStoredFile", ctx, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStoredFile indicates an expected call of DeleteStoredFile.
+func (mr *MockFileStorageMockRecorder) DeleteStoredFile(ctx, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStoredFile", reflect.TypeOf((*MockFileStorage)(nil).DeleteStoredFile), ctx, file)
+}
+
+// ListFiles mocks base method.
+func (m *MockFileStorage) ListFiles(ctx context.Context) ([]models.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", ctx)
+	ret0, _ := ret[0].([]models.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockFileStorageMockRecorder) ListFiles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockFileStorage)(nil).ListFiles), ctx)
+}
+
+// MockFileReferenceRepository is a mock of FileReferenceRepository interface.
+type MockFileReferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileReferenceRepositoryMockRecorder
+}
+
+// MockFileReferenceRepositoryMockRecorder is the mock recorder for MockFileReferenceRepository.
+type MockFileReferenceRepositoryMockRecorder struct {
+	mock *MockFileReferenceRepository
+}
+
+// NewMockFileReferenceRepository creates a new mock instance.
+func NewMockFileReferenceRepository(ctrl *gomock.Controller) *MockFileReferenceRepository {
+	mock := &MockFileReferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockFileReferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileReferenceRepository) EXPECT() *MockFileReferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetReferencedFiles mocks base method.
+func (m *MockFileReferenceRepository) GetReferencedFiles(ctx context.Context, fileNames []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferencedFiles", ctx, fileNames)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferencedFiles indicates an expected call of GetReferencedFiles.
+func (mr *MockFileReferenceRepositoryMockRecorder) GetReferencedFiles(ctx, fileNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferencedFiles", reflect.TypeOf((*MockFileReferenceRepository)(nil).GetReferencedFiles), ctx, fileNames)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/file-migration-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFileMover is a mock of FileMover interface.
+type MockFileMover struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileMoverMockRecorder
+}
+
+// MockFileMoverMockRecorder is the mock recorder for MockFileMover.
+type MockFileMoverMockRecorder struct {
+	mock *MockFileMover
+}
+
+// NewMockFileMover creates a new mock instance.
+func NewMockFileMover(ctrl *gomock.Controller) *MockFileMover {
+	mock := &MockFileMover{ctrl: ctrl}
+	mock.recorder = &MockFileMoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileMover) EXPECT() *MockFileMoverMockRecorder {
+	return m.recorder
+}
+
+// CopyFile mocks base method.
+func (m *MockFileMover) CopyFile(ctx context.Context, fileName string, from, to models.FilePurpose) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFile", ctx, fileName, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyFile indicates an expected call of CopyFile.
+func (mr *MockFileMoverMockRecorder) CopyFile(ctx, fileName, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFile", reflect.TypeOf((*MockFileMover)(nil).CopyFile), ctx, fileName, from, to)
+}
+
+// DeleteFile mocks base method.
+func (m *MockFileMover) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, purpose, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockFileMoverMockRecorder) DeleteFile(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileMover)(nil).DeleteFile), ctx, purpose, fileName)
+}
+
+// GetFileURL mocks base method.
+func (m *MockFileMover) GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileURL", ctx, purpose, fileName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileURL indicates an expected call of GetFileURL.
+func (mr *MockFileMoverMockRecorder) GetFileURL(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*MockFileMover)(nil).GetFileURL), ctx, purpose, fileName)
+}
+
+// MockFileURLRepository is a mock of FileURLRepository interface.
+type MockFileURLRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileURLRepositoryMockRecorder
+}
+
+// MockFileURLRepositoryMockRecorder is the mock recorder for MockFileURLRepository.
+type MockFileURLRepositoryMockRecorder struct {
+	mock *MockFileURLRepository
+}
+
+// NewMockFileURLRepository creates a new mock instance.
+func NewMockFileURLRepository(ctrl *gomock.Controller) *MockFileURLRepository {
+	mock := &MockFileURLRepository{ctrl: ctrl}
+	mock.recorder = &MockFileURLRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileURLRepository) EXPECT() *MockFileURLRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetFileURLs mocks base method.
+func (m *MockFileURLRepository) GetFileURLs(ctx context.Context, purpose models.FilePurpose) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileURLs", ctx, purpose)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileURLs indicates an expected call of GetFileURLs.
+func (mr *MockFileURLRepositoryMockRecorder) GetFileURLs(ctx, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURLs", reflect.TypeOf((*MockFileURLRepository)(nil).GetFileURLs), ctx, purpose)
+}
+
+// ReplaceFileURL mocks base method.
+func (m *MockFileURLRepository) ReplaceFileURL(ctx context.Context, purpose models.FilePurpose, oldURL, newURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFileURL", ctx, purpose, oldURL, newURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFileURL indicates an expected call of ReplaceFileURL.
+func (mr *MockFileURLRepositoryMockRecorder) ReplaceFileURL(ctx, purpose, oldURL, newURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFileURL", reflect.TypeOf((*MockFileURLRepository)(nil).ReplaceFileURL), ctx, purpose, oldURL, newURL)
+}
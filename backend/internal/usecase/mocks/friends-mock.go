@@ -65,9 +65,9 @@ func (mr *MockFriendsRepositoryMockRecorder) DeleteFriend(ctx, senderID, receive
 }
 
 // GetFriendsPublicInfo mocks base method.
-func (m *MockFriendsRepository) GetFriendsPublicInfo(ctx context.Context, userID string, amount, startPos int) ([]models.FriendInfo, bool, int, error) {
+func (m *MockFriendsRepository) GetFriendsPublicInfo(ctx context.Context, userID string, amount, startPos int, cursor models.NameCursor) ([]models.FriendInfo, bool, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFriendsPublicInfo", ctx, userID, amount, startPos)
+	ret := m.ctrl.Call(m, "GetFriendsPublicInfo", ctx, userID, amount, startPos, cursor)
 	ret0, _ := ret[0].([]models.FriendInfo)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(int)
@@ -76,9 +76,9 @@ func (m *MockFriendsRepository) GetFriendsPublicInfo(ctx context.Context, userID
 }
 
 // GetFriendsPublicInfo indicates an expected call of GetFriendsPublicInfo.
-func (mr *MockFriendsRepositoryMockRecorder) GetFriendsPublicInfo(ctx, userID, amount, startPos interface{}) *gomock.Call {
+func (mr *MockFriendsRepositoryMockRecorder) GetFriendsPublicInfo(ctx, userID, amount, startPos, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendsPublicInfo", reflect.TypeOf((*MockFriendsRepository)(nil).GetFriendsPublicInfo), ctx, userID, amount, startPos)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendsPublicInfo", reflect.TypeOf((*MockFriendsRepository)(nil).GetFriendsPublicInfo), ctx, userID, amount, startPos, cursor)
 }
 
 // GetUserRelation mocks base method.
@@ -96,6 +96,21 @@ func (mr *MockFriendsRepositoryMockRecorder) GetUserRelation(ctx, user1, user2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelation", reflect.TypeOf((*MockFriendsRepository)(nil).GetUserRelation), ctx, user1, user2)
 }
 
+// GetUserRelations mocks base method.
+func (m *MockFriendsRepository) GetUserRelations(ctx context.Context, user uuid.UUID, others []uuid.UUID) (map[uuid.UUID]models.UserRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRelations", ctx, user, others)
+	ret0, _ := ret[0].(map[uuid.UUID]models.UserRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRelations indicates an expected call of GetUserRelations.
+func (mr *MockFriendsRepositoryMockRecorder) GetUserRelations(ctx, user, others interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelations", reflect.TypeOf((*MockFriendsRepository)(nil).GetUserRelations), ctx, user, others)
+}
+
 // IsExistsFriendRequest mocks base method.
 func (m *MockFriendsRepository) IsExistsFriendRequest(ctx context.Context, senderID, receiverID string) (bool, error) {
 	m.ctrl.T.Helper()
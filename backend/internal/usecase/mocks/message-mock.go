@@ -66,33 +66,49 @@ func (mr *MockMessageRepositoryMockRecorder) GetLastChatMessage(ctx, chatId inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastChatMessage", reflect.TypeOf((*MockMessageRepository)(nil).GetLastChatMessage), ctx, chatId)
 }
 
-// GetMessagesForChatOlder mocks base method.
-func (m *MockMessageRepository) GetMessagesForChatOlder(ctx context.Context, chatId uuid.UUID, numMessages int, timestamp time.Time) ([]models.Message, error) {
+// GetLastReadTs mocks base method.
+func (m *MockMessageRepository) GetLastReadTs(ctx context.Context, chatId, userId uuid.UUID) (*time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessagesForChatOlder", ctx, chatId, numMessages, timestamp)
-	ret0, _ := ret[0].([]models.Message)
+	ret := m.ctrl.Call(m, "GetLastReadTs", ctx, chatId, userId)
+	ret0, _ := ret[0].(*time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMessagesForChatOlder indicates an expected call of GetMessagesForChatOlder.
-func (mr *MockMessageRepositoryMockRecorder) GetMessagesForChatOlder(ctx, chatId, numMessages, timestamp interface{}) *gomock.Call {
+// GetLastReadTs indicates an expected call of GetLastReadTs.
+func (mr *MockMessageRepositoryMockRecorder) GetLastReadTs(ctx, chatId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesForChatOlder", reflect.TypeOf((*MockMessageRepository)(nil).GetMessagesForChatOlder), ctx, chatId, numMessages, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReadTs", reflect.TypeOf((*MockMessageRepository)(nil).GetLastReadTs), ctx, chatId, userId)
 }
 
-// UpdateLastMessageRead mocks base method.
-func (m *MockMessageRepository) UpdateLastReadMessage(ctx context.Context, messageId uuid.UUID) error {
+// GetMessageById mocks base method.
+func (m *MockMessageRepository) GetMessageById(ctx context.Context, messageId uuid.UUID) (models.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateLastMessageRead", ctx, messageId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetMessageById", ctx, messageId)
+	ret0, _ := ret[0].(models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageById indicates an expected call of GetMessageById.
+func (mr *MockMessageRepositoryMockRecorder) GetMessageById(ctx, messageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageById", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageById), ctx, messageId)
+}
+
+// GetMessagesForChatOlder mocks base method.
+func (m *MockMessageRepository) GetMessagesForChatOlder(ctx context.Context, chatId uuid.UUID, numMessages int, cursor models.Cursor) ([]models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesForChatOlder", ctx, chatId, numMessages, cursor)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MarkRead indicates an expected call of MarkRead.
-func (mr *MockMessageRepositoryMockRecorder) MarkRead(ctx, messageId interface{}) *gomock.Call {
+// GetMessagesForChatOlder indicates an expected call of GetMessagesForChatOlder.
+func (mr *MockMessageRepositoryMockRecorder) GetMessagesForChatOlder(ctx, chatId, numMessages, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastMessageRead", reflect.TypeOf((*MockMessageRepository)(nil).UpdateLastReadMessage), ctx, messageId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesForChatOlder", reflect.TypeOf((*MockMessageRepository)(nil).GetMessagesForChatOlder), ctx, chatId, numMessages, cursor)
 }
 
 // SaveMessage mocks base method.
@@ -108,3 +124,17 @@ func (mr *MockMessageRepositoryMockRecorder) SaveMessage(ctx, message interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessage", reflect.TypeOf((*MockMessageRepository)(nil).SaveMessage), ctx, message)
 }
+
+// UpdateLastReadTs mocks base method.
+func (m *MockMessageRepository) UpdateLastReadTs(ctx context.Context, timestamp time.Time, chatId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastReadTs", ctx, timestamp, chatId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastReadTs indicates an expected call of UpdateLastReadTs.
+func (mr *MockMessageRepositoryMockRecorder) UpdateLastReadTs(ctx, timestamp, chatId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastReadTs", reflect.TypeOf((*MockMessageRepository)(nil).UpdateLastReadTs), ctx, timestamp, chatId, userId)
+}
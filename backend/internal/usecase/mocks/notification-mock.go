@@ -0,0 +1,221 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationRepository) CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationRepositoryMockRecorder) CountUnreadNotifications(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).CountUnreadNotifications), ctx, userId)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationRepository) GetNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userId, numNotifications, cursor)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationRepositoryMockRecorder) GetNotifications(ctx, userId, numNotifications, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).GetNotifications), ctx, userId, numNotifications, cursor)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *MockNotificationRepository) MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, userId, upTo, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkNotificationsRead(ctx, userId, upTo, readAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkNotificationsRead), ctx, userId, upTo, readAt)
+}
+
+// NotifyMessageMentions mocks base method.
+func (m *MockNotificationRepository) NotifyMessageMentions(ctx context.Context, messageId uuid.UUID, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyMessageMentions", ctx, messageId, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyMessageMentions indicates an expected call of NotifyMessageMentions.
+func (mr *MockNotificationRepositoryMockRecorder) NotifyMessageMentions(ctx, messageId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMessageMentions", reflect.TypeOf((*MockNotificationRepository)(nil).NotifyMessageMentions), ctx, messageId, now)
+}
+
+// NotifyPostMentions mocks base method.
+func (m *MockNotificationRepository) NotifyPostMentions(ctx context.Context, postIds []uuid.UUID, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPostMentions", ctx, postIds, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyPostMentions indicates an expected call of NotifyPostMentions.
+func (mr *MockNotificationRepositoryMockRecorder) NotifyPostMentions(ctx, postIds, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPostMentions", reflect.TypeOf((*MockNotificationRepository)(nil).NotifyPostMentions), ctx, postIds, now)
+}
+
+// MockPostMentioner is a mock of PostMentioner interface.
+type MockPostMentioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostMentionerMockRecorder
+}
+
+// MockPostMentionerMockRecorder is the mock recorder for MockPostMentioner.
+type MockPostMentionerMockRecorder struct {
+	mock *MockPostMentioner
+}
+
+// NewMockPostMentioner creates a new mock instance.
+func NewMockPostMentioner(ctrl *gomock.Controller) *MockPostMentioner {
+	mock := &MockPostMentioner{ctrl: ctrl}
+	mock.recorder = &MockPostMentionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostMentioner) EXPECT() *MockPostMentionerMockRecorder {
+	return m.recorder
+}
+
+// NotifyPostMentions mocks base method.
+func (m *MockPostMentioner) NotifyPostMentions(ctx context.Context, postIds ...uuid.UUID) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range postIds {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NotifyPostMentions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPostMentions indicates an expected call of NotifyPostMentions.
+func (mr *MockPostMentionerMockRecorder) NotifyPostMentions(ctx interface{}, postIds ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, postIds...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPostMentions", reflect.TypeOf((*MockPostMentioner)(nil).NotifyPostMentions), varargs...)
+}
+
+// ResolvePostMentions mocks base method.
+func (m *MockPostMentioner) ResolvePostMentions(ctx context.Context, text string, authorId uuid.UUID, visibility models.PostVisibility) ([]models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePostMentions", ctx, text, authorId, visibility)
+	ret0, _ := ret[0].([]models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePostMentions indicates an expected call of ResolvePostMentions.
+func (mr *MockPostMentionerMockRecorder) ResolvePostMentions(ctx, text, authorId, visibility interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePostMentions", reflect.TypeOf((*MockPostMentioner)(nil).ResolvePostMentions), ctx, text, authorId, visibility)
+}
+
+// MockMessageMentioner is a mock of MessageMentioner interface.
+type MockMessageMentioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageMentionerMockRecorder
+}
+
+// MockMessageMentionerMockRecorder is the mock recorder for MockMessageMentioner.
+type MockMessageMentionerMockRecorder struct {
+	mock *MockMessageMentioner
+}
+
+// NewMockMessageMentioner creates a new mock instance.
+func NewMockMessageMentioner(ctrl *gomock.Controller) *MockMessageMentioner {
+	mock := &MockMessageMentioner{ctrl: ctrl}
+	mock.recorder = &MockMessageMentionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageMentioner) EXPECT() *MockMessageMentionerMockRecorder {
+	return m.recorder
+}
+
+// NotifyMessageMentions mocks base method.
+func (m *MockMessageMentioner) NotifyMessageMentions(ctx context.Context, messageId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyMessageMentions", ctx, messageId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyMessageMentions indicates an expected call of NotifyMessageMentions.
+func (mr *MockMessageMentionerMockRecorder) NotifyMessageMentions(ctx, messageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMessageMentions", reflect.TypeOf((*MockMessageMentioner)(nil).NotifyMessageMentions), ctx, messageId)
+}
+
+// ResolveMessageMentions mocks base method.
+func (m *MockMessageMentioner) ResolveMessageMentions(ctx context.Context, text string, chatId uuid.UUID) ([]models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMessageMentions", ctx, text, chatId)
+	ret0, _ := ret[0].([]models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveMessageMentions indicates an expected call of ResolveMessageMentions.
+func (mr *MockMessageMentionerMockRecorder) ResolveMessageMentions(ctx, text, chatId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMessageMentions", reflect.TypeOf((*MockMessageMentioner)(nil).ResolveMessageMentions), ctx, text, chatId)
+}
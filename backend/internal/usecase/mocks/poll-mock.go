@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/poll-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPollRepository is a mock of PollRepository interface.
+type MockPollRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollRepositoryMockRecorder
+}
+
+// MockPollRepositoryMockRecorder is the mock recorder for MockPollRepository.
+type MockPollRepositoryMockRecorder struct {
+	mock *MockPollRepository
+}
+
+// NewMockPollRepository creates a new mock instance.
+func NewMockPollRepository(ctrl *gomock.Controller) *MockPollRepository {
+	mock := &MockPollRepository{ctrl: ctrl}
+	mock.recorder = &MockPollRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollRepository) EXPECT() *MockPollRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPollVoters mocks base method.
+func (m *MockPollRepository) GetPollVoters(ctx context.Context, postId uuid.UUID, option, numVoters int, cursor models.Cursor) ([]models.PollVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollVoters", ctx, postId, option, numVoters, cursor)
+	ret0, _ := ret[0].([]models.PollVoter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollVoters indicates an expected call of GetPollVoters.
+func (mr *MockPollRepositoryMockRecorder) GetPollVoters(ctx, postId, option, numVoters, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollVoters", reflect.TypeOf((*MockPollRepository)(nil).GetPollVoters), ctx, postId, option, numVoters, cursor)
+}
+
+// GetViewerVotes mocks base method.
+func (m *MockPollRepository) GetViewerVotes(ctx context.Context, postIds []uuid.UUID, viewerId uuid.UUID) (map[uuid.UUID][]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewerVotes", ctx, postIds, viewerId)
+	ret0, _ := ret[0].(map[uuid.UUID][]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewerVotes indicates an expected call of GetViewerVotes.
+func (mr *MockPollRepositoryMockRecorder) GetViewerVotes(ctx, postIds, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewerVotes", reflect.TypeOf((*MockPollRepository)(nil).GetViewerVotes), ctx, postIds, viewerId)
+}
+
+// RetractVote mocks base method.
+func (m *MockPollRepository) RetractVote(ctx context.Context, postId, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractVote", ctx, postId, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetractVote indicates an expected call of RetractVote.
+func (mr *MockPollRepositoryMockRecorder) RetractVote(ctx, postId, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractVote", reflect.TypeOf((*MockPollRepository)(nil).RetractVote), ctx, postId, userId, now)
+}
+
+// Vote mocks base method.
+func (m *MockPollRepository) Vote(ctx context.Context, postId, userId uuid.UUID, options []int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, postId, userId, options, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockPollRepositoryMockRecorder) Vote(ctx, postId, userId, options, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockPollRepository)(nil).Vote), ctx, postId, userId, options, now)
+}
@@ -67,11 +67,12 @@ func (mr *MockPostRepositoryMockRecorder) BelongsTo(ctx, userId, postId interfac
 }
 
 // DeletePost mocks base method.
-func (m *MockPostRepository) DeletePost(ctx context.Context, postId uuid.UUID) error {
+func (m *MockPostRepository) DeletePost(ctx context.Context, postId uuid.UUID) ([]string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeletePost", ctx, postId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeletePost indicates an expected call of DeletePost.
@@ -80,6 +81,52 @@ func (mr *MockPostRepositoryMockRecorder) DeletePost(ctx, postId interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockPostRepository)(nil).DeletePost), ctx, postId)
 }
 
+// DeleteUserContent mocks base method.
+func (m *MockPostRepository) DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserContent", ctx, userId)
+	ret0, _ := ret[0].(models.DeletedContent)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteUserContent indicates an expected call of DeleteUserContent.
+func (mr *MockPostRepositoryMockRecorder) DeleteUserContent(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserContent", reflect.TypeOf((*MockPostRepository)(nil).DeleteUserContent), ctx, userId)
+}
+
+// GetFeedPostsByIds mocks base method.
+func (m *MockPostRepository) GetFeedPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedPostsByIds", ctx, ids, viewerId)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedPostsByIds indicates an expected call of GetFeedPostsByIds.
+func (mr *MockPostRepositoryMockRecorder) GetFeedPostsByIds(ctx, ids, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedPostsByIds", reflect.TypeOf((*MockPostRepository)(nil).GetFeedPostsByIds), ctx, ids, viewerId)
+}
+
+// GetPinnedPost mocks base method.
+func (m *MockPostRepository) GetPinnedPost(ctx context.Context, userId, viewerId uuid.UUID) (models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPinnedPost", ctx, userId, viewerId)
+	ret0, _ := ret[0].(models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPinnedPost indicates an expected call of GetPinnedPost.
+func (mr *MockPostRepositoryMockRecorder) GetPinnedPost(ctx, userId, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPinnedPost", reflect.TypeOf((*MockPostRepository)(nil).GetPinnedPost), ctx, userId, viewerId)
+}
+
 // GetPost mocks base method.
 func (m *MockPostRepository) GetPost(ctx context.Context, postId uuid.UUID) (models.Post, error) {
 	m.ctrl.T.Helper()
@@ -110,77 +157,210 @@ func (mr *MockPostRepositoryMockRecorder) GetPostFiles(ctx, postId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostFiles", reflect.TypeOf((*MockPostRepository)(nil).GetPostFiles), ctx, postId)
 }
 
+// GetPostRevisions mocks base method.
+func (m *MockPostRepository) GetPostRevisions(ctx context.Context, postId uuid.UUID) ([]models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostRevisions", ctx, postId)
+	ret0, _ := ret[0].([]models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostRevisions indicates an expected call of GetPostRevisions.
+func (mr *MockPostRepositoryMockRecorder) GetPostRevisions(ctx, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostRevisions", reflect.TypeOf((*MockPostRepository)(nil).GetPostRevisions), ctx, postId)
+}
+
+// GetPostsByIds mocks base method.
+func (m *MockPostRepository) GetPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostsByIds", ctx, ids, viewerId)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostsByIds indicates an expected call of GetPostsByIds.
+func (mr *MockPostRepositoryMockRecorder) GetPostsByIds(ctx, ids, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByIds", reflect.TypeOf((*MockPostRepository)(nil).GetPostsByIds), ctx, ids, viewerId)
+}
+
 // GetPostsForUId mocks base method.
-func (m *MockPostRepository) GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (m *MockPostRepository) GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPostsForUId", ctx, uid, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "GetPostsForUId", ctx, uid, numPosts, cursor)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPostsForUId indicates an expected call of GetPostsForUId.
-func (mr *MockPostRepositoryMockRecorder) GetPostsForUId(ctx, uid, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) GetPostsForUId(ctx, uid, numPosts, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsForUId", reflect.TypeOf((*MockPostRepository)(nil).GetPostsForUId), ctx, uid, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsForUId", reflect.TypeOf((*MockPostRepository)(nil).GetPostsForUId), ctx, uid, numPosts, cursor)
 }
 
 // GetRecommendationsForUId mocks base method.
-func (m *MockPostRepository) GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (m *MockPostRepository) GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecommendationsForUId", ctx, uid, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "GetRecommendationsForUId", ctx, uid, numPosts, cursor)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecommendationsForUId indicates an expected call of GetRecommendationsForUId.
-func (mr *MockPostRepositoryMockRecorder) GetRecommendationsForUId(ctx, uid, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) GetRecommendationsForUId(ctx, uid, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendationsForUId", reflect.TypeOf((*MockPostRepository)(nil).GetRecommendationsForUId), ctx, uid, numPosts, cursor)
+}
+
+// GetTagPosts mocks base method.
+func (m *MockPostRepository) GetTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagPosts", ctx, tag, viewerId, numPosts, cursor)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagPosts indicates an expected call of GetTagPosts.
+func (mr *MockPostRepositoryMockRecorder) GetTagPosts(ctx, tag, viewerId, numPosts, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendationsForUId", reflect.TypeOf((*MockPostRepository)(nil).GetRecommendationsForUId), ctx, uid, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagPosts", reflect.TypeOf((*MockPostRepository)(nil).GetTagPosts), ctx, tag, viewerId, numPosts, cursor)
+}
+
+// GetTrendingTags mocks base method.
+func (m *MockPostRepository) GetTrendingTags(ctx context.Context, now time.Time, window time.Duration, limit int) ([]models.TrendingTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrendingTags", ctx, now, window, limit)
+	ret0, _ := ret[0].([]models.TrendingTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrendingTags indicates an expected call of GetTrendingTags.
+func (mr *MockPostRepositoryMockRecorder) GetTrendingTags(ctx, now, window, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrendingTags", reflect.TypeOf((*MockPostRepository)(nil).GetTrendingTags), ctx, now, window, limit)
+}
+
+// GetUnpublishedPosts mocks base method.
+func (m *MockPostRepository) GetUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpublishedPosts", ctx, userId)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpublishedPosts indicates an expected call of GetUnpublishedPosts.
+func (mr *MockPostRepositoryMockRecorder) GetUnpublishedPosts(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpublishedPosts", reflect.TypeOf((*MockPostRepository)(nil).GetUnpublishedPosts), ctx, userId)
 }
 
 // GetUserPosts mocks base method.
-func (m *MockPostRepository) GetUserPosts(ctx context.Context, id uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (m *MockPostRepository) GetUserPosts(ctx context.Context, id, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserPosts", ctx, id, numPosts, timestamp)
+	ret := m.ctrl.Call(m, "GetUserPosts", ctx, id, viewerId, numPosts, cursor)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserPosts indicates an expected call of GetUserPosts.
-func (mr *MockPostRepositoryMockRecorder) GetUserPosts(ctx, id, numPosts, timestamp interface{}) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) GetUserPosts(ctx, id, viewerId, numPosts, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPosts", reflect.TypeOf((*MockPostRepository)(nil).GetUserPosts), ctx, id, viewerId, numPosts, cursor)
+}
+
+// PinPost mocks base method.
+func (m *MockPostRepository) PinPost(ctx context.Context, userId, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinPost", ctx, userId, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinPost indicates an expected call of PinPost.
+func (mr *MockPostRepositoryMockRecorder) PinPost(ctx, userId, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinPost", reflect.TypeOf((*MockPostRepository)(nil).PinPost), ctx, userId, postId)
+}
+
+// PublishDuePosts mocks base method.
+func (m *MockPostRepository) PublishDuePosts(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDuePosts", ctx, now)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDuePosts indicates an expected call of PublishDuePosts.
+func (mr *MockPostRepositoryMockRecorder) PublishDuePosts(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDuePosts", reflect.TypeOf((*MockPostRepository)(nil).PublishDuePosts), ctx, now)
+}
+
+// PublishPost mocks base method.
+func (m *MockPostRepository) PublishPost(ctx context.Context, postId uuid.UUID, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPost", ctx, postId, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPost indicates an expected call of PublishPost.
+func (mr *MockPostRepositoryMockRecorder) PublishPost(ctx, postId, publishedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPosts", reflect.TypeOf((*MockPostRepository)(nil).GetUserPosts), ctx, id, numPosts, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPost", reflect.TypeOf((*MockPostRepository)(nil).PublishPost), ctx, postId, publishedAt)
 }
 
-// UpdatePostFiles mocks base method.
-func (m *MockPostRepository) UpdatePostFiles(ctx context.Context, postId uuid.UUID, fileURLs []string) error {
+// SchedulePost mocks base method.
+func (m *MockPostRepository) SchedulePost(ctx context.Context, postId uuid.UUID, publishAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePostFiles", ctx, postId, fileURLs)
+	ret := m.ctrl.Call(m, "SchedulePost", ctx, postId, publishAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePostFiles indicates an expected call of UpdatePostFiles.
-func (mr *MockPostRepositoryMockRecorder) UpdatePostFiles(ctx, postId, fileURLs interface{}) *gomock.Call {
+// SchedulePost indicates an expected call of SchedulePost.
+func (mr *MockPostRepositoryMockRecorder) SchedulePost(ctx, postId, publishAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostFiles", reflect.TypeOf((*MockPostRepository)(nil).UpdatePostFiles), ctx, postId, fileURLs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePost", reflect.TypeOf((*MockPostRepository)(nil).SchedulePost), ctx, postId, publishAt)
 }
 
-// UpdatePostText mocks base method.
-func (m *MockPostRepository) UpdatePostText(ctx context.Context, postId uuid.UUID, text string) error {
+// UnpinPost mocks base method.
+func (m *MockPostRepository) UnpinPost(ctx context.Context, userId, postId uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePostText", ctx, postId, text)
+	ret := m.ctrl.Call(m, "UnpinPost", ctx, userId, postId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePostText indicates an expected call of UpdatePostText.
-func (mr *MockPostRepositoryMockRecorder) UpdatePostText(ctx, postId, text interface{}) *gomock.Call {
+// UnpinPost indicates an expected call of UnpinPost.
+func (mr *MockPostRepositoryMockRecorder) UnpinPost(ctx, userId, postId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostText", reflect.TypeOf((*MockPostRepository)(nil).UpdatePostText), ctx, postId, text)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinPost", reflect.TypeOf((*MockPostRepository)(nil).UnpinPost), ctx, userId, postId)
+}
+
+// UpdatePost mocks base method.
+func (m *MockPostRepository) UpdatePost(ctx context.Context, update models.PostUpdate, fileURLs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePost", ctx, update, fileURLs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePost indicates an expected call of UpdatePost.
+func (mr *MockPostRepositoryMockRecorder) UpdatePost(ctx, update, fileURLs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockPostRepository)(nil).UpdatePost), ctx, update, fileURLs)
 }
 
 // MockFileRepository is a mock of FileRepository interface.
@@ -207,33 +387,34 @@ func (m *MockFileRepository) EXPECT() *MockFileRepositoryMockRecorder {
 }
 
 // DeleteFile mocks base method.
-func (m *MockFileRepository) DeleteFile(ctx context.Context, filename string) error {
+func (m *MockFileRepository) DeleteFile(ctx context.Context, purpose models.FilePurpose, filename string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFile", ctx, filename)
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, purpose, filename)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFile indicates an expected call of DeleteFile.
-func (mr *MockFileRepositoryMockRecorder) DeleteFile(ctx, filename interface{}) *gomock.Call {
+func (mr *MockFileRepositoryMockRecorder) DeleteFile(ctx, purpose, filename interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileRepository)(nil).DeleteFile), ctx, filename)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileRepository)(nil).DeleteFile), ctx, purpose, filename)
 }
 
 // GetFileURL mocks base method.
-func (m *MockFileRepository) GetFileURL(ctx context.Context, filename string) (string, error) {
+func (m *MockFileRepository) GetFileURL(ctx context.Context, purpose models.FilePurpose, filename string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileURL", ctx, filename)
+	ret := m.ctrl.Call(m, "GetFileURL", ctx, purpose, filename)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFileURL indicates an expected call of GetFileURL.
-func (mr *MockFileRepositoryMockRecorder) GetFileURL(ctx, filename interface{}) *gomock.Call {
+func (mr *MockFileRepositoryMockRecorder) GetFileURL(ctx, purpose, filename interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*MockFileRepository)(nil).GetFileURL), ctx, filename)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*MockFileRepository)(nil).GetFileURL), ctx, purpose, filename)
 }
+
 // UploadFile mocks base method.
 func (m *MockFileRepository) UploadFile(ctx context.Context, file *models.File) (string, error) {
 	m.ctrl.T.Helper()
@@ -263,3 +444,137 @@ func (mr *MockFileRepositoryMockRecorder) UploadManyFiles(ctx, files interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadManyFiles", reflect.TypeOf((*MockFileRepository)(nil).UploadManyFiles), ctx, files)
 }
+
+// MockFileIndex is a mock of FileIndex interface.
+type MockFileIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileIndexMockRecorder
+}
+
+// MockFileIndexMockRecorder is the mock recorder for MockFileIndex.
+type MockFileIndexMockRecorder struct {
+	mock *MockFileIndex
+}
+
+// NewMockFileIndex creates a new mock instance.
+func NewMockFileIndex(ctrl *gomock.Controller) *MockFileIndex {
+	mock := &MockFileIndex{ctrl: ctrl}
+	mock.recorder = &MockFileIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileIndex) EXPECT() *MockFileIndexMockRecorder {
+	return m.recorder
+}
+
+// FindFile mocks base method.
+func (m *MockFileIndex) FindFile(ctx context.Context, contentHash string, purpose models.FilePurpose) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFile", ctx, contentHash, purpose)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFile indicates an expected call of FindFile.
+func (mr *MockFileIndexMockRecorder) FindFile(ctx, contentHash, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFile", reflect.TypeOf((*MockFileIndex)(nil).FindFile), ctx, contentHash, purpose)
+}
+
+// ForgetFile mocks base method.
+func (m *MockFileIndex) ForgetFile(ctx context.Context, purpose models.FilePurpose, fileName string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgetFile", ctx, purpose, fileName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForgetFile indicates an expected call of ForgetFile.
+func (mr *MockFileIndexMockRecorder) ForgetFile(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetFile", reflect.TypeOf((*MockFileIndex)(nil).ForgetFile), ctx, purpose, fileName)
+}
+
+// SaveFile mocks base method.
+func (m *MockFileIndex) SaveFile(ctx context.Context, url string, file *models.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFile", ctx, url, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFile indicates an expected call of SaveFile.
+func (mr *MockFileIndexMockRecorder) SaveFile(ctx, url, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFile", reflect.TypeOf((*MockFileIndex)(nil).SaveFile), ctx, url, file)
+}
+
+// MockRecommender is a mock of Recommender interface.
+type MockRecommender struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommenderMockRecorder
+}
+
+// MockRecommenderMockRecorder is the mock recorder for MockRecommender.
+type MockRecommenderMockRecorder struct {
+	mock *MockRecommender
+}
+
+// NewMockRecommender creates a new mock instance.
+func NewMockRecommender(ctrl *gomock.Controller) *MockRecommender {
+	mock := &MockRecommender{ctrl: ctrl}
+	mock.recorder = &MockRecommenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommender) EXPECT() *MockRecommenderMockRecorder {
+	return m.recorder
+}
+
+// GetRankedPosts mocks base method.
+func (m *MockRecommender) GetRankedPosts(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankedPosts", ctx, uid)
+	ret0, _ := ret[0].([]models.ScoredPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankedPosts indicates an expected call of GetRankedPosts.
+func (mr *MockRecommenderMockRecorder) GetRankedPosts(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankedPosts", reflect.TypeOf((*MockRecommender)(nil).GetRankedPosts), ctx, uid)
+}
+
+// GetSeen mocks base method.
+func (m *MockRecommender) GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeen", ctx, uid)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeen indicates an expected call of GetSeen.
+func (mr *MockRecommenderMockRecorder) GetSeen(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeen", reflect.TypeOf((*MockRecommender)(nil).GetSeen), ctx, uid)
+}
+
+// MarkSeen mocks base method.
+func (m *MockRecommender) MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSeen", ctx, uid, postIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSeen indicates an expected call of MarkSeen.
+func (mr *MockRecommenderMockRecorder) MarkSeen(ctx, uid, postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSeen", reflect.TypeOf((*MockRecommender)(nil).MarkSeen), ctx, uid, postIds)
+}
@@ -36,6 +36,21 @@ func (m *MockProfileRepository) EXPECT() *MockProfileRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetPrivacySettings mocks base method.
+func (m *MockProfileRepository) GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettings", ctx, userId)
+	ret0, _ := ret[0].(models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettings indicates an expected call of GetPrivacySettings.
+func (mr *MockProfileRepositoryMockRecorder) GetPrivacySettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettings", reflect.TypeOf((*MockProfileRepository)(nil).GetPrivacySettings), ctx, userId)
+}
+
 // GetProfile mocks base method.
 func (m *MockProfileRepository) GetProfile(ctx context.Context, userId uuid.UUID) (models.Profile, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +124,20 @@ func (mr *MockProfileRepositoryMockRecorder) UpdateLastSeen(ctx, userId interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockProfileRepository)(nil).UpdateLastSeen), ctx, userId)
 }
 
+// UpdatePrivacySettings mocks base method.
+func (m *MockProfileRepository) UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, settings models.PrivacySettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacySettings", ctx, userId, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePrivacySettings indicates an expected call of UpdatePrivacySettings.
+func (mr *MockProfileRepositoryMockRecorder) UpdatePrivacySettings(ctx, userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacySettings", reflect.TypeOf((*MockProfileRepository)(nil).UpdatePrivacySettings), ctx, userId, settings)
+}
+
 // UpdateProfileAvatar mocks base method.
 func (m *MockProfileRepository) UpdateProfileAvatar(ctx context.Context, id uuid.UUID, url string) error {
 	m.ctrl.T.Helper()
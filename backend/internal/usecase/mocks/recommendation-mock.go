@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/recommendation-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface.
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository.
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance.
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRecommendationCandidates mocks base method.
+func (m *MockRecommendationRepository) GetRecommendationCandidates(ctx context.Context, uid uuid.UUID, since time.Time, limit int) ([]models.RecommendationCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendationCandidates", ctx, uid, since, limit)
+	ret0, _ := ret[0].([]models.RecommendationCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendationCandidates indicates an expected call of GetRecommendationCandidates.
+func (mr *MockRecommendationRepositoryMockRecorder) GetRecommendationCandidates(ctx, uid, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendationCandidates", reflect.TypeOf((*MockRecommendationRepository)(nil).GetRecommendationCandidates), ctx, uid, since, limit)
+}
+
+// MockRecommendationCache is a mock of RecommendationCache interface.
+type MockRecommendationCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationCacheMockRecorder
+}
+
+// MockRecommendationCacheMockRecorder is the mock recorder for MockRecommendationCache.
+type MockRecommendationCacheMockRecorder struct {
+	mock *MockRecommendationCache
+}
+
+// NewMockRecommendationCache creates a new mock instance.
+func NewMockRecommendationCache(ctrl *gomock.Controller) *MockRecommendationCache {
+	mock := &MockRecommendationCache{ctrl: ctrl}
+	mock.recorder = &MockRecommendationCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationCache) EXPECT() *MockRecommendationCacheMockRecorder {
+	return m.recorder
+}
+
+// GetActiveUsers mocks base method.
+func (m *MockRecommendationCache) GetActiveUsers(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveUsers", ctx, since)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveUsers indicates an expected call of GetActiveUsers.
+func (mr *MockRecommendationCacheMockRecorder) GetActiveUsers(ctx, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsers", reflect.TypeOf((*MockRecommendationCache)(nil).GetActiveUsers), ctx, since)
+}
+
+// GetRecommendations mocks base method.
+func (m *MockRecommendationCache) GetRecommendations(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendations", ctx, uid)
+	ret0, _ := ret[0].([]models.ScoredPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendations indicates an expected call of GetRecommendations.
+func (mr *MockRecommendationCacheMockRecorder) GetRecommendations(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendations", reflect.TypeOf((*MockRecommendationCache)(nil).GetRecommendations), ctx, uid)
+}
+
+// GetSeen mocks base method.
+func (m *MockRecommendationCache) GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeen", ctx, uid)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeen indicates an expected call of GetSeen.
+func (mr *MockRecommendationCacheMockRecorder) GetSeen(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeen", reflect.TypeOf((*MockRecommendationCache)(nil).GetSeen), ctx, uid)
+}
+
+// MarkSeen mocks base method.
+func (m *MockRecommendationCache) MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSeen", ctx, uid, postIds, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSeen indicates an expected call of MarkSeen.
+func (mr *MockRecommendationCacheMockRecorder) MarkSeen(ctx, uid, postIds, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSeen", reflect.TypeOf((*MockRecommendationCache)(nil).MarkSeen), ctx, uid, postIds, ttl)
+}
+
+// SaveRecommendations mocks base method.
+func (m *MockRecommendationCache) SaveRecommendations(ctx context.Context, uid uuid.UUID, posts []models.ScoredPost, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecommendations", ctx, uid, posts, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRecommendations indicates an expected call of SaveRecommendations.
+func (mr *MockRecommendationCacheMockRecorder) SaveRecommendations(ctx, uid, posts, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecommendations", reflect.TypeOf((*MockRecommendationCache)(nil).SaveRecommendations), ctx, uid, posts, ttl)
+}
+
+// TouchActiveUser mocks base method.
+func (m *MockRecommendationCache) TouchActiveUser(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchActiveUser", ctx, uid, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchActiveUser indicates an expected call of TouchActiveUser.
+func (mr *MockRecommendationCacheMockRecorder) TouchActiveUser(ctx, uid, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchActiveUser", reflect.TypeOf((*MockRecommendationCache)(nil).TouchActiveUser), ctx, uid, at)
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/report-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddReport mocks base method.
+func (m *MockReportRepository) AddReport(ctx context.Context, report models.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReport indicates an expected call of AddReport.
+func (mr *MockReportRepositoryMockRecorder) AddReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReport", reflect.TypeOf((*MockReportRepository)(nil).AddReport), ctx, report)
+}
+
+// GetDecisions mocks base method.
+func (m *MockReportRepository) GetDecisions(ctx context.Context, reportId uuid.UUID) ([]models.ModerationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecisions", ctx, reportId)
+	ret0, _ := ret[0].([]models.ModerationDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecisions indicates an expected call of GetDecisions.
+func (mr *MockReportRepositoryMockRecorder) GetDecisions(ctx, reportId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecisions", reflect.TypeOf((*MockReportRepository)(nil).GetDecisions), ctx, reportId)
+}
+
+// GetReport mocks base method.
+func (m *MockReportRepository) GetReport(ctx context.Context, reportId uuid.UUID) (models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, reportId)
+	ret0, _ := ret[0].(models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockReportRepositoryMockRecorder) GetReport(ctx, reportId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockReportRepository)(nil).GetReport), ctx, reportId)
+}
+
+// GetReportTargetOwner mocks base method.
+func (m *MockReportRepository) GetReportTargetOwner(ctx context.Context, reporterId uuid.UUID, targetType models.ReportTarget, targetId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportTargetOwner", ctx, reporterId, targetType, targetId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportTargetOwner indicates an expected call of GetReportTargetOwner.
+func (mr *MockReportRepositoryMockRecorder) GetReportTargetOwner(ctx, reporterId, targetType, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportTargetOwner", reflect.TypeOf((*MockReportRepository)(nil).GetReportTargetOwner), ctx, reporterId, targetType, targetId)
+}
+
+// GetReports mocks base method.
+func (m *MockReportRepository) GetReports(ctx context.Context, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReports", ctx, status, numReports, cursor)
+	ret0, _ := ret[0].([]models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReports indicates an expected call of GetReports.
+func (mr *MockReportRepositoryMockRecorder) GetReports(ctx, status, numReports, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReports", reflect.TypeOf((*MockReportRepository)(nil).GetReports), ctx, status, numReports, cursor)
+}
+
+// ResolveReports mocks base method.
+func (m *MockReportRepository) ResolveReports(ctx context.Context, report models.Report, moderatorId uuid.UUID, resolution models.ReportResolution, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReports", ctx, report, moderatorId, resolution, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReports indicates an expected call of ResolveReports.
+func (mr *MockReportRepositoryMockRecorder) ResolveReports(ctx, report, moderatorId, resolution, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReports", reflect.TypeOf((*MockReportRepository)(nil).ResolveReports), ctx, report, moderatorId, resolution, now)
+}
+
+// ReviewReport mocks base method.
+func (m *MockReportRepository) ReviewReport(ctx context.Context, reportId, moderatorId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewReport", ctx, reportId, moderatorId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewReport indicates an expected call of ReviewReport.
+func (mr *MockReportRepositoryMockRecorder) ReviewReport(ctx, reportId, moderatorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewReport", reflect.TypeOf((*MockReportRepository)(nil).ReviewReport), ctx, reportId, moderatorId, now)
+}
+
+// MockPostRemover is a mock of PostRemover interface.
+type MockPostRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostRemoverMockRecorder
+}
+
+// MockPostRemoverMockRecorder is the mock recorder for MockPostRemover.
+type MockPostRemoverMockRecorder struct {
+	mock *MockPostRemover
+}
+
+// NewMockPostRemover creates a new mock instance.
+func NewMockPostRemover(ctrl *gomock.Controller) *MockPostRemover {
+	mock := &MockPostRemover{ctrl: ctrl}
+	mock.recorder = &MockPostRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostRemover) EXPECT() *MockPostRemoverMockRecorder {
+	return m.recorder
+}
+
+// DeletePost mocks base method.
+func (m *MockPostRemover) DeletePost(ctx context.Context, user models.User, postId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePost", ctx, user, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePost indicates an expected call of DeletePost.
+func (mr *MockPostRemoverMockRecorder) DeletePost(ctx, user, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockPostRemover)(nil).DeletePost), ctx, user, postId)
+}
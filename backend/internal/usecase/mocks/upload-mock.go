@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/upload-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	models "quickflow/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUploadStorage is a mock of UploadStorage interface.
+type MockUploadStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadStorageMockRecorder
+}
+
+// MockUploadStorageMockRecorder is the mock recorder for MockUploadStorage.
+type MockUploadStorageMockRecorder struct {
+	mock *MockUploadStorage
+}
+
+// NewMockUploadStorage creates a new mock instance.
+func NewMockUploadStorage(ctrl *gomock.Controller) *MockUploadStorage {
+	mock := &MockUploadStorage{ctrl: ctrl}
+	mock.recorder = &MockUploadStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadStorage) EXPECT() *MockUploadStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFile mocks base method.
+func (m *MockUploadStorage) DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, purpose, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockUploadStorageMockRecorder) DeleteFile(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockUploadStorage)(nil).DeleteFile), ctx, purpose, fileName)
+}
+
+// GetFileURL mocks base method.
+func (m *MockUploadStorage) GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileURL", ctx, purpose, fileName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileURL indicates an expected call of GetFileURL.
+func (mr *MockUploadStorageMockRecorder) GetFileURL(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*MockUploadStorage)(nil).GetFileURL), ctx, purpose, fileName)
+}
+
+// OpenFile mocks base method.
+func (m *MockUploadStorage) OpenFile(ctx context.Context, purpose models.FilePurpose, fileName string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", ctx, purpose, fileName)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockUploadStorageMockRecorder) OpenFile(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockUploadStorage)(nil).OpenFile), ctx, purpose, fileName)
+}
+
+// PresignUpload mocks base method.
+func (m *MockUploadStorage) PresignUpload(ctx context.Context, purpose models.FilePurpose, fileName string, size int64, mimeType string, expires time.Duration) (string, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUpload", ctx, purpose, fileName, size, mimeType, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PresignUpload indicates an expected call of PresignUpload.
+func (mr *MockUploadStorageMockRecorder) PresignUpload(ctx, purpose, fileName, size, mimeType, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUpload", reflect.TypeOf((*MockUploadStorage)(nil).PresignUpload), ctx, purpose, fileName, size, mimeType, expires)
+}
+
+// StatFile mocks base method.
+func (m *MockUploadStorage) StatFile(ctx context.Context, purpose models.FilePurpose, fileName string) (models.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatFile", ctx, purpose, fileName)
+	ret0, _ := ret[0].(models.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatFile indicates an expected call of StatFile.
+func (mr *MockUploadStorageMockRecorder) StatFile(ctx, purpose, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatFile", reflect.TypeOf((*MockUploadStorage)(nil).StatFile), ctx, purpose, fileName)
+}
+
+// MockUploadSessionRepository is a mock of UploadSessionRepository interface.
+type MockUploadSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadSessionRepositoryMockRecorder
+}
+
+// MockUploadSessionRepositoryMockRecorder is the mock recorder for MockUploadSessionRepository.
+type MockUploadSessionRepositoryMockRecorder struct {
+	mock *MockUploadSessionRepository
+}
+
+// NewMockUploadSessionRepository creates a new mock instance.
+func NewMockUploadSessionRepository(ctrl *gomock.Controller) *MockUploadSessionRepository {
+	mock := &MockUploadSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadSessionRepository) EXPECT() *MockUploadSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SaveUploadSession mocks base method.
+func (m *MockUploadSessionRepository) SaveUploadSession(ctx context.Context, session models.UploadSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUploadSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUploadSession indicates an expected call of SaveUploadSession.
+func (mr *MockUploadSessionRepositoryMockRecorder) SaveUploadSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUploadSession", reflect.TypeOf((*MockUploadSessionRepository)(nil).SaveUploadSession), ctx, session)
+}
+
+// TakeUploadSession mocks base method.
+func (m *MockUploadSessionRepository) TakeUploadSession(ctx context.Context, key string) (models.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeUploadSession", ctx, key)
+	ret0, _ := ret[0].(models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeUploadSession indicates an expected call of TakeUploadSession.
+func (mr *MockUploadSessionRepositoryMockRecorder) TakeUploadSession(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeUploadSession", reflect.TypeOf((*MockUploadSessionRepository)(nil).TakeUploadSession), ctx, key)
+}
+
+// MockUploadCommitter is a mock of UploadCommitter interface.
+type MockUploadCommitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadCommitterMockRecorder
+}
+
+// MockUploadCommitterMockRecorder is the mock recorder for MockUploadCommitter.
+type MockUploadCommitterMockRecorder struct {
+	mock *MockUploadCommitter
+}
+
+// NewMockUploadCommitter creates a new mock instance.
+func NewMockUploadCommitter(ctrl *gomock.Controller) *MockUploadCommitter {
+	mock := &MockUploadCommitter{ctrl: ctrl}
+	mock.recorder = &MockUploadCommitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadCommitter) EXPECT() *MockUploadCommitterMockRecorder {
+	return m.recorder
+}
+
+// CommitUploads mocks base method.
+func (m *MockUploadCommitter) CommitUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitUploads", ctx, userId, purpose, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitUploads indicates an expected call of CommitUploads.
+func (mr *MockUploadCommitterMockRecorder) CommitUploads(ctx, userId, purpose, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitUploads", reflect.TypeOf((*MockUploadCommitter)(nil).CommitUploads), ctx, userId, purpose, keys)
+}
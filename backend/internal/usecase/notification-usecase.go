@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+	"quickflow/pkg/mention"
+)
+
+var ErrInvalidNumNotifications = errors.New("invalid number of notifications")
+
+const maxNotificationsPerPage = 100
+
+type NotificationRepository interface {
+	// NotifyPostMentions notifies users mentioned in given published posts who were not notified about them yet.
+	// It returns the number of notifications created.
+	NotifyPostMentions(ctx context.Context, postIds []uuid.UUID, now time.Time) (int, error)
+	NotifyMessageMentions(ctx context.Context, messageId uuid.UUID, now time.Time) (int, error)
+	GetNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error)
+	// MarkNotificationsRead marks notifications of the user created up to the given time as read.
+	MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo time.Time, readAt time.Time) error
+}
+
+// PostMentioner resolves mentions in post texts and notifies mentioned users.
+type PostMentioner interface {
+	ResolvePostMentions(ctx context.Context, text string, authorId uuid.UUID, visibility models.PostVisibility) ([]models.Mention, error)
+	NotifyPostMentions(ctx context.Context, postIds ...uuid.UUID) error
+}
+
+// MessageMentioner resolves mentions in chat messages and notifies mentioned users.
+type MessageMentioner interface {
+	ResolveMessageMentions(ctx context.Context, text string, chatId uuid.UUID) ([]models.Mention, error)
+	NotifyMessageMentions(ctx context.Context, messageId uuid.UUID) error
+}
+
+type NotificationService struct {
+	notificationRepo NotificationRepository
+	userRepo         UserRepository
+	friendsRepo      FriendsRepository
+	chatRepo         ChatRepository
+}
+
+// NewNotificationService creates new service that resolves mentions and keeps notifications of users.
+func NewNotificationService(notificationRepo NotificationRepository, userRepo UserRepository, friendsRepo FriendsRepository, chatRepo ChatRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		friendsRepo:      friendsRepo,
+		chatRepo:         chatRepo,
+	}
+}
+
+// ResolvePostMentions returns mentions of the post text that refer to existing users
+// allowed to see a post of the author with given visibility. Other mentions are ignored,
+// so nobody learns about posts they can not open.
+func (n *NotificationService) ResolvePostMentions(ctx context.Context, text string, authorId uuid.UUID, visibility models.PostVisibility) ([]models.Mention, error) {
+	return n.resolveMentions(ctx, text, func(user models.User) (bool, error) {
+		relation, err := resolveRelation(ctx, n.friendsRepo, user.Id, authorId)
+		if err != nil {
+			return false, fmt.Errorf("resolveRelation: %w", err)
+		}
+		return visibility.AllowsRelation(relation), nil
+	})
+}
+
+// ResolveMessageMentions returns mentions of the message text that refer to participants of the chat.
+func (n *NotificationService) ResolveMessageMentions(ctx context.Context, text string, chatId uuid.UUID) ([]models.Mention, error) {
+	return n.resolveMentions(ctx, text, func(user models.User) (bool, error) {
+		isParticipant, err := n.chatRepo.IsParticipant(ctx, chatId, user.Id)
+		if err != nil {
+			return false, fmt.Errorf("n.chatRepo.IsParticipant: %w", err)
+		}
+		return isParticipant, nil
+	})
+}
+
+// resolveMentions parses mentions of the text and keeps those of existing users accepted by allowed.
+// Every username is looked up once, however many times it is mentioned.
+func (n *NotificationService) resolveMentions(ctx context.Context, text string, allowed func(user models.User) (bool, error)) ([]models.Mention, error) {
+	parsed := mention.Parse(text)
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+
+	users := make(map[string]models.User)
+	for _, username := range mention.Usernames(parsed) {
+		user, err := n.userRepo.GetUserByUsername(ctx, username)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("n.userRepo.GetUserByUsername: %w", err)
+		}
+
+		ok, err := allowed(user)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			users[username] = user
+		}
+	}
+
+	var mentions []models.Mention
+	for _, m := range parsed {
+		if user, ok := users[m.Username]; ok {
+			mentions = append(mentions, models.Mention{UserId: user.Id, Username: user.Username, Offset: m.Offset, Length: m.Length})
+		}
+	}
+	return mentions, nil
+}
+
+// NotifyPostMentions notifies users mentioned in the published posts.
+// Users already notified about a post are not notified again when it is edited.
+func (n *NotificationService) NotifyPostMentions(ctx context.Context, postIds ...uuid.UUID) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	created, err := n.notificationRepo.NotifyPostMentions(ctx, postIds, time.Now())
+	if err != nil {
+		return fmt.Errorf("n.notificationRepo.NotifyPostMentions: %w", err)
+	}
+	if created > 0 {
+		logger.Info(ctx, fmt.Sprintf("Notified %d users about mentions in posts %v", created, postIds))
+	}
+	return nil
+}
+
+// NotifyMessageMentions notifies users mentioned in the message.
+func (n *NotificationService) NotifyMessageMentions(ctx context.Context, messageId uuid.UUID) error {
+	created, err := n.notificationRepo.NotifyMessageMentions(ctx, messageId, time.Now())
+	if err != nil {
+		return fmt.Errorf("n.notificationRepo.NotifyMessageMentions: %w", err)
+	}
+	if created > 0 {
+		logger.Info(ctx, fmt.Sprintf("Notified %d users about mentions in message %v", created, messageId))
+	}
+	return nil
+}
+
+// FetchNotifications returns notifications of the user older than cursor, newest first.
+func (n *NotificationService) FetchNotifications(ctx context.Context, userId uuid.UUID, numNotifications int, cursor models.Cursor) ([]models.Notification, error) {
+	if numNotifications <= 0 || numNotifications > maxNotificationsPerPage {
+		return []models.Notification{}, ErrInvalidNumNotifications
+	}
+
+	notifications, err := n.notificationRepo.GetNotifications(ctx, userId, numNotifications, cursor)
+	if err != nil {
+		return []models.Notification{}, fmt.Errorf("n.notificationRepo.GetNotifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns the number of notifications the user has not read yet.
+func (n *NotificationService) CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error) {
+	count, err := n.notificationRepo.CountUnreadNotifications(ctx, userId)
+	if err != nil {
+		return 0, fmt.Errorf("n.notificationRepo.CountUnreadNotifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationsRead marks notifications of the user created up to the given time as read,
+// so notifications that arrived after the user had looked at the list stay unread.
+func (n *NotificationService) MarkNotificationsRead(ctx context.Context, userId uuid.UUID, upTo time.Time) error {
+	now := time.Now()
+	if upTo.IsZero() || upTo.After(now) {
+		upTo = now
+	}
+
+	if err := n.notificationRepo.MarkNotificationsRead(ctx, userId, upTo, now); err != nil {
+		return fmt.Errorf("n.notificationRepo.MarkNotificationsRead: %w", err)
+	}
+	return nil
+}
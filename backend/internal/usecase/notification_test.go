@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase/mocks"
+)
+
+func newNotificationServiceMocks(t *testing.T) (*NotificationService, *mocks.MockNotificationRepository, *mocks.MockUserRepository, *mocks.MockFriendsRepository, *mocks.MockChatRepository) {
+	ctrl := gomock.NewController(t)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	friendsRepo := mocks.NewMockFriendsRepository(ctrl)
+	chatRepo := mocks.NewMockChatRepository(ctrl)
+	return NewNotificationService(notificationRepo, userRepo, friendsRepo, chatRepo), notificationRepo, userRepo, friendsRepo, chatRepo
+}
+
+func TestResolvePostMentions(t *testing.T) {
+	service, _, userRepo, friendsRepo, _ := newNotificationServiceMocks(t)
+	ctx := context.Background()
+
+	authorId := uuid.New()
+	friend := models.User{Id: uuid.New(), Username: "friend"}
+	stranger := models.User{Id: uuid.New(), Username: "stranger"}
+
+	// every username is looked up once, however many times it is mentioned
+	userRepo.EXPECT().GetUserByUsername(gomock.Any(), "friend").Return(friend, nil)
+	userRepo.EXPECT().GetUserByUsername(gomock.Any(), "stranger").Return(stranger, nil)
+	userRepo.EXPECT().GetUserByUsername(gomock.Any(), "ghost").Return(models.User{}, ErrNotFound)
+	friendsRepo.EXPECT().GetUserRelation(gomock.Any(), friend.Id, authorId).Return(models.RelationFriend, nil)
+	friendsRepo.EXPECT().GetUserRelation(gomock.Any(), stranger.Id, authorId).Return(models.RelationStranger, nil)
+
+	mentions, err := service.ResolvePostMentions(ctx, "@friend @stranger @ghost and @friend again", authorId, models.VisibilityFriends)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Mention{
+		{UserId: friend.Id, Username: "friend", Offset: 0, Length: 7},
+		{UserId: friend.Id, Username: "friend", Offset: 29, Length: 7},
+	}, mentions)
+}
+
+func TestResolvePostMentions_NoMentions(t *testing.T) {
+	service, _, _, _, _ := newNotificationServiceMocks(t)
+
+	mentions, err := service.ResolvePostMentions(context.Background(), "mail me at me@example.com", uuid.New(), models.VisibilityPublic)
+	require.NoError(t, err)
+	assert.Empty(t, mentions)
+}
+
+func TestResolveMessageMentions_IgnoresNonParticipants(t *testing.T) {
+	service, _, userRepo, _, chatRepo := newNotificationServiceMocks(t)
+
+	chatId := uuid.New()
+	member := models.User{Id: uuid.New(), Username: "member"}
+	outsider := models.User{Id: uuid.New(), Username: "outsider"}
+	userRepo.EXPECT().GetUserByUsername(gomock.Any(), "member").Return(member, nil)
+	userRepo.EXPECT().GetUserByUsername(gomock.Any(), "outsider").Return(outsider, nil)
+	chatRepo.EXPECT().IsParticipant(gomock.Any(), chatId, member.Id).Return(true, nil)
+	chatRepo.EXPECT().IsParticipant(gomock.Any(), chatId, outsider.Id).Return(false, nil)
+
+	mentions, err := service.ResolveMessageMentions(context.Background(), "hi @member, @outsider", chatId)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Mention{{UserId: member.Id, Username: "member", Offset: 3, Length: 7}}, mentions)
+}
+
+func TestFetchNotifications_InvalidNum(t *testing.T) {
+	service, _, _, _, _ := newNotificationServiceMocks(t)
+
+	for _, num := range []int{0, -1, maxNotificationsPerPage + 1} {
+		_, err := service.FetchNotifications(context.Background(), uuid.New(), num, models.CursorFromTs(time.Now()))
+		assert.ErrorIs(t, err, ErrInvalidNumNotifications)
+	}
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	service, notificationRepo, _, _, _ := newNotificationServiceMocks(t)
+	ctx := context.Background()
+	userId := uuid.New()
+
+	seen := time.Now().Add(-time.Minute)
+	notificationRepo.EXPECT().MarkNotificationsRead(gomock.Any(), userId, seen, gomock.Any()).Return(nil)
+	require.NoError(t, service.MarkNotificationsRead(ctx, userId, seen))
+
+	// missing and future times are clamped to the current time
+	for _, upTo := range []time.Time{{}, time.Now().Add(time.Hour)} {
+		notificationRepo.EXPECT().MarkNotificationsRead(gomock.Any(), userId, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, upTo, readAt time.Time) error {
+				assert.Equal(t, readAt, upTo)
+				return nil
+			})
+		require.NoError(t, service.MarkNotificationsRead(ctx, userId, upTo))
+	}
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var (
+	ErrInvalidPoll      = errors.New("invalid poll")
+	ErrPollNotFound     = errors.New("poll not found")
+	ErrPollClosed       = errors.New("poll is closed")
+	ErrAlreadyVoted     = errors.New("user has already voted in the poll")
+	ErrInvalidPollVote  = errors.New("invalid poll vote")
+	ErrPollAnonymous    = errors.New("voters of anonymous poll are hidden")
+	ErrInvalidNumVoters = errors.New("invalid number of voters")
+)
+
+const (
+	minPollOptions        = 2
+	maxPollOptions        = 10
+	maxPollQuestionLength = 300
+	maxPollOptionLength   = 100
+	maxPollVotersPerPage  = 100
+)
+
+type PollRepository interface {
+	// Vote saves options chosen by the user. It returns ErrAlreadyVoted if the user has voted already
+	// and ErrPollClosed if the poll is closed at now.
+	Vote(ctx context.Context, postId uuid.UUID, userId uuid.UUID, options []int, now time.Time) error
+	RetractVote(ctx context.Context, postId uuid.UUID, userId uuid.UUID, now time.Time) error
+	GetViewerVotes(ctx context.Context, postIds []uuid.UUID, viewerId uuid.UUID) (map[uuid.UUID][]int, error)
+	GetPollVoters(ctx context.Context, postId uuid.UUID, option int, numVoters int, cursor models.Cursor) ([]models.PollVoter, error)
+}
+
+type PollService struct {
+	pollRepo    PollRepository
+	postRepo    PostRepository
+	friendsRepo FriendsRepository
+	profileRepo ProfileRepository
+}
+
+// NewPollService creates new service of votes in polls attached to posts.
+func NewPollService(pollRepo PollRepository, postRepo PostRepository, friendsRepo FriendsRepository, profileRepo ProfileRepository) *PollService {
+	return &PollService{
+		pollRepo:    pollRepo,
+		postRepo:    postRepo,
+		friendsRepo: friendsRepo,
+		profileRepo: profileRepo,
+	}
+}
+
+// validatePoll checks the poll of a new post, options are trimmed.
+// Deadline has to be after the post is published.
+func validatePoll(poll *models.Poll, publishAt time.Time, now time.Time) error {
+	poll.Question = strings.TrimSpace(poll.Question)
+	if len(poll.Question) == 0 || utf8.RuneCountInString(poll.Question) > maxPollQuestionLength {
+		return fmt.Errorf("%w: question must be between 1 and %d characters", ErrInvalidPoll, maxPollQuestionLength)
+	}
+	if len(poll.Options) < minPollOptions || len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("%w: poll must have between %d and %d options", ErrInvalidPoll, minPollOptions, maxPollOptions)
+	}
+	for i := range poll.Options {
+		poll.Options[i].Text = strings.TrimSpace(poll.Options[i].Text)
+		if len(poll.Options[i].Text) == 0 || utf8.RuneCountInString(poll.Options[i].Text) > maxPollOptionLength {
+			return fmt.Errorf("%w: option must be between 1 and %d characters", ErrInvalidPoll, maxPollOptionLength)
+		}
+	}
+
+	if publishAt.After(now) {
+		now = publishAt
+	}
+	if !poll.Deadline.IsZero() && !poll.Deadline.After(now) {
+		return fmt.Errorf("%w: deadline must be after the post is published", ErrInvalidPoll)
+	}
+	return nil
+}
+
+// validatePollVote checks that chosen options exist in the poll and are not repeated.
+func validatePollVote(poll *models.Poll, options []int) error {
+	if len(options) == 0 || (!poll.MultipleChoice && len(options) > 1) {
+		return fmt.Errorf("%w: wrong number of options", ErrInvalidPollVote)
+	}
+
+	chosen := make(map[int]struct{}, len(options))
+	for _, option := range options {
+		if option < 0 || option >= len(poll.Options) {
+			return fmt.Errorf("%w: unknown option %d", ErrInvalidPollVote, option)
+		}
+		if _, ok := chosen[option]; ok {
+			return fmt.Errorf("%w: option %d is repeated", ErrInvalidPollVote, option)
+		}
+		chosen[option] = struct{}{}
+	}
+	return nil
+}
+
+// attachViewerVotes fills options the viewer has chosen in polls of the posts.
+// Anonymous viewers have not voted anywhere, so nothing is requested for them.
+func attachViewerVotes(ctx context.Context, pollRepo PollRepository, posts []models.Post, viewerId uuid.UUID) error {
+	if viewerId == uuid.Nil {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	for _, post := range posts {
+		if post.Poll != nil {
+			ids = append(ids, post.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	votes, err := pollRepo.GetViewerVotes(ctx, ids, viewerId)
+	if err != nil {
+		return fmt.Errorf("pollRepo.GetViewerVotes: %w", err)
+	}
+	for i := range posts {
+		if posts[i].Poll != nil {
+			posts[i].Poll.ViewerVote = votes[posts[i].Id]
+		}
+	}
+	return nil
+}
+
+// Vote saves options of the poll chosen by the user and returns updated results.
+func (p *PollService) Vote(ctx context.Context, userId uuid.UUID, postId uuid.UUID, options []int) (models.Poll, error) {
+	post, err := p.openPoll(ctx, userId, postId)
+	if err != nil {
+		return models.Poll{}, err
+	}
+	if err = validatePollVote(post.Poll, options); err != nil {
+		return models.Poll{}, err
+	}
+
+	if err = p.pollRepo.Vote(ctx, postId, userId, options, time.Now()); err != nil {
+		return models.Poll{}, fmt.Errorf("p.pollRepo.Vote: %w", err)
+	}
+	return p.viewerPoll(ctx, userId, postId)
+}
+
+// RetractVote removes the vote of the user, so the user may vote again while the poll is open.
+func (p *PollService) RetractVote(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Poll, error) {
+	if _, err := p.openPoll(ctx, userId, postId); err != nil {
+		return models.Poll{}, err
+	}
+
+	if err := p.pollRepo.RetractVote(ctx, postId, userId, time.Now()); err != nil {
+		return models.Poll{}, fmt.Errorf("p.pollRepo.RetractVote: %w", err)
+	}
+	return p.viewerPoll(ctx, userId, postId)
+}
+
+// FetchPollVoters returns users who chose the option of the public poll, latest first.
+// Anonymous viewers are passed as uuid.Nil.
+func (p *PollService) FetchPollVoters(ctx context.Context, postId uuid.UUID, option int, viewerId uuid.UUID, numVoters int, cursor models.Cursor) ([]models.PollVoter, error) {
+	if numVoters <= 0 || numVoters > maxPollVotersPerPage {
+		return []models.PollVoter{}, ErrInvalidNumVoters
+	}
+
+	post, err := p.visiblePoll(ctx, viewerId, postId)
+	if err != nil {
+		return []models.PollVoter{}, err
+	}
+	if post.Poll.Anonymous {
+		return []models.PollVoter{}, ErrPollAnonymous
+	}
+	if option < 0 || option >= len(post.Poll.Options) {
+		return []models.PollVoter{}, fmt.Errorf("%w: unknown option %d", ErrInvalidPollVote, option)
+	}
+
+	voters, err := p.pollRepo.GetPollVoters(ctx, postId, option, numVoters, cursor)
+	if err != nil {
+		return []models.PollVoter{}, fmt.Errorf("p.pollRepo.GetPollVoters: %w", err)
+	}
+	return voters, nil
+}
+
+// visiblePoll returns the post with the poll if the viewer is allowed to see the post.
+func (p *PollService) visiblePoll(ctx context.Context, viewerId uuid.UUID, postId uuid.UUID) (models.Post, error) {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+
+	visible, err := canViewPost(ctx, p.friendsRepo, p.profileRepo, post, viewerId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("canViewPost: %w", err)
+	}
+	if !visible {
+		// do not reveal existence of the post
+		return models.Post{}, ErrPostNotFound
+	}
+	if post.Poll == nil {
+		return models.Post{}, ErrPollNotFound
+	}
+	return post, nil
+}
+
+// openPoll returns the post with the poll the user may vote in.
+// Polls of drafts and scheduled posts are not open yet.
+func (p *PollService) openPoll(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Post, error) {
+	post, err := p.visiblePoll(ctx, userId, postId)
+	if err != nil {
+		return models.Post{}, err
+	}
+	if !post.Status.IsPublished() || post.Poll.IsClosed(time.Now()) {
+		return models.Post{}, ErrPollClosed
+	}
+	return post, nil
+}
+
+// viewerPoll returns current results of the poll with options chosen by the viewer.
+func (p *PollService) viewerPoll(ctx context.Context, viewerId uuid.UUID, postId uuid.UUID) (models.Poll, error) {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return models.Poll{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+	if post.Poll == nil {
+		return models.Poll{}, ErrPollNotFound
+	}
+
+	posts := []models.Post{post}
+	if err = attachViewerVotes(ctx, p.pollRepo, posts, viewerId); err != nil {
+		return models.Poll{}, err
+	}
+	return *posts[0].Poll, nil
+}
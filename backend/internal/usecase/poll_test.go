@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase/mocks"
+)
+
+func newTestPoll() *models.Poll {
+	return &models.Poll{
+		Question: "Tea or coffee?",
+		Options:  []models.PollOption{{Text: "Tea"}, {Text: "Coffee"}},
+	}
+}
+
+func TestValidatePoll(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		modify    func(poll *models.Poll)
+		publishAt time.Time
+		wantErr   bool
+	}{
+		{name: "valid poll", modify: func(poll *models.Poll) {}},
+		{name: "blank question", modify: func(poll *models.Poll) { poll.Question = "  " }, wantErr: true},
+		{name: "one option", modify: func(poll *models.Poll) { poll.Options = poll.Options[:1] }, wantErr: true},
+		{name: "too many options", modify: func(poll *models.Poll) {
+			poll.Options = make([]models.PollOption, maxPollOptions+1)
+			for i := range poll.Options {
+				poll.Options[i].Text = "option"
+			}
+		}, wantErr: true},
+		{name: "blank option", modify: func(poll *models.Poll) { poll.Options[1].Text = " " }, wantErr: true},
+		{name: "long option", modify: func(poll *models.Poll) { poll.Options[1].Text = strings.Repeat("a", maxPollOptionLength+1) }, wantErr: true},
+		{name: "deadline in the past", modify: func(poll *models.Poll) { poll.Deadline = now.Add(-time.Minute) }, wantErr: true},
+		{
+			name:      "deadline before scheduled post is published",
+			modify:    func(poll *models.Poll) { poll.Deadline = now.Add(time.Hour) },
+			publishAt: now.Add(2 * time.Hour),
+			wantErr:   true,
+		},
+		{
+			name:      "deadline after scheduled post is published",
+			modify:    func(poll *models.Poll) { poll.Deadline = now.Add(3 * time.Hour) },
+			publishAt: now.Add(2 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := newTestPoll()
+			tt.modify(poll)
+			err := validatePoll(poll, tt.publishAt, now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPoll)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func newPollServiceMocks(t *testing.T) (*PollService, *mocks.MockPollRepository, *mocks.MockPostRepository, *mocks.MockFriendsRepository) {
+	ctrl := gomock.NewController(t)
+	pollRepo := mocks.NewMockPollRepository(ctrl)
+	postRepo := mocks.NewMockPostRepository(ctrl)
+	friendsRepo := mocks.NewMockFriendsRepository(ctrl)
+	// authors of polls keep posts open to everyone
+	profileRepo := mocks.NewMockProfileRepository(ctrl)
+	profileRepo.EXPECT().GetPrivacySettings(gomock.Any(), gomock.Any()).Return(models.DefaultPrivacySettings(), nil).AnyTimes()
+	return NewPollService(pollRepo, postRepo, friendsRepo, profileRepo), pollRepo, postRepo, friendsRepo
+}
+
+func newPollPost(poll *models.Poll) models.Post {
+	return models.Post{
+		Id:         uuid.New(),
+		CreatorId:  uuid.New(),
+		Visibility: models.VisibilityPublic,
+		Status:     models.PostStatusPublished,
+		Poll:       poll,
+	}
+}
+
+func TestPollService_Vote(t *testing.T) {
+	service, pollRepo, postRepo, friendsRepo := newPollServiceMocks(t)
+	ctx := context.Background()
+	userId := uuid.New()
+	post := newPollPost(newTestPoll())
+
+	postRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil).Times(2)
+	friendsRepo.EXPECT().GetUserRelation(gomock.Any(), userId, post.CreatorId).Return(models.RelationStranger, nil)
+	pollRepo.EXPECT().Vote(gomock.Any(), post.Id, userId, []int{1}, gomock.Any()).Return(nil)
+	pollRepo.EXPECT().GetViewerVotes(gomock.Any(), []uuid.UUID{post.Id}, userId).Return(map[uuid.UUID][]int{post.Id: {1}}, nil)
+
+	poll, err := service.Vote(ctx, userId, post.Id, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, poll.ViewerVote)
+}
+
+func TestPollService_VoteRejected(t *testing.T) {
+	userId := uuid.New()
+	closed := newTestPoll()
+	closed.Deadline = time.Now().Add(-time.Minute)
+	draft := newPollPost(newTestPoll())
+	draft.Status = models.PostStatusDraft
+	draft.CreatorId = userId
+	friendsOnly := newPollPost(newTestPoll())
+	friendsOnly.Visibility = models.VisibilityFriends
+
+	tests := []struct {
+		name     string
+		post     models.Post
+		relation models.UserRelation
+		options  []int
+		wantErr  error
+	}{
+		{name: "several options in single choice poll", post: newPollPost(newTestPoll()), options: []int{0, 1}, wantErr: ErrInvalidPollVote},
+		{name: "unknown option", post: newPollPost(newTestPoll()), options: []int{2}, wantErr: ErrInvalidPollVote},
+		{name: "no options", post: newPollPost(newTestPoll()), wantErr: ErrInvalidPollVote},
+		{name: "poll after deadline", post: newPollPost(closed), options: []int{0}, wantErr: ErrPollClosed},
+		{name: "poll of draft", post: draft, options: []int{0}, wantErr: ErrPollClosed},
+		{name: "post without poll", post: newPollPost(nil), options: []int{0}, wantErr: ErrPollNotFound},
+		{name: "post hidden from user", post: friendsOnly, options: []int{0}, wantErr: ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, postRepo, friendsRepo := newPollServiceMocks(t)
+			postRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			if tt.post.CreatorId != userId {
+				relation := tt.relation
+				if relation == "" {
+					relation = models.RelationStranger
+				}
+				friendsRepo.EXPECT().GetUserRelation(gomock.Any(), userId, tt.post.CreatorId).Return(relation, nil)
+			}
+
+			_, err := service.Vote(context.Background(), userId, tt.post.Id, tt.options)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPollService_FetchPollVoters(t *testing.T) {
+	viewerId := uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+
+	t.Run("anonymous poll", func(t *testing.T) {
+		service, _, postRepo, friendsRepo := newPollServiceMocks(t)
+		poll := newTestPoll()
+		poll.Anonymous = true
+		post := newPollPost(poll)
+		postRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+		friendsRepo.EXPECT().GetUserRelation(gomock.Any(), viewerId, post.CreatorId).Return(models.RelationStranger, nil)
+
+		_, err := service.FetchPollVoters(context.Background(), post.Id, 0, viewerId, 10, cursor)
+		assert.ErrorIs(t, err, ErrPollAnonymous)
+	})
+
+	t.Run("public poll", func(t *testing.T) {
+		service, pollRepo, postRepo, friendsRepo := newPollServiceMocks(t)
+		post := newPollPost(newTestPoll())
+		voters := []models.PollVoter{{UserId: uuid.New(), VotedAt: time.Now()}}
+		postRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+		friendsRepo.EXPECT().GetUserRelation(gomock.Any(), viewerId, post.CreatorId).Return(models.RelationStranger, nil)
+		pollRepo.EXPECT().GetPollVoters(gomock.Any(), post.Id, 1, 10, cursor).Return(voters, nil)
+
+		got, err := service.FetchPollVoters(context.Background(), post.Id, 1, viewerId, 10, cursor)
+		require.NoError(t, err)
+		assert.Equal(t, voters, got)
+	})
+
+	t.Run("invalid number of voters", func(t *testing.T) {
+		service, _, _, _ := newPollServiceMocks(t)
+		_, err := service.FetchPollVoters(context.Background(), uuid.New(), 0, viewerId, maxPollVotersPerPage+1, cursor)
+		assert.ErrorIs(t, err, ErrInvalidNumVoters)
+	})
+}
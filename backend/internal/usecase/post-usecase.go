@@ -8,9 +8,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/sync/errgroup"
 
 	"quickflow/internal/models"
+	"quickflow/pkg/hashtag"
+	"quickflow/pkg/logger"
 	"quickflow/utils/validation"
 )
 
@@ -18,99 +19,225 @@ var (
 	ErrPostDoesNotBelongToUser = errors.New("post does not belong to user")
 	ErrPostNotFound            = errors.New("post not found")
 	ErrUploadFile              = errors.New("upload file error")
+	ErrInvalidImage            = errors.New("invalid image")
+	ErrInvalidMedia            = errors.New("invalid media file")
+	ErrMediaTooLong            = errors.New("media file is too long")
+	ErrFileTooLarge            = errors.New("file is too large")
 	ErrInvalidNumPosts         = errors.New("invalid number of posts")
 	ErrInvalidTimestamp        = errors.New("invalid timestamp")
+	ErrInvalidVisibility       = errors.New("invalid post visibility")
+	ErrInvalidPublishTime      = errors.New("invalid publish time")
+	ErrPostAlreadyPublished    = errors.New("post is already published")
+	ErrPostNotPublished        = errors.New("post is not published")
+	ErrInvalidTag              = errors.New("invalid tag")
+	ErrInvalidTrendingWindow   = errors.New("invalid trending window")
+	ErrInvalidNumTags          = errors.New("invalid number of tags")
 )
 
+// TrendingWindows are windows trending tags may be computed over, by their names.
+var TrendingWindows = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+	"week": 7 * 24 * time.Hour,
+}
+
+const maxTrendingTags = 50
+
 type PostRepository interface {
 	AddPost(ctx context.Context, post models.Post) error
-	UpdatePostText(ctx context.Context, postId uuid.UUID, text string) error
-	UpdatePostFiles(ctx context.Context, postId uuid.UUID, fileURLs []string) error
-	DeletePost(ctx context.Context, postId uuid.UUID) error
+	// UpdatePost and DeletePost return URLs of files that are no longer referenced by anything.
+	UpdatePost(ctx context.Context, update models.PostUpdate, fileURLs []string) ([]string, error)
+	DeletePost(ctx context.Context, postId uuid.UUID) ([]string, error)
+	DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, []string, error)
 	BelongsTo(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (bool, error)
 	GetPost(ctx context.Context, postId uuid.UUID) (models.Post, error)
-	GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error)
-	GetUserPosts(ctx context.Context, id uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error)
-	GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, timestamp time.Time) ([]models.Post, error)
+	GetPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error)
+	// GetPostsForUId, GetFeedPostsByIds and GetRecommendationsForUId skip posts the user has hidden or muted.
+	GetFeedPostsByIds(ctx context.Context, ids []uuid.UUID, viewerId uuid.UUID) ([]models.Post, error)
+	GetPostsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error)
+	GetUserPosts(ctx context.Context, id uuid.UUID, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error)
+	GetRecommendationsForUId(ctx context.Context, uid uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error)
 	GetPostFiles(ctx context.Context, postId uuid.UUID) ([]string, error)
+	GetUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error)
+	// SchedulePost and PublishPost return ErrPostNotFound if the post is not found among unpublished ones.
+	SchedulePost(ctx context.Context, postId uuid.UUID, publishAt time.Time) error
+	PublishPost(ctx context.Context, postId uuid.UUID, publishedAt time.Time) error
+	PublishDuePosts(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	GetPostRevisions(ctx context.Context, postId uuid.UUID) ([]models.PostRevision, error)
+	GetTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error)
+	GetTrendingTags(ctx context.Context, now time.Time, window time.Duration, limit int) ([]models.TrendingTag, error)
+	// GetPinnedPost and PinPost return ErrPostNotFound if there is no such post.
+	GetPinnedPost(ctx context.Context, userId uuid.UUID, viewerId uuid.UUID) (models.Post, error)
+	PinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	UnpinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
 }
 
 type FileRepository interface {
 	UploadFile(ctx context.Context, file *models.File) (string, error)
 	UploadManyFiles(ctx context.Context, files []*models.File) ([]string, error)
-	GetFileURL(ctx context.Context, filename string) (string, error)
-	DeleteFile(ctx context.Context, filename string) error
+	GetFileURL(ctx context.Context, purpose models.FilePurpose, filename string) (string, error)
+	DeleteFile(ctx context.Context, purpose models.FilePurpose, filename string) error
+}
+
+// FileIndex keeps metadata of files stored by content hash.
+// Reference counts of files are maintained by the database itself.
+type FileIndex interface {
+	// FindFile returns URL of the file with given content hash. The file is leased for a while,
+	// so it is not forgotten before the caller references it.
+	FindFile(ctx context.Context, contentHash string, purpose models.FilePurpose) (string, error)
+	SaveFile(ctx context.Context, url string, file *models.File) error
+	// ForgetFile removes metadata of the file unless it is still referenced or leased.
+	// It reports whether the file can be deleted from storage.
+	ForgetFile(ctx context.Context, purpose models.FilePurpose, fileName string) (bool, error)
+}
+
+type Recommender interface {
+	GetRankedPosts(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error)
+	MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID) error
+	GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error)
 }
 
 type PostService struct {
-	postRepo PostRepository
-	fileRepo FileRepository
+	postRepo       PostRepository
+	fileRepo       FileRepository
+	profileRepo    ProfileRepository
+	friendsRepo    FriendsRepository
+	recommender    Recommender
+	uploads        UploadCommitter
+	mentions       PostMentioner
+	pollRepo       PollRepository
+	bookmarkRepo   BookmarkRepository
+	views          ViewCounter
+	feedFilterRepo FeedFilterRepository
 }
 
 // NewPostService creates new post service.
-func NewPostService(postRepo PostRepository, fileRepo FileRepository) *PostService {
+func NewPostService(postRepo PostRepository, fileRepo FileRepository, profileRepo ProfileRepository, friendsRepo FriendsRepository, recommender Recommender, uploads UploadCommitter, mentions PostMentioner, pollRepo PollRepository, bookmarkRepo BookmarkRepository, views ViewCounter, feedFilterRepo FeedFilterRepository) *PostService {
 	return &PostService{
-		postRepo: postRepo,
-		fileRepo: fileRepo,
+		postRepo:       postRepo,
+		fileRepo:       fileRepo,
+		profileRepo:    profileRepo,
+		friendsRepo:    friendsRepo,
+		recommender:    recommender,
+		uploads:        uploads,
+		mentions:       mentions,
+		pollRepo:       pollRepo,
+		bookmarkRepo:   bookmarkRepo,
+		views:          views,
+		feedFilterRepo: feedFilterRepo,
 	}
 }
 
 // AddPost adds post to the repository.
 func (p *PostService) AddPost(ctx context.Context, post models.Post) (models.Post, error) {
 	post.Id = uuid.New()
+	if post.Visibility == "" {
+		post.Visibility = models.VisibilityPublic
+	}
+	if !post.Visibility.IsValid() {
+		return models.Post{}, ErrInvalidVisibility
+	}
+	if post.Status == "" {
+		post.Status = models.PostStatusPublished
+	}
+	now := time.Now()
+	if err := validatePublishTime(post.Status, post.PublishAt, now); err != nil {
+		return models.Post{}, err
+	}
+	if post.Poll != nil {
+		if err := validatePoll(post.Poll, post.PublishAt, now); err != nil {
+			return models.Post{}, err
+		}
+	}
+	post.Tags = hashtag.Parse(post.Desc)
 
 	var err error
+	post.Mentions, err = p.mentions.ResolvePostMentions(ctx, post.Desc, post.CreatorId, post.Visibility)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.mentions.ResolvePostMentions: %w", err)
+	}
+
 	// Upload files to storage
+	for _, image := range post.Images {
+		image.Purpose = models.FilePurposePost
+	}
 	post.ImagesURL, err = p.fileRepo.UploadManyFiles(ctx, post.Images)
 	if err != nil {
 		return models.Post{}, fmt.Errorf("p.fileRepo.UploadManyFiles: %w", err)
 	}
 
+	// files uploaded directly to storage go after attached ones
+	if len(post.UploadKeys) > 0 {
+		committed, err := p.uploads.CommitUploads(ctx, post.CreatorId, models.FilePurposePost, post.UploadKeys)
+		if err != nil {
+			p.removeFiles(ctx, post.ImagesURL)
+			return models.Post{}, fmt.Errorf("p.uploads.CommitUploads: %w", err)
+		}
+		post.ImagesURL = append(post.ImagesURL, committed...)
+	}
+
 	// Update post images with urls
 	err = p.postRepo.AddPost(ctx, post)
 	if err != nil {
+		// post was not saved, so nobody references uploaded files
+		p.removeFiles(ctx, post.ImagesURL)
 		return models.Post{}, fmt.Errorf("p.postRepo.AddPost: %w", err)
 	}
 
+	// drafts and scheduled posts notify mentioned users when they are published
+	if post.Status.IsPublished() {
+		p.notifyMentions(ctx, post.Id)
+	}
+
 	return post, nil
 }
 
+// notifyMentions notifies users mentioned in the published posts.
+// Failures are only logged: the posts are already saved and must not fail the request.
+func (p *PostService) notifyMentions(ctx context.Context, postIds ...uuid.UUID) {
+	if err := p.mentions.NotifyPostMentions(ctx, postIds...); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to notify users mentioned in posts %v: %s", postIds, err.Error()))
+	}
+}
+
 // DeletePost removes post from the repository.
 func (p *PostService) DeletePost(ctx context.Context, user models.User, postId uuid.UUID) error {
 	belongsTo, err := p.postRepo.BelongsTo(ctx, user.Id, postId)
 	if err != nil {
 		return ErrPostNotFound
 	}
-	if !belongsTo && user.Username != "Nikita" && user.Username != "rvasutenko" {
+	if !belongsTo && Authorize(user, models.PermissionModerateContent) != nil {
 		return ErrPostDoesNotBelongToUser
 	}
 
-	// retrieve post files
-	postFiles, err := p.postRepo.GetPostFiles(ctx, postId)
+	unreferenced, err := p.postRepo.DeletePost(ctx, postId)
 	if err != nil {
-		return fmt.Errorf("p.postRepo.GetPostFiles: %w", err)
+		return fmt.Errorf("p.postRepo.DeletePost: %w", err)
 	}
 
-	err = p.postRepo.DeletePost(ctx, postId)
+	// files shared with other posts, messages or profiles are kept
+	p.removeFiles(ctx, unreferenced)
+
+	return nil
+}
+
+// DeleteUserContent removes every post, comment and message of the user along with files of the posts.
+// It does not check permissions, callers must do it.
+func (p *PostService) DeleteUserContent(ctx context.Context, userId uuid.UUID) (models.DeletedContent, error) {
+	deleted, unreferenced, err := p.postRepo.DeleteUserContent(ctx, userId)
 	if err != nil {
-		return fmt.Errorf("p.postRepo.DeletePost: %w", err)
+		return models.DeletedContent{}, fmt.Errorf("p.postRepo.DeleteUserContent: %w", err)
 	}
 
-	// delete post files
-	for _, pic := range postFiles {
-		err = p.fileRepo.DeleteFile(ctx, path.Base(pic))
-		if err != nil {
-			return fmt.Errorf("p.fileRepo.DeleteFile: %w", err)
-		}
-	}
+	p.removeFiles(ctx, unreferenced)
 
-	return nil
+	return deleted, nil
 }
 
 // FetchFeed returns feed for user.
-func (p *PostService) FetchFeed(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+func (p *PostService) FetchFeed(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, error) {
 	// validate params
-	err := validation.ValidateFeedParams(numPosts, timestamp)
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
 	if errors.Is(err, validation.ErrInvalidNumPosts) {
 		return []models.Post{}, ErrInvalidNumPosts
 	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
@@ -120,38 +247,116 @@ func (p *PostService) FetchFeed(ctx context.Context, user models.User, numPosts
 	}
 
 	// fetch posts
-	posts, err := p.postRepo.GetPostsForUId(ctx, user.Id, numPosts, timestamp)
+	posts, err := p.postRepo.GetPostsForUId(ctx, user.Id, numPosts, cursor)
 	if err != nil {
 		return []models.Post{}, fmt.Errorf("p.repo.GetPostsForUId: %w", err)
 	}
+	if err = p.attachViewerState(ctx, posts, user.Id); err != nil {
+		return []models.Post{}, err
+	}
 
 	return posts, nil
 }
 
 // FetchRecommendations returns recommendations for user.
-func (p *PostService) FetchRecommendations(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+// Posts are ordered by score computed by the recommender, already shown posts
+// are not returned again. When ranked posts run out, the rest of the page is
+// filled with posts older than cursor in chronological order. Returned cursor
+// points after the last chronological post and stays the same for ranked-only pages.
+func (p *PostService) FetchRecommendations(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
 	// validate params
-	err := validation.ValidateFeedParams(numPosts, timestamp)
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
 	if errors.Is(err, validation.ErrInvalidNumPosts) {
-		return []models.Post{}, ErrInvalidNumPosts
+		return []models.Post{}, cursor, ErrInvalidNumPosts
 	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
-		return []models.Post{}, ErrInvalidTimestamp
+		return []models.Post{}, cursor, ErrInvalidTimestamp
 	} else if err != nil {
-		return []models.Post{}, fmt.Errorf("validation.ValidateFeedParams: %w", err)
+		return []models.Post{}, cursor, fmt.Errorf("validation.ValidateFeedParams: %w", err)
 	}
 
-	// fetch posts
-	posts, err := p.postRepo.GetRecommendationsForUId(ctx, user.Id, numPosts, timestamp)
+	ranked, err := p.recommender.GetRankedPosts(ctx, user.Id)
 	if err != nil {
-		return []models.Post{}, fmt.Errorf("p.repo.GetRecommendationsForUId: %w", err)
+		return []models.Post{}, cursor, fmt.Errorf("p.recommender.GetRankedPosts: %w", err)
+	}
+	if len(ranked) > numPosts {
+		ranked = ranked[:numPosts]
 	}
 
-	return posts, nil
+	posts := make([]models.Post, 0, numPosts)
+	if len(ranked) > 0 {
+		ids := make([]uuid.UUID, 0, len(ranked))
+		for _, scored := range ranked {
+			ids = append(ids, scored.PostId)
+		}
+
+		found, err := p.postRepo.GetFeedPostsByIds(ctx, ids, user.Id)
+		if err != nil {
+			return []models.Post{}, cursor, fmt.Errorf("p.postRepo.GetFeedPostsByIds: %w", err)
+		}
+
+		// keep the order of the ranking, posts deleted, hidden or muted since scoring are skipped
+		byId := make(map[uuid.UUID]models.Post, len(found))
+		for _, post := range found {
+			byId[post.Id] = post
+		}
+		for _, id := range ids {
+			if post, ok := byId[id]; ok {
+				posts = append(posts, post)
+			}
+		}
+	}
+
+	if len(posts) < numPosts {
+		older, err := p.postRepo.GetRecommendationsForUId(ctx, user.Id, numPosts, cursor)
+		if err != nil {
+			return []models.Post{}, cursor, fmt.Errorf("p.repo.GetRecommendationsForUId: %w", err)
+		}
+		// fallback posts shown before are skipped just like ranked ones
+		seen, err := p.recommender.GetSeen(ctx, user.Id)
+		if err != nil {
+			return []models.Post{}, cursor, fmt.Errorf("p.recommender.GetSeen: %w", err)
+		}
+
+		included := make(map[uuid.UUID]struct{}, len(posts)+len(seen))
+		for _, post := range posts {
+			included[post.Id] = struct{}{}
+		}
+		for _, id := range seen {
+			included[id] = struct{}{}
+		}
+		for _, post := range older {
+			if len(posts) == numPosts {
+				break
+			}
+			if _, ok := included[post.Id]; !ok {
+				posts = append(posts, post)
+			}
+			cursor = models.Cursor{Ts: post.CreatedAt, Id: post.Id}
+		}
+	}
+
+	seen := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		seen = append(seen, post.Id)
+	}
+	if err = p.recommender.MarkSeen(ctx, user.Id, seen); err != nil {
+		return []models.Post{}, cursor, fmt.Errorf("p.recommender.MarkSeen: %w", err)
+	}
+	if err = p.attachViewerState(ctx, posts, user.Id); err != nil {
+		return []models.Post{}, cursor, err
+	}
+
+	return posts, cursor, nil
 }
 
-func (p *PostService) FetchUserPosts(ctx context.Context, user models.User, numPosts int, timestamp time.Time) ([]models.Post, error) {
+// FetchUserPosts returns posts of the user if viewer is allowed to see them.
+// Anonymous viewers are passed as uuid.Nil. The pinned post is never returned among
+// other posts: it goes first on the page requested withPinned, regardless of its time.
+// Pages requested by timestamp only get it as well if they start from the newest post.
+// Posts carry the relation of the viewer to the user, so it is not resolved again.
+func (p *PostService) FetchUserPosts(ctx context.Context, user models.User, viewerId uuid.UUID, numPosts int, cursor models.Cursor, withPinned bool) ([]models.Post, error) {
 	// validate params
-	err := validation.ValidateFeedParams(numPosts, timestamp)
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
 	if errors.Is(err, validation.ErrInvalidNumPosts) {
 		return []models.Post{}, ErrInvalidNumPosts
 	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
@@ -160,82 +365,517 @@ func (p *PostService) FetchUserPosts(ctx context.Context, user models.User, numP
 		return []models.Post{}, fmt.Errorf("validation.ValidateFeedParams: %w", err)
 	}
 
+	// check privacy settings
+	relation, err := resolveRelation(ctx, p.friendsRepo, viewerId, user.Id)
+	if err != nil {
+		return []models.Post{}, fmt.Errorf("resolveRelation: %w", err)
+	}
+	if relation != models.RelationSelf {
+		settings, err := p.profileRepo.GetPrivacySettings(ctx, user.Id)
+		if err != nil {
+			return []models.Post{}, fmt.Errorf("p.profileRepo.GetPrivacySettings: %w", err)
+		}
+		if !settings.Posts.AllowsRelation(relation) {
+			return []models.Post{}, ErrAccessDenied
+		}
+	}
+
 	// fetch posts
-	posts, err := p.postRepo.GetUserPosts(ctx, user.Id, numPosts, timestamp)
+	posts, err := p.postRepo.GetUserPosts(ctx, user.Id, viewerId, numPosts, cursor)
 	if err != nil {
 		return []models.Post{}, fmt.Errorf("p.repo.GetPostsForUId: %w", err)
 	}
+	// legacy clients request the first page by the current time rather than without position
+	if !withPinned && cursor.Id == uuid.Nil {
+		if withPinned, err = p.startsFromNewest(ctx, user.Id, viewerId, posts); err != nil {
+			return []models.Post{}, err
+		}
+	}
+	if withPinned {
+		pinned, err := p.postRepo.GetPinnedPost(ctx, user.Id, viewerId)
+		if err != nil && !errors.Is(err, ErrPostNotFound) {
+			return []models.Post{}, fmt.Errorf("p.postRepo.GetPinnedPost: %w", err)
+		}
+		if err == nil {
+			pinned.IsPinned = true
+			posts = append([]models.Post{pinned}, posts...)
+		}
+	}
+	if err = p.attachViewerState(ctx, posts, viewerId); err != nil {
+		return []models.Post{}, err
+	}
+	if viewerId != uuid.Nil {
+		for i := range posts {
+			posts[i].CreatorRelation = relation
+		}
+	}
 
 	return posts, nil
 }
 
-func (p *PostService) UpdatePost(ctx context.Context, postUpdate models.PostUpdate, userId uuid.UUID) (models.Post, error) {
-	// check if user owns the post
-	belongsTo, err := p.postRepo.BelongsTo(ctx, userId, postUpdate.Id)
+// FetchPost returns single post if viewer is allowed to see it.
+// Anonymous viewers are passed as uuid.Nil.
+func (p *PostService) FetchPost(ctx context.Context, postId uuid.UUID, viewerId uuid.UUID) (models.Post, error) {
+	post, err := p.postRepo.GetPost(ctx, postId)
 	if err != nil {
-		return models.Post{}, fmt.Errorf("p.postRepo.BelongsTo: %w", err)
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
 	}
 
-	if !belongsTo {
-		return models.Post{}, ErrPostDoesNotBelongToUser
+	visible, err := canViewPost(ctx, p.friendsRepo, p.profileRepo, post, viewerId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("canViewPost: %w", err)
+	}
+	if !visible {
+		// do not reveal existence of the post
+		return models.Post{}, ErrPostNotFound
 	}
 
-	// retrieve old post photos
-	oldPics, err := p.postRepo.GetPostFiles(ctx, postUpdate.Id)
+	posts := []models.Post{post}
+	if err = p.attachViewerState(ctx, posts, viewerId); err != nil {
+		return models.Post{}, err
+	}
+	return posts[0], nil
+}
+
+// startsFromNewest reports whether the page of posts of the user starts from their newest post visible to the viewer.
+func (p *PostService) startsFromNewest(ctx context.Context, userId uuid.UUID, viewerId uuid.UUID, page []models.Post) (bool, error) {
+	newest, err := p.postRepo.GetUserPosts(ctx, userId, viewerId, 1, models.CursorFromTs(time.Now()))
 	if err != nil {
-		return models.Post{}, fmt.Errorf("p.postRepo.GetPostFiles: %w", err)
+		return false, fmt.Errorf("p.postRepo.GetUserPosts: %w", err)
 	}
+	if len(newest) == 0 {
+		return true, nil
+	}
+	return len(page) > 0 && page[0].Id == newest[0].Id, nil
+}
 
-	// Upload files to storage
+// attachViewerState fills state of the posts that depends on the viewer: votes in polls and bookmarks.
+// The posts are returned to the viewer, so their views are counted as well.
+func (p *PostService) attachViewerState(ctx context.Context, posts []models.Post, viewerId uuid.UUID) error {
+	if err := attachViewerVotes(ctx, p.pollRepo, posts, viewerId); err != nil {
+		return err
+	}
+	if err := attachBookmarks(ctx, p.bookmarkRepo, posts, viewerId); err != nil {
+		return err
+	}
 
-	var g errgroup.Group
-	fileURLChan := make(chan []string, 1)
+	// views are statistics only and must not fail the request
+	if err := recordViews(ctx, p.views, posts, viewerId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to record views of posts by user %s: %s", viewerId, err.Error()))
+	}
+	return nil
+}
 
-	g.Go(func() error {
-		var urls []string
-		var err error
-		if len(postUpdate.Files) > 0 {
-			urls, err = p.fileRepo.UploadManyFiles(ctx, postUpdate.Files)
-			if err != nil {
-				return fmt.Errorf("p.fileRepo.UploadManyFiles: %w", err)
-			}
+// FetchBookmarks returns posts bookmarked by the user, latest bookmarks first.
+// Posts of all collections are returned when collectionId is uuid.Nil. Posts hidden from
+// the user since they were bookmarked are skipped, so the page may be shorter than numPosts.
+// Returned cursor points after the last bookmark and is zero when bookmarks are over.
+func (p *PostService) FetchBookmarks(ctx context.Context, user models.User, collectionId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
+	if errors.Is(err, validation.ErrInvalidNumPosts) {
+		return []models.Post{}, models.Cursor{}, ErrInvalidNumPosts
+	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
+		return []models.Post{}, models.Cursor{}, ErrInvalidTimestamp
+	} else if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("validation.ValidateFeedParams: %w", err)
+	}
+
+	bookmarks, err := p.bookmarkRepo.GetBookmarks(ctx, user.Id, collectionId, numPosts, cursor)
+	if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("p.bookmarkRepo.GetBookmarks: %w", err)
+	}
+	if len(bookmarks) == 0 {
+		return []models.Post{}, models.Cursor{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		ids = append(ids, bookmark.PostId)
+	}
+	found, err := p.postRepo.GetPostsByIds(ctx, ids, user.Id)
+	if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("p.postRepo.GetPostsByIds: %w", err)
+	}
+
+	// keep the order of bookmarks
+	byId := make(map[uuid.UUID]models.Post, len(found))
+	for _, post := range found {
+		byId[post.Id] = post
+	}
+	posts := make([]models.Post, 0, len(found))
+	for _, id := range ids {
+		if post, ok := byId[id]; ok {
+			post.IsBookmarked = true
+			posts = append(posts, post)
 		}
-		fileURLChan <- urls
-		return nil
-	})
+	}
+	if err = attachViewerVotes(ctx, p.pollRepo, posts, user.Id); err != nil {
+		return []models.Post{}, models.Cursor{}, err
+	}
+
+	var next models.Cursor
+	if len(bookmarks) == numPosts {
+		last := bookmarks[len(bookmarks)-1]
+		next = models.Cursor{Ts: last.CreatedAt, Id: last.PostId}
+	}
+	return posts, next, nil
+}
 
-	g.Go(func() error {
-		if err := p.postRepo.UpdatePostText(ctx, postUpdate.Id, postUpdate.Desc); err != nil {
-			return fmt.Errorf("p.postRepo.UpdatePostText: %w", err)
+// FetchHiddenPosts returns posts hidden by the user, latest hidden first. Posts the user
+// can no longer see are skipped, so the page may be shorter than numPosts.
+// Returned cursor points after the last hidden post and is zero when hidden posts are over.
+func (p *PostService) FetchHiddenPosts(ctx context.Context, user models.User, numPosts int, cursor models.Cursor) ([]models.Post, models.Cursor, error) {
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
+	if errors.Is(err, validation.ErrInvalidNumPosts) {
+		return []models.Post{}, models.Cursor{}, ErrInvalidNumPosts
+	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
+		return []models.Post{}, models.Cursor{}, ErrInvalidTimestamp
+	} else if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("validation.ValidateFeedParams: %w", err)
+	}
+
+	hidden, err := p.feedFilterRepo.GetHiddenPosts(ctx, user.Id, numPosts, cursor)
+	if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("p.feedFilterRepo.GetHiddenPosts: %w", err)
+	}
+	if len(hidden) == 0 {
+		return []models.Post{}, models.Cursor{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(hidden))
+	for _, post := range hidden {
+		ids = append(ids, post.PostId)
+	}
+	found, err := p.postRepo.GetPostsByIds(ctx, ids, user.Id)
+	if err != nil {
+		return []models.Post{}, models.Cursor{}, fmt.Errorf("p.postRepo.GetPostsByIds: %w", err)
+	}
+
+	// keep the order of hiding
+	byId := make(map[uuid.UUID]models.Post, len(found))
+	for _, post := range found {
+		byId[post.Id] = post
+	}
+	posts := make([]models.Post, 0, len(found))
+	for _, id := range ids {
+		if post, ok := byId[id]; ok {
+			posts = append(posts, post)
 		}
-		return nil
-	})
+	}
+	// hidden posts are not counted as viewed
+	if err = attachViewerVotes(ctx, p.pollRepo, posts, user.Id); err != nil {
+		return []models.Post{}, models.Cursor{}, err
+	}
+	if err = attachBookmarks(ctx, p.bookmarkRepo, posts, user.Id); err != nil {
+		return []models.Post{}, models.Cursor{}, err
+	}
+
+	var next models.Cursor
+	if len(hidden) == numPosts {
+		last := hidden[len(hidden)-1]
+		next = models.Cursor{Ts: last.CreatedAt, Id: last.PostId}
+	}
+	return posts, next, nil
+}
+
+// PinPost pins the published post of the user to the top of the profile instead of the post pinned before.
+func (p *PostService) PinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+
+	switch {
+	case post.CreatorId != userId && !post.Status.IsPublished():
+		return ErrPostNotFound
+	case post.CreatorId != userId:
+		return ErrPostDoesNotBelongToUser
+	case !post.Status.IsPublished():
+		return ErrPostNotPublished
+	}
 
-	g.Go(func() error {
-		fileURLs := <-fileURLChan
-		if err := p.postRepo.UpdatePostFiles(ctx, postUpdate.Id, fileURLs); err != nil {
-			return fmt.Errorf("p.postRepo.UpdatePostFiles: %w", err)
+	if err = p.postRepo.PinPost(ctx, userId, postId); err != nil {
+		return fmt.Errorf("p.postRepo.PinPost: %w", err)
+	}
+	return nil
+}
+
+// UnpinPost unpins the post of the user, the post stays among other posts of the profile.
+// Unpinning a post that is not pinned does nothing.
+func (p *PostService) UnpinPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+	if post.CreatorId != userId {
+		if !post.Status.IsPublished() {
+			return ErrPostNotFound
 		}
-		return nil
-	})
+		return ErrPostDoesNotBelongToUser
+	}
+
+	if err = p.postRepo.UnpinPost(ctx, userId, postId); err != nil {
+		return fmt.Errorf("p.postRepo.UnpinPost: %w", err)
+	}
+	return nil
+}
 
-	if err = g.Wait(); err != nil {
+// validatePublishTime checks that only scheduled posts have publish time and it is in the future.
+func validatePublishTime(status models.PostStatus, publishAt time.Time, now time.Time) error {
+	switch status {
+	case models.PostStatusPublished, models.PostStatusDraft:
+		if !publishAt.IsZero() {
+			return fmt.Errorf("%w: %v post can not be scheduled", ErrInvalidPublishTime, status)
+		}
+	case models.PostStatusScheduled:
+		if !publishAt.After(now) {
+			return fmt.Errorf("%w: %v is not in the future", ErrInvalidPublishTime, publishAt)
+		}
+	default:
+		return fmt.Errorf("%w: unknown post status %q", ErrInvalidPublishTime, status)
+	}
+	return nil
+}
+
+// FetchUnpublishedPosts returns drafts and scheduled posts of the user.
+func (p *PostService) FetchUnpublishedPosts(ctx context.Context, userId uuid.UUID) ([]models.Post, error) {
+	posts, err := p.postRepo.GetUnpublishedPosts(ctx, userId)
+	if err != nil {
+		return []models.Post{}, fmt.Errorf("p.postRepo.GetUnpublishedPosts: %w", err)
+	}
+	return posts, nil
+}
+
+// SchedulePost sets time the draft or scheduled post of the user is published at.
+// Post is published right away if the time has already come.
+func (p *PostService) SchedulePost(ctx context.Context, userId uuid.UUID, postId uuid.UUID, publishAt time.Time) (models.Post, error) {
+	if publishAt.IsZero() {
+		return models.Post{}, fmt.Errorf("%w: publish time is missing", ErrInvalidPublishTime)
+	}
+	if _, err := p.unpublishedPost(ctx, userId, postId); err != nil {
 		return models.Post{}, err
 	}
-	close(fileURLChan)
 
-	// delete old photos
-	for _, pic := range oldPics {
-		err = p.fileRepo.DeleteFile(ctx, path.Base(pic))
+	if now := time.Now(); publishAt.After(now) {
+		if err := p.postRepo.SchedulePost(ctx, postId, publishAt); err != nil {
+			return models.Post{}, fmt.Errorf("p.postRepo.SchedulePost: %w", err)
+		}
+	} else if err := p.postRepo.PublishPost(ctx, postId, now); err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.PublishPost: %w", err)
+	} else {
+		p.notifyMentions(ctx, postId)
+	}
+
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+	return post, nil
+}
+
+// CancelScheduledPost turns scheduled post of the user back into a draft.
+func (p *PostService) CancelScheduledPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Post, error) {
+	post, err := p.unpublishedPost(ctx, userId, postId)
+	if err != nil {
+		return models.Post{}, err
+	}
+	if post.Status != models.PostStatusScheduled {
+		return post, nil
+	}
+
+	if err = p.postRepo.SchedulePost(ctx, postId, time.Time{}); err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.SchedulePost: %w", err)
+	}
+
+	post, err = p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+	return post, nil
+}
+
+// unpublishedPost returns post of the user that is not published yet.
+// Unpublished posts of other users are reported as not found.
+func (p *PostService) unpublishedPost(ctx context.Context, userId uuid.UUID, postId uuid.UUID) (models.Post, error) {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+
+	switch {
+	case post.CreatorId != userId && !post.Status.IsPublished():
+		return models.Post{}, ErrPostNotFound
+	case post.CreatorId != userId:
+		return models.Post{}, ErrPostDoesNotBelongToUser
+	case post.Status.IsPublished():
+		return models.Post{}, ErrPostAlreadyPublished
+	}
+	return post, nil
+}
+
+// PublishDuePosts publishes scheduled posts which time has come.
+func (p *PostService) PublishDuePosts(ctx context.Context) error {
+	published, err := p.postRepo.PublishDuePosts(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("p.postRepo.PublishDuePosts: %w", err)
+	}
+
+	if len(published) > 0 {
+		logger.Info(ctx, fmt.Sprintf("Published %d scheduled posts", len(published)))
+		p.notifyMentions(ctx, published...)
+	}
+	return nil
+}
+
+func (p *PostService) UpdatePost(ctx context.Context, postUpdate models.PostUpdate, userId uuid.UUID) (models.Post, error) {
+	if postUpdate.Visibility != "" && !postUpdate.Visibility.IsValid() {
+		return models.Post{}, ErrInvalidVisibility
+	}
+
+	// check if user owns the post
+	current, err := p.postRepo.GetPost(ctx, postUpdate.Id)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+
+	if current.CreatorId != userId {
+		return models.Post{}, ErrPostDoesNotBelongToUser
+	}
+	postUpdate.EditorId = userId
+	postUpdate.Tags = hashtag.Parse(postUpdate.Desc)
+
+	// mentions are checked against the audience the post will have after the update
+	visibility := postUpdate.Visibility
+	if visibility == "" {
+		visibility = current.Visibility
+	}
+	postUpdate.Mentions, err = p.mentions.ResolvePostMentions(ctx, postUpdate.Desc, userId, visibility)
+	if err != nil {
+		return models.Post{}, fmt.Errorf("p.mentions.ResolvePostMentions: %w", err)
+	}
+
+	// Upload files to storage
+	var fileURLs []string
+	if len(postUpdate.Files) > 0 {
+		for _, file := range postUpdate.Files {
+			file.Purpose = models.FilePurposePost
+		}
+		fileURLs, err = p.fileRepo.UploadManyFiles(ctx, postUpdate.Files)
 		if err != nil {
-			return models.Post{}, fmt.Errorf("p.fileRepo.DeleteFile: %w", err)
+			return models.Post{}, fmt.Errorf("p.fileRepo.UploadManyFiles: %w", err)
 		}
 	}
+	if len(postUpdate.UploadKeys) > 0 {
+		committed, err := p.uploads.CommitUploads(ctx, userId, models.FilePurposePost, postUpdate.UploadKeys)
+		if err != nil {
+			p.removeFiles(ctx, fileURLs)
+			return models.Post{}, fmt.Errorf("p.uploads.CommitUploads: %w", err)
+		}
+		fileURLs = append(fileURLs, committed...)
+	}
+
+	unreferenced, err := p.postRepo.UpdatePost(ctx, postUpdate, fileURLs)
+	if err != nil {
+		// old files are still referenced by the post, new ones are not
+		p.removeFiles(ctx, fileURLs)
+		return models.Post{}, fmt.Errorf("p.postRepo.UpdatePost: %w", err)
+	}
+
+	// update is committed, replaced files nobody else uses can be removed
+	p.removeFiles(ctx, unreferenced)
 
 	post, err := p.postRepo.GetPost(ctx, postUpdate.Id)
 	if err != nil {
 		return models.Post{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
 	}
 
+	// only users mentioned by the edit are notified
+	if post.Status.IsPublished() {
+		p.notifyMentions(ctx, post.Id)
+	}
+
 	return post, nil
 }
+
+// FetchTagPosts returns posts with the tag the viewer is allowed to see.
+// Anonymous viewers are passed as uuid.Nil.
+func (p *PostService) FetchTagPosts(ctx context.Context, tag string, viewerId uuid.UUID, numPosts int, cursor models.Cursor) ([]models.Post, error) {
+	tag, ok := hashtag.Normalize(tag)
+	if !ok {
+		return []models.Post{}, ErrInvalidTag
+	}
+
+	err := validation.ValidateFeedParams(numPosts, cursor.Ts)
+	if errors.Is(err, validation.ErrInvalidNumPosts) {
+		return []models.Post{}, ErrInvalidNumPosts
+	} else if errors.Is(err, validation.ErrInvalidTimestamp) {
+		return []models.Post{}, ErrInvalidTimestamp
+	} else if err != nil {
+		return []models.Post{}, fmt.Errorf("validation.ValidateFeedParams: %w", err)
+	}
+
+	posts, err := p.postRepo.GetTagPosts(ctx, tag, viewerId, numPosts, cursor)
+	if err != nil {
+		return []models.Post{}, fmt.Errorf("p.postRepo.GetTagPosts: %w", err)
+	}
+	if err = p.attachViewerState(ctx, posts, viewerId); err != nil {
+		return []models.Post{}, err
+	}
+	return posts, nil
+}
+
+// FetchTrendingTags returns tags used in public posts by the most authors within the window ending now.
+func (p *PostService) FetchTrendingTags(ctx context.Context, window string, numTags int) ([]models.TrendingTag, error) {
+	duration, ok := TrendingWindows[window]
+	if !ok {
+		return []models.TrendingTag{}, ErrInvalidTrendingWindow
+	}
+	if numTags <= 0 || numTags > maxTrendingTags {
+		return []models.TrendingTag{}, ErrInvalidNumTags
+	}
+
+	tags, err := p.postRepo.GetTrendingTags(ctx, time.Now(), duration, numTags)
+	if err != nil {
+		return []models.TrendingTag{}, fmt.Errorf("p.postRepo.GetTrendingTags: %w", err)
+	}
+	return tags, nil
+}
+
+// FetchPostHistory returns versions of the post from the original one to the latest.
+// History is available to the author of the post and to moderators only.
+func (p *PostService) FetchPostHistory(ctx context.Context, user models.User, postId uuid.UUID) ([]models.PostRevision, error) {
+	post, err := p.postRepo.GetPost(ctx, postId)
+	if err != nil {
+		return []models.PostRevision{}, fmt.Errorf("p.postRepo.GetPost: %w", err)
+	}
+
+	if post.CreatorId != user.Id && Authorize(user, models.PermissionModerateContent) != nil {
+		visible, err := canViewPost(ctx, p.friendsRepo, p.profileRepo, post, user.Id)
+		if err != nil {
+			return []models.PostRevision{}, fmt.Errorf("canViewPost: %w", err)
+		}
+		if !visible {
+			// do not reveal existence of the post
+			return []models.PostRevision{}, ErrPostNotFound
+		}
+		return []models.PostRevision{}, ErrPostDoesNotBelongToUser
+	}
+
+	revisions, err := p.postRepo.GetPostRevisions(ctx, postId)
+	if err != nil {
+		return []models.PostRevision{}, fmt.Errorf("p.postRepo.GetPostRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// removeFiles deletes files that are not referenced by any post.
+// Failures are only logged: the files are garbage and must not fail the request.
+func (p *PostService) removeFiles(ctx context.Context, fileURLs []string) {
+	// cleanup must happen even if the request was cancelled
+	ctx = context.WithoutCancel(ctx)
+	for _, fileURL := range fileURLs {
+		if err := p.fileRepo.DeleteFile(ctx, models.FilePurposePost, path.Base(fileURL)); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to remove file %s: %s", fileURL, err.Error()))
+		}
+	}
+}
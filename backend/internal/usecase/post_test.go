@@ -3,7 +3,9 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -15,10 +17,15 @@ import (
 )
 
 func TestPostService_AddPost(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+
 	tests := []struct {
 		name           string
 		post           models.Post
 		uploadFilesErr error
+		uploadedURLs   []string
+		committedURLs  []string
+		commitErr      error
 		addPostErr     error
 		expectedPost   models.Post
 		expectedErr    error
@@ -29,16 +36,77 @@ func TestPostService_AddPost(t *testing.T) {
 				Desc: "Hi",
 			},
 			expectedPost: models.Post{
-				Desc: "Hi",
+				Desc:       "Hi",
+				Visibility: models.VisibilityPublic,
+				Status:     models.PostStatusPublished,
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "friends only post",
+			post: models.Post{
+				Desc:       "Hi",
+				Visibility: models.VisibilityFriends,
+			},
+			expectedPost: models.Post{
+				Desc:       "Hi",
+				Visibility: models.VisibilityFriends,
+				Status:     models.PostStatusPublished,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "scheduled post",
+			post: models.Post{
+				Desc:      "Hi",
+				Status:    models.PostStatusScheduled,
+				PublishAt: publishAt,
+			},
+			expectedPost: models.Post{
+				Desc:       "Hi",
+				Visibility: models.VisibilityPublic,
+				Status:     models.PostStatusScheduled,
+				PublishAt:  publishAt,
+			},
+		},
 		{
 			name:        "add post error",
 			post:        models.Post{Images: []*models.File{}},
 			addPostErr:  errors.New("add post error"),
 			expectedErr: errors.New("p.postRepo.AddPost: add post error"),
 		},
+		{
+			name:         "uploaded files are removed when post is not saved",
+			post:         models.Post{Images: []*models.File{{Name: "a.png"}, {Name: "b.png"}}},
+			uploadedURLs: []string{"http://minio/posts/a.png", "http://minio/posts/b.png"},
+			addPostErr:   errors.New("add post error"),
+			expectedErr:  errors.New("p.postRepo.AddPost: add post error"),
+		},
+		{
+			name: "directly uploaded files go after attached ones",
+			post: models.Post{
+				Desc:       "Hi",
+				Images:     []*models.File{{Name: "a.png"}},
+				UploadKeys: []string{"clip.mp4"},
+			},
+			uploadedURLs:  []string{"http://minio/posts/a.png"},
+			committedURLs: []string{"http://minio/posts/clip.mp4"},
+			expectedPost: models.Post{
+				Desc:       "Hi",
+				Images:     []*models.File{{Name: "a.png", Purpose: models.FilePurposePost}},
+				ImagesURL:  []string{"http://minio/posts/a.png", "http://minio/posts/clip.mp4"},
+				UploadKeys: []string{"clip.mp4"},
+				Visibility: models.VisibilityPublic,
+				Status:     models.PostStatusPublished,
+			},
+		},
+		{
+			name:         "attached files are removed when upload is invalid",
+			post:         models.Post{Images: []*models.File{{Name: "a.png"}}, UploadKeys: []string{"foreign.mp4"}},
+			uploadedURLs: []string{"http://minio/posts/a.png"},
+			commitErr:    usecase.ErrInvalidUpload,
+			expectedErr:  errors.New("p.uploads.CommitUploads: invalid upload"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -48,20 +116,37 @@ func TestPostService_AddPost(t *testing.T) {
 
 			mockPostRepo := mocks.NewMockPostRepository(ctrl)
 			mockFileRepo := mocks.NewMockFileRepository(ctrl)
+			mockUploads := mocks.NewMockUploadCommitter(ctrl)
+			mockMentions := mocks.NewMockPostMentioner(ctrl)
 
+			mockMentions.EXPECT().ResolvePostMentions(gomock.Any(), tt.post.Desc, tt.post.CreatorId, gomock.Any()).Return(nil, nil)
 			if tt.uploadFilesErr != nil {
 				mockFileRepo.EXPECT().UploadManyFiles(gomock.Any(), tt.post.Images).Return(nil, tt.uploadFilesErr)
 			} else {
-				mockFileRepo.EXPECT().UploadManyFiles(gomock.Any(), tt.post.Images).Return(nil, nil)
+				mockFileRepo.EXPECT().UploadManyFiles(gomock.Any(), tt.post.Images).Return(tt.uploadedURLs, nil)
+			}
+			if len(tt.post.UploadKeys) > 0 {
+				mockUploads.EXPECT().CommitUploads(gomock.Any(), tt.post.CreatorId, models.FilePurposePost, tt.post.UploadKeys).
+					Return(tt.committedURLs, tt.commitErr)
 			}
 
-			if tt.addPostErr != nil {
+			if tt.commitErr != nil {
+				for _, url := range tt.uploadedURLs {
+					mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, path.Base(url)).Return(nil)
+				}
+			} else if tt.addPostErr != nil {
 				mockPostRepo.EXPECT().AddPost(gomock.Any(), gomock.Any()).Return(tt.addPostErr)
+				for _, url := range tt.uploadedURLs {
+					mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, path.Base(url)).Return(nil)
+				}
 			} else {
 				mockPostRepo.EXPECT().AddPost(gomock.Any(), gomock.Any()).Return(nil)
+				if tt.expectedPost.Status.IsPublished() {
+					mockMentions.EXPECT().NotifyPostMentions(gomock.Any(), gomock.Any()).Return(nil)
+				}
 			}
 
-			postService := usecase.NewPostService(mockPostRepo, mockFileRepo)
+			postService := usecase.NewPostService(mockPostRepo, mockFileRepo, mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mockUploads, mockMentions, mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
 
 			result, err := postService.AddPost(context.Background(), tt.post)
 
@@ -77,23 +162,97 @@ func TestPostService_AddPost(t *testing.T) {
 	}
 }
 
+func TestPostService_UpdatePost(t *testing.T) {
+	userId := uuid.New()
+	update := models.PostUpdate{Id: uuid.New(), Desc: "new text", Files: []*models.File{{Name: "new.png"}}}
+
+	tests := []struct {
+		name         string
+		unreferenced []string
+		updateErr    error
+		removedFiles []string
+		expectedErr  error
+	}{
+		{
+			name:         "unreferenced old files are removed after commit",
+			unreferenced: []string{"http://minio/posts/old.png"},
+			removedFiles: []string{"old.png"},
+		},
+		{
+			name: "old files shared with other posts are kept",
+		},
+		{
+			name:         "new files are removed when update fails",
+			updateErr:    errors.New("update error"),
+			removedFiles: []string{"new.png"},
+			expectedErr:  errors.New("p.postRepo.UpdatePost: update error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFileRepo := mocks.NewMockFileRepository(ctrl)
+			mockMentions := mocks.NewMockPostMentioner(ctrl)
+
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), update.Id).Return(models.Post{Id: update.Id, CreatorId: userId, Visibility: models.VisibilityFriends}, nil)
+			// visibility is not changed by the update, so mentions are checked against the current one
+			mockMentions.EXPECT().ResolvePostMentions(gomock.Any(), update.Desc, userId, models.VisibilityFriends).Return(nil, nil)
+			mockFileRepo.EXPECT().UploadManyFiles(gomock.Any(), update.Files).Return([]string{"http://minio/posts/new.png"}, nil)
+			// the edit is attributed to the user who made it
+			edit := update
+			edit.EditorId = userId
+			mockPostRepo.EXPECT().UpdatePost(gomock.Any(), edit, []string{"http://minio/posts/new.png"}).Return(tt.unreferenced, tt.updateErr)
+			for _, file := range tt.removedFiles {
+				mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, file).Return(nil)
+			}
+			if tt.updateErr == nil {
+				mockPostRepo.EXPECT().GetPost(gomock.Any(), update.Id).Return(models.Post{Id: update.Id, Desc: update.Desc}, nil)
+				mockMentions.EXPECT().NotifyPostMentions(gomock.Any(), update.Id).Return(nil)
+			}
+
+			postService := usecase.NewPostService(mockPostRepo, mockFileRepo, mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mockMentions, mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+			post, err := postService.UpdatePost(context.Background(), update, userId)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, update.Desc, post.Desc)
+			}
+		})
+	}
+}
+
 func TestPostService_DeletePost(t *testing.T) {
 	tests := []struct {
-		name            string
-		user            models.User
-		postId          uuid.UUID
-		belongsTo       bool
-		deletePostErr   error
-		getPostFilesErr error
-		deleteFileErr   error
-		expectedErr     error
+		name          string
+		user          models.User
+		postId        uuid.UUID
+		belongsTo     bool
+		unreferenced  []string
+		deletePostErr error
+		deleteFileErr error
+		removedFiles  []string
+		expectedErr   error
 	}{
 		{
-			name:        "success",
-			user:        models.User{Id: uuid.New(), Username: "testuser"},
-			postId:      uuid.New(),
-			belongsTo:   true,
-			expectedErr: nil,
+			name:         "success",
+			user:         models.User{Id: uuid.New(), Username: "testuser"},
+			postId:       uuid.New(),
+			belongsTo:    true,
+			unreferenced: []string{"http://minio/posts/file1.jpg"},
+			removedFiles: []string{"file1.jpg"},
+			expectedErr:  nil,
+		},
+		{
+			name:      "files shared with other posts are kept",
+			user:      models.User{Id: uuid.New(), Username: "testuser"},
+			postId:    uuid.New(),
+			belongsTo: true,
 		},
 		{
 			name:        "post does not belong to user",
@@ -103,12 +262,34 @@ func TestPostService_DeletePost(t *testing.T) {
 			expectedErr: usecase.ErrPostDoesNotBelongToUser,
 		},
 		{
-			name:          "delete file error",
+			name:      "moderator deletes post of another user",
+			user:      models.User{Id: uuid.New(), Username: "moderator", Role: models.RoleModerator},
+			postId:    uuid.New(),
+			belongsTo: false,
+		},
+		{
+			name:        "username alone grants nothing",
+			user:        models.User{Id: uuid.New(), Username: "Nikita", Role: models.RoleUser},
+			postId:      uuid.New(),
+			belongsTo:   false,
+			expectedErr: usecase.ErrPostDoesNotBelongToUser,
+		},
+		{
+			name:          "delete post error",
 			user:          models.User{Id: uuid.New(), Username: "testuser"},
 			postId:        uuid.New(),
 			belongsTo:     true,
+			deletePostErr: errors.New("delete post error"),
+			expectedErr:   errors.New("p.postRepo.DeletePost: delete post error"),
+		},
+		{
+			name:          "file that failed to delete is left to gc",
+			user:          models.User{Id: uuid.New(), Username: "testuser"},
+			postId:        uuid.New(),
+			belongsTo:     true,
+			unreferenced:  []string{"http://minio/posts/file1.jpg"},
+			removedFiles:  []string{"file1.jpg"},
 			deleteFileErr: errors.New("delete file error"),
-			expectedErr:   errors.New("p.fileRepo.DeleteFile: delete file error"),
 		},
 	}
 
@@ -123,28 +304,15 @@ func TestPostService_DeletePost(t *testing.T) {
 			// В ожиданиях проверяем, что BelongsTo вызывается для пользователя и поста
 			mockPostRepo.EXPECT().BelongsTo(gomock.Any(), tt.user.Id, tt.postId).Return(tt.belongsTo, nil)
 
-			if tt.belongsTo { // только если пост принадлежит пользователю
-				if tt.getPostFilesErr != nil {
-					mockPostRepo.EXPECT().GetPostFiles(gomock.Any(), tt.postId).Return(nil, tt.getPostFilesErr)
-				} else {
-					mockPostRepo.EXPECT().GetPostFiles(gomock.Any(), tt.postId).Return([]string{"file1.jpg"}, nil)
-				}
-
-				if tt.deletePostErr != nil {
-					mockPostRepo.EXPECT().DeletePost(gomock.Any(), tt.postId).Return(tt.deletePostErr)
-				} else {
-					mockPostRepo.EXPECT().DeletePost(gomock.Any(), tt.postId).Return(nil)
-				}
-
-				if tt.deleteFileErr != nil {
-					mockFileRepo.EXPECT().DeleteFile(gomock.Any(), gomock.Any()).Return(tt.deleteFileErr)
-				} else {
-					mockFileRepo.EXPECT().DeleteFile(gomock.Any(), gomock.Any()).Return(nil)
+			if tt.expectedErr != usecase.ErrPostDoesNotBelongToUser { // только если пользователь может удалить пост
+				mockPostRepo.EXPECT().DeletePost(gomock.Any(), tt.postId).Return(tt.unreferenced, tt.deletePostErr)
+				for _, file := range tt.removedFiles {
+					mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, file).Return(tt.deleteFileErr)
 				}
 			}
 
 			// Создаем сервис
-			postService := usecase.NewPostService(mockPostRepo, mockFileRepo)
+			postService := usecase.NewPostService(mockPostRepo, mockFileRepo, mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
 
 			// Вызов метода DeletePost
 			err := postService.DeletePost(context.Background(), tt.user, tt.postId)
@@ -158,3 +326,605 @@ func TestPostService_DeletePost(t *testing.T) {
 		})
 	}
 }
+
+func TestPostService_DeleteUserContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.New()
+	deleted := models.DeletedContent{Posts: 2, Comments: 3, Messages: 4}
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockFileRepo := mocks.NewMockFileRepository(ctrl)
+	mockPostRepo.EXPECT().DeleteUserContent(gomock.Any(), userId).Return(deleted, []string{"/posts/a.png", "/posts/b.png"}, nil)
+	mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "a.png").Return(nil)
+	mockFileRepo.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "b.png").Return(errors.New("storage error"))
+
+	postService := usecase.NewPostService(mockPostRepo, mockFileRepo, mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+	result, err := postService.DeleteUserContent(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Equal(t, deleted, result)
+}
+
+func TestPostService_FetchPost(t *testing.T) {
+	ownerId := uuid.New()
+	viewerId := uuid.New()
+
+	tests := []struct {
+		name        string
+		visibility  models.PostVisibility
+		viewerId    uuid.UUID
+		relation    models.UserRelation
+		posts       models.PrivacyLevel // posts privacy level of the author, everyone if empty
+		expectedErr error
+	}{
+		{
+			name:       "public post for guest",
+			visibility: models.VisibilityPublic,
+			viewerId:   uuid.Nil,
+		},
+		{
+			name:        "friends post for guest",
+			visibility:  models.VisibilityFriends,
+			viewerId:    uuid.Nil,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:       "friends post for friend",
+			visibility: models.VisibilityFriends,
+			viewerId:   viewerId,
+			relation:   models.RelationFriend,
+		},
+		{
+			name:        "friends post for follower",
+			visibility:  models.VisibilityFriends,
+			viewerId:    viewerId,
+			relation:    models.RelationFollowing,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "private post for friend",
+			visibility:  models.VisibilityPrivate,
+			viewerId:    viewerId,
+			relation:    models.RelationFriend,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:       "private post for owner",
+			visibility: models.VisibilityPrivate,
+			viewerId:   ownerId,
+		},
+		{
+			name:        "public post of friends only author for stranger",
+			visibility:  models.VisibilityPublic,
+			viewerId:    viewerId,
+			relation:    models.RelationStranger,
+			posts:       models.PrivacyFriends,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "public post of friends only author for guest",
+			visibility:  models.VisibilityPublic,
+			viewerId:    uuid.Nil,
+			posts:       models.PrivacyFriends,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:       "public post of friends only author for friend",
+			visibility: models.VisibilityPublic,
+			viewerId:   viewerId,
+			relation:   models.RelationFriend,
+			posts:      models.PrivacyFriends,
+		},
+		{
+			name:        "public post of author hiding posts for friend",
+			visibility:  models.VisibilityPublic,
+			viewerId:    viewerId,
+			relation:    models.RelationFriend,
+			posts:       models.PrivacyOnlyMe,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:       "post of author hiding posts for themselves",
+			visibility: models.VisibilityPublic,
+			viewerId:   ownerId,
+			posts:      models.PrivacyOnlyMe,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+
+			post := models.Post{Id: uuid.New(), CreatorId: ownerId, Visibility: tt.visibility}
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+			if tt.viewerId != uuid.Nil && tt.viewerId != ownerId {
+				mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), tt.viewerId, ownerId).Return(tt.relation, nil)
+			}
+			mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+			if tt.viewerId != uuid.Nil && tt.expectedErr == nil {
+				mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), tt.viewerId, []uuid.UUID{post.Id}).Return(map[uuid.UUID]bool{}, nil)
+			}
+			// guests and the author do not count as viewers
+			mockViews := mocks.NewMockViewCounter(ctrl)
+			if tt.viewerId != uuid.Nil && tt.viewerId != ownerId && tt.expectedErr == nil {
+				mockViews.EXPECT().RecordViews(gomock.Any(), tt.viewerId, []uuid.UUID{post.Id}, gomock.Any()).Return(nil)
+			}
+
+			settings := models.DefaultPrivacySettings()
+			if len(tt.posts) > 0 {
+				settings.Posts = tt.posts
+			}
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), ownerId).Return(settings, nil).AnyTimes()
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mockProfileRepo, mockFriendsRepo, mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mockViews, mocks.NewMockFeedFilterRepository(ctrl))
+
+			result, err := postService.FetchPost(context.Background(), post.Id, tt.viewerId)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, post, result)
+			}
+		})
+	}
+}
+
+func TestPostService_AddPost_InvalidPublishTime(t *testing.T) {
+	tests := []struct {
+		name string
+		post models.Post
+	}{
+		{
+			name: "scheduled in the past",
+			post: models.Post{Status: models.PostStatusScheduled, PublishAt: time.Now().Add(-time.Minute)},
+		},
+		{
+			name: "scheduled without time",
+			post: models.Post{Status: models.PostStatusScheduled},
+		},
+		{
+			name: "draft with publish time",
+			post: models.Post{Status: models.PostStatusDraft, PublishAt: time.Now().Add(time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// nothing is uploaded or saved
+			postService := usecase.NewPostService(mocks.NewMockPostRepository(ctrl), mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			_, err := postService.AddPost(context.Background(), tt.post)
+			assert.ErrorIs(t, err, usecase.ErrInvalidPublishTime)
+		})
+	}
+}
+
+func TestPostService_FetchPost_Unpublished(t *testing.T) {
+	ownerId := uuid.New()
+
+	tests := []struct {
+		name        string
+		viewerId    uuid.UUID
+		relation    models.UserRelation
+		expectedErr error
+	}{
+		{
+			name:     "draft for owner",
+			viewerId: ownerId,
+		},
+		{
+			name:        "draft for friend",
+			viewerId:    uuid.New(),
+			relation:    models.RelationFriend,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "draft for guest",
+			viewerId:    uuid.Nil,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+
+			post := models.Post{Id: uuid.New(), CreatorId: ownerId, Visibility: models.VisibilityPublic, Status: models.PostStatusDraft}
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+			if tt.viewerId != uuid.Nil && tt.viewerId != ownerId {
+				mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), tt.viewerId, ownerId).Return(tt.relation, nil)
+			}
+			mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+			if tt.viewerId != uuid.Nil && tt.expectedErr == nil {
+				mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), tt.viewerId, []uuid.UUID{post.Id}).Return(map[uuid.UUID]bool{}, nil)
+			}
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mockFriendsRepo, mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			result, err := postService.FetchPost(context.Background(), post.Id, tt.viewerId)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, post, result)
+			}
+		})
+	}
+}
+
+func TestPostService_SchedulePost(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name        string
+		post        models.Post
+		publishAt   time.Time
+		mockSetup   func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time)
+		published   bool
+		expectedErr error
+	}{
+		{
+			name:      "schedule draft",
+			post:      models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusDraft},
+			publishAt: time.Now().Add(time.Hour),
+			mockSetup: func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time) {
+				repo.EXPECT().SchedulePost(gomock.Any(), post.Id, publishAt).Return(nil)
+				repo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+			},
+		},
+		{
+			name:      "publish right away when time has come",
+			post:      models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusScheduled, PublishAt: time.Now().Add(time.Hour)},
+			publishAt: time.Now().Add(-time.Minute),
+			mockSetup: func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time) {
+				repo.EXPECT().PublishPost(gomock.Any(), post.Id, gomock.Any()).Return(nil)
+				repo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil)
+			},
+			published: true,
+		},
+		{
+			name:        "draft of another user",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Status: models.PostStatusDraft},
+			publishAt:   time.Now().Add(time.Hour),
+			mockSetup:   func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time) {},
+			expectedErr: usecase.ErrPostNotFound,
+		},
+		{
+			name:        "published post of another user",
+			post:        models.Post{Id: uuid.New(), CreatorId: uuid.New(), Status: models.PostStatusPublished},
+			publishAt:   time.Now().Add(time.Hour),
+			mockSetup:   func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time) {},
+			expectedErr: usecase.ErrPostDoesNotBelongToUser,
+		},
+		{
+			name:        "already published",
+			post:        models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusPublished},
+			publishAt:   time.Now().Add(time.Hour),
+			mockSetup:   func(repo *mocks.MockPostRepository, post models.Post, publishAt time.Time) {},
+			expectedErr: usecase.ErrPostAlreadyPublished,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			tt.mockSetup(mockPostRepo, tt.post, tt.publishAt)
+			mockMentions := mocks.NewMockPostMentioner(ctrl)
+			if tt.published {
+				mockMentions.EXPECT().NotifyPostMentions(gomock.Any(), tt.post.Id).Return(nil)
+			}
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mockMentions, mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			_, err := postService.SchedulePost(context.Background(), userId, tt.post.Id, tt.publishAt)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostService_CancelScheduledPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.New()
+	post := models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusScheduled, PublishAt: time.Now().Add(time.Hour)}
+	draft := models.Post{Id: post.Id, CreatorId: userId, Status: models.PostStatusDraft}
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	gomock.InOrder(
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(post, nil),
+		mockPostRepo.EXPECT().SchedulePost(gomock.Any(), post.Id, time.Time{}).Return(nil),
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), post.Id).Return(draft, nil),
+	)
+
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+	result, err := postService.CancelScheduledPost(context.Background(), userId, post.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, draft, result)
+}
+
+func TestPostService_FetchUserPosts_Pinned(t *testing.T) {
+	user := models.User{Id: uuid.New(), Username: "author"}
+	pinned := models.Post{Id: uuid.New(), CreatorId: user.Id, Status: models.PostStatusPublished}
+	regular := models.Post{Id: uuid.New(), CreatorId: user.Id, Status: models.PostStatusPublished}
+	newer := models.Post{Id: uuid.New(), CreatorId: user.Id, Status: models.PostStatusPublished}
+
+	tests := []struct {
+		name       string
+		cursor     models.Cursor
+		withPinned bool
+		newest     []models.Post // nil if the page is not checked against the newest post
+		pinnedErr  error
+		wantIds    []uuid.UUID
+	}{
+		{name: "first page starts with pinned post", cursor: models.CursorFromTs(time.Now()), withPinned: true, wantIds: []uuid.UUID{pinned.Id, regular.Id}},
+		{name: "no pinned post", cursor: models.CursorFromTs(time.Now()), withPinned: true, pinnedErr: usecase.ErrPostNotFound, wantIds: []uuid.UUID{regular.Id}},
+		{name: "next pages have no pinned post", cursor: models.Cursor{Ts: time.Now(), Id: newer.Id}, wantIds: []uuid.UUID{regular.Id}},
+		{
+			// legacy clients send current time cut to seconds
+			name:    "legacy first page by timestamp",
+			cursor:  models.CursorFromTs(time.Now().Truncate(time.Second)),
+			newest:  []models.Post{regular},
+			wantIds: []uuid.UUID{pinned.Id, regular.Id},
+		},
+		{
+			name:    "legacy next page by timestamp",
+			cursor:  models.CursorFromTs(time.Now().Add(-time.Hour)),
+			newest:  []models.Post{newer},
+			wantIds: []uuid.UUID{regular.Id},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+			mockPostRepo.EXPECT().GetUserPosts(gomock.Any(), user.Id, user.Id, 10, tt.cursor).Return([]models.Post{regular}, nil)
+			if tt.newest != nil {
+				mockPostRepo.EXPECT().GetUserPosts(gomock.Any(), user.Id, user.Id, 1, gomock.Any()).Return(tt.newest, nil)
+			}
+			if tt.withPinned || (tt.newest != nil && tt.newest[0].Id == regular.Id) {
+				mockPostRepo.EXPECT().GetPinnedPost(gomock.Any(), user.Id, user.Id).Return(pinned, tt.pinnedErr)
+			}
+			mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), user.Id, gomock.Any()).Return(map[uuid.UUID]bool{}, nil)
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			posts, err := postService.FetchUserPosts(context.Background(), user, user.Id, 10, tt.cursor, tt.withPinned)
+			assert.NoError(t, err)
+			var ids []uuid.UUID
+			for _, post := range posts {
+				ids = append(ids, post.Id)
+				assert.Equal(t, post.Id == pinned.Id, post.IsPinned)
+				assert.Equal(t, models.RelationSelf, post.CreatorRelation)
+			}
+			assert.Equal(t, tt.wantIds, ids)
+		})
+	}
+}
+
+func TestPostService_PinPost(t *testing.T) {
+	userId := uuid.New()
+	own := models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusPublished}
+	draft := models.Post{Id: uuid.New(), CreatorId: userId, Status: models.PostStatusDraft}
+	foreign := models.Post{Id: uuid.New(), CreatorId: uuid.New(), Status: models.PostStatusPublished}
+	foreignDraft := models.Post{Id: uuid.New(), CreatorId: uuid.New(), Status: models.PostStatusDraft}
+
+	tests := []struct {
+		name    string
+		post    models.Post
+		wantErr error
+	}{
+		{name: "own published post", post: own},
+		{name: "own draft", post: draft, wantErr: usecase.ErrPostNotPublished},
+		{name: "post of another user", post: foreign, wantErr: usecase.ErrPostDoesNotBelongToUser},
+		{name: "draft of another user", post: foreignDraft, wantErr: usecase.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			if tt.wantErr == nil {
+				mockPostRepo.EXPECT().PinPost(gomock.Any(), userId, tt.post.Id).Return(nil)
+			}
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			err := postService.PinPost(context.Background(), userId, tt.post.Id)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostService_FetchPostHistory(t *testing.T) {
+	author := models.User{Id: uuid.New(), Username: "author"}
+	stranger := models.User{Id: uuid.New(), Username: "stranger"}
+	moderator := models.User{Id: uuid.New(), Username: "moderator", Role: models.RoleModerator}
+
+	tests := []struct {
+		name        string
+		user        models.User
+		post        models.Post
+		relation    models.UserRelation
+		expectedErr error
+	}{
+		{
+			name: "author",
+			user: author,
+			post: models.Post{Id: uuid.New(), CreatorId: author.Id, Visibility: models.VisibilityPrivate},
+		},
+		{
+			name: "moderator",
+			user: moderator,
+			post: models.Post{Id: uuid.New(), CreatorId: author.Id, Visibility: models.VisibilityPrivate},
+		},
+		{
+			name:        "public post for another user",
+			user:        stranger,
+			post:        models.Post{Id: uuid.New(), CreatorId: author.Id, Visibility: models.VisibilityPublic},
+			relation:    models.RelationStranger,
+			expectedErr: usecase.ErrPostDoesNotBelongToUser,
+		},
+		{
+			name:        "private post for another user",
+			user:        stranger,
+			post:        models.Post{Id: uuid.New(), CreatorId: author.Id, Visibility: models.VisibilityPrivate},
+			relation:    models.RelationFriend,
+			expectedErr: usecase.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+
+			revisions := []models.PostRevision{{Id: uuid.New(), PostId: tt.post.Id, EditorId: author.Id, Desc: "original"}}
+			mockPostRepo.EXPECT().GetPost(gomock.Any(), tt.post.Id).Return(tt.post, nil)
+			if tt.expectedErr != nil {
+				mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), tt.user.Id, author.Id).Return(tt.relation, nil)
+			} else {
+				mockPostRepo.EXPECT().GetPostRevisions(gomock.Any(), tt.post.Id).Return(revisions, nil)
+			}
+
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), author.Id).Return(models.DefaultPrivacySettings(), nil).AnyTimes()
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mockProfileRepo, mockFriendsRepo, mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			result, err := postService.FetchPostHistory(context.Background(), tt.user, tt.post.Id)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, revisions, result)
+			}
+		})
+	}
+}
+
+func TestPostService_Tags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockFileRepo := mocks.NewMockFileRepository(ctrl)
+	mockMentions := mocks.NewMockPostMentioner(ctrl)
+	mockMentions.EXPECT().ResolvePostMentions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockMentions.EXPECT().NotifyPostMentions(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	postService := usecase.NewPostService(mockPostRepo, mockFileRepo, mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mockMentions, mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+	t.Run("tags are parsed when post is added", func(t *testing.T) {
+		mockFileRepo.EXPECT().UploadManyFiles(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockPostRepo.EXPECT().AddPost(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, post models.Post) error {
+			assert.Equal(t, []string{"go", "backend"}, post.Tags)
+			return nil
+		})
+
+		_, err := postService.AddPost(context.Background(), models.Post{Desc: "Writing #Go for the #backend, #go!"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("tags follow edited text", func(t *testing.T) {
+		userId := uuid.New()
+		update := models.PostUpdate{Id: uuid.New(), Desc: "now about #rust"}
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), update.Id).Return(models.Post{Id: update.Id, CreatorId: userId}, nil)
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, update models.PostUpdate, fileURLs []string) ([]string, error) {
+				assert.Equal(t, []string{"rust"}, update.Tags)
+				return nil, nil
+			})
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), update.Id).Return(models.Post{Id: update.Id}, nil)
+
+		_, err := postService.UpdatePost(context.Background(), update, userId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("tag is normalized before lookup", func(t *testing.T) {
+		cursor := models.CursorFromTs(time.Now())
+		mockPostRepo.EXPECT().GetTagPosts(gomock.Any(), "go", uuid.Nil, 10, cursor).Return([]models.Post{}, nil)
+
+		_, err := postService.FetchTagPosts(context.Background(), "#GO", uuid.Nil, 10, cursor)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		_, err := postService.FetchTagPosts(context.Background(), "42", uuid.Nil, 10, models.CursorFromTs(time.Now()))
+		assert.ErrorIs(t, err, usecase.ErrInvalidTag)
+	})
+}
+
+func TestPostService_FetchTrendingTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		window      string
+		numTags     int
+		expectedErr error
+	}{
+		{name: "day", window: "day", numTags: 10},
+		{name: "week", window: "week", numTags: 50},
+		{name: "unknown window", window: "year", numTags: 10, expectedErr: usecase.ErrInvalidTrendingWindow},
+		{name: "too many tags", window: "hour", numTags: 51, expectedErr: usecase.ErrInvalidNumTags},
+		{name: "no tags", window: "hour", numTags: 0, expectedErr: usecase.ErrInvalidNumTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPostRepo := mocks.NewMockPostRepository(ctrl)
+			tags := []models.TrendingTag{{Tag: "go", PostCount: 2, AuthorCount: 2}}
+			if tt.expectedErr == nil {
+				mockPostRepo.EXPECT().GetTrendingTags(gomock.Any(), gomock.Any(), usecase.TrendingWindows[tt.window], tt.numTags).Return(tags, nil)
+			}
+
+			postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockRecommender(ctrl), mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mocks.NewMockBookmarkRepository(ctrl), mocks.NewMockViewCounter(ctrl), mocks.NewMockFeedFilterRepository(ctrl))
+
+			result, err := postService.FetchTrendingTags(context.Background(), tt.window, tt.numTags)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tags, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var (
+	ErrAccessDenied           = errors.New("access denied")
+	ErrInvalidPrivacySettings = errors.New("invalid privacy settings")
+)
+
+// resolveRelation returns relation of the viewer to the owner.
+// Anonymous viewers (uuid.Nil) are treated as strangers.
+func resolveRelation(ctx context.Context, friendsRepo FriendsRepository, viewerId, ownerId uuid.UUID) (models.UserRelation, error) {
+	if viewerId == uuid.Nil {
+		return models.RelationStranger, nil
+	}
+	if viewerId == ownerId {
+		return models.RelationSelf, nil
+	}
+
+	relation, err := friendsRepo.GetUserRelation(ctx, viewerId, ownerId)
+	if err != nil {
+		return models.RelationStranger, fmt.Errorf("friendsRepo.GetUserRelation: %w", err)
+	}
+	return relation, nil
+}
+
+// canViewPost reports whether the viewer is allowed to see the post by its visibility
+// and the posts privacy level of its author. Drafts and scheduled posts are seen only by their authors.
+func canViewPost(ctx context.Context, friendsRepo FriendsRepository, profileRepo ProfileRepository, post models.Post, viewerId uuid.UUID) (bool, error) {
+	relation, err := resolveRelation(ctx, friendsRepo, viewerId, post.CreatorId)
+	if err != nil {
+		return false, err
+	}
+	if relation == models.RelationSelf {
+		return true, nil
+	}
+	if !post.Visibility.AllowsRelation(relation) || !post.Status.IsPublished() {
+		return false, nil
+	}
+
+	settings, err := profileRepo.GetPrivacySettings(ctx, post.CreatorId)
+	if err != nil {
+		return false, fmt.Errorf("profileRepo.GetPrivacySettings: %w", err)
+	}
+	return settings.Posts.AllowsRelation(relation), nil
+}
+
+// applyProfilePrivacy removes profile fields that viewer is not allowed to see.
+func applyProfilePrivacy(profile models.Profile, settings models.PrivacySettings, relation models.UserRelation) models.Profile {
+	if !settings.ContactInfo.AllowsRelation(relation) {
+		profile.ContactInfo = nil
+	}
+	if !settings.Education.AllowsRelation(relation) {
+		profile.SchoolEducation = nil
+		profile.UniversityEducation = nil
+	}
+	if !settings.BirthDate.AllowsRelation(relation) && profile.BasicInfo != nil {
+		basicInfo := *profile.BasicInfo
+		basicInfo.DateOfBirth = time.Time{}
+		profile.BasicInfo = &basicInfo
+	}
+	return profile
+}
@@ -26,20 +26,26 @@ type ProfileRepository interface {
 	GetPublicUserInfo(ctx context.Context, userId uuid.UUID) (models.PublicUserInfo, error)
 	GetPublicUsersInfo(ctx context.Context, userIds []uuid.UUID) ([]models.PublicUserInfo, error)
 	UpdateLastSeen(ctx context.Context, userId uuid.UUID) error
+	GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, settings models.PrivacySettings) error
 }
 
 type ProfileService struct {
 	userRepo    UserRepository
 	profileRepo ProfileRepository
 	fileRepo    FileRepository
+	friendsRepo FriendsRepository
+	uploads     UploadCommitter
 }
 
 // NewProfileService creates new profile service.
-func NewProfileService(profileRepo ProfileRepository, userRepo UserRepository, fileRepo FileRepository) *ProfileService {
+func NewProfileService(profileRepo ProfileRepository, userRepo UserRepository, fileRepo FileRepository, friendsRepo FriendsRepository, uploads UploadCommitter) *ProfileService {
 	return &ProfileService{
 		profileRepo: profileRepo,
 		fileRepo:    fileRepo,
 		userRepo:    userRepo,
+		friendsRepo: friendsRepo,
+		uploads:     uploads,
 	}
 }
 
@@ -53,7 +59,9 @@ func (p *ProfileService) GetUserInfo(ctx context.Context, userId uuid.UUID) (mod
 	return profile, nil
 }
 
-func (p *ProfileService) GetUserInfoByUserName(ctx context.Context, username string) (models.Profile, error) {
+// GetUserInfoByUserName gets user profile as it is visible to the viewer along with their relation.
+// Anonymous viewers are passed as uuid.Nil.
+func (p *ProfileService) GetUserInfoByUserName(ctx context.Context, username string, viewerId uuid.UUID) (models.Profile, error) {
 	user, err := p.userRepo.GetUserByUsername(ctx, username)
 	if errors.Is(err, ErrNotFound) {
 		return models.Profile{}, err
@@ -66,7 +74,64 @@ func (p *ProfileService) GetUserInfoByUserName(ctx context.Context, username str
 		return models.Profile{}, fmt.Errorf("p.profileRepo.GetProfile: %w", err)
 	}
 
-	return profile, nil
+	relation, err := resolveRelation(ctx, p.friendsRepo, viewerId, user.Id)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("resolveRelation: %w", err)
+	}
+	if viewerId != uuid.Nil {
+		profile.Relation = relation
+	}
+	if relation == models.RelationSelf {
+		return profile, nil
+	}
+
+	settings, err := p.profileRepo.GetPrivacySettings(ctx, user.Id)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("p.profileRepo.GetPrivacySettings: %w", err)
+	}
+
+	return applyProfilePrivacy(profile, settings, relation), nil
+}
+
+// GetPrivacySettings returns privacy settings of the user.
+func (p *ProfileService) GetPrivacySettings(ctx context.Context, userId uuid.UUID) (models.PrivacySettings, error) {
+	settings, err := p.profileRepo.GetPrivacySettings(ctx, userId)
+	if err != nil {
+		return models.PrivacySettings{}, fmt.Errorf("p.profileRepo.GetPrivacySettings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdatePrivacySettings updates privacy settings of the user.
+// Empty levels keep their current value.
+func (p *ProfileService) UpdatePrivacySettings(ctx context.Context, userId uuid.UUID, update models.PrivacySettings) (models.PrivacySettings, error) {
+	settings, err := p.profileRepo.GetPrivacySettings(ctx, userId)
+	if err != nil {
+		return models.PrivacySettings{}, fmt.Errorf("p.profileRepo.GetPrivacySettings: %w", err)
+	}
+
+	for _, field := range []struct {
+		dst *models.PrivacyLevel
+		src models.PrivacyLevel
+	}{
+		{&settings.ContactInfo, update.ContactInfo},
+		{&settings.BirthDate, update.BirthDate},
+		{&settings.Education, update.Education},
+		{&settings.Posts, update.Posts},
+	} {
+		if len(field.src) == 0 {
+			continue
+		}
+		if !field.src.IsValid() {
+			return models.PrivacySettings{}, ErrInvalidPrivacySettings
+		}
+		*field.dst = field.src
+	}
+
+	if err = p.profileRepo.UpdatePrivacySettings(ctx, userId, settings); err != nil {
+		return models.PrivacySettings{}, fmt.Errorf("p.profileRepo.UpdatePrivacySettings: %w", err)
+	}
+	return settings, nil
 }
 
 // UpdateProfile updates profile in the repository.
@@ -98,6 +163,7 @@ func (p *ProfileService) UpdateProfile(ctx context.Context, newProfile models.Pr
 	})
 
 	if newProfile.Avatar != nil {
+		newProfile.Avatar.Purpose = models.FilePurposeAvatar
 		g.Go(func() error {
 			avatarUrl, err := p.fileRepo.UploadFile(ctx, newProfile.Avatar)
 			if err != nil {
@@ -108,9 +174,21 @@ func (p *ProfileService) UpdateProfile(ctx context.Context, newProfile models.Pr
 			}
 			return nil
 		})
+	} else if len(newProfile.AvatarUploadKey) != 0 {
+		g.Go(func() error {
+			avatarUrl, err := p.commitUpload(ctx, newProfile.UserId, models.FilePurposeAvatar, newProfile.AvatarUploadKey)
+			if err != nil {
+				return err
+			}
+			if err := p.profileRepo.UpdateProfileAvatar(ctx, newProfile.UserId, avatarUrl); err != nil {
+				return fmt.Errorf("p.profileRepo.UpdateProfileAvatar: %w", err)
+			}
+			return nil
+		})
 	}
 
 	if newProfile.Background != nil {
+		newProfile.Background.Purpose = models.FilePurposeCover
 		g.Go(func() error {
 			backgroundUrl, err := p.fileRepo.UploadFile(ctx, newProfile.Background)
 			if err != nil {
@@ -121,6 +199,17 @@ func (p *ProfileService) UpdateProfile(ctx context.Context, newProfile models.Pr
 			}
 			return nil
 		})
+	} else if len(newProfile.BackgroundUploadKey) != 0 {
+		g.Go(func() error {
+			backgroundUrl, err := p.commitUpload(ctx, newProfile.UserId, models.FilePurposeCover, newProfile.BackgroundUploadKey)
+			if err != nil {
+				return err
+			}
+			if err := p.profileRepo.UpdateProfileCover(ctx, newProfile.UserId, backgroundUrl); err != nil {
+				return fmt.Errorf("p.profileRepo.UpdateProfileCover: %w", err)
+			}
+			return nil
+		})
 	}
 
 	if err := g.Wait(); err != nil {
@@ -130,6 +219,15 @@ func (p *ProfileService) UpdateProfile(ctx context.Context, newProfile models.Pr
 	return nil
 }
 
+// commitUpload returns URL of a single image uploaded directly to storage.
+func (p *ProfileService) commitUpload(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, key string) (string, error) {
+	urls, err := p.uploads.CommitUploads(ctx, userId, purpose, []string{key})
+	if err != nil {
+		return "", fmt.Errorf("p.uploads.CommitUploads (%s): %w", purpose, err)
+	}
+	return urls[0], nil
+}
+
 func (p *ProfileService) GetPublicUserInfo(ctx context.Context, userId uuid.UUID) (models.PublicUserInfo, error) {
 	if userId == uuid.Nil {
 		return models.PublicUserInfo{}, ErrInvalidUserId
@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func newPrivacyTestProfile(userId uuid.UUID) models.Profile {
+	return models.Profile{
+		UserId: userId,
+		BasicInfo: &models.BasicInfo{
+			Name:        "Ivan",
+			Surname:     "Ivanov",
+			DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		ContactInfo:         &models.ContactInfo{Email: "ivan@mail.ru", Phone: "+79990000000"},
+		SchoolEducation:     &models.SchoolEducation{City: "Moscow", School: "57"},
+		UniversityEducation: &models.UniversityEducation{University: "BMSTU"},
+	}
+}
+
+func TestProfileService_GetUserInfoByUserName_Privacy(t *testing.T) {
+	ownerId := uuid.New()
+	viewerId := uuid.New()
+
+	settings := models.PrivacySettings{
+		ContactInfo: models.PrivacyFriends,
+		BirthDate:   models.PrivacyOnlyMe,
+		Education:   models.PrivacyEveryone,
+		Posts:       models.PrivacyEveryone,
+	}
+
+	tests := []struct {
+		name            string
+		viewerId        uuid.UUID
+		relation        models.UserRelation
+		wantContactInfo bool
+		wantBirthDate   bool
+		wantEducation   bool
+		wantRelation    models.UserRelation
+	}{
+		{
+			name:            "guest",
+			viewerId:        uuid.Nil,
+			wantContactInfo: false,
+			wantBirthDate:   false,
+			wantEducation:   true,
+		},
+		{
+			name:            "stranger",
+			viewerId:        viewerId,
+			relation:        models.RelationStranger,
+			wantContactInfo: false,
+			wantBirthDate:   false,
+			wantEducation:   true,
+			wantRelation:    models.RelationStranger,
+		},
+		{
+			name:            "friend",
+			viewerId:        viewerId,
+			relation:        models.RelationFriend,
+			wantContactInfo: true,
+			wantBirthDate:   false,
+			wantEducation:   true,
+			wantRelation:    models.RelationFriend,
+		},
+		{
+			name:            "self",
+			viewerId:        ownerId,
+			wantContactInfo: true,
+			wantBirthDate:   true,
+			wantEducation:   true,
+			wantRelation:    models.RelationSelf,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockFileRepo := mocks.NewMockFileRepository(ctrl)
+			mockFriendsRepo := mocks.NewMockFriendsRepository(ctrl)
+
+			mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), "ivan").Return(models.User{Id: ownerId, Username: "ivan"}, nil)
+			mockProfileRepo.EXPECT().GetProfile(gomock.Any(), ownerId).Return(newPrivacyTestProfile(ownerId), nil)
+			if tt.viewerId != uuid.Nil && tt.viewerId != ownerId {
+				mockFriendsRepo.EXPECT().GetUserRelation(gomock.Any(), tt.viewerId, ownerId).Return(tt.relation, nil)
+			}
+			if tt.viewerId != ownerId {
+				mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), ownerId).Return(settings, nil)
+			}
+
+			profileService := usecase.NewProfileService(mockProfileRepo, mockUserRepo, mockFileRepo, mockFriendsRepo, mocks.NewMockUploadCommitter(ctrl))
+			profile, err := profileService.GetUserInfoByUserName(context.Background(), "ivan", tt.viewerId)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContactInfo, profile.ContactInfo != nil)
+			assert.Equal(t, tt.wantBirthDate, !profile.BasicInfo.DateOfBirth.IsZero())
+			assert.Equal(t, tt.wantEducation, profile.UniversityEducation != nil)
+			assert.Equal(t, tt.wantRelation, profile.Relation)
+		})
+	}
+}
+
+func TestProfileService_UpdatePrivacySettings(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name        string
+		update      models.PrivacySettings
+		expected    models.PrivacySettings
+		expectedErr error
+	}{
+		{
+			name:   "partial update",
+			update: models.PrivacySettings{Posts: models.PrivacyFriends},
+			expected: models.PrivacySettings{
+				ContactInfo: models.PrivacyFriends,
+				BirthDate:   models.PrivacyEveryone,
+				Education:   models.PrivacyEveryone,
+				Posts:       models.PrivacyFriends,
+			},
+		},
+		{
+			name:        "invalid level",
+			update:      models.PrivacySettings{Posts: "nobody"},
+			expectedErr: usecase.ErrInvalidPrivacySettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+			mockProfileRepo.EXPECT().GetPrivacySettings(gomock.Any(), userId).Return(models.DefaultPrivacySettings(), nil)
+			if tt.expectedErr == nil {
+				mockProfileRepo.EXPECT().UpdatePrivacySettings(gomock.Any(), userId, tt.expected).Return(nil)
+			}
+
+			profileService := usecase.NewProfileService(mockProfileRepo, mocks.NewMockUserRepository(ctrl),
+				mocks.NewMockFileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mocks.NewMockUploadCommitter(ctrl))
+			settings, err := profileService.UpdatePrivacySettings(context.Background(), userId, tt.update)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, settings)
+			}
+		})
+	}
+}
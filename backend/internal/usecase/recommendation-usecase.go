@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	recommendation_config "quickflow/config/recommendation"
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+)
+
+type RecommendationRepository interface {
+	GetRecommendationCandidates(ctx context.Context, uid uuid.UUID, since time.Time, limit int) ([]models.RecommendationCandidate, error)
+}
+
+type RecommendationCache interface {
+	SaveRecommendations(ctx context.Context, uid uuid.UUID, posts []models.ScoredPost, ttl time.Duration) error
+	GetRecommendations(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error)
+	MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID, ttl time.Duration) error
+	GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error)
+	TouchActiveUser(ctx context.Context, uid uuid.UUID, at time.Time) error
+	GetActiveUsers(ctx context.Context, since time.Time) ([]uuid.UUID, error)
+}
+
+type RecommendationService struct {
+	recRepo  RecommendationRepository
+	recCache RecommendationCache
+	cfg      *recommendation_config.RecommendationConfig
+}
+
+// NewRecommendationService creates new recommendation service.
+func NewRecommendationService(recRepo RecommendationRepository, recCache RecommendationCache, cfg *recommendation_config.RecommendationConfig) *RecommendationService {
+	return &RecommendationService{
+		recRepo:  recRepo,
+		recCache: recCache,
+		cfg:      cfg,
+	}
+}
+
+// GetRankedPosts returns posts ranked for the user that were not shown to him yet.
+// Cached scores are used when present, otherwise they are computed on demand.
+func (r *RecommendationService) GetRankedPosts(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error) {
+	if err := r.recCache.TouchActiveUser(ctx, uid, time.Now()); err != nil {
+		// not critical, user will be refreshed on the next request
+		logger.Error(ctx, fmt.Sprintf("Unable to mark user %v as active: %s", uid, err.Error()))
+	}
+
+	ranked, err := r.recCache.GetRecommendations(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("r.recCache.GetRecommendations: %w", err)
+	}
+	if len(ranked) == 0 {
+		ranked, err = r.RefreshUser(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("r.RefreshUser: %w", err)
+		}
+	}
+
+	// cache may have been built before some posts were shown
+	seen, err := r.recCache.GetSeen(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("r.recCache.GetSeen: %w", err)
+	}
+	seenSet := make(map[uuid.UUID]struct{}, len(seen))
+	for _, id := range seen {
+		seenSet[id] = struct{}{}
+	}
+
+	result := make([]models.ScoredPost, 0, len(ranked))
+	for _, post := range ranked {
+		if _, ok := seenSet[post.PostId]; !ok {
+			result = append(result, post)
+		}
+	}
+	return result, nil
+}
+
+// MarkSeen excludes posts from future recommendations for the user.
+func (r *RecommendationService) MarkSeen(ctx context.Context, uid uuid.UUID, postIds []uuid.UUID) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	if err := r.recCache.MarkSeen(ctx, uid, postIds, r.cfg.SeenTTL); err != nil {
+		return fmt.Errorf("r.recCache.MarkSeen: %w", err)
+	}
+	return nil
+}
+
+// GetSeen returns posts that were already shown to the user.
+func (r *RecommendationService) GetSeen(ctx context.Context, uid uuid.UUID) ([]uuid.UUID, error) {
+	seen, err := r.recCache.GetSeen(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("r.recCache.GetSeen: %w", err)
+	}
+	return seen, nil
+}
+
+// RefreshUser recomputes scores of recommendation candidates for the user and caches them.
+func (r *RecommendationService) RefreshUser(ctx context.Context, uid uuid.UUID) ([]models.ScoredPost, error) {
+	now := time.Now()
+	candidates, err := r.recRepo.GetRecommendationCandidates(ctx, uid, now.Add(-r.cfg.CandidateWindow), r.cfg.MaxCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("r.recRepo.GetRecommendationCandidates: %w", err)
+	}
+
+	seen, err := r.recCache.GetSeen(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("r.recCache.GetSeen: %w", err)
+	}
+	seenSet := make(map[uuid.UUID]struct{}, len(seen))
+	for _, id := range seen {
+		seenSet[id] = struct{}{}
+	}
+
+	unseen := make([]models.RecommendationCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := seenSet[candidate.PostId]; !ok && candidate.CreatorId != uid {
+			unseen = append(unseen, candidate)
+		}
+	}
+
+	ranked := RankCandidates(unseen, r.cfg, now)
+	if len(ranked) > r.cfg.CacheSize {
+		ranked = ranked[:r.cfg.CacheSize]
+	}
+
+	if err = r.recCache.SaveRecommendations(ctx, uid, ranked, r.cfg.CacheTTL); err != nil {
+		return nil, fmt.Errorf("r.recCache.SaveRecommendations: %w", err)
+	}
+	return ranked, nil
+}
+
+// RefreshActiveUsers recomputes recommendations for users that requested them recently.
+// It is meant to be called periodically by the background worker.
+func (r *RecommendationService) RefreshActiveUsers(ctx context.Context) error {
+	users, err := r.recCache.GetActiveUsers(ctx, time.Now().Add(-r.cfg.ActiveWindow))
+	if err != nil {
+		return fmt.Errorf("r.recCache.GetActiveUsers: %w", err)
+	}
+
+	for _, uid := range users {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err = r.RefreshUser(ctx, uid); err != nil {
+			// one broken user should not stop refreshing the others
+			logger.Error(ctx, fmt.Sprintf("Unable to refresh recommendations for user %v: %s", uid, err.Error()))
+		}
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Refreshed recommendations for %d users", len(users)))
+	return nil
+}
+
+// RankCandidates scores candidates by engagement, author affinity and recency
+// and orders them so that the same author does not dominate the top of the list.
+func RankCandidates(candidates []models.RecommendationCandidate, cfg *recommendation_config.RecommendationConfig, now time.Time) []models.ScoredPost {
+	type scored struct {
+		candidate models.RecommendationCandidate
+		score     float64
+	}
+
+	pending := make([]scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		pending = append(pending, scored{candidate: candidate, score: scoreCandidate(candidate, cfg, now)})
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].score > pending[j].score
+	})
+
+	// greedy re-ranking: every already picked post of the author lowers
+	// the score of his remaining posts by DiversityPenalty
+	result := make([]models.ScoredPost, 0, len(pending))
+	authorPicks := make(map[uuid.UUID]int)
+	for len(pending) > 0 {
+		best, bestScore := 0, -1.0
+		for i, item := range pending {
+			if item.score <= bestScore {
+				// items are sorted by base score, adjusted score can only be lower
+				break
+			}
+			adjusted := item.score * math.Pow(cfg.DiversityPenalty, float64(authorPicks[item.candidate.CreatorId]))
+			if adjusted > bestScore {
+				best, bestScore = i, adjusted
+			}
+		}
+
+		picked := pending[best]
+		result = append(result, models.ScoredPost{PostId: picked.candidate.PostId, Score: bestScore})
+		authorPicks[picked.candidate.CreatorId]++
+		pending = append(pending[:best], pending[best+1:]...)
+	}
+
+	return result
+}
+
+func scoreCandidate(candidate models.RecommendationCandidate, cfg *recommendation_config.RecommendationConfig, now time.Time) float64 {
+	engagement := cfg.LikeWeight*float64(candidate.LikeCount) +
+		cfg.CommentWeight*float64(candidate.CommentCount) +
+		cfg.RepostWeight*float64(candidate.RepostCount)
+
+	var affinity float64
+	switch candidate.Affinity {
+	case models.AffinityFriend:
+		affinity = cfg.FriendAffinity
+	case models.AffinityFollowed:
+		affinity = cfg.FollowedAffinity
+	case models.AffinityFriendOfFriend:
+		affinity = cfg.FriendOfFriendAffinity
+	}
+
+	decay := 1.0
+	if age := now.Sub(candidate.CreatedAt); age > 0 && cfg.HalfLife > 0 {
+		decay = math.Pow(0.5, float64(age)/float64(cfg.HalfLife))
+	}
+
+	return (1 + math.Log1p(engagement)) * (1 + affinity) * decay
+}
@@ -0,0 +1,169 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	recommendation_config "quickflow/config/recommendation"
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func testRecommendationConfig() *recommendation_config.RecommendationConfig {
+	return &recommendation_config.RecommendationConfig{
+		CandidateWindow:        7 * 24 * time.Hour,
+		MaxCandidates:          100,
+		CacheSize:              10,
+		CacheTTL:               time.Minute,
+		SeenTTL:                time.Hour,
+		LikeWeight:             1,
+		CommentWeight:          2,
+		RepostWeight:           3,
+		FriendAffinity:         1.5,
+		FollowedAffinity:       1,
+		FriendOfFriendAffinity: 0.5,
+		HalfLife:               24 * time.Hour,
+		DiversityPenalty:       0.5,
+	}
+}
+
+func TestRankCandidates(t *testing.T) {
+	now := time.Now()
+	cfg := testRecommendationConfig()
+	author1, author2 := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		candidates []models.RecommendationCandidate
+		wantOrder  []int // indexes of candidates in expected order
+	}{
+		{
+			name: "engagement wins",
+			candidates: []models.RecommendationCandidate{
+				{PostId: uuid.New(), CreatorId: author1, CreatedAt: now, LikeCount: 1},
+				{PostId: uuid.New(), CreatorId: author2, CreatedAt: now, LikeCount: 50, CommentCount: 10},
+			},
+			wantOrder: []int{1, 0},
+		},
+		{
+			name: "friend is preferred over stranger",
+			candidates: []models.RecommendationCandidate{
+				{PostId: uuid.New(), CreatorId: author1, CreatedAt: now, LikeCount: 3},
+				{PostId: uuid.New(), CreatorId: author2, CreatedAt: now, LikeCount: 3, Affinity: models.AffinityFriend},
+			},
+			wantOrder: []int{1, 0},
+		},
+		{
+			name: "fresh post is preferred over old one",
+			candidates: []models.RecommendationCandidate{
+				{PostId: uuid.New(), CreatorId: author1, CreatedAt: now.Add(-72 * time.Hour), LikeCount: 3},
+				{PostId: uuid.New(), CreatorId: author2, CreatedAt: now, LikeCount: 3},
+			},
+			wantOrder: []int{1, 0},
+		},
+		{
+			name: "same author is diversified",
+			candidates: []models.RecommendationCandidate{
+				{PostId: uuid.New(), CreatorId: author1, CreatedAt: now, LikeCount: 10},
+				{PostId: uuid.New(), CreatorId: author1, CreatedAt: now, LikeCount: 9},
+				{PostId: uuid.New(), CreatorId: author2, CreatedAt: now, LikeCount: 8},
+			},
+			wantOrder: []int{0, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := usecase.RankCandidates(tt.candidates, cfg, now)
+
+			assert.Len(t, ranked, len(tt.wantOrder))
+			for i, idx := range tt.wantOrder {
+				assert.Equal(t, tt.candidates[idx].PostId, ranked[i].PostId)
+			}
+		})
+	}
+}
+
+func TestRecommendationService_GetRankedPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	seenPost := uuid.New()
+	freshPost := uuid.New()
+	author := uuid.New()
+
+	mockRepo := mocks.NewMockRecommendationRepository(ctrl)
+	mockCache := mocks.NewMockRecommendationCache(ctrl)
+
+	mockCache.EXPECT().TouchActiveUser(gomock.Any(), uid, gomock.Any()).Return(nil)
+	// nothing cached yet, scores are computed on demand
+	mockCache.EXPECT().GetRecommendations(gomock.Any(), uid).Return(nil, nil)
+	mockRepo.EXPECT().GetRecommendationCandidates(gomock.Any(), uid, gomock.Any(), 100).Return([]models.RecommendationCandidate{
+		{PostId: seenPost, CreatorId: author, CreatedAt: time.Now()},
+		{PostId: freshPost, CreatorId: author, CreatedAt: time.Now()},
+		{PostId: uuid.New(), CreatorId: uid, CreatedAt: time.Now()},
+	}, nil)
+	mockCache.EXPECT().GetSeen(gomock.Any(), uid).Return([]uuid.UUID{seenPost}, nil).Times(2)
+	mockCache.EXPECT().SaveRecommendations(gomock.Any(), uid, gomock.Any(), time.Minute).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, posts []models.ScoredPost, _ time.Duration) error {
+			assert.Len(t, posts, 1)
+			return nil
+		})
+
+	service := usecase.NewRecommendationService(mockRepo, mockCache, testRecommendationConfig())
+	ranked, err := service.GetRankedPosts(context.Background(), uid)
+
+	assert.NoError(t, err)
+	assert.Len(t, ranked, 1)
+	assert.Equal(t, freshPost, ranked[0].PostId)
+}
+
+func TestPostService_FetchRecommendations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.New()}
+	first, second, shown, older := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	cursor := models.CursorFromTs(time.Now())
+
+	mockPostRepo := mocks.NewMockPostRepository(ctrl)
+	mockRecommender := mocks.NewMockRecommender(ctrl)
+
+	mockRecommender.EXPECT().GetRankedPosts(gomock.Any(), user.Id).Return([]models.ScoredPost{
+		{PostId: first, Score: 2},
+		{PostId: second, Score: 1},
+	}, nil)
+	// repository does not keep the order of ids
+	mockPostRepo.EXPECT().GetFeedPostsByIds(gomock.Any(), []uuid.UUID{first, second}, user.Id).
+		Return([]models.Post{{Id: second}, {Id: first}}, nil)
+	mockPostRepo.EXPECT().GetRecommendationsForUId(gomock.Any(), user.Id, 3, cursor).
+		Return([]models.Post{
+			{Id: first, CreatedAt: cursor.Ts.Add(-time.Hour)},
+			{Id: shown, CreatedAt: cursor.Ts.Add(-90 * time.Minute)},
+			{Id: older, CreatedAt: cursor.Ts.Add(-2 * time.Hour)},
+		}, nil)
+	// fallback does not repeat posts shown on previous pages
+	mockRecommender.EXPECT().GetSeen(gomock.Any(), user.Id).Return([]uuid.UUID{shown}, nil)
+	mockRecommender.EXPECT().MarkSeen(gomock.Any(), user.Id, []uuid.UUID{first, second, older}).Return(nil)
+	mockBookmarkRepo := mocks.NewMockBookmarkRepository(ctrl)
+	mockBookmarkRepo.EXPECT().GetBookmarkedPosts(gomock.Any(), user.Id, []uuid.UUID{first, second, older}).
+		Return(map[uuid.UUID]bool{second: true}, nil)
+	mockViews := mocks.NewMockViewCounter(ctrl)
+	mockViews.EXPECT().RecordViews(gomock.Any(), user.Id, []uuid.UUID{first, second, older}, gomock.Any()).Return(nil)
+
+	postService := usecase.NewPostService(mockPostRepo, mocks.NewMockFileRepository(ctrl), mocks.NewMockProfileRepository(ctrl), mocks.NewMockFriendsRepository(ctrl), mockRecommender, mocks.NewMockUploadCommitter(ctrl), mocks.NewMockPostMentioner(ctrl), mocks.NewMockPollRepository(ctrl), mockBookmarkRepo, mockViews, mocks.NewMockFeedFilterRepository(ctrl))
+	posts, next, err := postService.FetchRecommendations(context.Background(), user, 3, cursor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first, second, older}, []uuid.UUID{posts[0].Id, posts[1].Id, posts[2].Id})
+	assert.Equal(t, []bool{false, true, false}, []bool{posts[0].IsBookmarked, posts[1].IsBookmarked, posts[2].IsBookmarked})
+	// next page continues chronological fallback after the oldest considered post
+	assert.Equal(t, models.Cursor{Ts: cursor.Ts.Add(-2 * time.Hour), Id: older}, next)
+}
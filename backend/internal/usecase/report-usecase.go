@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+)
+
+var (
+	ErrReportNotFound          = errors.New("report not found")
+	ErrReportTargetNotFound    = errors.New("reported content not found")
+	ErrInvalidReport           = errors.New("invalid report")
+	ErrAlreadyReported         = errors.New("content is already reported by the user")
+	ErrReportResolved          = errors.New("report is already resolved")
+	ErrInvalidModerationAction = errors.New("invalid moderation action")
+	ErrInvalidNumReports       = errors.New("invalid number of reports")
+)
+
+const (
+	maxReportDetailsLength  = 1000
+	maxModerationNoteLength = 1000
+	maxReportsPerPage       = 100
+)
+
+type ReportRepository interface {
+	// GetReportTargetOwner returns the author of the content the reporter is allowed to see.
+	// It returns ErrReportTargetNotFound if there is no such content or the reporter can't see it.
+	GetReportTargetOwner(ctx context.Context, reporterId uuid.UUID, targetType models.ReportTarget, targetId uuid.UUID) (uuid.UUID, error)
+	// AddReport returns ErrAlreadyReported if the reporter has already reported the content.
+	AddReport(ctx context.Context, report models.Report) error
+	// GetReport returns ErrReportNotFound if there is no such report.
+	GetReport(ctx context.Context, reportId uuid.UUID) (models.Report, error)
+	GetReports(ctx context.Context, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error)
+	GetDecisions(ctx context.Context, reportId uuid.UUID) ([]models.ModerationDecision, error)
+	// ReviewReport and ResolveReports return ErrReportResolved if there is nothing left to review or resolve.
+	ReviewReport(ctx context.Context, reportId uuid.UUID, moderatorId uuid.UUID, now time.Time) error
+	// ResolveReports resolves every unresolved report of the content of the report and applies
+	// the resolution in a single transaction. Reported posts are not removed by the repository.
+	ResolveReports(ctx context.Context, report models.Report, moderatorId uuid.UUID, resolution models.ReportResolution, now time.Time) error
+}
+
+// PostRemover removes posts along with their files.
+type PostRemover interface {
+	DeletePost(ctx context.Context, user models.User, postId uuid.UUID) error
+}
+
+type ReportService struct {
+	reportRepo ReportRepository
+	userRepo   UserRepository
+	posts      PostRemover
+}
+
+// NewReportService creates new service of reports of users and the moderation queue.
+func NewReportService(reportRepo ReportRepository, userRepo UserRepository, posts PostRemover) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+		userRepo:   userRepo,
+		posts:      posts,
+	}
+}
+
+// Report files a report of the user about content of another user they are allowed to see.
+// Details are trimmed.
+func (r *ReportService) Report(ctx context.Context, reporterId uuid.UUID, report models.Report) (models.Report, error) {
+	report.Details = strings.TrimSpace(report.Details)
+	switch {
+	case !report.TargetType.IsValid():
+		return models.Report{}, fmt.Errorf("%w: unknown target %q", ErrInvalidReport, report.TargetType)
+	case !report.Reason.IsValid():
+		return models.Report{}, fmt.Errorf("%w: unknown reason %q", ErrInvalidReport, report.Reason)
+	case utf8.RuneCountInString(report.Details) > maxReportDetailsLength:
+		return models.Report{}, fmt.Errorf("%w: details can not be longer than %d characters", ErrInvalidReport, maxReportDetailsLength)
+	}
+
+	ownerId, err := r.reportRepo.GetReportTargetOwner(ctx, reporterId, report.TargetType, report.TargetId)
+	if err != nil {
+		return models.Report{}, fmt.Errorf("r.reportRepo.GetReportTargetOwner: %w", err)
+	}
+	if ownerId == reporterId {
+		return models.Report{}, fmt.Errorf("%w: users can not report themselves", ErrInvalidReport)
+	}
+
+	now := time.Now()
+	report.Id = uuid.New()
+	report.ReporterId = reporterId
+	report.TargetOwnerId = ownerId
+	report.Status = models.ReportStatusOpen
+	report.ModeratorId = uuid.Nil
+	report.CreatedAt = now
+	report.UpdatedAt = now
+	if err = r.reportRepo.AddReport(ctx, report); err != nil {
+		return models.Report{}, fmt.Errorf("r.reportRepo.AddReport: %w", err)
+	}
+	return report, nil
+}
+
+// FetchQueue returns reports with the status older than cursor, newest first.
+func (r *ReportService) FetchQueue(ctx context.Context, moderator models.User, status models.ReportStatus, numReports int, cursor models.Cursor) ([]models.Report, error) {
+	if err := Authorize(moderator, models.PermissionModerateReports); err != nil {
+		return []models.Report{}, err
+	}
+	if numReports <= 0 || numReports > maxReportsPerPage {
+		return []models.Report{}, ErrInvalidNumReports
+	}
+
+	reports, err := r.reportRepo.GetReports(ctx, status, numReports, cursor)
+	if err != nil {
+		return []models.Report{}, fmt.Errorf("r.reportRepo.GetReports: %w", err)
+	}
+	return reports, nil
+}
+
+// FetchReport returns the report with its audit trail, oldest decisions first.
+func (r *ReportService) FetchReport(ctx context.Context, moderator models.User, reportId uuid.UUID) (models.Report, []models.ModerationDecision, error) {
+	if err := Authorize(moderator, models.PermissionModerateReports); err != nil {
+		return models.Report{}, nil, err
+	}
+
+	report, err := r.reportRepo.GetReport(ctx, reportId)
+	if err != nil {
+		return models.Report{}, nil, fmt.Errorf("r.reportRepo.GetReport: %w", err)
+	}
+	decisions, err := r.reportRepo.GetDecisions(ctx, reportId)
+	if err != nil {
+		return models.Report{}, nil, fmt.Errorf("r.reportRepo.GetDecisions: %w", err)
+	}
+	return report, decisions, nil
+}
+
+// ReviewReport takes the unresolved report into review by the moderator.
+func (r *ReportService) ReviewReport(ctx context.Context, moderator models.User, reportId uuid.UUID) error {
+	if err := Authorize(moderator, models.PermissionModerateReports); err != nil {
+		return err
+	}
+
+	report, err := r.reportRepo.GetReport(ctx, reportId)
+	if err != nil {
+		return fmt.Errorf("r.reportRepo.GetReport: %w", err)
+	}
+	if report.Status == models.ReportStatusResolved {
+		return ErrReportResolved
+	}
+
+	if err = r.reportRepo.ReviewReport(ctx, reportId, moderator.Id, time.Now()); err != nil {
+		return fmt.Errorf("r.reportRepo.ReviewReport: %w", err)
+	}
+	return nil
+}
+
+// ResolveReport applies the decision of the moderator to the reported content and resolves
+// every unresolved report of the content. Note is trimmed, the note of a ban is its reason
+// and defaults to the reason of the report. Banning also requires permission to manage users
+// and is only allowed for authors of a lower role.
+func (r *ReportService) ResolveReport(ctx context.Context, moderator models.User, reportId uuid.UUID, resolution models.ReportResolution) error {
+	if err := Authorize(moderator, models.PermissionModerateReports); err != nil {
+		return err
+	}
+	if resolution.Action == models.ModerationBanUser {
+		if err := Authorize(moderator, models.PermissionManageUsers); err != nil {
+			return err
+		}
+	}
+
+	resolution.Note = strings.TrimSpace(resolution.Note)
+	if !resolution.Action.IsValid() {
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidModerationAction, resolution.Action)
+	}
+	if utf8.RuneCountInString(resolution.Note) > maxModerationNoteLength {
+		return fmt.Errorf("%w: note can not be longer than %d characters", ErrInvalidModerationAction, maxModerationNoteLength)
+	}
+
+	report, err := r.reportRepo.GetReport(ctx, reportId)
+	if err != nil {
+		return fmt.Errorf("r.reportRepo.GetReport: %w", err)
+	}
+	if report.Status == models.ReportStatusResolved {
+		return ErrReportResolved
+	}
+
+	now := time.Now()
+	switch resolution.Action {
+	case models.ModerationRemoveContent:
+		if report.TargetType == models.ReportTargetProfile {
+			return fmt.Errorf("%w: profiles can not be removed, ban the user instead", ErrInvalidModerationAction)
+		}
+	case models.ModerationWarnUser, models.ModerationBanUser:
+		if report.TargetOwnerId == uuid.Nil {
+			return fmt.Errorf("%w: author of the content is deleted", ErrInvalidModerationAction)
+		}
+	}
+	if resolution.Action == models.ModerationBanUser {
+		if !resolution.BanUntil.IsZero() && !resolution.BanUntil.After(now) {
+			return fmt.Errorf("%w: ban must end in the future", ErrInvalidModerationAction)
+		}
+		if resolution.Note == "" {
+			resolution.Note = string(report.Reason)
+		}
+
+		author, err := r.userRepo.GetUserByUId(ctx, report.TargetOwnerId)
+		if err != nil {
+			return fmt.Errorf("r.userRepo.GetUserByUId: %w", err)
+		}
+		if !moderator.Role.Outranks(author.Role) {
+			return fmt.Errorf("%w: %s has equal or higher role", ErrForbidden, author.Username)
+		}
+	}
+
+	if resolution.Action == models.ModerationRemoveContent && report.TargetType == models.ReportTargetPost {
+		err = r.posts.DeletePost(ctx, moderator, report.TargetId)
+		// the post may be already removed by its author
+		if err != nil && !errors.Is(err, ErrPostNotFound) {
+			return fmt.Errorf("r.posts.DeletePost: %w", err)
+		}
+	}
+
+	if err = r.reportRepo.ResolveReports(ctx, report, moderator.Id, resolution, now); err != nil {
+		return fmt.Errorf("r.reportRepo.ResolveReports: %w", err)
+	}
+	return nil
+}
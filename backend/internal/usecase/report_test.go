@@ -0,0 +1,325 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+var (
+	moderator = models.User{Id: uuid.New(), Username: "moderator", Role: models.RoleModerator}
+	admin     = models.User{Id: uuid.New(), Username: "admin", Role: models.RoleAdmin}
+)
+
+func TestReportService_Report(t *testing.T) {
+	reporterId, ownerId, targetId := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		report      models.Report
+		ownerId     uuid.UUID
+		ownerErr    error
+		expectOwner bool
+		addErr      error
+		expectAdd   bool
+		expectedErr error
+	}{
+		{
+			name:        "comment",
+			report:      models.Report{TargetType: models.ReportTargetComment, TargetId: targetId, Reason: models.ReportReasonSpam, Details: "  ads  "},
+			ownerId:     ownerId,
+			expectOwner: true,
+			expectAdd:   true,
+		},
+		{
+			name:        "unknown target",
+			report:      models.Report{TargetType: "story", TargetId: targetId, Reason: models.ReportReasonSpam},
+			expectedErr: usecase.ErrInvalidReport,
+		},
+		{
+			name:        "unknown reason",
+			report:      models.Report{TargetType: models.ReportTargetPost, TargetId: targetId, Reason: "boring"},
+			expectedErr: usecase.ErrInvalidReport,
+		},
+		{
+			name:        "too long details",
+			report:      models.Report{TargetType: models.ReportTargetPost, TargetId: targetId, Reason: models.ReportReasonOther, Details: strings.Repeat("я", 1001)},
+			expectedErr: usecase.ErrInvalidReport,
+		},
+		{
+			name:        "invisible post",
+			report:      models.Report{TargetType: models.ReportTargetPost, TargetId: targetId, Reason: models.ReportReasonHate},
+			ownerErr:    usecase.ErrReportTargetNotFound,
+			expectOwner: true,
+			expectedErr: usecase.ErrReportTargetNotFound,
+		},
+		{
+			name:        "own profile",
+			report:      models.Report{TargetType: models.ReportTargetProfile, TargetId: reporterId, Reason: models.ReportReasonHarassment},
+			ownerId:     reporterId,
+			expectOwner: true,
+			expectedErr: usecase.ErrInvalidReport,
+		},
+		{
+			name:        "reported twice",
+			report:      models.Report{TargetType: models.ReportTargetMessage, TargetId: targetId, Reason: models.ReportReasonSpam},
+			ownerId:     ownerId,
+			expectOwner: true,
+			addErr:      usecase.ErrAlreadyReported,
+			expectAdd:   true,
+			expectedErr: usecase.ErrAlreadyReported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := mocks.NewMockReportRepository(ctrl)
+			if tt.expectOwner {
+				mockReportRepo.EXPECT().GetReportTargetOwner(gomock.Any(), reporterId, tt.report.TargetType, tt.report.TargetId).Return(tt.ownerId, tt.ownerErr)
+			}
+			if tt.expectAdd {
+				mockReportRepo.EXPECT().AddReport(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report models.Report) error {
+					assert.Equal(t, reporterId, report.ReporterId)
+					assert.Equal(t, ownerId, report.TargetOwnerId)
+					assert.Equal(t, models.ReportStatusOpen, report.Status)
+					assert.Equal(t, strings.TrimSpace(tt.report.Details), report.Details)
+					return tt.addErr
+				})
+			}
+
+			reportService := usecase.NewReportService(mockReportRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockPostRemover(ctrl))
+			report, err := reportService.Report(context.Background(), reporterId, tt.report)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, report.Id)
+			assert.Equal(t, models.ReportStatusOpen, report.Status)
+		})
+	}
+}
+
+func TestReportService_FetchQueue(t *testing.T) {
+	cursor := models.CursorFromTs(time.Now())
+
+	tests := []struct {
+		name        string
+		user        models.User
+		numReports  int
+		expectFetch bool
+		expectedErr error
+	}{
+		{name: "moderator", user: moderator, numReports: 20, expectFetch: true},
+		{name: "regular user", user: models.User{Id: uuid.New(), Username: "ivan"}, numReports: 20, expectedErr: usecase.ErrForbidden},
+		{name: "too many reports", user: moderator, numReports: 101, expectedErr: usecase.ErrInvalidNumReports},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reports := []models.Report{{Id: uuid.New(), Status: models.ReportStatusOpen}}
+			mockReportRepo := mocks.NewMockReportRepository(ctrl)
+			if tt.expectFetch {
+				mockReportRepo.EXPECT().GetReports(gomock.Any(), models.ReportStatusOpen, tt.numReports, cursor).Return(reports, nil)
+			}
+
+			reportService := usecase.NewReportService(mockReportRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockPostRemover(ctrl))
+			got, err := reportService.FetchQueue(context.Background(), tt.user, models.ReportStatusOpen, tt.numReports, cursor)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, reports, got)
+		})
+	}
+}
+
+func TestReportService_ResolveReport(t *testing.T) {
+	ownerId := uuid.New()
+	postReport := models.Report{Id: uuid.New(), TargetType: models.ReportTargetPost, TargetId: uuid.New(), TargetOwnerId: ownerId, Reason: models.ReportReasonSpam, Status: models.ReportStatusInReview}
+	profileReport := models.Report{Id: uuid.New(), TargetType: models.ReportTargetProfile, TargetId: ownerId, TargetOwnerId: ownerId, Reason: models.ReportReasonHate, Status: models.ReportStatusOpen}
+	orphanReport := models.Report{Id: uuid.New(), TargetType: models.ReportTargetComment, TargetId: uuid.New(), Reason: models.ReportReasonSpam, Status: models.ReportStatusOpen}
+
+	tests := []struct {
+		name          string
+		user          models.User
+		report        models.Report
+		resolution    models.ReportResolution
+		expectGet     bool
+		author        models.User
+		expectAuthor  bool
+		deletePostErr error
+		expectDelete  bool
+		expectResolve bool
+		expectedNote  string
+		expectedErr   error
+	}{
+		{
+			name:          "remove post",
+			user:          moderator,
+			report:        postReport,
+			resolution:    models.ReportResolution{Action: models.ModerationRemoveContent, Note: " spam links "},
+			expectGet:     true,
+			expectDelete:  true,
+			expectResolve: true,
+			expectedNote:  "spam links",
+		},
+		{
+			name:          "post already removed by author",
+			user:          moderator,
+			report:        postReport,
+			resolution:    models.ReportResolution{Action: models.ModerationRemoveContent},
+			expectGet:     true,
+			deletePostErr: usecase.ErrPostNotFound,
+			expectDelete:  true,
+			expectResolve: true,
+		},
+		{
+			name:          "ban without note",
+			user:          admin,
+			report:        profileReport,
+			resolution:    models.ReportResolution{Action: models.ModerationBanUser, BanUntil: time.Now().Add(24 * time.Hour)},
+			expectGet:     true,
+			author:        models.User{Id: ownerId, Username: "spammer", Role: models.RoleUser},
+			expectAuthor:  true,
+			expectResolve: true,
+			expectedNote:  string(models.ReportReasonHate),
+		},
+		{
+			name:        "moderator can't ban",
+			user:        moderator,
+			report:      profileReport,
+			resolution:  models.ReportResolution{Action: models.ModerationBanUser},
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:         "ban of another admin",
+			user:         admin,
+			report:       profileReport,
+			resolution:   models.ReportResolution{Action: models.ModerationBanUser},
+			expectGet:    true,
+			author:       models.User{Id: ownerId, Username: "root", Role: models.RoleAdmin},
+			expectAuthor: true,
+			expectedErr:  usecase.ErrForbidden,
+		},
+		{
+			name:        "regular user",
+			user:        models.User{Id: uuid.New(), Username: "ivan"},
+			report:      postReport,
+			resolution:  models.ReportResolution{Action: models.ModerationDismiss},
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "unknown action",
+			user:        moderator,
+			report:      postReport,
+			resolution:  models.ReportResolution{Action: "shadow_ban"},
+			expectedErr: usecase.ErrInvalidModerationAction,
+		},
+		{
+			name:        "remove profile",
+			user:        moderator,
+			report:      profileReport,
+			resolution:  models.ReportResolution{Action: models.ModerationRemoveContent},
+			expectGet:   true,
+			expectedErr: usecase.ErrInvalidModerationAction,
+		},
+		{
+			name:        "warn deleted author",
+			user:        moderator,
+			report:      orphanReport,
+			resolution:  models.ReportResolution{Action: models.ModerationWarnUser},
+			expectGet:   true,
+			expectedErr: usecase.ErrInvalidModerationAction,
+		},
+		{
+			name:        "ban ended in the past",
+			user:        admin,
+			report:      profileReport,
+			resolution:  models.ReportResolution{Action: models.ModerationBanUser, BanUntil: time.Now().Add(-time.Hour)},
+			expectGet:   true,
+			expectedErr: usecase.ErrInvalidModerationAction,
+		},
+		{
+			name:        "resolved report",
+			user:        moderator,
+			report:      models.Report{Id: uuid.New(), Status: models.ReportStatusResolved},
+			resolution:  models.ReportResolution{Action: models.ModerationDismiss},
+			expectGet:   true,
+			expectedErr: usecase.ErrReportResolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := mocks.NewMockReportRepository(ctrl)
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockPostRemover := mocks.NewMockPostRemover(ctrl)
+			if tt.expectGet {
+				mockReportRepo.EXPECT().GetReport(gomock.Any(), tt.report.Id).Return(tt.report, nil)
+			}
+			if tt.expectAuthor {
+				mockUserRepo.EXPECT().GetUserByUId(gomock.Any(), tt.report.TargetOwnerId).Return(tt.author, nil)
+			}
+			if tt.expectDelete {
+				mockPostRemover.EXPECT().DeletePost(gomock.Any(), tt.user, tt.report.TargetId).Return(tt.deletePostErr)
+			}
+			if tt.expectResolve {
+				mockReportRepo.EXPECT().ResolveReports(gomock.Any(), tt.report, tt.user.Id, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ models.Report, _ uuid.UUID, resolution models.ReportResolution, _ time.Time) error {
+						assert.Equal(t, tt.resolution.Action, resolution.Action)
+						assert.Equal(t, tt.expectedNote, resolution.Note)
+						return nil
+					})
+			}
+
+			reportService := usecase.NewReportService(mockReportRepo, mockUserRepo, mockPostRemover)
+			err := reportService.ResolveReport(context.Background(), tt.user, tt.report.Id, tt.resolution)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReportService_ResolveReport_DeletePostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	report := models.Report{Id: uuid.New(), TargetType: models.ReportTargetPost, TargetId: uuid.New(), TargetOwnerId: uuid.New(), Status: models.ReportStatusOpen}
+	mockReportRepo := mocks.NewMockReportRepository(ctrl)
+	mockPostRemover := mocks.NewMockPostRemover(ctrl)
+	mockReportRepo.EXPECT().GetReport(gomock.Any(), report.Id).Return(report, nil)
+	mockPostRemover.EXPECT().DeletePost(gomock.Any(), moderator, report.TargetId).Return(errors.New("minio is down"))
+	// reports stay unresolved while the post is not removed, so ResolveReports expects no calls
+
+	reportService := usecase.NewReportService(mockReportRepo, mocks.NewMockUserRepository(ctrl), mockPostRemover)
+	err := reportService.ResolveReport(context.Background(), moderator, report.Id, models.ReportResolution{Action: models.ModerationRemoveContent})
+	assert.Error(t, err)
+}
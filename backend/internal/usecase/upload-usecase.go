@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quickflow/internal/models"
+	"quickflow/pkg/logger"
+	probe "quickflow/pkg/media"
+)
+
+const (
+	maxUploadsPerRequest = 10
+	// sniffLength is how much of uploaded object is read to detect its real type.
+	sniffLength = 512
+)
+
+var (
+	ErrInvalidUpload  = errors.New("invalid upload")
+	ErrTooManyUploads = errors.New("too many uploads")
+)
+
+// UploadStorage is file storage clients can upload objects to directly.
+type UploadStorage interface {
+	PresignUpload(ctx context.Context, purpose models.FilePurpose, fileName string, size int64, mimeType string, expires time.Duration) (string, map[string]string, error)
+	StatFile(ctx context.Context, purpose models.FilePurpose, fileName string) (models.StoredFile, error)
+	OpenFile(ctx context.Context, purpose models.FilePurpose, fileName string) (io.ReadCloser, error)
+	GetFileURL(ctx context.Context, purpose models.FilePurpose, fileName string) (string, error)
+	DeleteFile(ctx context.Context, purpose models.FilePurpose, fileName string) error
+}
+
+type UploadSessionRepository interface {
+	SaveUploadSession(ctx context.Context, session models.UploadSession) error
+	TakeUploadSession(ctx context.Context, key string) (models.UploadSession, error)
+}
+
+// UploadCommitter turns directly uploaded objects into file URLs that can be saved.
+type UploadCommitter interface {
+	CommitUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, keys []string) ([]string, error)
+}
+
+type UploadService struct {
+	storage  UploadStorage
+	sessions UploadSessionRepository
+	fileRepo FileRepository
+	expires  time.Duration
+}
+
+// NewUploadService creates new service that issues and verifies direct uploads.
+// Every object is passed through fileRepo on commit so it gets the same
+// variants and validation as multipart uploads.
+func NewUploadService(storage UploadStorage, sessions UploadSessionRepository, fileRepo FileRepository, expires time.Duration) *UploadService {
+	return &UploadService{
+		storage:  storage,
+		sessions: sessions,
+		fileRepo: fileRepo,
+		expires:  expires,
+	}
+}
+
+// CreateUploads returns presigned URLs the user can upload files of given purpose to.
+// Size and content type are signed, so storage rejects uploads that differ from the request.
+// Only content types allowed for the purpose can be requested.
+func (u *UploadService) CreateUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, requests []models.UploadRequest) ([]models.PresignedUpload, error) {
+	if !slices.Contains(models.FilePurposes, purpose) {
+		return nil, fmt.Errorf("%w: unknown purpose %q", ErrInvalidUpload, purpose)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("%w: no files", ErrInvalidUpload)
+	}
+	if len(requests) > maxUploadsPerRequest {
+		return nil, ErrTooManyUploads
+	}
+
+	uploads := make([]models.PresignedUpload, 0, len(requests))
+	for _, req := range requests {
+		if req.Size <= 0 || len(req.MimeType) == 0 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUpload, req.Name)
+		}
+		if !models.IsUploadAllowed(purpose, req.MimeType) {
+			return nil, fmt.Errorf("%w: %v of type %v can't be uploaded as %v", ErrInvalidUpload, req.Name, req.MimeType, purpose)
+		}
+
+		session := models.UploadSession{
+			Key:       uuid.New().String() + path.Ext(req.Name),
+			UserId:    userId,
+			Purpose:   purpose,
+			Size:      req.Size,
+			MimeType:  req.MimeType,
+			ExpiresAt: time.Now().Add(u.expires),
+		}
+
+		url, headers, err := u.storage.PresignUpload(ctx, purpose, session.Key, req.Size, req.MimeType, u.expires)
+		if err != nil {
+			return nil, fmt.Errorf("u.storage.PresignUpload: %w", err)
+		}
+		if err = u.sessions.SaveUploadSession(ctx, session); err != nil {
+			return nil, fmt.Errorf("u.sessions.SaveUploadSession: %w", err)
+		}
+
+		uploads = append(uploads, models.PresignedUpload{
+			Key:       session.Key,
+			URL:       url,
+			Headers:   headers,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+
+	return uploads, nil
+}
+
+// CommitUploads checks that objects were uploaded by the user exactly as requested
+// and returns their URLs in order of keys. Every key can be committed only once:
+// sessions are taken before processing and given back only if the commit fails.
+// Uploaded files are re-stored through file repository and the original object is removed.
+func (u *UploadService) CommitUploads(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if len(keys) > maxUploadsPerRequest {
+		return nil, ErrTooManyUploads
+	}
+
+	// check everything before processing, so a bad key does not leave half of files processed
+	sessions := make([]models.UploadSession, 0, len(keys))
+	for _, key := range keys {
+		session, err := u.takeUpload(ctx, userId, purpose, key)
+		if err != nil {
+			u.releaseUploads(ctx, sessions)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	urls := make([]string, len(sessions))
+	for i, session := range sessions {
+		url, err := u.commitUpload(ctx, session)
+		if err != nil {
+			u.releaseUploads(ctx, sessions[i:])
+			return nil, err
+		}
+		urls[i] = url
+	}
+
+	return urls, nil
+}
+
+// takeUpload claims upload session of the key and checks the object matches it.
+// Session that does not belong to the user is given back untouched.
+func (u *UploadService) takeUpload(ctx context.Context, userId uuid.UUID, purpose models.FilePurpose, key string) (models.UploadSession, error) {
+	session, err := u.sessions.TakeUploadSession(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return models.UploadSession{}, fmt.Errorf("%w: unknown key %v", ErrInvalidUpload, key)
+	} else if err != nil {
+		return models.UploadSession{}, fmt.Errorf("u.sessions.TakeUploadSession: %w", err)
+	}
+	if session.UserId != userId || session.Purpose != purpose {
+		u.releaseUploads(ctx, []models.UploadSession{session})
+		return models.UploadSession{}, fmt.Errorf("%w: unknown key %v", ErrInvalidUpload, key)
+	}
+
+	if err = u.verifyUpload(ctx, session); err != nil {
+		u.releaseUploads(ctx, []models.UploadSession{session})
+		return models.UploadSession{}, err
+	}
+	return session, nil
+}
+
+func (u *UploadService) verifyUpload(ctx context.Context, session models.UploadSession) error {
+	stored, err := u.storage.StatFile(ctx, session.Purpose, session.Key)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: %v is not uploaded", ErrInvalidUpload, session.Key)
+	} else if err != nil {
+		return fmt.Errorf("u.storage.StatFile: %w", err)
+	}
+	if stored.Size != session.Size || stored.MimeType != session.MimeType {
+		return fmt.Errorf("%w: %v does not match requested file", ErrInvalidUpload, session.Key)
+	}
+	return nil
+}
+
+// releaseUploads gives back sessions of objects that were not committed, so the client can retry.
+func (u *UploadService) releaseUploads(ctx context.Context, sessions []models.UploadSession) {
+	for _, session := range sessions {
+		if !time.Now().Before(session.ExpiresAt) {
+			continue
+		}
+		if err := u.sessions.SaveUploadSession(ctx, session); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Unable to give back upload session %v: %s", session.Key, err.Error()))
+		}
+	}
+}
+
+// commitUpload re-stores the object through file repository. Declared type is not trusted,
+// the beginning of the object is sniffed and must be allowed for the purpose of the upload.
+func (u *UploadService) commitUpload(ctx context.Context, session models.UploadSession) (string, error) {
+	reader, err := u.storage.OpenFile(ctx, session.Purpose, session.Key)
+	if err != nil {
+		return "", fmt.Errorf("u.storage.OpenFile: %w", err)
+	}
+	defer reader.Close()
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("unable to read uploaded file %v: %w", session.Key, err)
+	}
+	head = head[:n]
+	if detected := probe.DetectContentType(head); !models.IsUploadAllowed(session.Purpose, detected) {
+		return "", fmt.Errorf("%w: content of %v is %v", ErrInvalidUpload, session.Key, detected)
+	}
+
+	url, err := u.fileRepo.UploadFile(ctx, &models.File{
+		Reader:   io.MultiReader(bytes.NewReader(head), reader),
+		Name:     session.Key,
+		Size:     session.Size,
+		Ext:      path.Ext(session.Key),
+		MimeType: session.MimeType,
+		Purpose:  session.Purpose,
+	})
+	if err != nil {
+		return "", fmt.Errorf("u.fileRepo.UploadFile: %w", err)
+	}
+
+	if err = u.storage.DeleteFile(ctx, session.Purpose, session.Key); err != nil {
+		// original is not referenced, file gc will remove it later
+		logger.Error(ctx, fmt.Sprintf("Unable to delete original of uploaded file %v: %s", session.Key, err.Error()))
+	}
+	return url, nil
+}
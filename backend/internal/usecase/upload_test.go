@@ -0,0 +1,295 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quickflow/internal/models"
+	"quickflow/internal/usecase"
+	"quickflow/internal/usecase/mocks"
+)
+
+func TestUploadService_CreateUploads(t *testing.T) {
+	userId := uuid.New()
+	video := models.UploadRequest{Name: "clip.mp4", Size: 1024, MimeType: "video/mp4"}
+
+	tests := []struct {
+		name       string
+		purpose    models.FilePurpose
+		requests   []models.UploadRequest
+		setupMocks func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository)
+		wantErr    error
+	}{
+		{
+			name:     "upload is presigned and remembered",
+			purpose:  models.FilePurposeAttachment,
+			requests: []models.UploadRequest{video},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {
+				storage.EXPECT().PresignUpload(gomock.Any(), models.FilePurposeAttachment, gomock.Any(), int64(1024), "video/mp4", time.Hour).
+					Return("http://minio/attachments/key?sig", map[string]string{"Content-Type": "video/mp4"}, nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, session models.UploadSession) error {
+						assert.Equal(t, userId, session.UserId)
+						assert.Equal(t, models.FilePurposeAttachment, session.Purpose)
+						assert.True(t, strings.HasSuffix(session.Key, ".mp4"))
+						return nil
+					})
+			},
+		},
+		{
+			name:       "unknown purpose",
+			purpose:    "secret",
+			requests:   []models.UploadRequest{video},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {},
+			wantErr:    usecase.ErrInvalidUpload,
+		},
+		{
+			name:       "empty file",
+			purpose:    models.FilePurposePost,
+			requests:   []models.UploadRequest{{Name: "a.jpg", MimeType: "image/jpeg"}},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {},
+			wantErr:    usecase.ErrInvalidUpload,
+		},
+		{
+			name:       "html is not an attachment",
+			purpose:    models.FilePurposeAttachment,
+			requests:   []models.UploadRequest{{Name: "page.html", Size: 10, MimeType: "text/html"}},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {},
+			wantErr:    usecase.ErrInvalidUpload,
+		},
+		{
+			name:       "avatar must be an image",
+			purpose:    models.FilePurposeAvatar,
+			requests:   []models.UploadRequest{{Name: "cv.pdf", Size: 10, MimeType: "application/pdf"}},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {},
+			wantErr:    usecase.ErrInvalidUpload,
+		},
+		{
+			name:       "too many files",
+			purpose:    models.FilePurposePost,
+			requests:   make([]models.UploadRequest, 11),
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {},
+			wantErr:    usecase.ErrTooManyUploads,
+		},
+		{
+			name:     "file exceeds limit of purpose",
+			purpose:  models.FilePurposeAvatar,
+			requests: []models.UploadRequest{{Name: "a.jpg", Size: 1 << 30, MimeType: "image/jpeg"}},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository) {
+				storage.EXPECT().PresignUpload(gomock.Any(), models.FilePurposeAvatar, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", nil, usecase.ErrFileTooLarge)
+			},
+			wantErr: usecase.ErrFileTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := mocks.NewMockUploadStorage(ctrl)
+			sessions := mocks.NewMockUploadSessionRepository(ctrl)
+			tt.setupMocks(storage, sessions)
+
+			service := usecase.NewUploadService(storage, sessions, mocks.NewMockFileRepository(ctrl), time.Hour)
+			uploads, err := service.CreateUploads(context.Background(), userId, tt.purpose, tt.requests)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, uploads, 1)
+			assert.Equal(t, "http://minio/attachments/key?sig", uploads[0].URL)
+			assert.Equal(t, "video/mp4", uploads[0].Headers["Content-Type"])
+		})
+	}
+}
+
+func TestUploadService_CommitUploads(t *testing.T) {
+	userId := uuid.New()
+	expires := time.Now().Add(time.Hour)
+	video := models.UploadSession{Key: "v.mp4", UserId: userId, Purpose: models.FilePurposePost, Size: 100, MimeType: "video/mp4", ExpiresAt: expires}
+	photo := models.UploadSession{Key: "p.jpg", UserId: userId, Purpose: models.FilePurposePost, Size: 50, MimeType: "image/jpeg", ExpiresAt: expires}
+	document := models.UploadSession{Key: "d.pdf", UserId: userId, Purpose: models.FilePurposePost, Size: 70, MimeType: "application/pdf", ExpiresAt: expires}
+
+	const (
+		mp4Content  = "\x00\x00\x00\x18ftypisom\x00\x00\x02\x00isomiso2"
+		jpegContent = "\xff\xd8\xff\xe0\x00\x10JFIF\x00"
+		pdfContent  = "%PDF-1.7\n"
+	)
+	open := func(content string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(content)), nil
+	}
+
+	tests := []struct {
+		name       string
+		keys       []string
+		setupMocks func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository)
+		want       []string
+		wantErr    error
+	}{
+		{
+			name: "every file is re-stored through file repository",
+			keys: []string{"d.pdf", "v.mp4", "p.jpg"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "d.pdf").Return(document, nil)
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(video, nil)
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "p.jpg").Return(photo, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "d.pdf").Return(models.StoredFile{Size: 70, MimeType: "application/pdf"}, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{Size: 100, MimeType: "video/mp4"}, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "p.jpg").Return(models.StoredFile{Size: 50, MimeType: "image/jpeg"}, nil)
+
+				storage.EXPECT().OpenFile(gomock.Any(), models.FilePurposePost, "d.pdf").Return(open(pdfContent))
+				storage.EXPECT().OpenFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(open(mp4Content))
+				storage.EXPECT().OpenFile(gomock.Any(), models.FilePurposePost, "p.jpg").Return(open(jpegContent))
+				gomock.InOrder(
+					files.EXPECT().UploadFile(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, file *models.File) (string, error) {
+							assert.Equal(t, "application/pdf", file.MimeType)
+							data, err := io.ReadAll(file.Reader)
+							require.NoError(t, err)
+							assert.Equal(t, pdfContent, string(data))
+							return "http://minio/posts/z.pdf", nil
+						}),
+					files.EXPECT().UploadFile(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, file *models.File) (string, error) {
+							assert.Equal(t, "video/mp4", file.MimeType)
+							return "http://minio/posts/y.mp4", nil
+						}),
+					files.EXPECT().UploadFile(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, file *models.File) (string, error) {
+							assert.Equal(t, "image/jpeg", file.MimeType)
+							assert.Equal(t, models.FilePurposePost, file.Purpose)
+							return "http://minio/posts/x_full.jpg", nil
+						}),
+				)
+				storage.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "d.pdf").Return(nil)
+				storage.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(nil)
+				storage.EXPECT().DeleteFile(gomock.Any(), models.FilePurposePost, "p.jpg").Return(nil)
+			},
+			want: []string{"http://minio/posts/z.pdf", "http://minio/posts/y.mp4", "http://minio/posts/x_full.jpg"},
+		},
+		{
+			name: "expired or already committed session",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(models.UploadSession{}, usecase.ErrNotFound)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "upload of another user is given back",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				foreign := video
+				foreign.UserId = uuid.New()
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(foreign, nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), foreign).Return(nil)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "upload of another purpose",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				attachment := video
+				attachment.Purpose = models.FilePurposeAttachment
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(attachment, nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), attachment).Return(nil)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "file was not uploaded",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(video, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{}, usecase.ErrNotFound)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), video).Return(nil)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "uploaded file differs from requested, nothing is processed",
+			keys: []string{"p.jpg", "v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "p.jpg").Return(photo, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "p.jpg").Return(models.StoredFile{Size: 50, MimeType: "image/jpeg"}, nil)
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(video, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{Size: 100, MimeType: "text/html"}, nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), video).Return(nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), photo).Return(nil)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "content is not what was declared",
+			keys: []string{"d.pdf", "v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "d.pdf").Return(document, nil)
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(video, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "d.pdf").Return(models.StoredFile{Size: 70, MimeType: "application/pdf"}, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{Size: 100, MimeType: "video/mp4"}, nil)
+				storage.EXPECT().OpenFile(gomock.Any(), models.FilePurposePost, "d.pdf").Return(open("<html><script>alert(1)</script></html>"))
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), document).Return(nil)
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), video).Return(nil)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "expired session is not given back",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				expired := video
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(expired, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{}, usecase.ErrNotFound)
+			},
+			wantErr: usecase.ErrInvalidUpload,
+		},
+		{
+			name: "storage error",
+			keys: []string{"v.mp4"},
+			setupMocks: func(storage *mocks.MockUploadStorage, sessions *mocks.MockUploadSessionRepository, files *mocks.MockFileRepository) {
+				sessions.EXPECT().TakeUploadSession(gomock.Any(), "v.mp4").Return(video, nil)
+				storage.EXPECT().StatFile(gomock.Any(), models.FilePurposePost, "v.mp4").Return(models.StoredFile{}, errors.New("storage error"))
+				sessions.EXPECT().SaveUploadSession(gomock.Any(), video).Return(nil)
+			},
+			wantErr: errors.New("storage error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := mocks.NewMockUploadStorage(ctrl)
+			sessions := mocks.NewMockUploadSessionRepository(ctrl)
+			files := mocks.NewMockFileRepository(ctrl)
+			tt.setupMocks(storage, sessions, files)
+
+			service := usecase.NewUploadService(storage, sessions, files, time.Hour)
+			urls, err := service.CommitUploads(context.Background(), userId, models.FilePurposePost, tt.keys)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, usecase.ErrInvalidUpload) {
+					assert.ErrorIs(t, err, usecase.ErrInvalidUpload)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, urls)
+		})
+	}
+}
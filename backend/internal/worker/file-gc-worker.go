@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+type FileCollector interface {
+	CollectScheduled(ctx context.Context) error
+}
+
+// FileGCWorker periodically removes orphaned objects from file storage.
+type FileGCWorker struct {
+	collector FileCollector
+	interval  time.Duration
+}
+
+// NewFileGCWorker creates new file gc worker.
+func NewFileGCWorker(collector FileCollector, interval time.Duration) *FileGCWorker {
+	return &FileGCWorker{
+		collector: collector,
+		interval:  interval,
+	}
+}
+
+// Run collects orphaned files every interval until ctx is done.
+func (w *FileGCWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.interval, "collect orphaned files", w.collector.CollectScheduled)
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"quickflow/pkg/logger"
+)
+
+// runPeriodically runs the job right away and then every interval until ctx is done.
+// Failed runs are logged with the description of the job and do not stop the worker.
+func runPeriodically(ctx context.Context, interval time.Duration, description string, job func(ctx context.Context) error) {
+	if interval <= 0 {
+		logger.Error(ctx, fmt.Sprintf("Unable to start worker to %s: interval %v is not positive", description, interval))
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Worker failed to %s: %s", description, err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
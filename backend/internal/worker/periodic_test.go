@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		// failed runs do not stop the worker
+		runPeriodically(ctx, time.Millisecond, "test", func(ctx context.Context) error {
+			if runs.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("job failed")
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after ctx was done")
+	}
+	// the tick may win over cancellation once more
+	assert.GreaterOrEqual(t, runs.Load(), int32(3))
+}
+
+func TestRunPeriodically_RunsRightAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan struct{}, 1)
+
+	go runPeriodically(ctx, time.Hour, "test", func(ctx context.Context) error {
+		started <- struct{}{}
+		return nil
+	})
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run before the first tick")
+	}
+}
+
+func TestRunPeriodically_InvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		assert.NotPanics(t, func() {
+			runPeriodically(context.Background(), interval, "test", func(ctx context.Context) error {
+				t.Error("job must not run with invalid interval")
+				return nil
+			})
+		})
+	}
+}
+
+type publisherFunc func(ctx context.Context) error
+
+func (f publisherFunc) PublishDuePosts(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestPostPublishWorker_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+
+	NewPostPublishWorker(publisherFunc(func(ctx context.Context) error {
+		runs.Add(1)
+		cancel()
+		return nil
+	}), time.Minute).Run(ctx)
+
+	assert.Equal(t, int32(1), runs.Load())
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+type PostPublisher interface {
+	PublishDuePosts(ctx context.Context) error
+}
+
+// PostPublishWorker periodically publishes scheduled posts whose time has come.
+type PostPublishWorker struct {
+	publisher PostPublisher
+	interval  time.Duration
+}
+
+// NewPostPublishWorker creates new post publish worker.
+func NewPostPublishWorker(publisher PostPublisher, interval time.Duration) *PostPublishWorker {
+	return &PostPublishWorker{
+		publisher: publisher,
+		interval:  interval,
+	}
+}
+
+// Run publishes due posts every interval until ctx is done.
+func (w *PostPublishWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.interval, "publish scheduled posts", w.publisher.PublishDuePosts)
+}
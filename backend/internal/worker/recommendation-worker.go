@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+type RecommendationRefresher interface {
+	RefreshActiveUsers(ctx context.Context) error
+}
+
+// RecommendationWorker periodically recomputes cached recommendation scores.
+type RecommendationWorker struct {
+	refresher RecommendationRefresher
+	interval  time.Duration
+}
+
+// NewRecommendationWorker creates new recommendation worker.
+func NewRecommendationWorker(refresher RecommendationRefresher, interval time.Duration) *RecommendationWorker {
+	return &RecommendationWorker{
+		refresher: refresher,
+		interval:  interval,
+	}
+}
+
+// Run refreshes recommendations every interval until ctx is done.
+func (w *RecommendationWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.interval, "refresh recommendations", w.refresher.RefreshActiveUsers)
+}
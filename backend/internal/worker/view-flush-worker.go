@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+type ViewFlusher interface {
+	FlushViews(ctx context.Context) error
+}
+
+// ViewFlushWorker periodically saves post views buffered in redis to the database.
+type ViewFlushWorker struct {
+	flusher  ViewFlusher
+	interval time.Duration
+}
+
+// NewViewFlushWorker creates new view flush worker.
+func NewViewFlushWorker(flusher ViewFlusher, interval time.Duration) *ViewFlushWorker {
+	return &ViewFlushWorker{
+		flusher:  flusher,
+		interval: interval,
+	}
+}
+
+// Run flushes views every interval until ctx is done.
+func (w *ViewFlushWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.interval, "flush post views", w.flusher.FlushViews)
+}
@@ -6,20 +6,34 @@ import (
 	"log"
 
 	"quickflow/config"
+	analytics_config "quickflow/config/analytics"
 	"quickflow/config/cors"
+	gc_config "quickflow/config/gc"
+	image_config "quickflow/config/image"
 	minio_config "quickflow/config/minio"
 	postgres_config "quickflow/config/postgres"
+	recommendation_config "quickflow/config/recommendation"
 	redis_config "quickflow/config/redis"
+	scheduler_config "quickflow/config/scheduler"
 	"quickflow/config/server"
+	storage_config "quickflow/config/storage"
 	validation_config "quickflow/config/validation"
 	"quickflow/internal"
 )
 
+var gcDryRun = flag.Bool("gc-dry-run", false, "Only report orphaned files when running gc command")
+
 func initCfg() (*config.Config, error) {
 	serverConfigPath := flag.String("server-config", "", "Path to config file")
 	corsConfigPath := flag.String("cors-config", "", "Path to CORS config file")
 	minioConfigPath := flag.String("minio-config", "", "Path to Minio config file")
 	validationConfig := flag.String("validation-config", "", "Path to Validation config file")
+	recommendationConfig := flag.String("recommendation-config", "", "Path to Recommendation config file")
+	imageConfig := flag.String("image-config", "", "Path to Image config file")
+	gcConfig := flag.String("gc-config", "", "Path to file GC config file")
+	storageConfig := flag.String("storage-config", "", "Path to file storage config file")
+	schedulerConfig := flag.String("scheduler-config", "", "Path to post scheduler config file")
+	analyticsConfig := flag.String("analytics-config", "", "Path to analytics config file")
 	flag.Parse()
 
 	serverCfg, err := server_config.Parse(*serverConfigPath)
@@ -45,6 +59,36 @@ func initCfg() (*config.Config, error) {
 		return nil, fmt.Errorf("failed to load project validation configuration: %v", err)
 	}
 
+	recommendationCfg, err := recommendation_config.NewRecommendationConfig(*recommendationConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project recommendation configuration: %v", err)
+	}
+
+	imageCfg, err := image_config.NewImageConfig(*imageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project image configuration: %v", err)
+	}
+
+	gcCfg, err := gc_config.NewFileGCConfig(*gcConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project file gc configuration: %v", err)
+	}
+
+	storageCfg, err := storage_config.NewStorageConfig(*storageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project file storage configuration: %v", err)
+	}
+
+	schedulerCfg, err := scheduler_config.NewSchedulerConfig(*schedulerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project scheduler configuration: %v", err)
+	}
+
+	analyticsCfg, err := analytics_config.NewAnalyticsConfig(*analyticsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project analytics configuration: %v", err)
+	}
+
 	return &config.Config{
 		PostgresConfig:   postgresCfg,
 		ServerConfig:     serverCfg,
@@ -52,6 +96,13 @@ func initCfg() (*config.Config, error) {
 		MinioConfig:      minioCfg,
 		RedisConfig:      redisCfg,
 		ValidationConfig: validationCfg,
+		ImageConfig:      imageCfg,
+		StorageConfig:    storageCfg,
+
+		RecommendationConfig: recommendationCfg,
+		FileGCConfig:         gcCfg,
+		SchedulerConfig:      schedulerCfg,
+		AnalyticsConfig:      analyticsCfg,
 	}, nil
 }
 
@@ -61,6 +112,28 @@ func main() {
 		log.Fatalf("failed to initialize configuration: %v", err)
 	}
 
+	// subcommands run a single maintenance job instead of starting the server
+	switch flag.Arg(0) {
+	case "gc":
+		if err = internal.RunFileGC(appCfg, *gcDryRun); err != nil {
+			log.Fatalf("failed to collect orphaned files: %v", err)
+		}
+		return
+	case "migrate-buckets":
+		if err = internal.RunBucketMigration(appCfg); err != nil {
+			log.Fatalf("failed to migrate files to buckets: %v", err)
+		}
+		return
+	case "grant-admin":
+		if flag.NArg() != 2 {
+			log.Fatalf("usage: grant-admin <username>")
+		}
+		if err = internal.RunGrantAdmin(appCfg, flag.Arg(1)); err != nil {
+			log.Fatalf("failed to grant admin: %v", err)
+		}
+		return
+	}
+
 	if err = internal.Run(appCfg); err != nil {
 		log.Fatalf("failed to start QuickFlow: %v", err)
 	}
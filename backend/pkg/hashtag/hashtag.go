@@ -0,0 +1,80 @@
+// Package hashtag extracts hashtags from post text and brings them to the form
+// they are indexed and searched by.
+package hashtag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxLength is the maximum number of characters in a tag, longer tags are ignored.
+	MaxLength = 64
+	// MaxPerText is the maximum number of distinct tags taken from a single text.
+	MaxPerText = 30
+)
+
+func isTagRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// Normalize brings tag to its canonical form: NFKC, lower case, without leading '#'.
+// It returns false if the tag is empty, too long, contains characters tags can not contain
+// or has no letters.
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(norm.NFKC.String(strings.TrimPrefix(tag, "#")))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxLength {
+		return "", false
+	}
+
+	hasLetter := false
+	for _, r := range tag {
+		if !isTagRune(r) {
+			return "", false
+		}
+		hasLetter = hasLetter || unicode.IsLetter(r)
+	}
+	return tag, hasLetter
+}
+
+// Parse returns distinct normalized tags of the text in order of their first occurrence.
+// A tag starts with '#' that does not follow a word character, so anchors like "page#top"
+// and HTML entities like "&#39;" left by the sanitizer are not tags.
+func Parse(text string) []string {
+	var (
+		tags []string
+		seen = make(map[string]bool)
+		prev rune
+	)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r != '#' || isTagRune(prev) || prev == '&' || prev == '#' {
+			prev = r
+			i += size
+			continue
+		}
+
+		end := i + size
+		for end < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[end:])
+			if !isTagRune(next) {
+				break
+			}
+			end += nextSize
+		}
+
+		if tag, ok := Normalize(text[i:end]); ok && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+			if len(tags) == MaxPerText {
+				break
+			}
+		}
+		prev = r
+		i = end
+	}
+	return tags
+}
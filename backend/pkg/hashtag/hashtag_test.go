@@ -0,0 +1,52 @@
+package hashtag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "no tags", text: "just text", want: nil},
+		{name: "tags are lower cased and deduplicated", text: "#Go is #fun, #go!", want: []string{"go", "fun"}},
+		{name: "unicode letters", text: "Привет #МирКода и #café_2025", want: []string{"миркода", "café_2025"}},
+		{name: "decomposed characters are composed", text: "#cafe\u0301", want: []string{"caf\u00e9"}},
+		{name: "digits only are not tags", text: "issue #42 fixed #v42", want: []string{"v42"}},
+		{name: "anchors are not tags", text: "see page#top", want: nil},
+		{name: "html entities are not tags", text: "it&#39;s #ok", want: []string{"ok"}},
+		{name: "double hash", text: "##tag", want: nil},
+		{name: "tag at the end", text: "done #finally", want: []string{"finally"}},
+		{name: "too long tag is ignored", text: "#" + strings.Repeat("a", MaxLength+1) + " #short", want: []string{"short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.text))
+		})
+	}
+}
+
+func TestParse_Limit(t *testing.T) {
+	var text strings.Builder
+	for i := 0; i < MaxPerText+5; i++ {
+		text.WriteString(" #tag" + strings.Repeat("x", i))
+	}
+	assert.Len(t, Parse(text.String()), MaxPerText)
+}
+
+func TestNormalize(t *testing.T) {
+	tag, ok := Normalize("#GoLang")
+	assert.True(t, ok)
+	assert.Equal(t, "golang", tag)
+
+	for _, invalid := range []string{"", "#", "#123", "go lang", "go-lang"} {
+		_, ok = Normalize(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
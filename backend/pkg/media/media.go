@@ -0,0 +1,86 @@
+// Package media detects types of uploaded files by their content and extracts
+// playback properties of MP4, WebM and Ogg files without external tools.
+package media
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported media format")
+	ErrMalformed   = errors.New("malformed media file")
+)
+
+// Info describes playback properties of a media file.
+type Info struct {
+	Duration time.Duration
+	Width    int
+	Height   int
+	HasVideo bool
+}
+
+var (
+	ebmlMagic = []byte{0x1A, 0x45, 0xDF, 0xA3}
+	oggMagic  = []byte("OggS")
+)
+
+// durationOf converts duration read from a file to time.Duration. Values crafted to be
+// negative, NaN or too large to represent are rejected, so they can't pass duration limits.
+func durationOf(nanoseconds float64) (time.Duration, error) {
+	if math.IsNaN(nanoseconds) || nanoseconds < 0 || nanoseconds >= math.MaxInt64 {
+		return 0, ErrMalformed
+	}
+	return time.Duration(nanoseconds), nil
+}
+
+func isMP4(data []byte) bool {
+	return len(data) >= 12 && string(data[4:8]) == "ftyp"
+}
+
+func isEBML(data []byte) bool {
+	return bytes.HasPrefix(data, ebmlMagic)
+}
+
+func isOgg(data []byte) bool {
+	return bytes.HasPrefix(data, oggMagic)
+}
+
+// DetectContentType returns mime type of data judging by its magic bytes only.
+// Audio and video containers are recognized in addition to formats known to http.DetectContentType.
+func DetectContentType(data []byte) string {
+	switch {
+	case isMP4(data):
+		switch string(data[8:12]) {
+		case "M4A ", "M4B ":
+			return "audio/mp4"
+		case "qt  ":
+			return "video/quicktime"
+		}
+		return "video/mp4"
+	case isEBML(data):
+		if bytes.Contains(data[:min(len(data), 64)], []byte("webm")) {
+			return "video/webm"
+		}
+		return "video/x-matroska"
+	case isOgg(data):
+		return "audio/ogg"
+	}
+	return http.DetectContentType(data)
+}
+
+// Probe extracts playback properties of MP4, WebM or Ogg file.
+func Probe(data []byte) (Info, error) {
+	switch {
+	case isMP4(data):
+		return probeMP4(data)
+	case isEBML(data):
+		return probeWebM(data)
+	case isOgg(data):
+		return probeOgg(data)
+	}
+	return Info{}, ErrUnsupported
+}
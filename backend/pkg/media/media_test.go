@@ -0,0 +1,214 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mp4Atom(typ string, children ...[]byte) []byte {
+	body := bytes.Join(children, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(out, typ...), body...)
+}
+
+func mvhd(timescale, duration uint32) []byte {
+	body := make([]byte, 100)
+	binary.BigEndian.PutUint32(body[12:], timescale)
+	binary.BigEndian.PutUint32(body[16:], duration)
+	return mp4Atom("mvhd", body)
+}
+
+func trak(handler string, width, height uint32) []byte {
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], width<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], height<<16)
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:], handler)
+	return mp4Atom("trak", mp4Atom("tkhd", tkhd), mp4Atom("mdia", mp4Atom("hdlr", hdlr)))
+}
+
+func testMP4(brand string, tracks ...[]byte) []byte {
+	ftyp := mp4Atom("ftyp", []byte(brand), make([]byte, 4))
+	moov := mp4Atom("moov", append([][]byte{mvhd(1000, 12500)}, tracks...)...)
+	return bytes.Join([][]byte{ftyp, mp4Atom("mdat", []byte("data")), moov}, nil)
+}
+
+func ebml(id uint32, body []byte) []byte {
+	var out []byte
+	switch {
+	case id > 0xFFFFFF:
+		out = binary.BigEndian.AppendUint32(nil, id)
+	case id > 0xFFFF:
+		out = []byte{byte(id >> 16), byte(id >> 8), byte(id)}
+	case id > 0xFF:
+		out = []byte{byte(id >> 8), byte(id)}
+	default:
+		out = []byte{byte(id)}
+	}
+	size := binary.BigEndian.AppendUint64(nil, uint64(len(body)))
+	size[0] = 0x01 // eight byte size
+	return append(append(out, size...), body...)
+}
+
+func ebmlUint(id uint32, value uint64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, value))
+}
+
+func testWebM(duration float64, video bool, blocks ...int16) []byte {
+	info := [][]byte{ebmlUint(idTimecodeScale, 1000000)}
+	if duration != 0 {
+		info = append(info, ebml(idDuration, binary.BigEndian.AppendUint64(nil, math.Float64bits(duration))))
+	}
+
+	track := [][]byte{ebmlUint(idTrackType, 2)}
+	if video {
+		track = [][]byte{ebmlUint(idTrackType, trackTypeVideo), ebml(idVideo, bytes.Join([][]byte{
+			ebmlUint(idPixelWidth, 640), ebmlUint(idPixelHeight, 360),
+		}, nil))}
+	}
+
+	cluster := [][]byte{ebmlUint(idTimecode, 1000)}
+	for _, offset := range blocks {
+		cluster = append(cluster, ebml(idSimpleBlock, binary.BigEndian.AppendUint16([]byte{0x81}, uint16(offset))))
+	}
+
+	segment := bytes.Join([][]byte{
+		ebml(idInfo, bytes.Join(info, nil)),
+		ebml(idTracks, ebml(idTrackEntry, bytes.Join(track, nil))),
+	}, nil)
+	// cluster of unknown size as written by browsers
+	segment = append(segment, 0x1F, 0x43, 0xB6, 0x75, 0xFF)
+	segment = append(segment, bytes.Join(cluster, nil)...)
+
+	header := ebml(0x1A45DFA3, ebml(0x4282, []byte("webm")))
+	return append(header, ebml(idSegment, segment)...)
+}
+
+func oggPageBytes(granule int64, payload []byte) []byte {
+	page := make([]byte, oggPageHeaderSize)
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:], uint64(granule))
+	binary.LittleEndian.PutUint32(page[14:], 7)
+	page[26] = 1
+	page = append(page, byte(len(payload)))
+	return append(page, payload...)
+}
+
+func testOpus(granule int64) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	binary.LittleEndian.PutUint16(head[10:], 312)
+	return bytes.Join([][]byte{
+		oggPageBytes(0, head),
+		oggPageBytes(0, []byte("OpusTags")),
+		oggPageBytes(-1, []byte("audio")),
+		oggPageBytes(granule, []byte("audio")),
+	}, nil)
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "mp4 video", data: testMP4("isom"), want: "video/mp4"},
+		{name: "m4a audio", data: testMP4("M4A "), want: "audio/mp4"},
+		{name: "webm", data: testWebM(0, true), want: "video/webm"},
+		{name: "ogg", data: testOpus(48000), want: "audio/ogg"},
+		{name: "png", data: []byte("\x89PNG\r\n\x1a\n"), want: "image/png"},
+		{name: "unknown", data: []byte{0, 1, 2, 3}, want: "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectContentType(tt.data))
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Info
+		wantErr error
+	}{
+		{
+			name: "mp4 with video track",
+			data: testMP4("isom", trak("soun", 0, 0), trak("vide", 1280, 720)),
+			want: Info{Duration: 12500 * time.Millisecond, Width: 1280, Height: 720, HasVideo: true},
+		},
+		{
+			name: "mp4 audio only",
+			data: testMP4("M4A ", trak("soun", 0, 0)),
+			want: Info{Duration: 12500 * time.Millisecond},
+		},
+		{
+			name: "webm with duration",
+			data: testWebM(4200, true),
+			want: Info{Duration: 4200 * time.Millisecond, Width: 640, Height: 360, HasVideo: true},
+		},
+		{
+			name: "webm without duration is measured by blocks",
+			data: testWebM(0, false, 0, 1500, 700),
+			want: Info{Duration: 2500 * time.Millisecond},
+		},
+		{
+			name: "opus",
+			data: testOpus(312 + 48000*3),
+			want: Info{Duration: 3 * time.Second},
+		},
+		{
+			name:    "webm with negative duration",
+			data:    testWebM(-4200, true),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "webm with NaN duration",
+			data:    testWebM(math.NaN(), true),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "webm with duration that overflows",
+			data:    testWebM(1e300, true),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "opus with granule that overflows",
+			data:    testOpus(math.MaxInt64),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "truncated mp4",
+			data:    testMP4("isom")[:30],
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "ogg with unknown codec",
+			data:    oggPageBytes(0, []byte("\x80theora")),
+			wantErr: ErrUnsupported,
+		},
+		{
+			name:    "not a media file",
+			data:    []byte("hello"),
+			wantErr: ErrUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Probe(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, info)
+		})
+	}
+}
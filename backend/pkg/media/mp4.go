@@ -0,0 +1,150 @@
+package media
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+type mp4Box struct {
+	typ  string
+	body []byte
+}
+
+// readBoxes splits data into consecutive ISO BMFF boxes.
+func readBoxes(data []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data))
+		typ := string(data[4:8])
+		header := uint64(8)
+		switch size {
+		case 0: // box extends to the end of file
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, ErrMalformed
+			}
+			size = binary.BigEndian.Uint64(data[8:])
+			header = 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return nil, ErrMalformed
+		}
+		boxes = append(boxes, mp4Box{typ: typ, body: data[header:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+func findBox(boxes []mp4Box, typ string) ([]byte, bool) {
+	for _, box := range boxes {
+		if box.typ == typ {
+			return box.body, true
+		}
+	}
+	return nil, false
+}
+
+// findPath descends into nested boxes by their types.
+func findPath(data []byte, path ...string) ([]byte, bool) {
+	for _, typ := range path {
+		boxes, err := readBoxes(data)
+		if err != nil {
+			return nil, false
+		}
+		var ok bool
+		if data, ok = findBox(boxes, typ); !ok {
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+func probeMP4(data []byte) (Info, error) {
+	moov, ok := findPath(data, "moov")
+	if !ok {
+		return Info{}, ErrMalformed
+	}
+	mvhd, ok := findPath(moov, "mvhd")
+	if !ok {
+		return Info{}, ErrMalformed
+	}
+
+	timescale, duration, err := parseMvhd(mvhd)
+	if err != nil {
+		return Info{}, err
+	}
+	// fragmented files keep total duration in movie extends header
+	if mehd, ok := findPath(moov, "mvex", "mehd"); duration == 0 && ok && len(mehd) >= 8 {
+		if mehd[0] == 1 && len(mehd) >= 12 {
+			duration = binary.BigEndian.Uint64(mehd[4:])
+		} else {
+			duration = uint64(binary.BigEndian.Uint32(mehd[4:]))
+		}
+	}
+
+	info := Info{}
+	if timescale > 0 {
+		info.Duration, err = durationOf(float64(duration) / float64(timescale) * float64(time.Second))
+		if err != nil {
+			return Info{}, err
+		}
+	}
+
+	boxes, err := readBoxes(moov)
+	if err != nil {
+		return Info{}, err
+	}
+	for _, box := range boxes {
+		if box.typ != "trak" {
+			continue
+		}
+		hdlr, ok := findPath(box.body, "mdia", "hdlr")
+		if !ok || len(hdlr) < 12 || string(hdlr[8:12]) != "vide" {
+			continue
+		}
+		tkhd, ok := findPath(box.body, "tkhd")
+		if !ok {
+			return Info{}, ErrMalformed
+		}
+		width, height, err := parseTkhdSize(tkhd)
+		if err != nil {
+			return Info{}, err
+		}
+		info.HasVideo = true
+		info.Width, info.Height = width, height
+		break
+	}
+	return info, nil
+}
+
+// parseMvhd returns timescale and duration of the movie header.
+func parseMvhd(mvhd []byte) (uint32, uint64, error) {
+	if len(mvhd) < 1 {
+		return 0, 0, ErrMalformed
+	}
+	if mvhd[0] == 1 {
+		if len(mvhd) < 32 {
+			return 0, 0, ErrMalformed
+		}
+		return binary.BigEndian.Uint32(mvhd[20:]), binary.BigEndian.Uint64(mvhd[24:]), nil
+	}
+	if len(mvhd) < 20 {
+		return 0, 0, ErrMalformed
+	}
+	return binary.BigEndian.Uint32(mvhd[12:]), uint64(binary.BigEndian.Uint32(mvhd[16:])), nil
+}
+
+// parseTkhdSize returns presentation size of the track, stored as 16.16 fixed point numbers.
+func parseTkhdSize(tkhd []byte) (int, int, error) {
+	offset := 76
+	if len(tkhd) > 0 && tkhd[0] == 1 {
+		offset = 88
+	}
+	if len(tkhd) < offset+8 {
+		return 0, 0, ErrMalformed
+	}
+	width := binary.BigEndian.Uint32(tkhd[offset:]) >> 16
+	height := binary.BigEndian.Uint32(tkhd[offset+4:]) >> 16
+	return int(width), int(height), nil
+}
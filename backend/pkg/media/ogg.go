@@ -0,0 +1,92 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+const (
+	oggPageHeaderSize = 27
+	opusGranuleRate   = 48000 // granule position of Opus streams is always counted at 48 kHz
+)
+
+type oggPage struct {
+	granule int64
+	serial  uint32
+	payload []byte
+}
+
+// readOggPages splits data into pages. Truncated last page is ignored.
+func readOggPages(data []byte) ([]oggPage, error) {
+	var pages []oggPage
+	for len(data) >= oggPageHeaderSize {
+		if !isOgg(data) {
+			return nil, ErrMalformed
+		}
+		segments := int(data[26])
+		header := oggPageHeaderSize + segments
+		if len(data) < header {
+			break
+		}
+		payloadLen := 0
+		for _, lacing := range data[oggPageHeaderSize:header] {
+			payloadLen += int(lacing)
+		}
+		if len(data) < header+payloadLen {
+			break
+		}
+		pages = append(pages, oggPage{
+			granule: int64(binary.LittleEndian.Uint64(data[6:])),
+			serial:  binary.LittleEndian.Uint32(data[14:]),
+			payload: data[header : header+payloadLen],
+		})
+		data = data[header+payloadLen:]
+	}
+	if len(pages) == 0 {
+		return nil, ErrMalformed
+	}
+	return pages, nil
+}
+
+// probeOgg computes duration of Opus or Vorbis audio by granule position of its last page.
+func probeOgg(data []byte) (Info, error) {
+	pages, err := readOggPages(data)
+	if err != nil {
+		return Info{}, err
+	}
+
+	first := pages[0]
+	var (
+		rate    uint64
+		preSkip int64
+	)
+	switch {
+	case bytes.HasPrefix(first.payload, []byte("OpusHead")) && len(first.payload) >= 12:
+		rate = opusGranuleRate
+		preSkip = int64(binary.LittleEndian.Uint16(first.payload[10:]))
+	case bytes.HasPrefix(first.payload, []byte("\x01vorbis")) && len(first.payload) >= 16:
+		rate = uint64(binary.LittleEndian.Uint32(first.payload[12:]))
+	default:
+		return Info{}, ErrUnsupported
+	}
+	if rate == 0 {
+		return Info{}, ErrMalformed
+	}
+
+	var granule int64
+	for _, page := range pages {
+		// -1 marks pages where no packet ends
+		if page.serial == first.serial && page.granule > 0 {
+			granule = page.granule
+		}
+	}
+	samples := uint64(max(granule-preSkip, 0))
+	// whole seconds are counted apart, so crafted granule positions can't overflow into a negative duration
+	seconds, rest := samples/rate, samples%rate
+	if seconds > math.MaxInt64/uint64(time.Second)-1 {
+		return Info{}, ErrMalformed
+	}
+	return Info{Duration: time.Duration(seconds)*time.Second + time.Duration(rest)*time.Second/time.Duration(rate)}, nil
+}
@@ -0,0 +1,227 @@
+package media
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Matroska element IDs, markers of their length are kept.
+const (
+	idSegment       = 0x18538067
+	idInfo          = 0x1549A966
+	idTimecodeScale = 0x2AD7B1
+	idDuration      = 0x4489
+	idTracks        = 0x1654AE6B
+	idTrackEntry    = 0xAE
+	idTrackType     = 0x83
+	idVideo         = 0xE0
+	idPixelWidth    = 0xB0
+	idPixelHeight   = 0xBA
+	idCluster       = 0x1F43B675
+	idTimecode      = 0xE7
+	idSimpleBlock   = 0xA3
+	idBlockGroup    = 0xA0
+	idBlock         = 0xA1
+
+	trackTypeVideo = 1
+
+	defaultTimecodeScale = 1000000 // nanoseconds in a timecode unit
+)
+
+// readVint reads variable length integer. Length marker is stripped from sizes
+// but kept in IDs. Size with all value bits set is unknown and returned as -1.
+func readVint(data []byte, keepMarker bool) (int64, int, error) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0, ErrMalformed
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if len(data) < length {
+		return 0, 0, ErrMalformed
+	}
+
+	value := uint64(data[0])
+	if !keepMarker {
+		value &= uint64(0xFF >> length)
+	}
+	allOnes := value == uint64(0xFF>>length)
+	for _, b := range data[1:length] {
+		value = value<<8 | uint64(b)
+		allOnes = allOnes && b == 0xFF
+	}
+	if !keepMarker && allOnes {
+		return -1, length, nil
+	}
+	return int64(value), length, nil
+}
+
+// readElement returns ID, body size (-1 when unknown) and header length of the element.
+func readElement(data []byte) (int64, int64, int, error) {
+	id, idLen, err := readVint(data, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	size, sizeLen, err := readVint(data[idLen:], false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return id, size, idLen + sizeLen, nil
+}
+
+// forEachElement calls fn for every child element with known size.
+func forEachElement(data []byte, fn func(id int64, body []byte) error) error {
+	for len(data) > 0 {
+		id, size, header, err := readElement(data)
+		if err != nil {
+			return err
+		}
+		if size < 0 || int64(header)+size > int64(len(data)) {
+			return ErrMalformed
+		}
+		end := header + int(size)
+		if err = fn(id, data[header:end]); err != nil {
+			return err
+		}
+		data = data[end:]
+	}
+	return nil
+}
+
+func readUint(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func readFloat(data []byte) (float64, error) {
+	switch len(data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+	}
+	return 0, ErrMalformed
+}
+
+// probeWebM reads segment info and tracks of a WebM file. Files recorded by browsers
+// often have no duration, then it is estimated by the timecode of the last block.
+func probeWebM(data []byte) (Info, error) {
+	var (
+		info          Info
+		scale         uint64 = defaultTimecodeScale
+		duration      float64
+		clusterTime   int64
+		lastBlockTime int64
+		hasTracks     bool
+	)
+
+	// containers are entered rather than skipped, so clusters of unknown size
+	// recorded by live encoders are read as well
+	for len(data) > 0 {
+		id, size, header, err := readElement(data)
+		if err != nil {
+			return Info{}, err
+		}
+		switch id {
+		case idSegment, idCluster, idBlockGroup:
+			data = data[header:]
+			continue
+		}
+		if size < 0 {
+			return Info{}, ErrMalformed
+		}
+		if int64(header)+size > int64(len(data)) {
+			break // truncated tail is ignored
+		}
+		end := header + int(size)
+		body := data[header:end]
+
+		switch id {
+		case idInfo:
+			err = forEachElement(body, func(id int64, body []byte) error {
+				switch id {
+				case idTimecodeScale:
+					scale = readUint(body)
+				case idDuration:
+					var err error
+					duration, err = readFloat(body)
+					return err
+				}
+				return nil
+			})
+		case idTracks:
+			hasTracks = true
+			err = forEachElement(body, func(id int64, body []byte) error {
+				if id != idTrackEntry || info.HasVideo {
+					return nil
+				}
+				return parseTrackEntry(body, &info)
+			})
+		case idTimecode:
+			clusterTime = int64(readUint(body))
+		case idSimpleBlock, idBlock:
+			// track number is followed by timecode relative to cluster
+			_, trackLen, err := readVint(body, false)
+			if err != nil || len(body) < trackLen+2 {
+				return Info{}, ErrMalformed
+			}
+			blockTime := clusterTime + int64(int16(binary.BigEndian.Uint16(body[trackLen:])))
+			lastBlockTime = max(lastBlockTime, blockTime)
+		}
+		if err != nil {
+			return Info{}, err
+		}
+		data = data[end:]
+	}
+
+	if !hasTracks {
+		return Info{}, ErrMalformed
+	}
+	if duration == 0 {
+		duration = float64(lastBlockTime)
+	}
+	d, err := durationOf(duration * float64(scale))
+	if err != nil {
+		return Info{}, err
+	}
+	info.Duration = d
+	return info, nil
+}
+
+func parseTrackEntry(data []byte, info *Info) error {
+	var (
+		trackType     uint64
+		width, height uint64
+	)
+	err := forEachElement(data, func(id int64, body []byte) error {
+		switch id {
+		case idTrackType:
+			trackType = readUint(body)
+		case idVideo:
+			return forEachElement(body, func(id int64, body []byte) error {
+				switch id {
+				case idPixelWidth:
+					width = readUint(body)
+				case idPixelHeight:
+					height = readUint(body)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if trackType == trackTypeVideo {
+		info.HasVideo = true
+		info.Width, info.Height = int(width), int(height)
+	}
+	return nil
+}
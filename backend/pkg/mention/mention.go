@@ -0,0 +1,95 @@
+// Package mention extracts @username mentions from texts of posts and messages.
+package mention
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLength is the maximum number of characters in a username, longer mentions are ignored.
+	MaxLength = 20
+	// MaxPerText is the maximum number of distinct users mentioned in a single text.
+	MaxPerText = 20
+)
+
+// Mention is an occurrence of @username in text.
+// Offset and Length include the leading '@' and are measured in UTF-16 code units,
+// which is how clients index strings when they render mentions as links.
+type Mention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func isUsernameRune(r rune) bool {
+	return r < utf8.RuneSelf && (r == '.' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Parse returns mentions of the text in order of their occurrence.
+// A mention starts with '@' that does not follow a word character, so e-mail addresses
+// are not mentions. Dots at the end of a mention are treated as punctuation.
+// Mentions of users beyond the first MaxPerText distinct ones are dropped.
+func Parse(text string) []Mention {
+	var (
+		mentions []Mention
+		seen     = make(map[string]bool)
+		prev     rune
+		offset   int
+	)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r != '@' || isWordRune(prev) || prev == '@' || prev == '.' {
+			prev = r
+			i += size
+			offset += utf16.RuneLen(r)
+			continue
+		}
+
+		end := i + size
+		for end < len(text) && isUsernameRune(rune(text[end])) {
+			end++
+		}
+		username := strings.TrimRight(text[i+size:end], ".")
+		end = i + size + len(username)
+
+		if valid(username) && (seen[username] || len(seen) < MaxPerText) {
+			seen[username] = true
+			mentions = append(mentions, Mention{Username: username, Offset: offset, Length: end - i})
+		}
+		// usernames are ASCII, so their length in bytes and in UTF-16 code units is the same
+		offset += end - i
+		prev = '@'
+		if end > i+size {
+			prev = rune(text[end-1])
+		}
+		i = end
+	}
+	return mentions
+}
+
+// valid reports whether username can belong to a user.
+func valid(username string) bool {
+	return username != "" && len(username) <= MaxLength && username[0] != '.' && username[0] != '_'
+}
+
+// Usernames returns distinct usernames of the mentions in order of their first occurrence.
+func Usernames(mentions []Mention) []string {
+	var (
+		usernames []string
+		seen      = make(map[string]bool)
+	)
+	for _, m := range mentions {
+		if !seen[m.Username] {
+			seen[m.Username] = true
+			usernames = append(usernames, m.Username)
+		}
+	}
+	return usernames
+}
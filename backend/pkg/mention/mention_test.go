@@ -0,0 +1,53 @@
+package mention
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Mention
+	}{
+		{name: "no mentions", text: "just text", want: nil},
+		{name: "mention at the start", text: "@bob hi", want: []Mention{{Username: "bob", Offset: 0, Length: 4}}},
+		{
+			name: "repeated mentions are all kept",
+			text: "@bob and @alice.k, @bob!",
+			want: []Mention{
+				{Username: "bob", Offset: 0, Length: 4},
+				{Username: "alice.k", Offset: 9, Length: 8},
+				{Username: "bob", Offset: 19, Length: 4},
+			},
+		},
+		{name: "trailing dot is punctuation", text: "thanks @bob.", want: []Mention{{Username: "bob", Offset: 7, Length: 4}}},
+		{name: "emails are not mentions", text: "mail bob@example.com", want: nil},
+		{name: "offsets are in utf-16 code units", text: "😀 привет @bob", want: []Mention{{Username: "bob", Offset: 10, Length: 4}}},
+		{name: "invalid first character", text: "@_bob @.bob", want: nil},
+		{name: "too long username is ignored", text: "@" + strings.Repeat("a", MaxLength+1) + " @short", want: []Mention{{Username: "short", Offset: 23, Length: 6}}},
+		{name: "lone at sign", text: "meet @ noon", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.text))
+		})
+	}
+}
+
+func TestParse_Limit(t *testing.T) {
+	var text strings.Builder
+	for i := 0; i < MaxPerText+5; i++ {
+		text.WriteString(fmt.Sprintf(" @user%d", i))
+	}
+	text.WriteString(" @user0")
+
+	mentions := Parse(text.String())
+	assert.Len(t, mentions, MaxPerText+1)
+	assert.Len(t, Usernames(mentions), MaxPerText)
+}
@@ -19,12 +19,22 @@ func SanitizeSignUpData(signUpData *forms.SignUpForm, policy *bluemonday.Policy)
 
 func SanitizePost(postData *forms.PostForm, policy *bluemonday.Policy) {
 	postData.Text = policy.Sanitize(postData.Text)
+	if postData.Poll != nil {
+		postData.Poll.Question = policy.Sanitize(postData.Poll.Question)
+		for i := range postData.Poll.Options {
+			postData.Poll.Options[i].Text = policy.Sanitize(postData.Poll.Options[i].Text)
+		}
+	}
 }
 
 func SanitizeUpdatePost(postData *forms.UpdatePostForm, policy *bluemonday.Policy) {
 	postData.Text = policy.Sanitize(postData.Text)
 }
 
+func SanitizeBookmarkCollection(collectionData *forms.BookmarkCollectionForm, policy *bluemonday.Policy) {
+	collectionData.Name = policy.Sanitize(collectionData.Name)
+}
+
 func SanitizeMessage(messageData *forms.MessageForm, policy *bluemonday.Policy) {
 	messageData.Text = policy.Sanitize(messageData.Text)
 }
@@ -53,3 +63,11 @@ func SanitizeUniversityInfo(universityInfo *forms.UniversityEducationForm, polic
 	universityInfo.UniversityCity = policy.Sanitize(universityInfo.UniversityCity)
 	universityInfo.UniversityName = policy.Sanitize(universityInfo.UniversityName)
 }
+
+func SanitizeReport(reportData *forms.ReportForm, policy *bluemonday.Policy) {
+	reportData.Details = policy.Sanitize(reportData.Details)
+}
+
+func SanitizeReportResolution(resolutionData *forms.ResolveReportForm, policy *bluemonday.Policy) {
+	resolutionData.Note = policy.Sanitize(resolutionData.Note)
+}
@@ -15,6 +15,7 @@ import (
 	"quickflow/internal/delivery/forms"
 	"quickflow/internal/models"
 	"quickflow/pkg/logger"
+	"quickflow/pkg/media"
 )
 
 var TooManyFilesErr = errors.New("too many files")
@@ -94,15 +95,8 @@ func GetFile(r *http.Request, key string) (*models.File, error) {
 	return fileModel, nil
 }
 
-// detectMimeType определяет MIME-тип файла, сначала проверяя заголовки, затем анализируя содержимое.
+// detectMimeType определяет MIME-тип файла по его содержимому, заголовок Content-Type задаётся клиентом и не проверяется.
 func detectMimeType(fileHeader *multipart.FileHeader) (string, error) {
-	// Попробуем получить MIME-тип из заголовков
-	mimeType := fileHeader.Header.Get("Content-Type")
-	if mimeType != "" {
-		return mimeType, nil
-	}
-
-	// Если в заголовках нет, пробуем определить по содержимому
 	file, err := fileHeader.Open()
 	if err != nil {
 		return "", err
@@ -111,10 +105,10 @@ func detectMimeType(fileHeader *multipart.FileHeader) (string, error) {
 
 	// Читаем первые 512 байтов (это стандартный размер для определения типа)
 	buf := make([]byte, 512)
-	n, err := file.Read(buf)
-	if err != nil && err != io.EOF {
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return "", err
 	}
 
-	return http.DetectContentType(buf[:n]), nil
+	return media.DetectContentType(buf[:n]), nil
 }
@@ -12,7 +12,7 @@ func ValidateMessage(message models.Message) error {
 		return errors.New("message cannot be empty")
 	}
 	// TODO make clean, move to config
-	if len(message.AttachmentURLs) > 10 {
+	if len(message.AttachmentURLs)+len(message.UploadKeys) > 10 {
 		return errors.New("too many attachments")
 	}
 	if message.ChatID == uuid.Nil && message.SenderID == uuid.Nil {
@@ -0,0 +1,11 @@
+-- +migrate Up
+create table if not exists privacy_settings(
+                                               profile_id uuid primary key references profile(id) on delete cascade,
+                                               contact_info text not null default 'friends',
+                                               birth_date text not null default 'everyone',
+                                               education text not null default 'everyone',
+                                               posts text not null default 'everyone'
+);
+
+-- +migrate Down
+drop table if exists privacy_settings cascade;
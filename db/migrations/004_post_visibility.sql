@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table post
+    add column if not exists visibility text not null default 'public';
+
+-- +migrate Down
+alter table post
+    drop column if exists visibility;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table friendship
+    add column if not exists created_at timestamptz not null default now();
+
+-- +migrate Down
+alter table friendship
+    drop column if exists created_at;
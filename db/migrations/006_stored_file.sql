@@ -0,0 +1,63 @@
+-- +migrate Up
+create table if not exists stored_file(
+                                          url text primary key,
+                                          purpose text not null,
+                                          name text not null,
+                                          content_hash text not null,
+                                          size bigint not null,
+                                          mime_type text not null,
+                                          ref_count int not null default 0 check (ref_count >= 0),
+                                          created_at timestamptz not null default now(),
+                                          used_at timestamptz not null default now(),
+                                          unique (purpose, name),
+                                          unique (content_hash, purpose)
+);
+
+-- count_file_refs keeps stored_file.ref_count in sync with the column named by its argument,
+-- so references removed by cascades are counted as well.
+-- +migrate StatementBegin
+create or replace function count_file_refs() returns trigger as $$
+declare
+    old_url text;
+    new_url text;
+begin
+    if tg_op <> 'INSERT' then
+        old_url := to_jsonb(old) ->> tg_argv[0];
+    end if;
+    if tg_op <> 'DELETE' then
+        new_url := to_jsonb(new) ->> tg_argv[0];
+    end if;
+    if old_url is not distinct from new_url then
+        return null;
+    end if;
+
+    if new_url is not null then
+        update stored_file set ref_count = ref_count + 1 where url = new_url;
+    end if;
+    if old_url is not null then
+        update stored_file set ref_count = greatest(ref_count - 1, 0) where url = old_url;
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+-- +migrate StatementEnd
+
+create or replace trigger post_file_refs after insert or update or delete on post_file
+    for each row execute function count_file_refs('file_url');
+create or replace trigger message_file_refs after insert or update or delete on message_file
+    for each row execute function count_file_refs('file_url');
+create or replace trigger profile_avatar_refs after insert or update of profile_avatar or delete on profile
+    for each row execute function count_file_refs('profile_avatar');
+create or replace trigger profile_background_refs after insert or update of profile_background or delete on profile
+    for each row execute function count_file_refs('profile_background');
+create or replace trigger chat_avatar_refs after insert or update of avatar_url or delete on chat
+    for each row execute function count_file_refs('avatar_url');
+
+-- +migrate Down
+drop trigger if exists chat_avatar_refs on chat;
+drop trigger if exists profile_background_refs on profile;
+drop trigger if exists profile_avatar_refs on profile;
+drop trigger if exists message_file_refs on message_file;
+drop trigger if exists post_file_refs on post_file;
+drop function if exists count_file_refs();
+drop table if exists stored_file;
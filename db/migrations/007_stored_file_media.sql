@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table stored_file
+    add column if not exists media_type text,
+    add column if not exists duration_ms bigint,
+    add column if not exists width int,
+    add column if not exists height int;
+
+-- +migrate Down
+alter table stored_file
+    drop column if exists media_type,
+    drop column if exists duration_ms,
+    drop column if exists width,
+    drop column if exists height;
@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table post
+    add column if not exists status text not null default 'published',
+    add column if not exists publish_at timestamptz;
+
+create index if not exists post_scheduled_idx on post(publish_at) where status = 'scheduled';
+
+-- +migrate Down
+drop index if exists post_scheduled_idx;
+
+alter table post
+    drop column if exists status,
+    drop column if exists publish_at;
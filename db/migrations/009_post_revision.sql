@@ -0,0 +1,31 @@
+-- +migrate Up
+alter table post add column if not exists edited_at timestamptz;
+
+create table if not exists post_revision(
+                                            id uuid primary key,
+                                            post_id uuid not null references post(id) on delete cascade,
+                                            editor_id uuid references "user"(id) on delete set null,
+                                            text text,
+                                            created_at timestamptz not null default now()
+);
+
+create index if not exists post_revision_post_idx on post_revision(post_id, created_at);
+
+create table if not exists post_revision_file(
+                                                 id int generated always as identity primary key,
+                                                 revision_id uuid not null references post_revision(id) on delete cascade,
+                                                 file_url text not null
+);
+
+create index if not exists post_revision_file_revision_idx on post_revision_file(revision_id);
+
+-- files of previous versions are kept while the post exists
+create or replace trigger post_revision_file_refs after insert or update or delete on post_revision_file
+    for each row execute function count_file_refs('file_url');
+
+-- +migrate Down
+drop trigger if exists post_revision_file_refs on post_revision_file;
+drop table if exists post_revision_file;
+drop table if exists post_revision;
+
+alter table post drop column if exists edited_at;
@@ -0,0 +1,14 @@
+-- +migrate Up
+create table if not exists post_tag(
+                                       post_id uuid not null references post(id) on delete cascade,
+                                       tag text not null,
+                                       primary key (post_id, tag)
+);
+
+create index if not exists post_tag_tag_idx on post_tag(tag);
+-- trending tags are counted over posts of the recent window
+create index if not exists post_created_at_idx on post(created_at);
+
+-- +migrate Down
+drop index if exists post_created_at_idx;
+drop table if exists post_tag;
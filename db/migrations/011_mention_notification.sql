@@ -0,0 +1,36 @@
+-- +migrate Up
+create table if not exists post_mention(
+                                           post_id uuid not null references post(id) on delete cascade,
+                                           user_id uuid not null references "user"(id) on delete cascade,
+                                           "offset" int not null,
+                                           length int not null,
+                                           primary key (post_id, "offset")
+);
+
+create table if not exists message_mention(
+                                              message_id uuid not null references message(id) on delete cascade,
+                                              user_id uuid not null references "user"(id) on delete cascade,
+                                              "offset" int not null,
+                                              length int not null,
+                                              primary key (message_id, "offset")
+);
+
+create table if not exists notification(
+                                           id uuid primary key,
+                                           user_id uuid not null references "user"(id) on delete cascade,
+                                           type text not null,
+                                           actor_id uuid references "user"(id) on delete cascade,
+                                           post_id uuid references post(id) on delete cascade,
+                                           message_id uuid references message(id) on delete cascade,
+                                           created_at timestamptz not null default now(),
+                                           read_at timestamptz
+);
+
+create index if not exists notification_user_idx on notification(user_id, created_at desc, id desc);
+-- user is told about mention in a post once, however many times the post is edited
+create unique index if not exists notification_post_mention_idx on notification(user_id, post_id) where type = 'post_mention';
+
+-- +migrate Down
+drop table if exists notification;
+drop table if exists message_mention;
+drop table if exists post_mention;
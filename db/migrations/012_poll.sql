@@ -0,0 +1,40 @@
+-- +migrate Up
+create table if not exists poll(
+                                   post_id uuid primary key references post(id) on delete cascade,
+                                   question text not null,
+                                   multiple_choice boolean not null default false,
+                                   anonymous boolean not null default true,
+                                   deadline timestamptz
+);
+
+create table if not exists poll_option(
+                                          post_id uuid not null references poll(post_id) on delete cascade,
+                                          position smallint not null,
+                                          text text not null,
+                                          primary key (post_id, position)
+);
+
+-- a user votes in a poll once, the vote has to be retracted before voting again
+create table if not exists poll_voter(
+                                         post_id uuid not null references poll(post_id) on delete cascade,
+                                         user_id uuid not null references "user"(id) on delete cascade,
+                                         voted_at timestamptz not null default now(),
+                                         primary key (post_id, user_id)
+);
+
+create table if not exists poll_vote(
+                                        post_id uuid not null,
+                                        user_id uuid not null,
+                                        position smallint not null,
+                                        primary key (post_id, user_id, position),
+                                        foreign key (post_id, user_id) references poll_voter(post_id, user_id) on delete cascade,
+                                        foreign key (post_id, position) references poll_option(post_id, position) on delete cascade
+);
+
+create index if not exists poll_vote_option_idx on poll_vote(post_id, position);
+
+-- +migrate Down
+drop table if exists poll_vote;
+drop table if exists poll_voter;
+drop table if exists poll_option;
+drop table if exists poll;
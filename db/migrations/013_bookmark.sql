@@ -0,0 +1,26 @@
+-- +migrate Up
+create table if not exists bookmark_collection(
+                                                  id uuid primary key,
+                                                  user_id uuid not null references "user"(id) on delete cascade,
+                                                  name text not null,
+                                                  created_at timestamptz not null default now(),
+                                                  unique (user_id, name),
+                                                  unique (id, user_id)
+);
+
+-- bookmarks of deleted posts are removed with them, bookmarks of deleted collections are kept uncollected
+create table if not exists bookmark(
+                                       user_id uuid not null references "user"(id) on delete cascade,
+                                       post_id uuid not null references post(id) on delete cascade,
+                                       collection_id uuid,
+                                       created_at timestamptz not null default now(),
+                                       primary key (user_id, post_id),
+                                       foreign key (collection_id, user_id) references bookmark_collection(id, user_id) on delete set null (collection_id)
+);
+
+create index if not exists bookmark_user_created_idx on bookmark(user_id, created_at desc, post_id desc);
+create index if not exists bookmark_collection_idx on bookmark(collection_id, created_at desc, post_id desc);
+
+-- +migrate Down
+drop table if exists bookmark;
+drop table if exists bookmark_collection;
@@ -0,0 +1,12 @@
+-- +migrate Up
+alter table post
+    add column if not exists pinned boolean not null default false;
+
+-- a user pins at most one post
+create unique index if not exists post_pinned_idx on post(creator_id) where pinned;
+
+-- +migrate Down
+drop index if exists post_pinned_idx;
+
+alter table post
+    drop column if exists pinned;
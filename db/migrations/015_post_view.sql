@@ -0,0 +1,29 @@
+-- +migrate Up
+alter table post
+    add column if not exists view_count int not null default 0 check (view_count >= 0);
+
+-- likes made before this migration are attributed to the day it was applied
+alter table like_post
+    add column if not exists created_at timestamptz not null default now();
+
+-- unique views of posts by UTC days, flushed from redis
+create table if not exists post_view_daily(
+                                              post_id uuid not null references post(id) on delete cascade,
+                                              day date not null,
+                                              view_count int not null default 0 check (view_count >= 0),
+                                              primary key (post_id, day)
+);
+
+create index if not exists post_creator_idx on post(creator_id);
+create index if not exists like_post_post_created_idx on like_post(post_id, created_at);
+create index if not exists comment_post_created_idx on comment(post_id, created_at);
+
+-- +migrate Down
+drop index if exists comment_post_created_idx;
+drop index if exists like_post_post_created_idx;
+drop index if exists post_creator_idx;
+drop table if exists post_view_daily;
+alter table like_post
+    drop column if exists created_at;
+alter table post
+    drop column if exists view_count;
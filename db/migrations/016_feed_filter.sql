@@ -0,0 +1,23 @@
+-- +migrate Up
+-- posts the user does not want to see in the feed
+create table if not exists hidden_post(
+                                          user_id uuid not null references "user"(id) on delete cascade,
+                                          post_id uuid not null references post(id) on delete cascade,
+                                          created_at timestamptz not null default now(),
+                                          primary key (user_id, post_id)
+);
+
+create index if not exists hidden_post_user_created_idx on hidden_post(user_id, created_at desc, post_id desc);
+
+-- authors whose posts the user does not want to see in the feed, following them is kept as is
+create table if not exists muted_user(
+                                         user_id uuid not null references "user"(id) on delete cascade,
+                                         muted_id uuid not null references "user"(id) on delete cascade,
+                                         created_at timestamptz not null default now(),
+                                         primary key (user_id, muted_id),
+                                         check (user_id <> muted_id)
+);
+
+-- +migrate Down
+drop table if exists muted_user;
+drop table if exists hidden_post;
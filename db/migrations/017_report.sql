@@ -0,0 +1,50 @@
+-- +migrate Up
+-- target_id refers to a post, comment, message or user depending on target_type,
+-- reports are kept after the reported content is removed
+create table if not exists report(
+                                     id uuid primary key,
+                                     reporter_id uuid not null references "user"(id) on delete cascade,
+                                     target_type text not null,
+                                     target_id uuid not null,
+                                     target_owner_id uuid references "user"(id) on delete set null,
+                                     reason text not null,
+                                     details text not null default '',
+                                     status text not null default 'open',
+                                     moderator_id uuid references "user"(id) on delete set null,
+                                     created_at timestamptz not null default now(),
+                                     updated_at timestamptz not null default now(),
+                                     unique (reporter_id, target_type, target_id)
+);
+
+create index if not exists report_status_created_idx on report(status, created_at desc, id desc);
+create index if not exists report_target_idx on report(target_type, target_id) where status <> 'resolved';
+
+-- audit trail of moderators, kept after moderators are deleted
+create table if not exists moderation_decision(
+                                                  id uuid primary key,
+                                                  report_id uuid not null references report(id) on delete cascade,
+                                                  moderator_id uuid references "user"(id) on delete set null,
+                                                  status text not null,
+                                                  action text not null default '',
+                                                  note text not null default '',
+                                                  created_at timestamptz not null default now()
+);
+
+create index if not exists moderation_decision_report_idx on moderation_decision(report_id, created_at);
+
+-- ban without until is permanent
+create table if not exists user_ban(
+                                       id uuid primary key,
+                                       user_id uuid not null references "user"(id) on delete cascade,
+                                       reason text not null,
+                                       banned_by uuid references "user"(id) on delete set null,
+                                       until timestamptz,
+                                       created_at timestamptz not null default now()
+);
+
+create index if not exists user_ban_user_idx on user_ban(user_id, created_at desc);
+
+-- +migrate Down
+drop table if exists user_ban;
+drop table if exists moderation_decision;
+drop table if exists report;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- the first admin is granted with the grant-admin command
+alter table "user"
+    add column if not exists role text not null default 'user';
+
+-- +migrate Down
+alter table "user"
+    drop column if exists role;
@@ -26,8 +26,9 @@ create_and_set_public_policy() {
     mc mb $MINIO_ALIAS/"$bucket_name"
   fi
 
-  echo "Setting public policy for bucket: $bucket_name"
-  mc anonymous set public $MINIO_ALIAS/"$bucket_name" || echo "Failed to set policy for $bucket_name"
+  # anyone can download files, only backend can upload them
+  echo "Setting public read policy for bucket: $bucket_name"
+  mc anonymous set download $MINIO_ALIAS/"$bucket_name" || echo "Failed to set policy for $bucket_name"
 }
 
 create_and_set_public_policy "$MINIO_POSTS_BUCKET_NAME"
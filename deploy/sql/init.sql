@@ -2,7 +2,8 @@ create table if not exists "user" (
                                       id uuid primary key,
                                       username text not null unique,
                                       psw_hash text not null,
-                                      salt text not null
+                                      salt text not null,
+                                      role text not null default 'user'
 );
 
 CREATE TABLE IF NOT EXISTS university (
@@ -67,9 +68,21 @@ create table if not exists post(
                                    like_count int default 0 check (like_count >= 0),
                                    repost_count int default 0 check(repost_count >= 0),
                                    comment_count int default 0 check(comment_count >= 0),
-                                   is_repost bool default false
+                                   is_repost bool default false,
+                                   visibility text not null default 'public',
+                                   status text not null default 'published',
+                                   publish_at timestamptz,
+                                   edited_at timestamptz,
+                                   pinned boolean not null default false,
+                                   view_count int not null default 0 check (view_count >= 0)
 );
 
+create index if not exists post_scheduled_idx on post(publish_at) where status = 'scheduled';
+-- a user pins at most one post
+create unique index if not exists post_pinned_idx on post(creator_id) where pinned;
+create index if not exists post_created_at_idx on post(created_at);
+create index if not exists post_creator_idx on post(creator_id);
+
 create table if not exists comment(
                                       id uuid primary key,
                                       post_id uuid references post(id) on delete cascade,
@@ -79,6 +92,16 @@ create table if not exists comment(
                                       text text not null
 );
 
+create index if not exists comment_post_created_idx on comment(post_id, created_at);
+
+-- unique views of posts by UTC days, flushed from redis
+create table if not exists post_view_daily(
+                                              post_id uuid not null references post(id) on delete cascade,
+                                              day date not null,
+                                              view_count int not null default 0 check (view_count >= 0),
+                                              primary key (post_id, day)
+);
+
 create table if not exists post_file(
                                         id int generated always as identity primary key,
                                         post_id uuid references post(id) on delete cascade,
@@ -86,6 +109,115 @@ create table if not exists post_file(
                                         added_at timestamptz not null default now()
 );
 
+create table if not exists post_revision(
+                                            id uuid primary key,
+                                            post_id uuid not null references post(id) on delete cascade,
+                                            editor_id uuid references "user"(id) on delete set null,
+                                            text text,
+                                            created_at timestamptz not null default now()
+);
+
+create index if not exists post_revision_post_idx on post_revision(post_id, created_at);
+
+create table if not exists post_revision_file(
+                                                 id int generated always as identity primary key,
+                                                 revision_id uuid not null references post_revision(id) on delete cascade,
+                                                 file_url text not null
+);
+
+create index if not exists post_revision_file_revision_idx on post_revision_file(revision_id);
+
+create table if not exists post_tag(
+                                       post_id uuid not null references post(id) on delete cascade,
+                                       tag text not null,
+                                       primary key (post_id, tag)
+);
+
+create index if not exists post_tag_tag_idx on post_tag(tag);
+
+create table if not exists post_mention(
+                                           post_id uuid not null references post(id) on delete cascade,
+                                           user_id uuid not null references "user"(id) on delete cascade,
+                                           "offset" int not null,
+                                           length int not null,
+                                           primary key (post_id, "offset")
+);
+
+create table if not exists poll(
+                                   post_id uuid primary key references post(id) on delete cascade,
+                                   question text not null,
+                                   multiple_choice boolean not null default false,
+                                   anonymous boolean not null default true,
+                                   deadline timestamptz
+);
+
+create table if not exists poll_option(
+                                          post_id uuid not null references poll(post_id) on delete cascade,
+                                          position smallint not null,
+                                          text text not null,
+                                          primary key (post_id, position)
+);
+
+-- a user votes in a poll once, the vote has to be retracted before voting again
+create table if not exists poll_voter(
+                                         post_id uuid not null references poll(post_id) on delete cascade,
+                                         user_id uuid not null references "user"(id) on delete cascade,
+                                         voted_at timestamptz not null default now(),
+                                         primary key (post_id, user_id)
+);
+
+create table if not exists poll_vote(
+                                        post_id uuid not null,
+                                        user_id uuid not null,
+                                        position smallint not null,
+                                        primary key (post_id, user_id, position),
+                                        foreign key (post_id, user_id) references poll_voter(post_id, user_id) on delete cascade,
+                                        foreign key (post_id, position) references poll_option(post_id, position) on delete cascade
+);
+
+create index if not exists poll_vote_option_idx on poll_vote(post_id, position);
+
+create table if not exists bookmark_collection(
+                                                  id uuid primary key,
+                                                  user_id uuid not null references "user"(id) on delete cascade,
+                                                  name text not null,
+                                                  created_at timestamptz not null default now(),
+                                                  unique (user_id, name),
+                                                  unique (id, user_id)
+);
+
+-- bookmarks of deleted posts are removed with them, bookmarks of deleted collections are kept uncollected
+create table if not exists bookmark(
+                                       user_id uuid not null references "user"(id) on delete cascade,
+                                       post_id uuid not null references post(id) on delete cascade,
+                                       collection_id uuid,
+                                       created_at timestamptz not null default now(),
+                                       primary key (user_id, post_id),
+                                       foreign key (collection_id, user_id) references bookmark_collection(id, user_id) on delete set null (collection_id)
+);
+
+create index if not exists bookmark_user_created_idx on bookmark(user_id, created_at desc, post_id desc);
+create index if not exists bookmark_collection_idx on bookmark(collection_id, created_at desc, post_id desc);
+
+-- posts the user does not want to see in the feed
+create table if not exists hidden_post(
+                                          user_id uuid not null references "user"(id) on delete cascade,
+                                          post_id uuid not null references post(id) on delete cascade,
+                                          created_at timestamptz not null default now(),
+                                          primary key (user_id, post_id)
+);
+
+create index if not exists hidden_post_user_created_idx on hidden_post(user_id, created_at desc, post_id desc);
+
+-- authors whose posts the user does not want to see in the feed, following them is kept as is
+create table if not exists muted_user(
+                                         user_id uuid not null references "user"(id) on delete cascade,
+                                         muted_id uuid not null references "user"(id) on delete cascade,
+                                         created_at timestamptz not null default now(),
+                                         primary key (user_id, muted_id),
+                                         check (user_id <> muted_id)
+);
+
 create table if not exists repost(
                                      repost_id uuid primary key,
                                      original_id uuid references post(id) on delete cascade,
@@ -97,9 +229,12 @@ create table if not exists like_post(
                                         id int generated always as identity primary key,
                                         user_id uuid references "user"(id) on delete cascade,
                                         post_id uuid references post(id) on delete cascade,
+                                        created_at timestamptz not null default now(),
                                         unique (user_id, post_id)
 );
 
+create index if not exists like_post_post_created_idx on like_post(post_id, created_at);
+
 create table if not exists like_comment(
                                            id int generated always as identity primary key,
                                            user_id uuid references "user"(id) on delete cascade,
@@ -112,6 +247,7 @@ create table if not exists friendship(
                                          user1_id uuid references "user"(id) on delete cascade,
                                          user2_id uuid references "user"(id) on delete cascade,
                                          status text not null default 'following',
+                                         created_at timestamptz not null default now(),
                                          unique (user1_id, user2_id),
                                          check (user1_id < user2_id)
 );
@@ -148,6 +284,74 @@ create table if not exists message_file(
                                            file_url text not null
 );
 
+create table if not exists message_mention(
+                                              message_id uuid not null references message(id) on delete cascade,
+                                              user_id uuid not null references "user"(id) on delete cascade,
+                                              "offset" int not null,
+                                              length int not null,
+                                              primary key (message_id, "offset")
+);
+
+create table if not exists notification(
+                                           id uuid primary key,
+                                           user_id uuid not null references "user"(id) on delete cascade,
+                                           type text not null,
+                                           actor_id uuid references "user"(id) on delete cascade,
+                                           post_id uuid references post(id) on delete cascade,
+                                           message_id uuid references message(id) on delete cascade,
+                                           created_at timestamptz not null default now(),
+                                           read_at timestamptz
+);
+
+create index if not exists notification_user_idx on notification(user_id, created_at desc, id desc);
+-- user is told about mention in a post once, however many times the post is edited
+create unique index if not exists notification_post_mention_idx on notification(user_id, post_id) where type = 'post_mention';
+
+-- target_id refers to a post, comment, message or user depending on target_type,
+-- reports are kept after the reported content is removed
+create table if not exists report(
+                                     id uuid primary key,
+                                     reporter_id uuid not null references "user"(id) on delete cascade,
+                                     target_type text not null,
+                                     target_id uuid not null,
+                                     target_owner_id uuid references "user"(id) on delete set null,
+                                     reason text not null,
+                                     details text not null default '',
+                                     status text not null default 'open',
+                                     moderator_id uuid references "user"(id) on delete set null,
+                                     created_at timestamptz not null default now(),
+                                     updated_at timestamptz not null default now(),
+                                     unique (reporter_id, target_type, target_id)
+);
+
+create index if not exists report_status_created_idx on report(status, created_at desc, id desc);
+create index if not exists report_target_idx on report(target_type, target_id) where status <> 'resolved';
+
+-- audit trail of moderators, kept after moderators are deleted
+create table if not exists moderation_decision(
+                                                  id uuid primary key,
+                                                  report_id uuid not null references report(id) on delete cascade,
+                                                  moderator_id uuid references "user"(id) on delete set null,
+                                                  status text not null,
+                                                  action text not null default '',
+                                                  note text not null default '',
+                                                  created_at timestamptz not null default now()
+);
+
+create index if not exists moderation_decision_report_idx on moderation_decision(report_id, created_at);
+
+-- ban without until is permanent
+create table if not exists user_ban(
+                                       id uuid primary key,
+                                       user_id uuid not null references "user"(id) on delete cascade,
+                                       reason text not null,
+                                       banned_by uuid references "user"(id) on delete set null,
+                                       until timestamptz,
+                                       created_at timestamptz not null default now()
+);
+
+create index if not exists user_ban_user_idx on user_ban(user_id, created_at desc);
+
 create table if not exists community(
                                         id uuid primary key,
                                         owner_id uuid references "user"(id) on delete cascade,
@@ -174,4 +378,70 @@ create table if not exists user_follow(
 );
 
 create extension if not exists pg_trgm;
-SET pg_trgm.similarity_threshold = 0.3;
\ No newline at end of file
+SET pg_trgm.similarity_threshold = 0.3;
+
+create table if not exists privacy_settings(
+                                               profile_id uuid primary key references profile(id) on delete cascade,
+                                               contact_info text not null default 'friends',
+                                               birth_date text not null default 'everyone',
+                                               education text not null default 'everyone',
+                                               posts text not null default 'everyone'
+);
+
+create table if not exists stored_file(
+                                          url text primary key,
+                                          purpose text not null,
+                                          name text not null,
+                                          content_hash text not null,
+                                          size bigint not null,
+                                          mime_type text not null,
+                                          ref_count int not null default 0 check (ref_count >= 0),
+                                          created_at timestamptz not null default now(),
+                                          used_at timestamptz not null default now(),
+                                          media_type text,
+                                          duration_ms bigint,
+                                          width int,
+                                          height int,
+                                          unique (purpose, name),
+                                          unique (content_hash, purpose)
+);
+
+-- count_file_refs keeps stored_file.ref_count in sync with the column named by its argument,
+-- so references removed by cascades are counted as well.
+create or replace function count_file_refs() returns trigger as $$
+declare
+    old_url text;
+    new_url text;
+begin
+    if tg_op <> 'INSERT' then
+        old_url := to_jsonb(old) ->> tg_argv[0];
+    end if;
+    if tg_op <> 'DELETE' then
+        new_url := to_jsonb(new) ->> tg_argv[0];
+    end if;
+    if old_url is not distinct from new_url then
+        return null;
+    end if;
+
+    if new_url is not null then
+        update stored_file set ref_count = ref_count + 1 where url = new_url;
+    end if;
+    if old_url is not null then
+        update stored_file set ref_count = greatest(ref_count - 1, 0) where url = old_url;
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+
+create or replace trigger post_file_refs after insert or update or delete on post_file
+    for each row execute function count_file_refs('file_url');
+create or replace trigger post_revision_file_refs after insert or update or delete on post_revision_file
+    for each row execute function count_file_refs('file_url');
+create or replace trigger message_file_refs after insert or update or delete on message_file
+    for each row execute function count_file_refs('file_url');
+create or replace trigger profile_avatar_refs after insert or update of profile_avatar or delete on profile
+    for each row execute function count_file_refs('profile_avatar');
+create or replace trigger profile_background_refs after insert or update of profile_background or delete on profile
+    for each row execute function count_file_refs('profile_background');
+create or replace trigger chat_avatar_refs after insert or update of avatar_url or delete on chat
+    for each row execute function count_file_refs('avatar_url');